@@ -12,17 +12,23 @@ class Ccm < Formula
 
   def install
     # Build the main binary with custom name
-    system "go", "build", *std_go_args(ldflags: "-s -w", output: bin/"ccm"), "./cmd"
+    ldflags = %W[
+      -s -w
+      -X github.com/shel-corp/Claude-command-manager/internal/version.Version=#{version}
+    ]
+    system "go", "build", *std_go_args(ldflags:, output: bin/"ccm"), "./cmd"
   end
 
   test do
     # Test that the binary was installed and responds to help command
     assert_match "Claude Command Manager", shell_output("#{bin}/ccm help")
     
-    # Test that version information is available
     assert_match "Usage:", shell_output("#{bin}/ccm help")
+
+    # Test that version information is available
+    assert_match version.to_s, shell_output("#{bin}/ccm version")
     
     # Test that the binary can handle invalid commands gracefully
-    assert_match "Unknown command", shell_output("#{bin}/ccm invalid-command", 1)
+    assert_match "Unknown command", shell_output("#{bin}/ccm invalid-command", 3)
   end
 end
\ No newline at end of file
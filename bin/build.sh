@@ -38,10 +38,19 @@ check_go() {
     print_info "Found Go version: $go_version"
 }
 
+# Linker flags that embed version, commit and build date
+version_ldflags() {
+    local pkg="github.com/shel-corp/Claude-command-manager/internal/version"
+    local version=$(cat VERSION 2>/dev/null || echo dev)
+    local commit=$(git rev-parse --short HEAD 2>/dev/null)
+    local date=$(date -u +%Y-%m-%dT%H:%M:%SZ)
+    echo "-X $pkg.Version=$version -X $pkg.Commit=$commit -X $pkg.Date=$date"
+}
+
 # Build for current platform
 build_current() {
     print_info "Building for current platform..."
-    go build -o ccm cmd/main.go
+    go build -ldflags "$(version_ldflags)" -o ccm cmd/main.go
     print_success "Built: ccm"
     
     # Make executable
@@ -61,6 +70,7 @@ build_all() {
     )
     
     mkdir -p dist
+    local ldflags=$(version_ldflags)
     
     for platform in "${platforms[@]}"; do
         local os=${platform%/*}
@@ -72,7 +82,7 @@ build_all() {
         fi
         
         print_info "Building for $os/$arch..."
-        GOOS=$os GOARCH=$arch go build -o "$output" cmd/main.go
+        GOOS=$os GOARCH=$arch go build -ldflags "$ldflags" -o "$output" cmd/main.go
         
         if [[ $? -eq 0 ]]; then
             print_success "Built: $output"
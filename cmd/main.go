@@ -1,77 +1,116 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 
+	"github.com/shel-corp/Claude-command-manager/internal/apperr"
+	"github.com/shel-corp/Claude-command-manager/internal/archive"
+	"github.com/shel-corp/Claude-command-manager/internal/cache"
 	"github.com/shel-corp/Claude-command-manager/internal/commands"
+	"github.com/shel-corp/Claude-command-manager/internal/completion"
 	"github.com/shel-corp/Claude-command-manager/internal/config"
+	"github.com/shel-corp/Claude-command-manager/internal/logging"
+	"github.com/shel-corp/Claude-command-manager/internal/models"
+	"github.com/shel-corp/Claude-command-manager/internal/project"
+	"github.com/shel-corp/Claude-command-manager/internal/registry"
 	"github.com/shel-corp/Claude-command-manager/internal/remote"
+	"github.com/shel-corp/Claude-command-manager/internal/templates"
+	"github.com/shel-corp/Claude-command-manager/internal/theme"
 	"github.com/shel-corp/Claude-command-manager/internal/tui"
+	"github.com/shel-corp/Claude-command-manager/internal/usage"
+	"github.com/shel-corp/Claude-command-manager/internal/version"
 )
 
 func main() {
+	// Version information is available outside of any project
+	if len(os.Args) > 1 && (os.Args[1] == "version" || os.Args[1] == "--version") {
+		fmt.Println(version.Get().String())
+		return
+	}
+
+	// Shell completion must work from any directory
+	if len(os.Args) > 1 && (os.Args[1] == "completion" || os.Args[1] == completion.Command) {
+		handleCompletionCommand(os.Args[1], os.Args[2:])
+		return
+	}
+
 	// Get paths by traversing up to find .claude directory
 	commandsDir, configPath, claudeDir, err := config.GetCommandLibraryPaths()
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		fmt.Fprintf(os.Stderr, "Make sure you are running this command from within a directory that contains a .claude folder.\n")
-		os.Exit(1)
+		exitWith(apperr.KindNotFound, "Make sure you are running this command from within a directory that contains a .claude folder.\n")
 	}
 
 	homeDir, err := os.UserHomeDir()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: Could not get home directory: %v\n", err)
-		os.Exit(1)
+		exitWith(apperr.KindOf(err), "Error: Could not get home directory: %v\n", err)
 	}
 	
 	userCommandsDir := filepath.Join(homeDir, ".claude", "commands")
 	projectCommandsDir := filepath.Join(claudeDir, "commands")
 
+	args, library, err := extractLibraryFlag(os.Args[1:])
+	if err != nil {
+		exitWith(apperr.KindValidation, "Error: %v\n", err)
+	}
+	args, dryRun := extractDryRunFlag(args)
+	args, verbose := extractVerboseFlag(args)
+	args, plain := extractPlainFlag(args)
+	initLogging(verbose, len(args) > 0)
+	defer logging.Close()
+
+	// Settle the color depth before anything is styled, CLI output included
+	theme.ApplyColorDepth(loadAppSettings().GetAppConfig().Theme.Colors)
+
 	// Handle CLI arguments for backward compatibility
-	if len(os.Args) > 1 {
-		if handleCLICommands(os.Args[1:], commandsDir, configPath, userCommandsDir, projectCommandsDir) {
+	if len(args) > 0 {
+		if handleCLICommands(args, commandsDir, configPath, userCommandsDir, projectCommandsDir, library, dryRun, plain) {
 			return
 		}
 	}
+	if dryRun {
+		exitWith(apperr.KindValidation, "Error: --dry-run is only supported for CLI commands\n")
+	}
 
 	// Initialize managers for project library
 	configManager := config.NewManager(configPath)
 	if err := configManager.Load(); err != nil {
-		fmt.Fprintf(os.Stderr, "Error loading configuration: %v\n", err)
-		os.Exit(1)
+		exitWith(apperr.KindOf(err), "Error loading configuration: %v\n", err)
 	}
 
 	commandManager := commands.NewManager(commandsDir, userCommandsDir, projectCommandsDir, configManager)
+	appSettings := loadAppSettings().GetAppConfig()
+	applyLibrarySettings(commandManager, appSettings)
 
 	// Initialize managers for user library
-	userCommandLibraryDir := filepath.Join(homeDir, ".claude", "command_library")
-	userConfigPath := filepath.Join(userCommandLibraryDir, ".config.json")
-	
-	// For user library, the commands are directly in the command_library directory
-	// (this maintains compatibility with existing user setups)
-	userCommandsLibraryDir := userCommandLibraryDir
+	userCommandsLibraryDir, userConfigPath := userLibraryPaths(homeDir)
 	
 	// Ensure user command library directory exists
 	if err := os.MkdirAll(userCommandsLibraryDir, 0755); err != nil {
-		fmt.Fprintf(os.Stderr, "Error creating user command library: %v\n", err)
-		os.Exit(1)
+		exitWith(apperr.KindOf(err), "Error creating user command library: %v\n", err)
 	}
 	
 	userConfigManager := config.NewManager(userConfigPath)
 	if err := userConfigManager.Load(); err != nil {
-		fmt.Fprintf(os.Stderr, "Error loading user configuration: %v\n", err)
-		os.Exit(1)
+		exitWith(apperr.KindOf(err), "Error loading user configuration: %v\n", err)
 	}
 
 	userCommandManager := commands.NewManager(userCommandsLibraryDir, userCommandsDir, projectCommandsDir, userConfigManager)
+	applyLibrarySettings(userCommandManager, appSettings)
 
 	// Clean up any broken symlinks
 	if err := commandManager.CleanupBrokenSymlinks(); err != nil {
@@ -84,8 +123,20 @@ func main() {
 	// Create TUI model
 	model, err := tui.NewModel(commandManager, configManager, userCommandManager, userConfigManager)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error creating TUI model: %v\n", err)
-		os.Exit(1)
+		exitWith(apperr.KindOf(err), "Error creating TUI model: %v\n", err)
+	}
+
+	// Compare against the project's pinned configuration, if it has one
+	projectConfig, err := project.Load(claudeDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	model.SetProjectConfig(projectConfig)
+	model.SetPlain(plain)
+	if library == "user" {
+		if err := model.SetLibraryMode(tui.LibraryModeUser); err != nil {
+			exitWith(apperr.KindOf(err), "Error loading user library: %v\n", err)
+		}
 	}
 	
 	// Use alt screen to ensure proper screen clearing
@@ -95,59 +146,670 @@ func main() {
 	)
 	
 	if _, err := p.Run(); err != nil {
-		fmt.Fprintf(os.Stderr, "Error running TUI: %v\n", err)
-		os.Exit(1)
+		exitWith(apperr.KindOf(err), "Error running TUI: %v\n", err)
+	}
+}
+
+// userLibraryPaths returns the user command library directory and its config path.
+// For the user library, the commands are directly in the command_library directory
+// (this maintains compatibility with existing user setups).
+func userLibraryPaths(homeDir string) (string, string) {
+	userCommandLibraryDir := filepath.Join(homeDir, ".claude", "command_library")
+	return userCommandLibraryDir, filepath.Join(userCommandLibraryDir, ".config.json")
+}
+
+// extractLibraryFlag removes the global --library flag from args and returns the selected
+// library ("project" or "user"), falling back to $CCM_LIBRARY and then the project library
+func extractLibraryFlag(args []string) ([]string, string, error) {
+	library := os.Getenv("CCM_LIBRARY")
+	remaining := make([]string, 0, len(args))
+	for i := 0; i < len(args); i++ {
+		switch {
+		case args[i] == "--library":
+			if i+1 >= len(args) {
+				return nil, "", fmt.Errorf("--library requires a value (user or project)")
+			}
+			library = args[i+1]
+			i++
+		case strings.HasPrefix(args[i], "--library="):
+			library = strings.TrimPrefix(args[i], "--library=")
+		default:
+			remaining = append(remaining, args[i])
+		}
+	}
+
+	switch strings.ToLower(library) {
+	case "", "project":
+		return remaining, "project", nil
+	case "user":
+		return remaining, "user", nil
+	}
+	return nil, "", fmt.Errorf("invalid library %q (expected user or project)", library)
+}
+
+// exitWith prints a message to stderr and exits with the exit code for kind
+func exitWith(kind apperr.Kind, format string, args ...interface{}) {
+	fmt.Fprintf(os.Stderr, format, args...)
+	os.Exit(kind.ExitCode())
+}
+
+// dryRunCommands lists the CLI verbs that can preview their changes with --dry-run
+var dryRunCommands = map[string]bool{
+	"enable":  true,
+	"disable": true,
+	"rename":  true,
+	"move":    true,
+	"delete":  true,
+	"import":  true,
+	"apply":   true,
+}
+
+// extractDryRunFlag removes the global --dry-run flag from args and reports whether it was given
+func extractDryRunFlag(args []string) ([]string, bool) {
+	dryRun := false
+	remaining := make([]string, 0, len(args))
+	for _, arg := range args {
+		if arg == "--dry-run" {
+			dryRun = true
+		} else {
+			remaining = append(remaining, arg)
+		}
+	}
+	return remaining, dryRun
+}
+
+// extractVerboseFlag removes the global --verbose flag from args and reports whether
+// debug logging was requested by the flag or by CCM_DEBUG
+func extractVerboseFlag(args []string) ([]string, bool) {
+	verbose := logging.DebugRequested()
+	remaining := make([]string, 0, len(args))
+	for _, arg := range args {
+		if arg == "--verbose" {
+			verbose = true
+		} else {
+			remaining = append(remaining, arg)
+		}
+	}
+	return remaining, verbose
+}
+
+// extractPlainFlag removes the global --plain flag from args and reports whether the TUI
+// should draw with plain ASCII, because of the flag or because NO_EMOJI is set
+func extractPlainFlag(args []string) ([]string, bool) {
+	plain := os.Getenv("NO_EMOJI") != ""
+	remaining := make([]string, 0, len(args))
+	for _, arg := range args {
+		if arg == "--plain" {
+			plain = true
+		} else {
+			remaining = append(remaining, arg)
+		}
+	}
+	return remaining, plain
+}
+
+// initLogging opens the log file. Verbose CLI runs also echo log messages to stderr;
+// the TUI never does, since it owns the terminal.
+func initLogging(verbose, cli bool) {
+	level := logging.LevelInfo
+	if verbose {
+		level = logging.LevelDebug
+	}
+	if err := logging.Init(logging.DefaultLogPath(), level); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	if verbose && cli {
+		logging.SetMirror(os.Stderr)
+	}
+	logging.Debugf("ccm %s starting: %s", version.Get().Short(), strings.Join(os.Args[1:], " "))
+}
+
+// printPlannedChanges reports what a dry run would have changed
+func printPlannedChanges(summary string, changes []commands.PlannedChange) {
+	fprintPlannedChanges(os.Stdout, summary, changes)
+}
+
+// fprintPlannedChanges writes the dry-run report to out
+func fprintPlannedChanges(out io.Writer, summary string, changes []commands.PlannedChange) {
+	fmt.Fprintf(out, "Dry run: %s\n", summary)
+	if len(changes) == 0 {
+		fmt.Fprintln(out, "  (no changes)")
+		return
+	}
+	for _, change := range changes {
+		fmt.Fprintf(out, "  %s\n", change)
+	}
+}
+
+// appSettingsManager holds the settings once loadAppSettings has read them
+var appSettingsManager *theme.Manager
+
+// loadAppSettings reads the unified application settings file, falling back to defaults.
+// The file is read once per run, so a problem with it is reported once.
+func loadAppSettings() *theme.Manager {
+	if appSettingsManager != nil {
+		return appSettingsManager
+	}
+	appSettingsManager = theme.NewManager(theme.DefaultConfigPath())
+	if err := appSettingsManager.Load(); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: Failed to load settings: %v\n", err)
+	}
+	return appSettingsManager
+}
+
+// applyLibrarySettings applies library defaults from the application settings to a command manager
+func applyLibrarySettings(commandManager *commands.Manager, appSettings theme.AppConfig) {
+	commandManager.SetSymlinkTargets(appSettings.Library.TargetDirs())
+	if location, err := commandManager.ParseSymlinkLocation(appSettings.Library.DefaultSymlinkLocation); err == nil {
+		commandManager.SetDefaultSymlinkLocation(location)
+	}
+}
+
+// loadUserLibrary opens the user-level command library regardless of the current directory
+func loadUserLibrary(userCommandsDir, projectCommandsDir string, appSettings theme.AppConfig) (*commands.Manager, *config.Manager, string) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		exitWith(apperr.KindOf(err), "Error: Could not get home directory: %v\n", err)
+	}
+	userDir, userConfigPath := userLibraryPaths(homeDir)
+	userConfigManager := config.NewManager(userConfigPath)
+	if err := userConfigManager.Load(); err != nil {
+		exitWith(apperr.KindOf(err), "Error loading user configuration: %v\n", err)
 	}
+	userCommandManager := commands.NewManager(userDir, userCommandsDir, projectCommandsDir, userConfigManager)
+	applyLibrarySettings(userCommandManager, appSettings)
+	return userCommandManager, userConfigManager, userConfigPath
 }
 
 // handleCLICommands handles command-line interface commands for backward compatibility
-func handleCLICommands(args []string, commandsDir, configPath, userCommandsDir, projectCommandsDir string) bool {
+func handleCLICommands(args []string, commandsDir, configPath, userCommandsDir, projectCommandsDir, library string, dryRun, plain bool) bool {
 	if len(args) == 0 {
 		return false
 	}
+	if dryRun && !dryRunCommands[args[0]] {
+		exitWith(apperr.KindValidation, "Error: --dry-run is not supported for '%s' (supported: enable, disable, rename, move, delete, import, apply)\n", args[0])
+	}
 
 	// Initialize managers
 	configManager := config.NewManager(configPath)
 	if err := configManager.Load(); err != nil {
-		fmt.Fprintf(os.Stderr, "Error loading configuration: %v\n", err)
-		os.Exit(1)
+		exitWith(apperr.KindOf(err), "Error loading configuration: %v\n", err)
 	}
 
 	commandManager := commands.NewManager(commandsDir, userCommandsDir, projectCommandsDir, configManager)
+	settingsManager := loadAppSettings()
+	appSettings := settingsManager.GetAppConfig()
+	applyLibrarySettings(commandManager, appSettings)
+
+	// Library verbs operate on the user library when selected with --library or $CCM_LIBRARY
+	if library == "user" {
+		commandManager, configManager, configPath = loadUserLibrary(userCommandsDir, projectCommandsDir, appSettings)
+	}
 
 	switch args[0] {
 	case "list":
-		return handleListCommands(commandManager)
+		modelFilter := ""
+		var tagFilters []string
+		for i := 1; i < len(args); i++ {
+			switch {
+			case (args[i] == "--model" || args[i] == "--tag") && i+1 < len(args):
+				if args[i] == "--model" {
+					modelFilter = args[i+1]
+				} else {
+					tagFilters = append(tagFilters, args[i+1])
+				}
+				i++
+			case strings.HasPrefix(args[i], "--model="):
+				modelFilter = strings.TrimPrefix(args[i], "--model=")
+			case strings.HasPrefix(args[i], "--tag="):
+				tagFilters = append(tagFilters, strings.TrimPrefix(args[i], "--tag="))
+			default:
+				exitWith(apperr.KindValidation, "Usage: ccm list [--model <model>] [--tag <tag>]...\n")
+			}
+		}
+		return handleListCommands(commandManager, modelFilter, tagFilters)
 	case "status":
-		return handleStatusCommands(commandManager)
+		// Pinned project configuration only describes the project library
+		pinnedDir := projectCommandsDir
+		if library == "user" {
+			pinnedDir = ""
+		}
+		return handleStatusCommands(commandManager, pinnedDir)
 	case "enable":
 		if len(args) < 2 {
-			fmt.Fprintf(os.Stderr, "Usage: command_library enable <command_name>\n")
-			os.Exit(1)
+			exitWith(apperr.KindValidation, "Usage: command_library enable <command_name>\n")
 		}
-		return handleEnableCommand(commandManager, configManager, args[1])
+		return handleEnableCommand(commandManager, configManager, args[1], dryRun)
 	case "disable":
 		if len(args) < 2 {
-			fmt.Fprintf(os.Stderr, "Usage: command_library disable <command_name>\n")
-			os.Exit(1)
+			exitWith(apperr.KindValidation, "Usage: command_library disable <command_name>\n")
 		}
-		return handleDisableCommand(commandManager, configManager, args[1])
+		return handleDisableCommand(commandManager, configManager, args[1], dryRun)
 	case "rename":
 		if len(args) < 3 {
-			fmt.Fprintf(os.Stderr, "Usage: ccm rename <command_name> <new_name>\n")
-			os.Exit(1)
+			exitWith(apperr.KindValidation, "Usage: ccm rename <command_name> <new_name>\n")
 		}
-		return handleRenameCommand(commandManager, configManager, args[1], args[2])
-	case "import":
+		return handleRenameCommand(commandManager, configManager, args[1], args[2], dryRun)
+	case "duplicate":
+		if len(args) != 3 {
+			exitWith(apperr.KindValidation, "Usage: ccm duplicate <command_name> <new_name>\n")
+		}
+		return handleDuplicateCommand(commandManager, configManager, args[1], args[2])
+	case "set-meta":
+		name := ""
+		values := make(map[string]string)
+		for i := 1; i < len(args); i++ {
+			arg := args[i]
+			switch {
+			case strings.HasPrefix(arg, "--") && slices.Contains(commands.MetaKeys, arg[2:]):
+				if i+1 >= len(args) {
+					exitWith(apperr.KindValidation, "Missing value for %s\n", arg)
+				}
+				i++
+				values[arg[2:]] = args[i]
+			case name == "" && !strings.HasPrefix(arg, "-"):
+				name = arg
+			default:
+				exitWith(apperr.KindValidation, "Unknown option for set-meta: %s\n", arg)
+			}
+		}
+		if name == "" {
+			exitWith(apperr.KindValidation, "Usage: ccm set-meta <command_name> [--description <text>] [--argument-hint <hint>] [--allowed-tools <tools>] [--model <model>]\n")
+		}
+		return handleSetMetaCommand(commandManager, name, values)
+	case "tag":
+		if len(args) < 2 {
+			exitWith(apperr.KindValidation, "Usage: ccm tag <command_name> [+tag|-tag]...\n")
+		}
+		return handleTagCommand(commandManager, configManager, args[1], args[2:])
+	case "doctor":
+		fix, yes := false, false
+		for _, arg := range args[1:] {
+			switch arg {
+			case "--fix":
+				fix = true
+			case "--yes", "-y":
+				yes = true
+			default:
+				exitWith(apperr.KindValidation, "Usage: ccm doctor [--fix] [--yes]\n")
+			}
+		}
+		libraries := []doctorLibrary{{"📁 Project library", commandManager, configManager}}
+		if library == "user" {
+			libraries[0].label = "👤 User library"
+		} else {
+			userCommandManager, userConfigManager, _ := loadUserLibrary(userCommandsDir, projectCommandsDir, appSettings)
+			libraries = append(libraries, doctorLibrary{"👤 User library", userCommandManager, userConfigManager})
+		}
+		return handleDoctorCommand(libraries, fix, yes)
+	case "lint":
+		asJSON := false
+		var names []string
+		for i := 1; i < len(args); i++ {
+			switch {
+			case args[i] == "--output" && i+1 < len(args):
+				i++
+				if args[i] != "json" && args[i] != "text" {
+					exitWith(apperr.KindValidation, "Error: unknown output format %q (use text or json)\n", args[i])
+				}
+				asJSON = args[i] == "json"
+			case !strings.HasPrefix(args[i], "-"):
+				names = append(names, args[i])
+			default:
+				exitWith(apperr.KindValidation, "Usage: ccm lint [<command_name>...] [--output text|json]\n")
+			}
+		}
+		return handleLintCommand(commandManager, names, asJSON)
+	case "usage":
+		all := false
+		for _, arg := range args[1:] {
+			if arg != "--all" {
+				exitWith(apperr.KindValidation, "Usage: ccm usage [--all]\n")
+			}
+			all = true
+		}
+		return handleUsageCommand(commandManager, all)
+	case "move":
+		enable := false
+		var positional []string
+		for _, arg := range args[1:] {
+			if arg == "--enable" {
+				enable = true
+			} else {
+				positional = append(positional, arg)
+			}
+		}
+		if len(positional) != 2 {
+			exitWith(apperr.KindValidation, "Usage: ccm move <command_name> user|project|<target> [--enable]\n")
+		}
+		return handleMoveCommand(commandManager, configManager, positional[0], positional[1], enable, dryRun)
+	case "delete":
+		if len(args) < 2 {
+			exitWith(apperr.KindValidation, "Usage: ccm delete <command_name> [--force]\n")
+		}
+		force := false
+		name := ""
+		for _, arg := range args[1:] {
+			if arg == "--force" || arg == "-f" {
+				force = true
+			} else if name == "" {
+				name = arg
+			}
+		}
+		if name == "" {
+			exitWith(apperr.KindValidation, "Usage: ccm delete <command_name> [--force]\n")
+		}
+		return handleDeleteCommand(commandManager, configManager, name, force || !appSettings.Confirm.Delete, dryRun)
+	case "trash":
+		action := "list"
+		if len(args) > 1 {
+			action = args[1]
+		}
+		force := false
+		var rest []string
+		for _, arg := range args[min(2, len(args)):] {
+			if arg == "--force" || arg == "-f" {
+				force = true
+			} else {
+				rest = append(rest, arg)
+			}
+		}
+		switch {
+		case action == "list" && len(rest) == 0:
+			return handleTrashList(commandManager)
+		case action == "restore" && len(rest) == 1:
+			return handleTrashRestore(commandManager, configManager, rest[0])
+		case action == "empty" && len(rest) == 0:
+			return handleTrashEmpty(commandManager, force || !appSettings.Confirm.Delete)
+		default:
+			exitWith(apperr.KindValidation, "Usage: ccm trash [list|restore <command_name>|empty [--force]]\n")
+		}
+	case "new":
+		opts := newCommandOptions{Template: templates.DefaultTemplate}
+		for i := 1; i < len(args); i++ {
+			arg := args[i]
+			switch {
+			case arg == "--templates":
+				return handleListTemplates()
+			case arg == "--edit" || arg == "-e":
+				opts.Edit = true
+			case (arg == "--template" || arg == "-t") && i+1 < len(args):
+				i++
+				opts.Template = args[i]
+			case arg == "--description" && i+1 < len(args):
+				i++
+				opts.Values.Description = args[i]
+			case arg == "--argument-hint" && i+1 < len(args):
+				i++
+				opts.Values.ArgumentHint = args[i]
+			case arg == "--allowed-tools" && i+1 < len(args):
+				i++
+				opts.Values.AllowedTools = args[i]
+			case opts.Path == "" && !strings.HasPrefix(arg, "-"):
+				opts.Path = arg
+			default:
+				exitWith(apperr.KindValidation, "Unknown option for new: %s\n", arg)
+			}
+		}
+		if opts.Path == "" {
+			exitWith(apperr.KindValidation, "Usage: ccm new <name> [--template <name>] [--description <text>] [--argument-hint <hint>] [--allowed-tools <tools>] [--edit]\n")
+		}
+		return handleNewCommand(commandManager, configManager, opts)
+	case "show":
+		raw := false
+		name := ""
+		for _, arg := range args[1:] {
+			if arg == "--raw" {
+				raw = true
+			} else if name == "" {
+				name = arg
+			}
+		}
+		if name == "" {
+			exitWith(apperr.KindValidation, "Usage: ccm show <command_name> [--raw]\n")
+		}
+		return handleShowCommand(commandManager, name, raw, plain || appSettings.Theme.Plain, settingsManager.GetStyles())
+	case "edit":
 		if len(args) < 2 {
-			fmt.Fprintf(os.Stderr, "Usage: ccm import <github_url>\n")
-			os.Exit(1)
+			exitWith(apperr.KindValidation, "Usage: ccm edit <command_name>\n")
+		}
+		return handleEditCommand(commandManager, args[1])
+	case "sync":
+		if library == "user" {
+			exitWith(apperr.KindValidation, "Error: ccm sync only applies to the project library\n")
+		}
+		checkOnly, prune := false, false
+		for _, arg := range args[1:] {
+			switch arg {
+			case "--check":
+				checkOnly = true
+			case "--prune":
+				prune = true
+			}
+		}
+		return handleSyncCommand(commandManager, configManager, filepath.Dir(projectCommandsDir), checkOnly, prune)
+	case "export":
+		userLibrary := false
+		dest := ""
+		for _, arg := range args[1:] {
+			if arg == "--user" {
+				userLibrary = true
+			} else if dest == "" {
+				dest = arg
+			}
+		}
+		if dest == "" {
+			exitWith(apperr.KindValidation, "Usage: ccm export <archive.tar.gz> [--user]\n")
+		}
+		if userLibrary && library != "user" {
+			userCommandManager, _, userConfigPath := loadUserLibrary(userCommandsDir, projectCommandsDir, appSettings)
+			return handleExportCommand(userCommandManager, userConfigPath, "user", dest)
+		}
+		return handleExportCommand(commandManager, configPath, library, dest)
+	case "import-archive":
+		userLibrary := false
+		src := ""
+		options := archiveImportOptions{}
+		for _, arg := range args[1:] {
+			switch arg {
+			case "--user":
+				userLibrary = true
+			case "--overwrite":
+				options.Overwrite = true
+			case "--skip":
+				options.Skip = true
+			case "--no-backup":
+				options.NoBackup = true
+			default:
+				if src == "" {
+					src = arg
+				}
+			}
+		}
+		options.NoBackup = options.NoBackup || !appSettings.Import.CreateBackups
+		options.Overwrite = options.Overwrite || (!options.Skip && !appSettings.Confirm.Overwrite)
+		if src == "" || (options.Overwrite && options.Skip) {
+			exitWith(apperr.KindValidation, "Usage: ccm import-archive <archive.tar.gz> [--user] [--overwrite|--skip] [--no-backup]\n")
+		}
+		if userLibrary && library != "user" {
+			userCommandManager, userConfigManager, _ := loadUserLibrary(userCommandsDir, projectCommandsDir, appSettings)
+			return handleImportArchiveCommand(userCommandManager, userConfigManager, "user", src, options)
+		}
+		return handleImportArchiveCommand(commandManager, configManager, library, src, options)
+	case "migrate":
+		yes := false
+		var keys []string
+		for _, arg := range args[1:] {
+			if arg == "--yes" || arg == "-y" {
+				yes = true
+			} else {
+				keys = append(keys, arg)
+			}
+		}
+		if len(keys) != 2 {
+			exitWith(apperr.KindValidation, "Usage: ccm migrate <old_key> <new_key> [--yes]\n")
+		}
+		return handleMigrateCommand(commandManager, keys[0], keys[1], yes)
+	case "import":
+		opts := importCommandOptions{DryRun: dryRun}
+		url := ""
+		for i := 1; i < len(args); i++ {
+			arg := args[i]
+			switch {
+			case arg == "--output" && i+1 < len(args):
+				i++
+				if args[i] != "json" && args[i] != "text" {
+					exitWith(apperr.KindValidation, "Error: unknown output format %q (use text or json)\n", args[i])
+				}
+				opts.JSON = args[i] == "json"
+			case arg == "--select" && i+1 < len(args):
+				i++
+				opts.Selection = args[i]
+			case url == "" && !strings.HasPrefix(arg, "-"):
+				url = arg
+			default:
+				exitWith(apperr.KindValidation, "Unknown option for import: %s\n", arg)
+			}
+		}
+		if url == "" {
+			exitWith(apperr.KindValidation, "Usage: ccm import <github_url> [--select <numbers|all>] [--output text|json]\n")
+		}
+		return handleImportCommand(url, appSettings, commandsDir, configPath, opts)
+	case "config":
+		action := "list"
+		var keyArgs []string
+		if len(args) > 1 {
+			action = args[1]
+			keyArgs = args[2:]
+		}
+		switch {
+		case (action == "list" || action == "path") && len(keyArgs) == 0,
+			action == "get" && len(keyArgs) == 1,
+			action == "set" && len(keyArgs) == 2:
+			return handleConfigCommand(settingsManager, action, keyArgs)
+		default:
+			exitWith(apperr.KindValidation, "Usage: ccm config [list|path|get <key>|set <key> <value>]\n")
+		}
+	case "registry":
+		return handleRegistryCommand(args[1:])
+	case "theme":
+		action := "list"
+		if len(args) > 1 {
+			action = args[1]
+		}
+		switch {
+		case action == "list" && len(args) <= 2:
+			return handleThemeCommand(settingsManager, action, "")
+		case (action == "set" || action == "preview" || action == "import") && len(args) == 3:
+			return handleThemeCommand(settingsManager, action, args[2])
+		case action == "profiles" && len(args) == 2:
+			return handleThemeProfiles(settingsManager)
+		case action == "bind" && len(args) == 4:
+			return handleThemeBind(settingsManager, args[2], args[3])
+		case action == "unbind" && len(args) == 3:
+			return handleThemeBind(settingsManager, args[2], "")
+		case action == "convert" && (len(args) == 3 || len(args) == 4):
+			id := ""
+			if len(args) == 4 {
+				id = args[3]
+			}
+			return handleThemeConvert(args[2], id)
+		case action == "export" && len(args) >= 3:
+			var positional []string
+			format := ""
+			for i := 2; i < len(args); i++ {
+				switch {
+				case args[i] == "--output" && i+1 < len(args):
+					i++
+					format = args[i]
+				case !strings.HasPrefix(args[i], "-"):
+					positional = append(positional, args[i])
+				default:
+					exitWith(apperr.KindValidation, "Unknown option for theme export: %s\n", args[i])
+				}
+			}
+			if len(positional) == 0 || len(positional) > 2 {
+				exitWith(apperr.KindValidation, "Usage: ccm theme export <id> [file] [--output json|yaml]\n")
+			}
+			dest := ""
+			if len(positional) == 2 {
+				dest = positional[1]
+			}
+			return handleThemeExport(positional[0], dest, format)
+		default:
+			exitWith(apperr.KindValidation, "Usage: ccm theme [list|set <id>|preview <id>|export <id> [file] [--output json|yaml]|import <file-or-url|->|convert <file-or-url> [id]|profiles|bind <profile> <id>|unbind <profile>]\n")
 		}
-		return handleImportCommand(args[1])
+	case "imports":
+		action := "list"
+		repoFilter := ""
+		if len(args) > 1 {
+			action = args[1]
+		}
+		if len(args) > 2 {
+			repoFilter = args[2]
+		}
+		switch action {
+		case "list", "retry", "clear":
+			return handleImportsCommand(action, repoFilter, commandsDir, configPath)
+		default:
+			exitWith(apperr.KindValidation, "Usage: ccm imports [list|retry|clear] [owner/repo]\n")
+		}
+	case "target":
+		action := "list"
+		if len(args) > 1 {
+			action = args[1]
+		}
+		switch {
+		case action == "list" && len(args) <= 2:
+			return handleTargetList(settingsManager)
+		case action == "add" && len(args) == 4:
+			if err := settingsManager.AddSymlinkTarget(args[2], args[3]); err != nil {
+				exitWith(apperr.KindOf(err), "Error: %v\n", err)
+			}
+			fmt.Printf("Added symlink target: %s -> %s\n", strings.ToLower(args[2]), args[3])
+			return true
+		case action == "remove" && len(args) == 3:
+			userCommandManager, _, _ := loadUserLibrary(userCommandsDir, projectCommandsDir, appSettings)
+			return handleTargetRemove(settingsManager, args[2], commandManager, userCommandManager)
+		}
+		exitWith(apperr.KindValidation, "Usage: ccm target [list|add <name> <dir>|remove <name>]\n")
+	case "apply":
+		src := "-"
+		if len(args) > 2 {
+			exitWith(apperr.KindValidation, "Usage: ccm apply [file|-]\n")
+		}
+		if len(args) == 2 {
+			src = args[1]
+		}
+		return handleApplyCommand(commandManager, configManager, src, dryRun)
+	case "report":
+		opts := reportOptions{Type: "bug"}
+		for i := 1; i < len(args); i++ {
+			arg := args[i]
+			switch {
+			case arg == "--title" && i+1 < len(args):
+				i++
+				opts.Title = args[i]
+			case arg == "--body" && i+1 < len(args):
+				i++
+				opts.Body = args[i]
+			case arg == "--body-file" && i+1 < len(args):
+				i++
+				opts.BodyFile = args[i]
+			case arg == "--type" && i+1 < len(args):
+				i++
+				opts.Type = args[i]
+			default:
+				exitWith(apperr.KindValidation, "Unknown option for report: %s\n", arg)
+			}
+		}
+		if opts.Title == "" || (opts.Body != "" && opts.BodyFile != "") {
+			exitWith(apperr.KindValidation, "Usage: ccm report --title <text> [--body <text>|--body-file <file|->] [--type bug|feature|question]\n")
+		}
+		return handleReportCommand(opts)
 	case "browse":
 		if len(args) < 2 {
-			fmt.Fprintf(os.Stderr, "Usage: ccm browse <github_url>\n")
-			os.Exit(1)
+			exitWith(apperr.KindValidation, "Usage: ccm browse <github_url>\n")
 		}
 		return handleBrowseCommand(args[1])
 	case "help", "-h", "--help":
@@ -162,402 +824,2236 @@ func handleCLICommands(args []string, commandsDir, configPath, userCommandsDir,
 	default:
 		fmt.Fprintf(os.Stderr, "Unknown command: %s\n", args[0])
 		printUsage()
-		os.Exit(1)
+		os.Exit(apperr.KindValidation.ExitCode())
 	}
 
 	return false
 }
 
-func handleListCommands(commandManager *commands.Manager) bool {
+func handleListCommands(commandManager *commands.Manager, modelFilter string, tagFilters []string) bool {
 	cmds, err := commandManager.ScanCommands()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error scanning commands: %v\n", err)
-		os.Exit(1)
+		exitWith(apperr.KindOf(err), "Error scanning commands: %v\n", err)
 	}
 
 	for _, cmd := range cmds {
+		if modelFilter != "" && cmd.Model != modelFilter {
+			continue
+		}
+		if slices.ContainsFunc(tagFilters, func(tag string) bool { return !slices.Contains(cmd.Tags, tag) }) {
+			continue
+		}
+		
 		status := "[ ]"
 		if cmd.Enabled {
 			status = "[✓]"
 		}
 		
-		// Add location decorator
-		var locationIcon string
-		switch cmd.SymlinkLocation {
-		case config.SymlinkLocationUser:
-			locationIcon = "👤"
-		case config.SymlinkLocationProject:
-			locationIcon = "📁"
-		default:
-			locationIcon = "👤"
+		icon := locationIcon(cmd.SymlinkLocation)
+		
+		modelBadge := ""
+		if badge := models.Badge(cmd.Model); badge != "" {
+			modelBadge = " [" + badge + "]"
 		}
 		
-		fmt.Printf("%s %s %s: %s\n", status, locationIcon, cmd.DisplayName, cmd.Description)
+		tags := ""
+		if len(cmd.Tags) > 0 {
+			tags = " #" + strings.Join(cmd.Tags, " #")
+		}
+		
+		fmt.Printf("%s %s %s%s%s%s: %s\n", status, icon, cmd.QualifiedName(), targetSuffix(cmd.SymlinkLocation), modelBadge, tags, cmd.Description)
+		if warning := models.DeprecationWarning(cmd.Model); warning != "" {
+			fmt.Fprintf(os.Stderr, "Warning: %s: %s\n", cmd.DisplayName, warning)
+		}
 	}
 	return true
 }
 
-func handleStatusCommands(commandManager *commands.Manager) bool {
+// locationIcon returns the decorator shown for a symlink location
+func locationIcon(location config.SymlinkLocation) string {
+	switch {
+	case location == config.SymlinkLocationProject:
+		return "📁"
+	case location.IsCustom():
+		return "🔗"
+	}
+	return "👤"
+}
+
+// targetSuffix names a custom symlink target after a command's name
+func targetSuffix(location config.SymlinkLocation) string {
+	if location.IsCustom() {
+		return " → " + string(location)
+	}
+	return ""
+}
+
+func handleStatusCommands(commandManager *commands.Manager, projectCommandsDir string) bool {
 	cmds, err := commandManager.ScanCommands()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error scanning commands: %v\n", err)
-		os.Exit(1)
+		exitWith(apperr.KindOf(err), "Error scanning commands: %v\n", err)
 	}
 
 	enabledCount := 0
 	for _, cmd := range cmds {
-		// Add location decorator
-		var locationIcon string
-		switch cmd.SymlinkLocation {
-		case config.SymlinkLocationUser:
-			locationIcon = "👤"
-		case config.SymlinkLocationProject:
-			locationIcon = "📁"
-		default:
-			locationIcon = "👤"
-		}
+		icon := locationIcon(cmd.SymlinkLocation)
 		
 		if cmd.Enabled {
-			fmt.Printf("✓ %s %s (enabled)\n", locationIcon, cmd.DisplayName)
+			fmt.Printf("✓ %s %s%s (enabled)\n", icon, cmd.QualifiedName(), targetSuffix(cmd.SymlinkLocation))
 			enabledCount++
 		} else {
-			fmt.Printf("○ %s %s (disabled)\n", locationIcon, cmd.DisplayName)
+			fmt.Printf("○ %s %s%s (disabled)\n", icon, cmd.QualifiedName(), targetSuffix(cmd.SymlinkLocation))
 		}
 	}
 
 	fmt.Printf("\nSummary: %d/%d commands enabled\n", enabledCount, len(cmds))
+	
+	if report, err := commandManager.CheckIntegrity(); err == nil && !report.IsHealthy() {
+		fmt.Fprintf(os.Stderr, "Warning: %s (run ccm doctor --fix)\n", report.Summary())
+	}
+	
+	if projectCommandsDir != "" {
+		if projectConfig, err := project.Load(filepath.Dir(projectCommandsDir)); err == nil && projectConfig != nil {
+			if drift := projectConfig.Check(cmds); len(drift) > 0 {
+				fmt.Fprintf(os.Stderr, "Warning: %s: %s (run ccm sync)\n", project.FileName, drift.Summary())
+			}
+		}
+	}
+	
+	for _, cmd := range cmds {
+		if warning := models.DeprecationWarning(cmd.Model); warning != "" {
+			fmt.Fprintf(os.Stderr, "Warning: %s: %s\n", cmd.DisplayName, warning)
+		}
+	}
 	return true
 }
 
-func handleEnableCommand(commandManager *commands.Manager, configManager *config.Manager, name string) bool {
+func handleEnableCommand(commandManager *commands.Manager, configManager *config.Manager, name string, dryRun bool) bool {
 	cmds, err := commandManager.ScanCommands()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error scanning commands: %v\n", err)
-		os.Exit(1)
+		exitWith(apperr.KindOf(err), "Error scanning commands: %v\n", err)
 	}
 
 	for _, cmd := range cmds {
 		if cmd.Name == name {
+			if collision := commandManager.CheckNameCollision(cmd, cmds); collision != nil && !cmd.Enabled {
+				exitWith(apperr.KindConflict, "Error enabling command: %v\nRun 'ccm rename %s %s' and enable it again\n",
+					collision, cmd.Name, collision.Suggested)
+			}
+			if dryRun {
+				printPlannedChanges("enable "+cmd.DisplayName, commandManager.PlanEnable(cmd))
+				return true
+			}
 			if err := commandManager.EnableCommand(cmd); err != nil {
-				fmt.Fprintf(os.Stderr, "Error enabling command: %v\n", err)
-				os.Exit(1)
+				exitWith(apperr.KindOf(err), "Error enabling command: %v\n", err)
 			}
 			if err := configManager.Save(); err != nil {
-				fmt.Fprintf(os.Stderr, "Error saving configuration: %v\n", err)
-				os.Exit(1)
+				exitWith(apperr.KindOf(err), "Error saving configuration: %v\n", err)
 			}
 			fmt.Printf("Enabled command: %s\n", cmd.DisplayName)
 			return true
 		}
 	}
 
-	fmt.Fprintf(os.Stderr, "Command not found: %s\n", name)
-	os.Exit(1)
+	exitWith(apperr.KindNotFound, "Command not found: %s\n", name)
 	return true
 }
 
-func handleDisableCommand(commandManager *commands.Manager, configManager *config.Manager, name string) bool {
+func handleDisableCommand(commandManager *commands.Manager, configManager *config.Manager, name string, dryRun bool) bool {
 	cmds, err := commandManager.ScanCommands()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error scanning commands: %v\n", err)
-		os.Exit(1)
+		exitWith(apperr.KindOf(err), "Error scanning commands: %v\n", err)
 	}
 
 	for _, cmd := range cmds {
 		if cmd.Name == name {
+			if dryRun {
+				printPlannedChanges("disable "+cmd.DisplayName, commandManager.PlanDisable(cmd))
+				return true
+			}
 			if err := commandManager.DisableCommand(cmd); err != nil {
-				fmt.Fprintf(os.Stderr, "Error disabling command: %v\n", err)
-				os.Exit(1)
+				exitWith(apperr.KindOf(err), "Error disabling command: %v\n", err)
 			}
 			if err := configManager.Save(); err != nil {
-				fmt.Fprintf(os.Stderr, "Error saving configuration: %v\n", err)
-				os.Exit(1)
+				exitWith(apperr.KindOf(err), "Error saving configuration: %v\n", err)
 			}
 			fmt.Printf("Disabled command: %s\n", cmd.DisplayName)
 			return true
 		}
 	}
 
-	fmt.Fprintf(os.Stderr, "Command not found: %s\n", name)
-	os.Exit(1)
+	exitWith(apperr.KindNotFound, "Command not found: %s\n", name)
 	return true
 }
 
-func handleRenameCommand(commandManager *commands.Manager, configManager *config.Manager, name, newName string) bool {
+func handleRenameCommand(commandManager *commands.Manager, configManager *config.Manager, name, newName string, dryRun bool) bool {
 	cmds, err := commandManager.ScanCommands()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error scanning commands: %v\n", err)
-		os.Exit(1)
+		exitWith(apperr.KindOf(err), "Error scanning commands: %v\n", err)
 	}
 
 	for _, cmd := range cmds {
 		if cmd.Name == name {
 			oldDisplayName := cmd.DisplayName
+			if dryRun {
+				printPlannedChanges(fmt.Sprintf("rename %s → %s", oldDisplayName, newName), commandManager.PlanRename(cmd, newName))
+				return true
+			}
 			if err := commandManager.RenameCommand(cmd, newName); err != nil {
-				fmt.Fprintf(os.Stderr, "Error renaming command: %v\n", err)
-				os.Exit(1)
+				exitWith(apperr.KindOf(err), "Error renaming command: %v\n", err)
 			}
 			if err := configManager.Save(); err != nil {
-				fmt.Fprintf(os.Stderr, "Error saving configuration: %v\n", err)
-				os.Exit(1)
+				exitWith(apperr.KindOf(err), "Error saving configuration: %v\n", err)
 			}
 			fmt.Printf("Renamed command: %s → %s\n", oldDisplayName, newName)
 			return true
 		}
 	}
 
-	fmt.Fprintf(os.Stderr, "Command not found: %s\n", name)
-	os.Exit(1)
+	exitWith(apperr.KindNotFound, "Command not found: %s\n", name)
 	return true
 }
 
-// centerText centers text in the terminal or returns it as-is if centering fails
-func centerText(text string) string {
-	// Try to get terminal width using tput command
-	if cmd := exec.Command("tput", "cols"); cmd != nil {
-		if output, err := cmd.Output(); err == nil {
-			if width, err := strconv.Atoi(strings.TrimSpace(string(output))); err == nil && width > len(text) {
-				padding := (width - len(text)) / 2
-				return strings.Repeat(" ", padding) + text
+// handleDuplicateCommand copies a command to a new file in the same library
+func handleDuplicateCommand(commandManager *commands.Manager, configManager *config.Manager, name, newName string) bool {
+	cmds, err := commandManager.ScanCommands()
+	if err != nil {
+		exitWith(apperr.KindOf(err), "Error scanning commands: %v\n", err)
+	}
+
+	for _, cmd := range cmds {
+		if cmd.Name == name {
+			copied, err := commandManager.DuplicateCommand(cmd, newName)
+			if err != nil {
+				exitWith(apperr.KindOf(err), "Error duplicating command: %v\n", err)
 			}
+			if err := configManager.Save(); err != nil {
+				exitWith(apperr.KindOf(err), "Error saving configuration: %v\n", err)
+			}
+			fmt.Printf("Duplicated %s as %s (%s)\n", cmd.DisplayName, copied.DisplayName, copied.RelativePath)
+			fmt.Printf("Enable it with 'ccm enable %s'\n", copied.Name)
+			return true
 		}
 	}
-	
-	// Fallback if we can't get terminal size
-	return text
-}
 
-func printUsage() {
-	// Center the title
-	fmt.Println(centerText("Claude Command Manager"))
-	fmt.Println()
-	fmt.Println("Usage:")
-	fmt.Println("  ccm                          Launch interactive TUI")
-	fmt.Println("  ccm list                     List all available commands")
-	fmt.Println("  ccm status                   Show current command status")
-	fmt.Println("  ccm enable <command_name>    Enable a specific command")
-	fmt.Println("  ccm disable <command_name>   Disable a specific command")
-	fmt.Println("  ccm rename <cmd> <new_name>  Rename a command")
-	fmt.Println("  ccm import <github_url>      Import commands from GitHub repository")
-	fmt.Println("  ccm browse <github_url>      Browse available commands in repository")
-	fmt.Println("  ccm help                     Show this help message")
-	fmt.Println()
-	
-	// Center the copyright text
-	copyrightText := fmt.Sprintf("© %d shelcorp. All rights reserved.", time.Now().Year())
-	fmt.Println(centerText(copyrightText))
+	exitWith(apperr.KindNotFound, "Command not found: %s\n", name)
+	return true
 }
 
-// handleBrowseCommand lists available commands in a remote repository
-func handleBrowseCommand(url string) bool {
-	// Parse the GitHub URL
-	repo, err := remote.ParseGitHubURL(url)
+// handleSetMetaCommand rewrites a command's frontmatter keys, or prints them when no values are given
+func handleSetMetaCommand(commandManager *commands.Manager, name string, values map[string]string) bool {
+	cmds, err := commandManager.ScanCommands()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+		exitWith(apperr.KindOf(err), "Error scanning commands: %v\n", err)
 	}
 
-	// Initialize GitHub client
-	client := remote.NewGitHubClient()
-
-	// Show loading and validate
-	fmt.Printf("🔍 Connecting to %s/%s...", repo.Owner, repo.Repo)
-	if err := client.ValidateRepository(repo); err != nil {
-		fmt.Printf(" ❌\n")
-		fmt.Fprintf(os.Stderr, "Repository not accessible: %v\n", err)
-		os.Exit(1)
+	for _, cmd := range cmds {
+		if cmd.Name != name {
+			continue
+		}
+		if len(values) > 0 {
+			if err := commandManager.SetMeta(cmd, values); err != nil {
+				exitWith(apperr.KindOf(err), "Error updating frontmatter: %v\n", err)
+			}
+			fmt.Printf("Updated frontmatter of %s (%s)\n", cmd.DisplayName, cmd.RelativePath)
+		}
+		meta, err := commands.ReadMeta(cmd)
+		if err != nil {
+			exitWith(apperr.KindOf(err), "Error reading frontmatter: %v\n", err)
+		}
+		for _, key := range commands.MetaKeys {
+			value := meta[key]
+			if value == "" {
+				value = "(not set)"
+			}
+			fmt.Printf("  %-14s %s\n", key+":", value)
+		}
+		return true
 	}
-	fmt.Printf(" ✅\n")
 
-	// Fetch commands with loading indicator
-	fmt.Printf("📦 Scanning for commands...")
-	if err := client.FetchCommands(repo); err != nil {
+	exitWith(apperr.KindNotFound, "Command not found: %s\n", name)
+	return true
+}
+
+// handleTagCommand adds (+tag or tag) and removes (-tag) a command's tags, then prints them
+func handleTagCommand(commandManager *commands.Manager, configManager *config.Manager, name string, edits []string) bool {
+	add, remove, err := commands.ParseTagEdit(strings.Join(edits, " "))
+	if err != nil {
+		exitWith(apperr.KindOf(err), "Error: %v\n", err)
+	}
+
+	cmds, err := commandManager.ScanCommands()
+	if err != nil {
+		exitWith(apperr.KindOf(err), "Error scanning commands: %v\n", err)
+	}
+
+	for _, cmd := range cmds {
+		if cmd.Name != name {
+			continue
+		}
+		tags := cmd.Tags
+		if changes := commands.PlanTagEdit([]commands.Command{cmd}, add, remove); len(changes) > 0 {
+			if _, err := commandManager.ApplyTagChanges(changes); err != nil {
+				exitWith(apperr.KindOf(err), "Error updating tags: %v\n", err)
+			}
+			if err := configManager.Save(); err != nil {
+				exitWith(apperr.KindOf(err), "Error saving configuration: %v\n", err)
+			}
+			tags = changes[0].After
+		}
+		if len(tags) == 0 {
+			fmt.Printf("%s has no tags\n", cmd.DisplayName)
+		} else {
+			fmt.Printf("%s: #%s\n", cmd.DisplayName, strings.Join(tags, " #"))
+		}
+		return true
+	}
+
+	exitWith(apperr.KindNotFound, "Command not found: %s\n", name)
+	return true
+}
+
+// doctorLibrary is a library checked by ccm doctor
+type doctorLibrary struct {
+	label         string
+	manager       *commands.Manager
+	configManager *config.Manager
+}
+
+// handleDoctorCommand reports where each library's symlinks have drifted from its config
+// and, with fix, repairs them after confirmation
+func handleDoctorCommand(libraries []doctorLibrary, fix, yes bool) bool {
+	type finding struct {
+		library doctorLibrary
+		report  commands.IntegrityReport
+	}
+	var findings []finding
+	for _, library := range libraries {
+		report, err := library.manager.CheckIntegrity()
+		if err != nil {
+			continue // The library does not exist
+		}
+		if report.IsHealthy() {
+			fmt.Printf("✅ %s: symlinks match the configuration\n", library.label)
+			continue
+		}
+		fmt.Printf("⚠️  %s: %s\n", library.label, report.Summary())
+		for _, line := range report.Details(library.manager) {
+			fmt.Printf("   • %s\n", line)
+		}
+		findings = append(findings, finding{library, report})
+	}
+	if len(findings) == 0 {
+		return true
+	}
+
+	if !fix {
+		fmt.Println("\n💡 Run 'ccm doctor --fix' to repair")
+		os.Exit(apperr.KindConflict.ExitCode())
+	}
+	if !yes {
+		fmt.Print("\nRepair now? (y/N): ")
+		var response string
+		fmt.Scanln(&response)
+		response = strings.ToLower(strings.TrimSpace(response))
+		if response != "y" && response != "yes" {
+			fmt.Println("Repair cancelled.")
+			return true
+		}
+	}
+
+	for _, f := range findings {
+		fixed, err := f.library.manager.RepairIntegrity(f.report)
+		if saveErr := f.library.configManager.Save(); err == nil {
+			err = saveErr
+		}
+		if err != nil {
+			exitWith(apperr.KindOf(err), "%s: fixed %d issue(s) before failing: %v\n", f.library.label, fixed, err)
+		}
+		fmt.Printf("✅ %s: fixed %d issue(s)\n", f.library.label, fixed)
+	}
+	return true
+}
+
+// handleUsageCommand prints how often each enabled command (or, with all, every command)
+// was invoked in Claude's session transcripts, least used first
+func handleUsageCommand(commandManager *commands.Manager, all bool) bool {
+	cmds, err := commandManager.ScanCommands()
+	if err != nil {
+		exitWith(apperr.KindOf(err), "Error scanning commands: %v\n", err)
+	}
+	dir, err := usage.DefaultDir()
+	if err != nil {
+		exitWith(apperr.KindGeneral, "Error finding Claude's session transcripts: %v\n", err)
+	}
+	stats, err := usage.NewScanner(dir).Scan(time.Now())
+	if err != nil {
+		exitWith(apperr.KindGeneral, "Error reading Claude's session transcripts: %v\n", err)
+	}
+
+	type row struct {
+		cmd   commands.Command
+		stats usage.Stats
+	}
+	var rows []row
+	for _, cmd := range cmds {
+		if cmd.Enabled || all {
+			rows = append(rows, row{cmd, usage.Lookup(stats, cmd.QualifiedName())})
+		}
+	}
+	if len(rows) == 0 {
+		fmt.Println("No enabled commands (use --all to include disabled ones)")
+		return true
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		return rows[i].stats.Total < rows[j].stats.Total
+	})
+
+	fmt.Printf("Uses in Claude sessions (%s)\n", dir)
+	fmt.Printf("  %-10s %-6s %-16s %s\n", "this month", "total", "last used", "command")
+	unused := 0
+	for _, r := range rows {
+		lastUsed := "never"
+		if r.stats.Total == 0 {
+			unused++
+		} else {
+			lastUsed = r.stats.LastUsed.Local().Format("2006-01-02 15:04")
+		}
+		fmt.Printf("  %-10d %-6d %-16s /%s\n", r.stats.ThisMonth, r.stats.Total, lastUsed, r.cmd.QualifiedName())
+	}
+	if unused > 0 {
+		fmt.Printf("%d command(s) never used; 'ccm disable <command>' removes one from Claude\n", unused)
+	}
+	return true
+}
+
+// handleLintCommand checks the library's command files and reports the issues found by file,
+// exiting with the validation code if any are errors
+func handleLintCommand(commandManager *commands.Manager, names []string, asJSON bool) bool {
+	issues, err := commandManager.Lint()
+	if err != nil {
+		exitWith(apperr.KindOf(err), "Error scanning commands: %v\n", err)
+	}
+	cmds, err := commandManager.ScanCommands()
+	if err != nil {
+		exitWith(apperr.KindOf(err), "Error scanning commands: %v\n", err)
+	}
+
+	// Limit the report to the named commands
+	if len(names) > 0 {
+		paths := make(map[string]bool)
+		for _, name := range names {
+			found := false
+			for _, cmd := range cmds {
+				if cmd.Name == name {
+					paths[cmd.RelativePath], found = true, true
+				}
+			}
+			if !found {
+				exitWith(apperr.KindNotFound, "Command not found: %s\n", name)
+			}
+		}
+		issues = slices.DeleteFunc(issues, func(issue commands.LintIssue) bool { return !paths[issue.RelativePath] })
+		cmds = slices.DeleteFunc(cmds, func(cmd commands.Command) bool { return !paths[cmd.RelativePath] })
+	}
+
+	counts := make(map[commands.LintSeverity]int)
+	files := make(map[string]bool)
+	for _, issue := range issues {
+		counts[issue.Severity]++
+		files[issue.RelativePath] = true
+	}
+
+	if asJSON {
+		if issues == nil {
+			issues = []commands.LintIssue{}
+		}
+		data, err := json.MarshalIndent(issues, "", "  ")
+		if err != nil {
+			exitWith(apperr.KindGeneral, "Error encoding lint report: %v\n", err)
+		}
+		fmt.Println(string(data))
+	} else {
+		current := ""
+		for _, issue := range issues {
+			if issue.RelativePath != current {
+				if current != "" {
+					fmt.Println()
+				}
+				current = issue.RelativePath
+				fmt.Println(current)
+			}
+			fmt.Printf("  %-8s %-14s %s\n", issue.Severity, issue.Rule, issue.Message)
+		}
+		if len(issues) > 0 {
+			fmt.Println()
+		}
+		if len(issues) == 0 {
+			fmt.Printf("✅ %d command(s) checked, no issues\n", len(cmds))
+		} else {
+			fmt.Printf("%d error(s), %d warning(s), %d info in %d of %d command(s)\n",
+				counts[commands.LintError], counts[commands.LintWarning], counts[commands.LintInfo], len(files), len(cmds))
+		}
+	}
+
+	if counts[commands.LintError] > 0 {
+		os.Exit(apperr.KindValidation.ExitCode())
+	}
+	return true
+}
+
+func handleMoveCommand(commandManager *commands.Manager, configManager *config.Manager, name, location string, enable, dryRun bool) bool {
+	newLocation, err := commandManager.ParseSymlinkLocation(location)
+	if err != nil {
+		exitWith(apperr.KindOf(err), "Error: %v\n", err)
+	}
+
+	cmds, err := commandManager.ScanCommands()
+	if err != nil {
+		exitWith(apperr.KindOf(err), "Error scanning commands: %v\n", err)
+	}
+
+	for _, cmd := range cmds {
+		if cmd.Name == name {
+			if dryRun {
+				changes := commandManager.PlanSetSymlinkLocation(cmd, newLocation)
+				if enable && !cmd.Enabled {
+					moved := cmd
+					moved.SymlinkLocation = newLocation
+					changes = append(changes, commandManager.PlanEnable(moved)...)
+				}
+				printPlannedChanges(fmt.Sprintf("move %s → %s", cmd.DisplayName, newLocation), changes)
+				return true
+			}
+			if err := commandManager.SetSymlinkLocation(cmd, newLocation); err != nil {
+				exitWith(apperr.KindOf(err), "Error moving command: %v\n", err)
+			}
+			cmd.SymlinkLocation = newLocation
+
+			if enable && !cmd.Enabled {
+				if err := commandManager.EnableCommand(cmd); err != nil {
+					exitWith(apperr.KindOf(err), "Error enabling command: %v\n", err)
+				}
+				cmd.Enabled = true
+			}
+
+			if err := configManager.Save(); err != nil {
+				exitWith(apperr.KindOf(err), "Error saving configuration: %v\n", err)
+			}
+
+			state := "disabled"
+			if cmd.Enabled {
+				state = "enabled"
+			}
+			fmt.Printf("Moved command: %s -> %s (%s)\n", cmd.DisplayName, newLocation, state)
+			return true
+		}
+	}
+
+	exitWith(apperr.KindNotFound, "Command not found: %s\n", name)
+	return true
+}
+
+// handleTrashList prints the deleted commands in the library's trash, newest first
+func handleTrashList(commandManager *commands.Manager) bool {
+	trashed, err := commandManager.ListTrash()
+	if err != nil {
+		exitWith(apperr.KindOf(err), "Error: %v\n", err)
+	}
+	if len(trashed) == 0 {
+		fmt.Println("Trash is empty.")
+		return true
+	}
+	for _, item := range trashed {
+		deleted := "unknown date"
+		if !item.DeletedAt.IsZero() {
+			deleted = item.DeletedAt.Format("2006-01-02 15:04")
+		}
+		fmt.Printf("%-30s %-16s %s\n", item.Name, deleted, item.RelativePath)
+	}
+	fmt.Printf("\nRestore one with 'ccm trash restore <name>'.\n")
+	return true
+}
+
+// handleTrashRestore moves the most recently deleted command with a name back into the library
+func handleTrashRestore(commandManager *commands.Manager, configManager *config.Manager, name string) bool {
+	trashed, err := commandManager.ListTrash()
+	if err != nil {
+		exitWith(apperr.KindOf(err), "Error: %v\n", err)
+	}
+	for _, item := range trashed {
+		matches := item.Name == name || item.RelativePath == name || item.RelativePath == name+".md"
+		if item.Config != nil && item.Config.DisplayName == name {
+			matches = true
+		}
+		if !matches {
+			continue
+		}
+
+		cmd, err := commandManager.RestoreCommand(item)
+		if err != nil {
+			exitWith(apperr.KindOf(err), "Error restoring command: %v\n", err)
+		}
+		if err := configManager.Save(); err != nil {
+			exitWith(apperr.KindOf(err), "Error saving configuration: %v\n", err)
+		}
+		fmt.Printf("Restored command: %s (%s)\n", cmd.DisplayName, cmd.RelativePath)
+		if item.Config != nil && item.Config.Enabled && !cmd.Enabled {
+			fmt.Printf("Note: it could not be re-enabled; run 'ccm enable %s' once its symlink path is free\n", cmd.Name)
+		}
+		return true
+	}
+
+	exitWith(apperr.KindNotFound, "No deleted command named %s (run 'ccm trash list')\n", name)
+	return true
+}
+
+// handleTrashEmpty permanently deletes the library's trash, asking first unless forced
+func handleTrashEmpty(commandManager *commands.Manager, force bool) bool {
+	trashed, err := commandManager.ListTrash()
+	if err != nil {
+		exitWith(apperr.KindOf(err), "Error: %v\n", err)
+	}
+	if len(trashed) == 0 {
+		fmt.Println("Trash is empty.")
+		return true
+	}
+	if !force {
+		fmt.Printf("Permanently delete %d command(s) in the trash? (y/N): ", len(trashed))
+		var response string
+		fmt.Scanln(&response)
+		response = strings.ToLower(strings.TrimSpace(response))
+		if response != "y" && response != "yes" {
+			fmt.Println("Cancelled.")
+			return true
+		}
+	}
+
+	removed, err := commandManager.EmptyTrash()
+	if err != nil {
+		exitWith(apperr.KindOf(err), "Error: %v\n", err)
+	}
+	fmt.Printf("Permanently deleted %d command(s).\n", removed)
+	return true
+}
+
+func handleDeleteCommand(commandManager *commands.Manager, configManager *config.Manager, name string, force, dryRun bool) bool {
+	cmds, err := commandManager.ScanCommands()
+	if err != nil {
+		exitWith(apperr.KindOf(err), "Error scanning commands: %v\n", err)
+	}
+
+	for _, cmd := range cmds {
+		if cmd.Name == name {
+			if dryRun {
+				printPlannedChanges("delete "+cmd.DisplayName, commandManager.PlanDelete(cmd))
+				return true
+			}
+			if !force {
+				fmt.Printf("Delete command '%s' (%s)? It will be moved to the trash. (y/N): ", cmd.DisplayName, cmd.RelativePath)
+				var response string
+				fmt.Scanln(&response)
+				response = strings.ToLower(strings.TrimSpace(response))
+				if response != "y" && response != "yes" {
+					fmt.Println("Delete cancelled.")
+					return true
+				}
+			}
+			
+			trashPath, err := commandManager.DeleteCommand(cmd)
+			if err != nil {
+				exitWith(apperr.KindOf(err), "Error deleting command: %v\n", err)
+			}
+			if err := configManager.Save(); err != nil {
+				exitWith(apperr.KindOf(err), "Error saving configuration: %v\n", err)
+			}
+			fmt.Printf("Deleted command: %s\n", cmd.DisplayName)
+			fmt.Printf("Backup saved to: %s\n", trashPath)
+			return true
+		}
+	}
+
+	exitWith(apperr.KindNotFound, "Command not found: %s\n", name)
+	return true
+}
+
+// centerText centers text in the terminal or returns it as-is if centering fails
+func centerText(text string) string {
+	// Try to get terminal width using tput command
+	if cmd := exec.Command("tput", "cols"); cmd != nil {
+		if output, err := cmd.Output(); err == nil {
+			if width, err := strconv.Atoi(strings.TrimSpace(string(output))); err == nil && width > len(text) {
+				padding := (width - len(text)) / 2
+				return strings.Repeat(" ", padding) + text
+			}
+		}
+	}
+	
+	// Fallback if we can't get terminal size
+	return text
+}
+
+func printUsage() {
+	// Center the title
+	fmt.Println(centerText("Claude Command Manager"))
+	fmt.Println()
+	fmt.Println("Usage:")
+	fmt.Println("  ccm                          Launch interactive TUI")
+	fmt.Println("  ccm list [--model <model>] [--tag <tag>]...")
+	fmt.Println("                               List all available commands, or those with every given tag")
+	fmt.Println("  ccm status                   Show current command status")
+	fmt.Println("  ccm enable <command_name>    Enable a specific command")
+	fmt.Println("  ccm disable <command_name>   Disable a specific command")
+	fmt.Println("  ccm rename <cmd> <new_name>  Rename a command")
+	fmt.Println("  ccm duplicate <cmd> <new_name>")
+	fmt.Println("                               Copy a command to a new file to make a variant")
+	fmt.Println("  ccm set-meta <cmd> [--description <text>] [--argument-hint <hint>]")
+	fmt.Println("                 [--allowed-tools <tools>] [--model <model>]")
+	fmt.Println("                               Rewrite a command's frontmatter (\"\" removes a key); no flags prints it")
+	fmt.Println("  ccm usage [--all]            Show how often enabled commands were used in Claude sessions")
+	fmt.Println("  ccm tag <cmd> [+tag|-tag]... Add or remove a command's tags; no changes prints them")
+	fmt.Println("  ccm move <cmd> user|project|<target> [--enable]")
+	fmt.Println("                               Set where a command is symlinked")
+	fmt.Println("  ccm target [list|add <name> <dir>|remove <name>]")
+	fmt.Println("                               Manage custom directories commands can be symlinked into")
+	fmt.Println("  ccm delete <cmd> [--force]   Delete a command (moved to trash)")
+	fmt.Println("  ccm trash [list]             Show deleted commands")
+	fmt.Println("  ccm trash restore <cmd>      Bring back the latest deleted copy of a command")
+	fmt.Println("  ccm trash empty [--force]    Permanently delete everything in the trash")
+	fmt.Println("  ccm doctor [--fix] [--yes]   Find symlinks that drifted from the config (renamed, replaced, missing) and repair them")
+	fmt.Println("  ccm lint [<cmd>...] [--output text|json]")
+	fmt.Println("                               Check command files for frontmatter, tool, argument and name problems")
+	fmt.Println("  ccm show <cmd> [--raw]       Print a command's frontmatter and content")
+	fmt.Println("  ccm edit <command_name>      Open a command in $EDITOR")
+	fmt.Println("  ccm new <name> [--template <t>] [--edit]")
+	fmt.Println("                               Create a command from a template")
+	fmt.Println("  ccm new --templates          List available command templates")
+	fmt.Println("  ccm sync [--check] [--prune] Reconcile with the project's .claude/ccm.yaml")
+	fmt.Println("  ccm apply [file|-]           Reconcile commands with a YAML/JSON desired state")
+	fmt.Println("  ccm export <file> [--user]   Export the project (or user) library as a .tar.gz")
+	fmt.Println("  ccm import-archive <file> [--user] [--overwrite|--skip] [--no-backup]")
+	fmt.Println("                               Restore commands from an exported archive")
+	fmt.Println("  ccm migrate <old> <new>      Rename a frontmatter key across all commands")
+	fmt.Println("  ccm import <github_url>      Import commands from GitHub repository")
+	fmt.Println("                               --select <numbers|all> skips the prompt;")
+	fmt.Println("                               --output json prints per-command results")
+	fmt.Println("  ccm imports [list|retry|clear] [owner/repo]")
+	fmt.Println("                               Inspect or retry previously failed imports")
+	fmt.Println("  ccm browse <github_url>      Browse available commands in repository")
+	fmt.Println("  ccm registry list|add|remove Manage custom repositories in the registry")
+	fmt.Println("  ccm registry update          Refresh the cached registry and download stats")
+	fmt.Println("  ccm theme [list|set|preview] [id]")
+	fmt.Println("                               List, switch or preview color themes")
+	fmt.Println("  ccm theme profiles           Show the detected terminal and per-profile themes")
+	fmt.Println("  ccm theme bind <profile> <id>")
+	fmt.Println("                               Use a theme in a terminal profile, program or tmux")
+	fmt.Println("  ccm theme unbind <profile>   Remove a per-profile theme")
+	fmt.Println("  ccm theme export <id> [file] [--output json|yaml]")
+	fmt.Println("                               Share a theme as JSON or YAML (from the file extension)")
+	fmt.Println("  ccm theme import <file-or-url|->")
+	fmt.Println("                               Add a shared theme to your themes (- reads stdin)")
+	fmt.Println("  ccm theme convert <file-or-url> [id]")
+	fmt.Println("                               Make a theme from a Base16, iTerm2 or Alacritty scheme")
+	fmt.Println("  ccm config [list|path]       Show application settings")
+	fmt.Println("  ccm config get|set <key> [value]")
+	fmt.Println("                               Read or change an application setting")
+	fmt.Println("  ccm report --title <text> [--body <text>|--body-file <file|->] [--type bug|feature|question]")
+	fmt.Println("                               File an issue against ccm, or report.repository (requires gh)")
+	fmt.Println("  ccm completion bash|zsh|fish Print a shell completion script")
+	fmt.Println("  ccm help                     Show this help message")
+	fmt.Println("  ccm version                  Show version and build information")
+	fmt.Println()
+	fmt.Println("Global options:")
+	fmt.Println("  --library user|project       Library to operate on (default: project, or $CCM_LIBRARY)")
+	fmt.Println("  --dry-run                    Show what enable, disable, rename, move, delete,")
+	fmt.Println("                               import or apply would change without touching disk")
+	fmt.Println("  --verbose                    Log debug detail (also CCM_DEBUG=1); CLI commands")
+	fmt.Println("                               echo it to stderr. Log: ~/.config/claude_command_manager/ccm.log")
+	fmt.Println("  --plain                      Draw the TUI with plain ASCII, without emoji or box drawing")
+	fmt.Println("                               (also NO_EMOJI=1 or ccm config set theme.plain true)")
+	fmt.Println()
+	fmt.Println("Exit codes:")
+	for _, kind := range apperr.Kinds() {
+		fmt.Printf("  %d  %s\n", kind.ExitCode(), kind)
+	}
+	fmt.Println()
+	
+	// Center the copyright text
+	copyrightText := fmt.Sprintf("© %d shelcorp. All rights reserved.", time.Now().Year())
+	fmt.Println(centerText(copyrightText))
+}
+
+// reportOptions holds the parsed arguments for `ccm report`
+type reportOptions struct {
+	Title    string
+	Body     string
+	BodyFile string // "-" reads the body from stdin
+	Type     string
+}
+
+// handleReportCommand files an issue against the ccm repository through the GitHub CLI
+func handleReportCommand(opts reportOptions) bool {
+	label, ok := remote.IssueTypes[opts.Type]
+	if !ok {
+		exitWith(apperr.KindValidation, "Error: unknown issue type %q (use bug, feature or question)\n", opts.Type)
+	}
+
+	title := strings.TrimSpace(opts.Title)
+	if len(title) > 100 {
+		exitWith(apperr.KindValidation, "Error: title too long (max 100 characters)\n")
+	}
+
+	body := opts.Body
+	if opts.BodyFile != "" {
+		var data []byte
+		var err error
+		if opts.BodyFile == "-" {
+			data, err = io.ReadAll(os.Stdin)
+		} else {
+			data, err = os.ReadFile(opts.BodyFile)
+		}
+		if err != nil {
+			exitWith(apperr.KindOf(err), "Error reading issue body: %v\n", err)
+		}
+		body = string(data)
+	}
+
+	repo, err := remote.IssueRepository(loadAppSettings().GetAppConfig().Report.Repository)
+	if err != nil {
+		exitWith(apperr.KindOf(err), "Error: %v\n", err)
+	}
+
+	issueURL, err := remote.CreateGitHubIssue(repo, title, strings.TrimSpace(body), label)
+	if err != nil {
+		exitWith(apperr.KindOf(err), "Error: %v\n", err)
+	}
+	fmt.Printf("Created issue: %s\n", issueURL)
+	return true
+}
+
+// newCommandOptions holds the parsed arguments for `ccm new`
+type newCommandOptions struct {
+	Path     string
+	Template string
+	Values   templates.Values
+	Edit     bool
+}
+
+func handleNewCommand(commandManager *commands.Manager, configManager *config.Manager, opts newCommandOptions) bool {
+	tmpl, err := templates.Get(opts.Template)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		exitWith(apperr.KindOf(err), "Run 'ccm new --templates' to see available templates\n")
+	}
+
+	opts.Values.Name = strings.TrimSuffix(filepath.Base(opts.Path), ".md")
+	content, err := tmpl.Render(opts.Values)
+	if err != nil {
+		exitWith(apperr.KindOf(err), "Error rendering template: %v\n", err)
+	}
+
+	cmd, err := commandManager.CreateCommand(opts.Path, content)
+	if err != nil {
+		exitWith(apperr.KindOf(err), "Error creating command: %v\n", err)
+	}
+
+	if err := configManager.Save(); err != nil {
+		exitWith(apperr.KindOf(err), "Error saving configuration: %v\n", err)
+	}
+
+	fmt.Printf("✅ Created command: %s\n", cmd.DisplayName)
+	fmt.Printf("   File: %s\n", cmd.FilePath)
+
+	if opts.Edit {
+		if err := openInEditor(cmd.FilePath); err != nil {
+			exitWith(apperr.KindOf(err), "Error opening editor: %v\n", err)
+		}
+	} else {
+		fmt.Printf("\n💡 Enable it with: ccm enable %s\n", cmd.Name)
+	}
+
+	return true
+}
+
+func handleShowCommand(commandManager *commands.Manager, name string, raw, plain bool, styles *theme.Styles) bool {
+	cmds, err := commandManager.ScanCommands()
+	if err != nil {
+		exitWith(apperr.KindOf(err), "Error scanning commands: %v\n", err)
+	}
+
+	for _, cmd := range cmds {
+		if cmd.Name != name {
+			continue
+		}
+
+		data, err := os.ReadFile(cmd.FilePath)
+		if err != nil {
+			exitWith(apperr.KindOf(err), "Error reading command: %v\n", err)
+		}
+
+		if raw {
+			fmt.Print(string(data))
+			return true
+		}
+
+		fields, body := commands.SplitFrontmatter(string(data))
+
+		titleStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.Primary)
+		keyStyle := lipgloss.NewStyle().Foreground(styles.MutedCol)
+
+		status := "disabled"
+		if cmd.Enabled {
+			status = fmt.Sprintf("enabled (%s)", cmd.SymlinkLocation)
+		}
+
+		fmt.Println(titleStyle.Render("/" + cmd.DisplayName))
+		fmt.Printf("%s %s\n", keyStyle.Render(fmt.Sprintf("%-14s", "file:")), cmd.FilePath)
+		fmt.Printf("%s %s\n", keyStyle.Render(fmt.Sprintf("%-14s", "status:")), status)
+		for _, field := range fields {
+			fmt.Printf("%s %s\n", keyStyle.Render(fmt.Sprintf("%-14s", field.Key+":")), field.Value)
+		}
+		if warning := models.DeprecationWarning(cmd.Model); warning != "" {
+			fmt.Fprintf(os.Stderr, "⚠️  %s\n", warning)
+		}
+		fmt.Println()
+		if plain {
+			fmt.Print(body)
+		} else {
+			fmt.Println(tui.RenderMarkdown(body, showWidth))
+		}
+		return true
+	}
+
+	exitWith(apperr.KindNotFound, "Command not found: %s\n", name)
+	return true
+}
+
+// showWidth is the width `ccm show` wraps a command body to
+const showWidth = 80
+
+func handleEditCommand(commandManager *commands.Manager, name string) bool {
+	cmds, err := commandManager.ScanCommands()
+	if err != nil {
+		exitWith(apperr.KindOf(err), "Error scanning commands: %v\n", err)
+	}
+
+	for _, cmd := range cmds {
+		if cmd.Name == name {
+			if err := openInEditor(cmd.FilePath); err != nil {
+				exitWith(apperr.KindOf(err), "Error opening editor: %v\n", err)
+			}
+			return true
+		}
+	}
+
+	exitWith(apperr.KindNotFound, "Command not found: %s\n", name)
+	return true
+}
+
+func handleListTemplates() bool {
+	all, err := templates.List()
+	if err != nil {
+		exitWith(apperr.KindOf(err), "Error loading templates: %v\n", err)
+	}
+
+	fmt.Println("Available templates:")
+	for _, tmpl := range all {
+		source := "user"
+		if tmpl.Builtin {
+			source = "built-in"
+		}
+		fmt.Printf("  %-16s %s (%s)\n", tmpl.Name, tmpl.Description, source)
+	}
+
+	if dir, err := templates.GetTemplateDir(); err == nil {
+		fmt.Printf("\n💡 Add your own templates as .md files in %s\n", dir)
+		fmt.Println("   They can use {{.Name}}, {{.Description}}, {{.ArgumentHint}} and {{.AllowedTools}};")
+		fmt.Println("   a first line like {{/* Bug triage */}} describes the template here.")
+	}
+	return true
+}
+
+// openInEditor opens a file in the user's editor attached to the terminal
+func openInEditor(path string) error {
+	cmd := commands.EditorCommand(path)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+func handleSyncCommand(commandManager *commands.Manager, configManager *config.Manager, claudeDir string, checkOnly, prune bool) bool {
+	projectConfig, err := project.Load(claudeDir)
+	if err != nil {
+		exitWith(apperr.KindOf(err), "Error: %v\n", err)
+	}
+	if projectConfig == nil {
+		fmt.Printf("No %s found in %s\n", project.FileName, claudeDir)
+		return true
+	}
+
+	cmds, err := commandManager.ScanCommands()
+	if err != nil {
+		exitWith(apperr.KindOf(err), "Error scanning commands: %v\n", err)
+	}
+
+	drift := projectConfig.Check(cmds)
+	if len(drift) == 0 {
+		fmt.Printf("✅ Library matches %s\n", projectConfig.Path())
+		return true
+	}
+
+	fmt.Printf("📌 %s: %s\n", projectConfig.Path(), drift.Summary())
+	for _, item := range drift {
+		fmt.Printf("   • %s\n", item.Describe())
+	}
+
+	if checkOnly {
+		os.Exit(apperr.KindConflict.ExitCode())
+	}
+
+	// Undeclared extras are only disabled when explicitly requested
+	toFix := make(project.Drift, 0, len(drift))
+	for _, item := range drift {
+		if item.Kind != project.DriftExtra || prune {
+			toFix = append(toFix, item)
+		}
+	}
+	if len(toFix) < len(drift) {
+		fmt.Printf("\n💡 Use --prune to also disable commands not declared in %s,\n", project.FileName)
+		fmt.Printf("   or add exceptions to %s\n", filepath.Join(claudeDir, project.LocalFileName))
+	}
+	if len(toFix) == 0 {
+		return true
+	}
+
+	fmt.Print("\nReconcile now? (y/N): ")
+	var response string
+	fmt.Scanln(&response)
+	response = strings.ToLower(strings.TrimSpace(response))
+	if response != "y" && response != "yes" {
+		fmt.Println("Sync cancelled.")
+		return true
+	}
+
+	result := project.Reconcile(commandManager, toFix)
+	if err := configManager.Save(); err != nil {
+		exitWith(apperr.KindOf(err), "Error saving configuration: %v\n", err)
+	}
+
+	for _, fixed := range result.Fixed {
+		fmt.Printf("   ✅ %s\n", fixed)
+	}
+	for _, unresolved := range result.Unresolved {
+		fmt.Printf("   ❌ %s\n", unresolved)
+	}
+	if len(result.Unresolved) > 0 {
+		os.Exit(apperr.KindConflict.ExitCode())
+	}
+	return true
+}
+
+// handleApplyCommand reconciles the library with a desired-state document read from
+// a file or stdin, printing each change as it is applied
+func handleApplyCommand(commandManager *commands.Manager, configManager *config.Manager, src string, dryRun bool) bool {
+	var data []byte
+	var err error
+	if src == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(src)
+	}
+	if err != nil {
+		exitWith(apperr.KindOf(err), "Error reading state: %v\n", err)
+	}
+
+	state, err := commands.ParseDesiredState(data)
+	if err != nil {
+		exitWith(apperr.KindOf(err), "Error: %v\n", err)
+	}
+
+	if dryRun {
+		changes, err := commandManager.DiffState(state)
+		if err != nil {
+			exitWith(apperr.KindOf(err), "Error: %v\n", err)
+		}
+		fmt.Printf("Dry run: apply %d change(s)\n", len(changes))
+		printStateChanges(changes)
+		return true
+	}
+
+	applied, err := commandManager.ApplyState(state)
+	if err != nil {
+		// Nothing was applied: the symlinks were restored and the config is untouched
+		exitWith(apperr.KindOf(err), "Error applying state (no changes made): %v\n", err)
+	}
+	if err := configManager.Save(); err != nil {
+		exitWith(apperr.KindOf(err), "Error saving configuration: %v\n", err)
+	}
+	printStateChanges(applied)
+	fmt.Printf("Applied %d change(s)\n", len(applied))
+	return true
+}
+
+// printStateChanges prints a state diff, one change per line
+func printStateChanges(changes []commands.StateChange) {
+	if len(changes) == 0 {
+		fmt.Println("  (no changes)")
+		return
+	}
+	for _, change := range changes {
+		fmt.Printf("  %s\n", change)
+	}
+}
+
+func handleExportCommand(commandManager *commands.Manager, configPath, library, dest string) bool {
+	cmds, err := commandManager.ScanCommands()
+	if err != nil {
+		exitWith(apperr.KindOf(err), "Error scanning commands: %v\n", err)
+	}
+
+	manifest, err := archive.Export(dest, cmds, configPath, library)
+	if err != nil {
+		exitWith(apperr.KindOf(err), "Error exporting library: %v\n", err)
+	}
+
+	enabled := 0
+	for _, cmd := range manifest.Commands {
+		if cmd.Enabled {
+			enabled++
+		}
+	}
+
+	fmt.Printf("📦 Exported %d commands (%d enabled) from the %s library\n", len(manifest.Commands), enabled, library)
+	fmt.Printf("   Archive: %s\n", dest)
+	return true
+}
+
+// noteGHAuth warns when GitHub requests will fall back to anonymous access
+func noteGHAuth() {
+	if status := remote.CheckGHAuth(); !status.Ready() {
+		fmt.Fprintf(os.Stderr, "Note: %s\nContinuing with anonymous access, which only reaches public repositories.\n", status.Message())
+	}
+}
+
+// archiveImportOptions holds the conflict flags for `ccm import-archive`
+type archiveImportOptions struct {
+	Overwrite bool
+	Skip      bool
+	NoBackup  bool
+}
+
+func handleImportArchiveCommand(commandManager *commands.Manager, configManager *config.Manager, library, src string, opts archiveImportOptions) bool {
+	bundle, err := archive.Open(src)
+	if err != nil {
+		exitWith(apperr.KindOf(err), "Error reading archive: %v\n", err)
+	}
+
+	if len(bundle.Manifest.Commands) == 0 {
+		fmt.Println("Archive contains no commands.")
+		return true
+	}
+
+	targetDir := commandManager.GetCommandsDir()
+	fmt.Printf("📦 %s: %d commands exported from the %s library on %s\n",
+		filepath.Base(src), len(bundle.Manifest.Commands), bundle.Manifest.Library,
+		bundle.Manifest.CreatedAt.Format("2006-01-02"))
+
+	options := remote.GetDefaultImportOptions(targetDir)
+	options.CreateBackups = !opts.NoBackup
+
+	conflicts := bundle.Conflicts(targetDir)
+	if len(conflicts) > 0 {
+		fmt.Printf("\n⚠️  %d commands already exist in the %s library:\n", len(conflicts), library)
+		for _, name := range conflicts {
+			fmt.Printf("   • %s\n", name)
+		}
+
+		switch {
+		case opts.Overwrite:
+			options.OverwriteExisting = true
+		case opts.Skip:
+			options.OverwriteExisting = false
+		default:
+			fmt.Print("\nOverwrite them? (y/N): ")
+			var response string
+			fmt.Scanln(&response)
+			options.OverwriteExisting = strings.ToLower(response) == "y" || strings.ToLower(response) == "yes"
+		}
+		if options.OverwriteExisting && options.CreateBackups {
+			fmt.Println("   Existing files will be backed up as <file>.backup_<timestamp>")
+		}
+	}
+
+	if err := os.MkdirAll(targetDir, 0755); err != nil {
+		exitWith(apperr.KindOf(err), "Error creating library directory: %v\n", err)
+	}
+
+	result := bundle.Extract(targetDir, options)
+	for _, err := range bundle.RestoreState(commandManager, result) {
+		fmt.Fprintf(os.Stderr, "Warning: Failed to restore state for %v\n", err)
+	}
+
+	if err := configManager.Save(); err != nil {
+		exitWith(apperr.KindOf(err), "Error saving configuration: %v\n", err)
+	}
+
+	fmt.Printf("\n🎉 Import Summary:\n")
+	fmt.Printf("   ✅ Imported: %d\n", len(result.Imported))
+	fmt.Printf("   ⏭️  Skipped:  %d\n", len(result.Skipped))
+	fmt.Printf("   ❌ Failed:   %d\n", len(result.Failed))
+
+	if len(result.Failed) > 0 {
+		fmt.Printf("\n❌ Failed imports:\n")
+		for _, msg := range result.Errors {
+			fmt.Printf("   • %s\n", msg)
+		}
+	}
+
+	if len(result.Imported) > 0 {
+		fmt.Printf("\n📁 Commands saved to: %s\n", targetDir)
+	}
+
+	return true
+}
+
+// handleMigrateCommand renames a frontmatter key across the library after showing a preview
+func handleMigrateCommand(commandManager *commands.Manager, oldKey, newKey string, yes bool) bool {
+	changes, err := commandManager.PlanFrontmatterKeyRename(oldKey, newKey)
+	if err != nil {
+		exitWith(apperr.KindOf(err), "Error planning migration: %v\n", err)
+	}
+
+	if len(changes) == 0 {
+		fmt.Printf("No commands use the frontmatter key '%s'.\n", oldKey)
+		return true
+	}
+
+	pending := 0
+	for _, change := range changes {
+		fmt.Printf("📄 %s\n", change.Command.RelativePath)
+		if change.Conflict {
+			fmt.Printf("   ⚠️  skipped: '%s' already present\n\n", newKey)
+			continue
+		}
+		fmt.Printf("   %d - %s\n", change.LineNumber, change.OldLine)
+		fmt.Printf("   %d + %s\n\n", change.LineNumber, change.NewLine)
+		pending++
+	}
+
+	if pending == 0 {
+		fmt.Println("Nothing to migrate.")
+		return true
+	}
+
+	if !yes {
+		fmt.Printf("Rewrite '%s' to '%s' in %d command(s)? (y/N): ", oldKey, newKey, pending)
+		var response string
+		fmt.Scanln(&response)
+		response = strings.ToLower(strings.TrimSpace(response))
+		if response != "y" && response != "yes" {
+			fmt.Println("Migration cancelled.")
+			return true
+		}
+	}
+
+	applied, err := commandManager.ApplyFrontmatterChanges(changes)
+	if err != nil {
+		exitWith(apperr.KindOf(err), "Error applying migration after %d file(s): %v\n", applied, err)
+	}
+
+	fmt.Printf("✅ Migrated %d command(s)\n", applied)
+	return true
+}
+
+// handleBrowseCommand lists available commands in a remote repository
+func handleBrowseCommand(url string) bool {
+	// Parse the GitHub URL
+	repo, err := remote.ParseGitHubURL(url)
+	if err != nil {
+		exitWith(apperr.KindOf(err), "Error: %v\n", err)
+	}
+	noteGHAuth()
+
+	// Initialize GitHub client
+	client := remote.NewGitHubClient()
+
+	// Show loading and validate
+	fmt.Printf("🔍 Connecting to %s/%s...", repo.Owner, repo.Repo)
+	if err := client.ValidateRepository(repo); err != nil {
 		fmt.Printf(" ❌\n")
-		fmt.Fprintf(os.Stderr, "Failed to fetch commands: %v\n", err)
-		os.Exit(1)
+		exitWith(apperr.KindOf(err), "Repository not accessible: %v\n", err)
+	}
+	fmt.Printf(" ✅\n")
+
+	// Fetch commands with loading indicator
+	fmt.Printf("📦 Scanning for commands...")
+	if err := client.FetchCommands(repo); err != nil {
+		fmt.Printf(" ❌\n")
+		exitWith(apperr.KindOf(err), "Failed to fetch commands: %v\n", err)
+	}
+	fmt.Printf(" ✅\n")
+
+	if len(repo.Commands) == 0 {
+		fmt.Println("No commands found in repository.")
+		return true
+	}
+
+	// Load command details
+	fmt.Printf("🔄 Loading command details...")
+	for i := range repo.Commands {
+		if err := client.FetchCommandContent(repo, &repo.Commands[i]); err != nil {
+			repo.Commands[i].Description = "Failed to load description"
+		}
+	}
+	fmt.Printf(" ✅\n")
+
+	// Display commands
+	fmt.Printf("\n📋 Available commands in %s/%s:\n\n", repo.Owner, repo.Repo)
+	for i, cmd := range repo.Commands {
+		fmt.Printf("  %2d. %-20s %s\n", i+1, cmd.Name, 
+			truncateDescription(cmd.Description, 60))
+	}
+
+	fmt.Printf("\n💡 To import commands: ccm import %s\n", url)
+	return true
+}
+
+// handleImportCommand provides interactive import from a remote repository
+func handleImportCommand(url string, appSettings theme.AppConfig, projectLibraryDir, projectConfigPath string, opts importCommandOptions) bool {
+	dryRun := opts.DryRun
+
+	// With JSON output, progress goes to stderr so stdout holds only the report
+	out := io.Writer(os.Stdout)
+	if opts.JSON {
+		out = os.Stderr
+	}
+
+	// Get target directory
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		exitWith(apperr.KindOf(err), "Error: Could not get home directory: %v\n", err)
+	}
+	targetDir, targetConfigPath := userLibraryPaths(homeDir)
+	if appSettings.Import.DefaultTarget == "project" {
+		targetDir, targetConfigPath = projectLibraryDir, projectConfigPath
+	}
+
+	// Scripts reading the JSON report get one however the import ends; a fatal error
+	// is included so they can tell it from an import with nothing to do
+	var repo *remote.RemoteRepository
+	report := func(result *remote.ImportResult) {
+		if opts.JSON {
+			printImportReport(remote.NewImportReport(repo, targetDir, result, dryRun))
+		}
+	}
+	fail := func(kind apperr.Kind, format string, args ...interface{}) {
+		if opts.JSON {
+			failed := remote.ImportReport{Repository: url, Target: targetDir, DryRun: dryRun, Commands: []remote.CommandImportStatus{}}
+			if repo != nil {
+				failed = remote.NewImportReport(repo, targetDir, &remote.ImportResult{}, dryRun)
+			}
+			failed.Error = strings.TrimPrefix(strings.TrimSpace(fmt.Sprintf(format, args...)), "Error: ")
+			printImportReport(failed)
+		}
+		exitWith(kind, format, args...)
+	}
+
+	// Parse the GitHub URL
+	repo, err = remote.ParseGitHubURL(url)
+	if err != nil {
+		fail(apperr.KindOf(err), "Error: %v\n", err)
+	}
+	noteGHAuth()
+
+	// Initialize GitHub client
+	client := remote.NewGitHubClient()
+
+	// Show loading and validate
+	fmt.Fprintf(out, "🔍 Connecting to %s/%s...", repo.Owner, repo.Repo)
+	if err := client.ValidateRepository(repo); err != nil {
+		fmt.Fprintf(out, " ❌\n")
+		fail(apperr.KindOf(err), "Repository not accessible: %v\n", err)
+	}
+	fmt.Fprintf(out, " ✅\n")
+
+	// Fetch commands with loading indicator
+	fmt.Fprintf(out, "📦 Scanning for commands...")
+	if err := client.FetchCommands(repo); err != nil {
+		fmt.Fprintf(out, " ❌\n")
+		fail(apperr.KindOf(err), "Failed to fetch commands: %v\n", err)
+	}
+	fmt.Fprintf(out, " ✅\n")
+
+	if len(repo.Commands) == 0 {
+		fmt.Fprintln(out, "No commands found in repository.")
+		report(&remote.ImportResult{})
+		return true
+	}
+
+	// Load command contents and check local conflicts
+	fmt.Fprintf(out, "🔄 Loading command details...")
+	importer := remote.NewImporter(targetDir)
+	
+	for i := range repo.Commands {
+		if err := client.FetchCommandContent(repo, &repo.Commands[i]); err != nil {
+			// Skip commands that fail to load
+			repo.Commands = append(repo.Commands[:i], repo.Commands[i+1:]...)
+			i--
+			continue
+		}
+	}
+	
+	if err := importer.CheckLocalExists(repo.Commands, targetDir); err != nil {
+		fmt.Fprintf(out, " ❌\n")
+		fail(apperr.KindOf(err), "Error checking local commands: %v\n", err)
+	}
+	fmt.Fprintf(out, " ✅\n")
+
+	// Display commands for selection
+	fmt.Fprintf(out, "\n📋 Found %d commands:\n\n", len(repo.Commands))
+	
+	for i, cmd := range repo.Commands {
+		status := "NEW"
+		statusIcon := "🆕"
+		if cmd.LocalExists {
+			status = "EXISTS"
+			statusIcon = "⚠️"
+		}
+		
+		fmt.Fprintf(out, "  %2d. %-20s %s %s %s\n", 
+			i+1, cmd.Name, statusIcon, status, 
+			truncateDescription(cmd.Description, 50))
+	}
+
+	// Interactive selection
+	input := opts.Selection
+	if input == "" {
+		fmt.Fprint(out, "\n🎯 Select commands to import:\n")
+		fmt.Fprint(out, "   • Enter numbers (e.g., 1,3,5-8) or 'all' for all commands\n")
+		fmt.Fprint(out, "   • Commands marked ⚠️ already exist locally\n")
+		fmt.Fprint(out, "\nSelection: ")
+		fmt.Scanln(&input)
+	}
+	
+	if input == "" {
+		fmt.Fprintln(out, "No commands selected.")
+		report(&remote.ImportResult{})
+		return true
+	}
+
+	// Parse selection
+	selectedIndices, err := parseSelection(input, len(repo.Commands))
+	if err != nil {
+		fail(apperr.KindValidation, "Invalid selection: %v\n", err)
+	}
+
+	// Mark selected commands
+	for i := range repo.Commands {
+		repo.Commands[i].Selected = false
+	}
+	for _, idx := range selectedIndices {
+		repo.Commands[idx].Selected = true
+	}
+
+	// Check for conflicts and ask about overwriting
+	hasConflicts := false
+	for _, idx := range selectedIndices {
+		if repo.Commands[idx].LocalExists {
+			hasConflicts = true
+			break
+		}
+	}
+
+	options := remote.GetDefaultImportOptions(targetDir)
+	options.CreateBackups = appSettings.Import.CreateBackups
+	options.DryRun = dryRun
+	if hasConflicts && !appSettings.Confirm.Overwrite {
+		options.OverwriteExisting = true
+	} else if hasConflicts {
+		options.Resolutions = promptConflictResolutions(out, repo, selectedIndices)
+	}
+
+	// Import selected commands
+	fmt.Fprintf(out, "\n📥 Importing %d commands...", len(selectedIndices))
+	result, err := importer.ImportCommands(repo, repo.Commands, options)
+	if err != nil {
+		fmt.Fprintf(out, " ❌\n")
+		if !dryRun {
+			recordImportFailures(repo, repo.Commands, options, remote.BatchFailure(repo.Commands, err))
+		}
+		fail(apperr.KindOf(err), "Import failed: %v\n", err)
+	}
+	fmt.Fprintf(out, " ✅\n")
+
+	if dryRun {
+		printImportPlan(out, repo, targetDir, targetConfigPath, options, result)
+		report(result)
+		return true
+	}
+
+	// Show results
+	fmt.Fprintf(out, "\n🎉 Import Summary:\n")
+	fmt.Fprintf(out, "   ✅ Imported: %d\n", len(result.Imported))
+	fmt.Fprintf(out, "   ⏭️  Skipped:  %d\n", len(result.Skipped))
+	fmt.Fprintf(out, "   ❌ Failed:   %d\n", len(result.Failed))
+
+	if len(result.Renamed) > 0 {
+		fmt.Fprintf(out, "\n✏️  Kept both:\n")
+		for _, name := range result.Imported {
+			if renamed, ok := result.Renamed[name]; ok {
+				fmt.Fprintf(out, "   • %s imported as %s\n", name, renamed)
+			}
+		}
+	}
+
+	if len(result.Failed) > 0 {
+		fmt.Fprintf(out, "\n❌ Failed imports:\n")
+		for i, name := range result.Failed {
+			fmt.Fprintf(out, "   • %s: %s\n", name, result.Errors[i])
+		}
+	}
+
+	recordImportFailures(repo, repo.Commands, options, result)
+	if len(result.Failed) > 0 {
+		fmt.Fprintf(out, "\n🔁 Failures were queued; run 'ccm imports retry' once the problem is fixed.\n")
+	}
+
+	if len(result.Imported) > 0 {
+		recordImportSources(targetConfigPath, targetDir, repo, result)
+		fmt.Fprintf(out, "\n📁 Commands saved to: %s\n", targetDir)
+	}
+
+	report(result)
+	if len(result.Failed) > 0 {
+		os.Exit(apperr.KindGeneral.ExitCode())
+	}
+	return true
+}
+
+// promptConflictResolutions asks, for each selected command that already exists locally,
+// whether to skip it, overwrite it or keep both
+func promptConflictResolutions(out io.Writer, repo *remote.RemoteRepository, selectedIndices []int) map[string]remote.ConflictResolution {
+	fmt.Fprint(out, "\n⚠️  Some selected commands already exist.\n")
+	fmt.Fprint(out, "   For each, [s]kip it, [o]verwrite it or keep [b]oth (the import is renamed to <name>-2)\n")
+
+	resolutions := make(map[string]remote.ConflictResolution)
+	for _, idx := range selectedIndices {
+		cmd := repo.Commands[idx]
+		if !cmd.LocalExists {
+			continue
+		}
+		fmt.Fprintf(out, "   %s (S/o/b): ", cmd.Name)
+		var response string
+		fmt.Scanln(&response)
+		switch strings.ToLower(response) {
+		case "o", "overwrite":
+			resolutions[cmd.Name] = remote.ConflictOverwrite
+		case "b", "both":
+			resolutions[cmd.Name] = remote.ConflictRename
+		default:
+			resolutions[cmd.Name] = remote.ConflictSkip
+		}
+	}
+	return resolutions
+}
+
+// importCommandOptions controls a `ccm import` run
+type importCommandOptions struct {
+	DryRun    bool
+	JSON      bool   // Print the result as an ImportReport on stdout
+	Selection string // Commands to import ("1,3,5-8" or "all"); prompts when empty
+}
+
+// printImportPlan reports the files and config entries a dry-run import would change
+func printImportPlan(out io.Writer, repo *remote.RemoteRepository, targetDir, targetConfigPath string, options remote.ImportOptions, result *remote.ImportResult) {
+	var changes []commands.PlannedChange
+	for _, file := range result.Files {
+		path := filepath.Join(targetDir, file)
+		if _, err := os.Stat(path); err == nil {
+			if options.CreateBackups {
+				changes = append(changes, commands.PlannedChange{Action: "back up file", Path: path, Detail: "to " + path + ".backup_<timestamp>"})
+			}
+			changes = append(changes, commands.PlannedChange{Action: "overwrite file", Path: path})
+		} else {
+			changes = append(changes, commands.PlannedChange{Action: "write file", Path: path})
+		}
+	}
+	if len(result.Files) > 0 {
+		changes = append(changes, commands.PlannedChange{Action: "update config", Path: targetConfigPath, Detail: "source " + repo.FullName()})
+	}
+
+	fprintPlannedChanges(out, fmt.Sprintf("import %d commands from %s", len(result.Imported), repo.FullName()), changes)
+	for _, name := range result.Skipped {
+		fmt.Fprintf(out, "  %-15s %s (already exists)\n", "skip", name)
+	}
+	for i, name := range result.Failed {
+		fmt.Fprintf(out, "  %-15s %s: %s\n", "fail", name, result.Errors[i])
+	}
+}
+
+// printImportReport writes an import report to stdout as JSON
+func printImportReport(report remote.ImportReport) {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		exitWith(apperr.KindGeneral, "Error encoding import report: %v\n", err)
+	}
+	fmt.Println(string(data))
+}
+
+// handleConfigCommand reads and writes application settings
+func handleConfigCommand(settingsManager *theme.Manager, action string, args []string) bool {
+	switch action {
+	case "path":
+		fmt.Println(theme.DefaultConfigPath())
+	case "get":
+		value, err := settingsManager.GetValue(args[0])
+		if err != nil {
+			exitWith(apperr.KindOf(err), "Error: %v\n", err)
+		}
+		fmt.Println(value)
+	case "set":
+		if err := settingsManager.SetValue(args[0], args[1]); err != nil {
+			exitWith(apperr.KindOf(err), "Error: %v\n", err)
+		}
+		value, _ := settingsManager.GetValue(args[0])
+		fmt.Printf("%s = %s\n", args[0], value)
+	default:
+		for _, key := range theme.ConfigKeys() {
+			value, _ := settingsManager.GetValue(key.Key)
+			if value == "" {
+				value = "(default)"
+			}
+			fmt.Printf("%-34s %-12s %s\n", key.Key, value, key.Description)
+		}
+	}
+	return true
+}
+
+// handleCompletionCommand prints a completion script, or the candidates for a partial
+// command line when called by one of those scripts
+func handleCompletionCommand(verb string, args []string) {
+	if verb == completion.Command {
+		for _, candidate := range completion.Complete(args, completionRegistry) {
+			fmt.Println(candidate)
+		}
+		return
+	}
+
+	if len(args) != 1 {
+		var usage strings.Builder
+		usage.WriteString("Usage: ccm completion bash|zsh|fish\n\nTo enable completion:\n")
+		for _, shell := range []string{"bash", "zsh", "fish"} {
+			fmt.Fprintf(&usage, "  %-5s %s\n", shell, completion.Install(shell))
+		}
+		exitWith(apperr.KindValidation, "%s", usage.String())
 	}
-	fmt.Printf(" ✅\n")
+	script, err := completion.Script(args[0])
+	if err != nil {
+		exitWith(apperr.KindOf(err), "Error: %v\n", err)
+	}
+	fmt.Print(script)
+}
 
-	if len(repo.Commands) == 0 {
-		fmt.Println("No commands found in repository.")
-		return true
+// completionRegistry loads the merged registry from the cache for completion, or nil
+func completionRegistry() completion.Registry {
+	registryManager, err := registry.NewEnhancedRegistryManager()
+	if err != nil {
+		return nil
+	}
+	if cacheManager, err := cache.NewManager(loadAppSettings().GetAppConfig().Cache); err == nil {
+		registryManager.SetCacheManager(cacheManager)
+	}
+	if err := registryManager.LoadCachedRegistries(); err != nil {
+		return nil
 	}
+	return registryManager
+}
 
-	// Load command details
-	fmt.Printf("🔄 Loading command details...")
-	for i := range repo.Commands {
-		if err := client.FetchCommandContent(repo, &repo.Commands[i]); err != nil {
-			repo.Commands[i].Description = "Failed to load description"
+// registryUsage describes the `ccm registry` subcommands
+const registryUsage = `Usage:
+  ccm registry list [--custom]
+  ccm registry add <github_url> [--category <key> [--new-category]] [--description <text>] [--tags a,b]
+  ccm registry remove <github_url>
+  ccm registry update
+`
+
+// handleRegistryCommand manages custom repositories in the user registry
+func handleRegistryCommand(args []string) bool {
+	if len(args) == 0 {
+		exitWith(apperr.KindValidation, "%s", registryUsage)
+	}
+
+	registryManager, err := registry.NewEnhancedRegistryManager()
+	if err != nil {
+		exitWith(apperr.KindOf(err), "Error initializing registry: %v\n", err)
+	}
+	if cacheManager, err := cache.NewManager(loadAppSettings().GetAppConfig().Cache); err == nil {
+		registryManager.SetCacheManager(cacheManager)
+	}
+	if args[0] == "update" {
+		return handleRegistryUpdate(registryManager)
+	}
+	if err := registryManager.LoadRegistries(); err != nil {
+		exitWith(apperr.KindOf(err), "Error loading registry: %v\n", err)
+	}
+
+	switch args[0] {
+	case "list":
+		customOnly := len(args) > 1 && args[1] == "--custom"
+		return handleRegistryList(registryManager, customOnly)
+	case "remove":
+		if len(args) != 2 {
+			exitWith(apperr.KindValidation, "%s", registryUsage)
+		}
+		if err := registryManager.RemoveCustomRepository(args[1]); err != nil {
+			exitWith(apperr.KindOf(err), "Error: %v\n", err)
+		}
+		fmt.Printf("Removed repository: %s\n", args[1])
+		return true
+	case "add":
+		input := registry.RepositoryInput{Tags: []string{}}
+		newCategory := false
+		for i := 1; i < len(args); i++ {
+			switch args[i] {
+			case "--new-category":
+				newCategory = true
+			case "--category", "--description", "--tags":
+				if i+1 >= len(args) {
+					exitWith(apperr.KindValidation, "Missing value for %s\n", args[i])
+				}
+				value := args[i+1]
+				switch args[i] {
+				case "--category":
+					input.Category.CategoryKey = value
+				case "--description":
+					input.Description = value
+				case "--tags":
+					for _, tag := range strings.Split(value, ",") {
+						if tag = strings.TrimSpace(tag); tag != "" {
+							input.Tags = append(input.Tags, tag)
+						}
+					}
+				}
+				i++
+			default:
+				if input.URL != "" {
+					exitWith(apperr.KindValidation, "%s", registryUsage)
+				}
+				input.URL = args[i]
+			}
 		}
+		if input.URL == "" {
+			exitWith(apperr.KindValidation, "%s", registryUsage)
+		}
+		return handleRegistryAdd(registryManager, input, newCategory)
+	default:
+		exitWith(apperr.KindValidation, "%s", registryUsage)
 	}
-	fmt.Printf(" ✅\n")
+	return true
+}
 
-	// Display commands
-	fmt.Printf("\n📋 Available commands in %s/%s:\n\n", repo.Owner, repo.Repo)
-	for i, cmd := range repo.Commands {
-		fmt.Printf("  %2d. %-20s %s\n", i+1, cmd.Name, 
-			truncateDescription(cmd.Description, 60))
+// handleRegistryUpdate refreshes the cached registry and its download stats
+func handleRegistryUpdate(registryManager *registry.EnhancedRegistryManager) bool {
+	result, err := registryManager.RefreshRegistries()
+	if err != nil {
+		exitWith(apperr.KindOf(err), "Error updating registry: %v\n", err)
 	}
 
-	fmt.Printf("\n💡 To import commands: ccm import %s\n", url)
+	fmt.Printf("Registry updated: %d repositories\n", result.Repositories)
+	if result.StatsError != nil {
+		fmt.Fprintf(os.Stderr, "Warning: download stats unavailable: %v\n", result.StatsError)
+	} else if result.WithStats > 0 {
+		fmt.Printf("Download stats: %d repositories\n", result.WithStats)
+		if trending := registryManager.GetTrendingRepositories(); len(trending) > 0 {
+			fmt.Println("Trending this week:")
+			for _, repo := range trending {
+				fmt.Printf("  📈 %-36s %d imports\n", repo.Name, repo.RecentDownloads)
+			}
+		}
+	}
 	return true
 }
 
-// handleImportCommand provides interactive import from a remote repository
-func handleImportCommand(url string) bool {
-	// Parse the GitHub URL
-	repo, err := remote.ParseGitHubURL(url)
+func handleRegistryAdd(registryManager *registry.EnhancedRegistryManager, input registry.RepositoryInput, newCategory bool) bool {
+	repo, err := remote.ParseGitHubURL(input.URL)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+		exitWith(apperr.KindOf(err), "Error: %v\n", err)
+	}
+	if registryManager.IsCustomRepository(input.URL) {
+		exitWith(apperr.KindConflict, "Repository already in registry: %s\n", input.URL)
 	}
 
-	// Initialize GitHub client
-	client := remote.NewGitHubClient()
+	input.Name = repo.FullName()
+	input.Author = repo.Owner
 
-	// Show loading and validate
-	fmt.Printf("🔍 Connecting to %s/%s...", repo.Owner, repo.Repo)
-	if err := client.ValidateRepository(repo); err != nil {
-		fmt.Printf(" ❌\n")
-		fmt.Fprintf(os.Stderr, "Repository not accessible: %v\n", err)
-		os.Exit(1)
+	// Fill in missing details from GitHub when available
+	if input.Description == "" || input.Category.CategoryKey == "" || len(input.Tags) == 0 {
+		if details, err := remote.NewGitHubClient().FetchRepositoryDetails(repo); err == nil {
+			if input.Description == "" {
+				input.Description = details.Description
+			}
+			if len(input.Tags) == 0 {
+				input.Tags = details.Topics
+			}
+		}
+	}
+	if input.Description == "" {
+		exitWith(apperr.KindValidation, "Error: a description is required (use --description)\n")
 	}
-	fmt.Printf(" ✅\n")
 
-	// Fetch commands with loading indicator
-	fmt.Printf("📦 Scanning for commands...")
-	if err := client.FetchCommands(repo); err != nil {
-		fmt.Printf(" ❌\n")
-		fmt.Fprintf(os.Stderr, "Failed to fetch commands: %v\n", err)
-		os.Exit(1)
+	categories := registryManager.GetAvailableCategories()
+	if input.Category.CategoryKey == "" {
+		input.Category.CategoryKey = registryManager.SuggestCategory(input.Name, input.Description, input.Tags)
+		if input.Category.CategoryKey == "" {
+			exitWith(apperr.KindValidation, "Error: could not suggest a category; use --category <key>\n")
+		}
+		fmt.Printf("Suggested category: %s\n", categories[input.Category.CategoryKey])
 	}
-	fmt.Printf(" ✅\n")
 
-	if len(repo.Commands) == 0 {
-		fmt.Println("No commands found in repository.")
-		return true
+	// Unknown category keys create a new user category, but only when asked to, so a
+	// misspelled key is not quietly turned into one
+	input.Category.CategoryKey = strings.ToLower(strings.ReplaceAll(input.Category.CategoryKey, " ", "_"))
+	if _, exists := categories[input.Category.CategoryKey]; !exists {
+		if !newCategory {
+			keys := make([]string, 0, len(categories))
+			for key := range categories {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+			exitWith(apperr.KindValidation, "Error: unknown category %q (available: %s); add --new-category to create it\n",
+				input.Category.CategoryKey, strings.Join(keys, ", "))
+		}
+		name := strings.ReplaceAll(input.Category.CategoryKey, "_", " ")
+		if name != "" {
+			name = strings.ToUpper(name[:1]) + name[1:]
+		}
+		input.Category.IsNew = true
+		input.Category.Name = name
+		input.Category.Description = fmt.Sprintf("Custom category: %s", name)
+		input.Category.Icon = "📦"
 	}
 
-	// Get target directory
-	homeDir, err := os.UserHomeDir()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: Could not get home directory: %v\n", err)
-		os.Exit(1)
+	if err := registryManager.AddCustomRepository(input); err != nil {
+		exitWith(apperr.KindOf(err), "Error: %v\n", err)
 	}
-	targetDir := filepath.Join(homeDir, ".claude", "command_library")
 
-	// Load command contents and check local conflicts
-	fmt.Printf("🔄 Loading command details...")
-	importer := remote.NewImporter(targetDir)
-	
-	for i := range repo.Commands {
-		if err := client.FetchCommandContent(repo, &repo.Commands[i]); err != nil {
-			// Skip commands that fail to load
-			repo.Commands = append(repo.Commands[:i], repo.Commands[i+1:]...)
-			i--
+	if input.Category.IsNew {
+		fmt.Printf("Created category: %s\n", input.Category.Name)
+	}
+	fmt.Printf("Added repository: %s (%s)\n", input.Name, input.Category.CategoryKey)
+	return true
+}
+
+func handleRegistryList(registryManager *registry.EnhancedRegistryManager, customOnly bool) bool {
+	categories := registryManager.GetCategories()
+	keys := make([]string, 0, len(categories))
+	for key := range categories {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	shown := 0
+	for _, key := range keys {
+		category := categories[key]
+		var lines []string
+		for _, repo := range category.Repositories {
+			custom := registryManager.IsCustomRepository(repo.URL)
+			if customOnly && !custom {
+				continue
+			}
+			marker := "  "
+			if custom {
+				marker = "👤"
+			} else if repo.Verified {
+				marker = "✓ "
+			}
+			line := fmt.Sprintf("  %s %-36s %s", marker, repo.Name, truncateDescription(repo.Description, 50))
+			if registryManager.IsPopular(repo.URL) {
+				line += " 🔥"
+			}
+			lines = append(lines, line)
+		}
+		if len(lines) == 0 {
 			continue
 		}
+
+		fmt.Printf("%s %s (%s)\n", category.Icon, category.Name, key)
+		for _, line := range lines {
+			fmt.Println(line)
+		}
+		fmt.Println()
+		shown += len(lines)
 	}
-	
-	if err := importer.CheckLocalExists(repo.Commands, targetDir); err != nil {
-		fmt.Printf(" ❌\n")
-		fmt.Fprintf(os.Stderr, "Error checking local commands: %v\n", err)
-		os.Exit(1)
+
+	if shown == 0 {
+		if customOnly {
+			fmt.Println("No custom repositories. Add one with 'ccm registry add <github_url>'.")
+		} else {
+			fmt.Println("Registry is empty.")
+		}
 	}
-	fmt.Printf(" ✅\n")
+	return true
+}
 
-	// Display commands for selection
-	fmt.Printf("\n📋 Found %d commands:\n\n", len(repo.Commands))
-	
-	for i, cmd := range repo.Commands {
-		status := "NEW"
-		statusIcon := "🆕"
-		if cmd.LocalExists {
-			status = "EXISTS"
-			statusIcon = "⚠️"
+// handleThemeCommand lists, previews or switches color themes
+func handleThemeCommand(settingsManager *theme.Manager, action, id string) bool {
+	switch action {
+	case "set":
+		if err := settingsManager.SetValue("theme.current", id); err != nil {
+			exitWith(apperr.KindOf(err), "Error: %v\n", err)
+		}
+		t := theme.GetThemeByID(id)
+		fmt.Printf("Theme set to %s\n", t.Name)
+		if key, boundID, ok := settingsManager.ActiveProfileBinding(); ok {
+			fmt.Printf("Note: this terminal uses %s (bound to profile %q)\n", boundID, key)
+		}
+	case "import":
+		data, err := readThemeSource(id)
+		if err != nil {
+			exitWith(apperr.KindOf(err), "Error: %v\n", err)
+		}
+		t, err := theme.ImportTheme(data)
+		if err != nil {
+			exitWith(apperr.KindOf(err), "Error: %v\n", err)
+		}
+		fmt.Printf("Imported theme: %s (%s)\n", t.Name, t.ID)
+		fmt.Printf("Apply it with 'ccm theme set %s'\n", t.ID)
+	case "preview":
+		t, ok := theme.FindTheme(id)
+		if !ok {
+			exitWith(apperr.KindNotFound, "Unknown theme: %s (run 'ccm theme list')\n", id)
+		}
+		printThemePreview(t)
+	default:
+		current := settingsManager.GetCurrentTheme().ID
+		for _, t := range theme.GetAllThemes() {
+			marker := "  "
+			if t.ID == current {
+				marker = "✓ "
+			}
+			description := t.Description
+			if t.Custom {
+				description = "(custom) " + description
+			}
+			fmt.Printf("%s%-18s %s  %s\n", marker, t.ID, t.GeneratePreview().ColorBar, description)
+		}
+		for _, problem := range theme.UserThemeProblems() {
+			fmt.Fprintf(os.Stderr, "Skipped %s: %v\n", filepath.Join(theme.UserThemesDir(), problem.File), problem.Err)
 		}
-		
-		fmt.Printf("  %2d. %-20s %s %s %s\n", 
-			i+1, cmd.Name, statusIcon, status, 
-			truncateDescription(cmd.Description, 50))
 	}
+	return true
+}
 
-	// Interactive selection
-	fmt.Print("\n🎯 Select commands to import:\n")
-	fmt.Print("   • Enter numbers (e.g., 1,3,5-8) or 'all' for all commands\n")
-	fmt.Print("   • Commands marked ⚠️ already exist locally\n")
-	fmt.Print("\nSelection: ")
-	
-	var input string
-	fmt.Scanln(&input)
-	
-	if input == "" {
-		fmt.Println("No commands selected.")
+// handleTargetList prints the built-in and custom symlink locations
+func handleTargetList(settingsManager *theme.Manager) bool {
+	library := settingsManager.GetAppConfig().Library
+	userDir, _ := os.UserHomeDir()
+	fmt.Printf("%-12s %s\n", "user", filepath.Join(userDir, ".claude", "commands"))
+	fmt.Printf("%-12s %s\n", "project", "<project>/.claude/commands")
+	for _, name := range library.TargetNames() {
+		fmt.Printf("%-12s %s\n", name, theme.ExpandTargetDir(library.Targets[name]))
+	}
+	if len(library.Targets) == 0 {
+		fmt.Println("\nNo custom targets. Add one with 'ccm target add <name> <dir>'.")
+	}
+	return true
+}
+
+// handleTargetRemove deletes a custom symlink target once no command in either library uses it
+func handleTargetRemove(settingsManager *theme.Manager, name string, managers ...*commands.Manager) bool {
+	var inUse []string
+	for _, manager := range managers {
+		cmds, err := manager.ScanCommands()
+		if err != nil {
+			continue
+		}
+		for _, cmd := range cmds {
+			if string(cmd.SymlinkLocation) == name {
+				inUse = append(inUse, cmd.Name)
+			}
+		}
+	}
+	if len(inUse) > 0 {
+		exitWith(apperr.KindConflict, "Error: target %s is used by %s; move them first with 'ccm move <cmd> user'\n", name, strings.Join(inUse, ", "))
+	}
+
+	if err := settingsManager.RemoveSymlinkTarget(name); err != nil {
+		exitWith(apperr.KindOf(err), "Error: %v\n", err)
+	}
+	fmt.Printf("Removed symlink target: %s\n", name)
+	return true
+}
+
+// handleThemeProfiles shows the detected terminal and the themes bound to terminal profiles
+func handleThemeProfiles(settingsManager *theme.Manager) bool {
+	fmt.Printf("Terminal: %s\n", settingsManager.GetTerminalProfile())
+	if keys := settingsManager.GetTerminalProfile().Keys(); len(keys) > 0 {
+		fmt.Printf("Matches:  %s\n", strings.Join(keys, ", "))
+	}
+
+	bindings := settingsManager.GetProfileBindings()
+	if len(bindings) == 0 {
+		fmt.Println("\nNo profile bindings. Add one with 'ccm theme bind <profile> <id>'.")
 		return true
 	}
 
-	// Parse selection
-	selectedIndices, err := parseSelection(input, len(repo.Commands))
+	activeKey, _, _ := settingsManager.ActiveProfileBinding()
+	keys := make([]string, 0, len(bindings))
+	for key := range bindings {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	fmt.Println("\nBindings:")
+	for _, key := range keys {
+		marker := "  "
+		if key == activeKey {
+			marker = "✓ "
+		}
+		fmt.Printf("%s%-20s %s\n", marker, key, bindings[key])
+	}
+	return true
+}
+
+// handleThemeBind binds a theme to a terminal profile, or removes the binding when id is empty
+func handleThemeBind(settingsManager *theme.Manager, profile, id string) bool {
+	if id == "" {
+		if err := settingsManager.UnbindProfile(profile); err != nil {
+			exitWith(apperr.KindOf(err), "Error: %v\n", err)
+		}
+		fmt.Printf("Removed theme binding for %s\n", profile)
+		return true
+	}
+
+	if err := settingsManager.BindProfile(profile, id); err != nil {
+		exitWith(apperr.KindOf(err), "Error: %v\n", err)
+	}
+	fmt.Printf("Terminal profile %s now uses %s\n", profile, theme.GetThemeByID(id).Name)
+	return true
+}
+
+// handleThemeExport writes a theme definition to a file, or to stdout when dest is empty.
+// Without an explicit format, .yaml and .yml files get YAML and everything else JSON.
+func handleThemeExport(id, dest, format string) bool {
+	if format == "" {
+		format = "json"
+		if ext := strings.ToLower(filepath.Ext(dest)); ext == ".yaml" || ext == ".yml" {
+			format = "yaml"
+		}
+	}
+	data, err := theme.ExportTheme(id, format)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Invalid selection: %v\n", err)
-		os.Exit(1)
+		exitWith(apperr.KindOf(err), "Error: %v\n", err)
 	}
 
-	// Mark selected commands
-	for i := range repo.Commands {
-		repo.Commands[i].Selected = false
+	if dest == "" {
+		os.Stdout.Write(data)
+		return true
 	}
-	for _, idx := range selectedIndices {
-		repo.Commands[idx].Selected = true
+	if err := os.WriteFile(dest, data, 0644); err != nil {
+		exitWith(apperr.KindOf(err), "Error writing theme: %v\n", err)
 	}
+	fmt.Printf("Exported theme %s to %s\n", id, dest)
+	return true
+}
 
-	// Check for conflicts and ask about overwriting
-	hasConflicts := false
-	for _, idx := range selectedIndices {
-		if repo.Commands[idx].LocalExists {
-			hasConflicts = true
-			break
+// handleThemeConvert turns a terminal color scheme into a custom theme, stored under id
+// when given and otherwise under an ID derived from the scheme's name
+func handleThemeConvert(src, id string) bool {
+	data, err := readThemeSource(src)
+	if err != nil {
+		exitWith(apperr.KindOf(err), "Error: %v\n", err)
+	}
+	t, err := theme.ConvertScheme(src, data)
+	if err != nil {
+		exitWith(apperr.KindOf(err), "Error: %v\n", err)
+	}
+	if id != "" {
+		t.ID = id
+		if err := t.Validate(); err != nil {
+			exitWith(apperr.KindOf(err), "Error: %v\n", err)
 		}
 	}
+	if t, err = theme.SaveTheme(t); err != nil {
+		exitWith(apperr.KindOf(err), "Error: %v\n", err)
+	}
+	fmt.Printf("Converted %s into theme: %s (%s)\n", src, t.Name, t.ID)
+	fmt.Printf("Apply it with 'ccm theme set %s'\n", t.ID)
+	return true
+}
 
-	options := remote.GetDefaultImportOptions(targetDir)
-	if hasConflicts {
-		fmt.Print("\n⚠️  Some selected commands already exist. Overwrite them? (y/N): ")
-		var response string
-		fmt.Scanln(&response)
-		options.OverwriteExisting = strings.ToLower(response) == "y" || strings.ToLower(response) == "yes"
+// readThemeSource reads a theme definition from a local file, an http(s) URL or stdin ("-")
+func readThemeSource(src string) ([]byte, error) {
+	if src == "-" {
+		return io.ReadAll(os.Stdin)
+	}
+	if !strings.HasPrefix(src, "http://") && !strings.HasPrefix(src, "https://") {
+		return os.ReadFile(src)
 	}
 
-	// Import selected commands
-	fmt.Printf("\n📥 Importing %d commands...", len(selectedIndices))
-	result, err := importer.ImportCommands(repo, repo.Commands, options)
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Get(src)
 	if err != nil {
-		fmt.Printf(" ❌\n")
-		fmt.Fprintf(os.Stderr, "Import failed: %v\n", err)
-		os.Exit(1)
+		return nil, apperr.Wrap(apperr.KindNetwork, fmt.Errorf("failed to download theme: %w", err))
 	}
-	fmt.Printf(" ✅\n")
+	defer resp.Body.Close()
 
-	// Show results
-	fmt.Printf("\n🎉 Import Summary:\n")
-	fmt.Printf("   ✅ Imported: %d\n", len(result.Imported))
-	fmt.Printf("   ⏭️  Skipped:  %d\n", len(result.Skipped))
-	fmt.Printf("   ❌ Failed:   %d\n", len(result.Failed))
+	if resp.StatusCode != http.StatusOK {
+		kind := apperr.KindNetwork
+		if resp.StatusCode == http.StatusNotFound {
+			kind = apperr.KindNotFound
+		}
+		return nil, apperr.New(kind, "failed to download theme: %s", resp.Status)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+}
 
-	if len(result.Failed) > 0 {
-		fmt.Printf("\n❌ Failed imports:\n")
-		for i, name := range result.Failed {
-			fmt.Printf("   • %s: %s\n", name, result.Errors[i])
+// printThemePreview renders sample output in a theme's colors
+func printThemePreview(t theme.Theme) {
+	header := lipgloss.NewStyle().Bold(true).Foreground(t.Primary)
+	border := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(t.Border).
+		Padding(0, 2)
+
+	var b strings.Builder
+	b.WriteString(header.Render(t.Name))
+	b.WriteString("\n")
+	b.WriteString(lipgloss.NewStyle().Foreground(t.Muted).Bold(t.Bold).Render(t.Description))
+	b.WriteString("\n\n")
+	b.WriteString(lipgloss.NewStyle().Foreground(t.Text).Bold(t.Bold).Render("  [✓] 📁 commit   Create a conventional commit"))
+	b.WriteString("\n")
+	b.WriteString(lipgloss.NewStyle().Foreground(t.Primary).Background(t.Selection).Bold(true).Render("> [ ] 👤 review   Review the current diff"))
+	b.WriteString("\n\n")
+	b.WriteString(lipgloss.NewStyle().Foreground(t.Success).Render("✓ Enabled command: commit"))
+	b.WriteString("\n")
+	b.WriteString(lipgloss.NewStyle().Foreground(t.Warning).Render("⚠ 1 command out of sync"))
+	b.WriteString("\n")
+	b.WriteString(lipgloss.NewStyle().Foreground(t.Danger).Render("✗ Failed to import: review"))
+	b.WriteString("\n\n")
+	b.WriteString(t.GeneratePreview().ColorBar)
+
+	fmt.Println(border.Render(b.String()))
+}
+
+// handleImportsCommand lists, retries or clears the persistent failed-imports queue
+func handleImportsCommand(action, repoFilter, projectLibraryDir, projectConfigPath string) bool {
+	queue, err := remote.LoadFailureQueue()
+	if err != nil {
+		exitWith(apperr.KindOf(err), "Error: %v\n", err)
+	}
+
+	switch action {
+	case "clear":
+		removed := queue.Clear(repoFilter)
+		if err := queue.Save(); err != nil {
+			exitWith(apperr.KindOf(err), "Error saving failed-imports queue: %v\n", err)
+		}
+		fmt.Printf("🗑️  Cleared %d failed import(s)\n", removed)
+		return true
+	case "retry":
+		if len(queue.Items) == 0 {
+			fmt.Println("No failed imports to retry.")
+			return true
+		}
+		noteGHAuth()
+
+		fmt.Printf("🔁 Retrying failed imports...\n")
+		outcomes := queue.Retry(repoFilter)
+		if err := queue.Save(); err != nil {
+			exitWith(apperr.KindOf(err), "Error saving failed-imports queue: %v\n", err)
+		}
+
+		imported, failed := 0, 0
+		for _, outcome := range outcomes {
+			fmt.Printf("\n📦 %s → %s\n", outcome.Repository.FullName(), outcome.TargetDirectory)
+			for _, name := range outcome.Result.Imported {
+				fmt.Printf("   ✅ %s\n", name)
+			}
+			for _, name := range outcome.Result.Skipped {
+				fmt.Printf("   ⏭️  %s (already exists)\n", name)
+			}
+			for _, msg := range outcome.Result.Errors {
+				fmt.Printf("   ❌ %s\n", msg)
+			}
+			imported += len(outcome.Result.Imported)
+			failed += len(outcome.Result.Failed)
+			if len(outcome.Result.Imported) > 0 {
+				configPath := filepath.Join(outcome.TargetDirectory, ".config.json")
+				if outcome.TargetDirectory == projectLibraryDir {
+					configPath = projectConfigPath
+				}
+				recordImportSources(configPath, outcome.TargetDirectory, outcome.Repository, outcome.Result)
+			}
 		}
+
+		fmt.Printf("\n🎉 Retry Summary: %d imported, %d still failing\n", imported, failed)
+		return true
 	}
 
-	if len(result.Imported) > 0 {
-		fmt.Printf("\n📁 Commands saved to: %s\n", targetDir)
+	if len(queue.Items) == 0 {
+		fmt.Println("No failed imports. 🎉")
+		return true
+	}
+
+	// Tally reasons so recurring problems (auth, network) stand out
+	reasons := make(map[string]int)
+	shown := 0
+	for _, item := range queue.Items {
+		if repoFilter != "" && !strings.EqualFold(item.Repository().FullName(), repoFilter) {
+			continue
+		}
+		if shown == 0 {
+			fmt.Printf("🔁 Failed imports:\n\n")
+		}
+		shown++
+		reasons[strings.TrimSpace(item.Reason)]++
+		fmt.Printf("  %-20s %s  (%d attempt(s), last %s)\n",
+			item.Command, item.Repository().FullName(), item.Attempts, item.LastFailed.Format("2006-01-02 15:04"))
+		fmt.Printf("  %-20s %s\n", "", truncateDescription(strings.TrimSpace(item.Reason), 70))
+	}
+
+	if shown == 0 {
+		fmt.Printf("No failed imports for %s.\n", repoFilter)
+		return true
 	}
 
+	fmt.Printf("\n%d failed import(s) across %d distinct reason(s). Run 'ccm imports retry' to try again.\n", shown, len(reasons))
 	return true
 }
 
+// recordImportFailures updates the persistent failed-imports queue with the outcome of an import
+func recordImportFailures(repo *remote.RemoteRepository, selected []remote.RemoteCommand, options remote.ImportOptions, result *remote.ImportResult) {
+	queue, err := remote.LoadFailureQueue()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: Failed to update failed-imports queue: %v\n", err)
+		return
+	}
+	queue.RecordResult(repo, selected, options, result)
+	if err := queue.Save(); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: Failed to update failed-imports queue: %v\n", err)
+	}
+}
+
+// recordImportSources remembers which repository imported commands came from
+func recordImportSources(configPath, targetDir string, repo *remote.RemoteRepository, result *remote.ImportResult) {
+	libraryConfigManager := config.NewManager(configPath)
+	if err := libraryConfigManager.Load(); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: Failed to record command sources: %v\n", err)
+		return
+	}
+
+	libraryCommandManager := commands.NewManager(targetDir, "", "", libraryConfigManager)
+	for _, file := range result.Files {
+		libraryCommandManager.RecordSource(file, repo.FullName())
+	}
+
+	if err := libraryConfigManager.Save(); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: Failed to record command sources: %v\n", err)
+	}
+}
+
 // truncateDescription truncates a description to fit display width
 func truncateDescription(desc string, maxLen int) string {
 	if len(desc) <= maxLen {
@@ -0,0 +1,135 @@
+// Package apperr defines the error categories shared by the CLI and the internal
+// packages, so scripts wrapping ccm can tell failure modes apart by exit code.
+package apperr
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+)
+
+// Kind is the category of a failure
+type Kind int
+
+const (
+	KindGeneral    Kind = iota // Unclassified failure
+	KindNotFound               // A command, repository, template or setting does not exist
+	KindValidation             // Bad arguments, names or content
+	KindNetwork                // A remote service could not be reached
+	KindAuth                   // Authentication or authorization failed
+	KindConflict               // The target already exists or is out of sync
+	KindPermission             // The filesystem refused access
+)
+
+// exitCodes maps each kind to the process exit code reported by the CLI
+var exitCodes = map[Kind]int{
+	KindGeneral:    1,
+	KindNotFound:   2,
+	KindValidation: 3,
+	KindNetwork:    4,
+	KindAuth:       5,
+	KindConflict:   6,
+	KindPermission: 7,
+}
+
+var kindNames = map[Kind]string{
+	KindGeneral:    "general",
+	KindNotFound:   "not found",
+	KindValidation: "validation",
+	KindNetwork:    "network",
+	KindAuth:       "auth",
+	KindConflict:   "conflict",
+	KindPermission: "permission",
+}
+
+// ExitCode returns the process exit code for the kind
+func (k Kind) ExitCode() int {
+	if code, ok := exitCodes[k]; ok {
+		return code
+	}
+	return 1
+}
+
+func (k Kind) String() string {
+	if name, ok := kindNames[k]; ok {
+		return name
+	}
+	return kindNames[KindGeneral]
+}
+
+// Kinds returns every kind in exit-code order
+func Kinds() []Kind {
+	return []Kind{KindGeneral, KindNotFound, KindValidation, KindNetwork, KindAuth, KindConflict, KindPermission}
+}
+
+// Error is an error tagged with a kind
+type Error struct {
+	Kind Kind
+	Err  error
+}
+
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// New returns a formatted error of the given kind
+func New(kind Kind, format string, args ...interface{}) error {
+	return &Error{Kind: kind, Err: fmt.Errorf(format, args...)}
+}
+
+// Wrap tags err with a kind; it returns nil when err is nil
+func Wrap(kind Kind, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &Error{Kind: kind, Err: err}
+}
+
+// kinded is implemented by error types that know their own kind
+type kinded interface {
+	Kind() Kind
+}
+
+// KindOf classifies err, looking through wrapped errors for a tagged kind
+// and falling back to well-known filesystem and network errors
+func KindOf(err error) Kind {
+	if err == nil {
+		return KindGeneral
+	}
+
+	var tagged *Error
+	if errors.As(err, &tagged) {
+		return tagged.Kind
+	}
+
+	var k kinded
+	if errors.As(err, &k) {
+		return k.Kind()
+	}
+
+	var netErr net.Error
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return KindNotFound
+	case errors.Is(err, os.ErrPermission):
+		return KindPermission
+	case errors.Is(err, os.ErrExist):
+		return KindConflict
+	case errors.As(err, &netErr):
+		return KindNetwork
+	}
+	return KindGeneral
+}
+
+// ExitCode returns the process exit code for err, or 0 when err is nil
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	return KindOf(err).ExitCode()
+}
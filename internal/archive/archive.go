@@ -0,0 +1,127 @@
+package archive
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+
+	"github.com/shel-corp/Claude-command-manager/internal/commands"
+)
+
+// ManifestVersion is the archive format version written by Export
+const ManifestVersion = 1
+
+// Archive entry names
+const (
+	manifestName = "manifest.json"
+	configName   = "config.json"
+	commandsDir  = "commands"
+)
+
+// Manifest describes the contents of an exported library archive
+type Manifest struct {
+	Version   int               `json:"version"`
+	CreatedAt time.Time         `json:"created_at"`
+	Library   string            `json:"library"` // "project" or "user"
+	Commands  []ManifestCommand `json:"commands"`
+}
+
+// ManifestCommand records a command's state and provenance at export time
+type ManifestCommand struct {
+	Name            string `json:"name"`
+	DisplayName     string `json:"display_name"`
+	RelativePath    string `json:"relative_path"`
+	Enabled         bool   `json:"enabled"`
+	SymlinkLocation string `json:"symlink_location"`
+	Source          string `json:"source,omitempty"`
+	Model           string `json:"model,omitempty"`
+}
+
+// Export writes a gzipped tar archive containing the manifest, the library config and every command file
+func Export(dest string, cmds []commands.Command, configPath, library string) (*Manifest, error) {
+	manifest := &Manifest{
+		Version:   ManifestVersion,
+		CreatedAt: time.Now().UTC(),
+		Library:   library,
+		Commands:  make([]ManifestCommand, 0, len(cmds)),
+	}
+	for _, cmd := range cmds {
+		manifest.Commands = append(manifest.Commands, ManifestCommand{
+			Name:            cmd.Name,
+			DisplayName:     cmd.DisplayName,
+			RelativePath:    filepath.ToSlash(cmd.RelativePath),
+			Enabled:         cmd.Enabled,
+			SymlinkLocation: string(cmd.SymlinkLocation),
+			Source:          cmd.Source,
+			Model:           cmd.Model,
+		})
+	}
+
+	file, err := os.Create(dest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create archive: %w", err)
+	}
+	defer file.Close()
+
+	gz := gzip.NewWriter(file)
+	tw := tar.NewWriter(gz)
+
+	manifestData, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal manifest: %w", err)
+	}
+	if err := writeEntry(tw, manifestName, manifestData, manifest.CreatedAt); err != nil {
+		return nil, err
+	}
+
+	// The config is optional - a library that was never modified may not have one yet
+	if configData, err := os.ReadFile(configPath); err == nil {
+		if err := writeEntry(tw, configName, configData, manifest.CreatedAt); err != nil {
+			return nil, err
+		}
+	} else if !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read config: %w", err)
+	}
+
+	for _, cmd := range cmds {
+		data, err := os.ReadFile(cmd.FilePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", cmd.RelativePath, err)
+		}
+		name := path.Join(commandsDir, filepath.ToSlash(cmd.RelativePath))
+		if err := writeEntry(tw, name, data, manifest.CreatedAt); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return nil, fmt.Errorf("failed to finalize archive: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return nil, fmt.Errorf("failed to finalize archive: %w", err)
+	}
+
+	return manifest, nil
+}
+
+// writeEntry adds a single regular file to the archive
+func writeEntry(tw *tar.Writer, name string, data []byte, modTime time.Time) error {
+	header := &tar.Header{
+		Name:    name,
+		Mode:    0644,
+		Size:    int64(len(data)),
+		ModTime: modTime,
+	}
+	if err := tw.WriteHeader(header); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	if _, err := tw.Write(data); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,222 @@
+package archive
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/shel-corp/Claude-command-manager/internal/commands"
+	"github.com/shel-corp/Claude-command-manager/internal/remote"
+)
+
+// Bundle is an exported library archive loaded into memory
+type Bundle struct {
+	Manifest *Manifest
+	files    map[string][]byte // Command file contents keyed by slash-separated relative path
+}
+
+// Open reads an archive written by Export
+func Open(src string) (*Bundle, error) {
+	file, err := os.Open(src)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open archive: %w", err)
+	}
+	defer file.Close()
+
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read archive: %w", err)
+	}
+	defer gz.Close()
+
+	bundle := &Bundle{files: make(map[string][]byte)}
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read archive: %w", err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", header.Name, err)
+		}
+
+		switch {
+		case header.Name == manifestName:
+			var manifest Manifest
+			if err := json.Unmarshal(data, &manifest); err != nil {
+				return nil, fmt.Errorf("invalid manifest: %w", err)
+			}
+			bundle.Manifest = &manifest
+		case strings.HasPrefix(header.Name, commandsDir+"/"):
+			bundle.files[strings.TrimPrefix(header.Name, commandsDir+"/")] = data
+		}
+	}
+
+	if bundle.Manifest == nil {
+		return nil, fmt.Errorf("archive has no %s - was it created with ccm export?", manifestName)
+	}
+	if bundle.Manifest.Version > ManifestVersion {
+		return nil, fmt.Errorf("archive format version %d is newer than supported version %d", bundle.Manifest.Version, ManifestVersion)
+	}
+
+	return bundle, nil
+}
+
+// Conflicts returns the commands in the archive that already exist in targetDir
+func (b *Bundle) Conflicts(targetDir string) []string {
+	var conflicts []string
+	for _, cmd := range b.Manifest.Commands {
+		target, err := safeJoin(targetDir, cmd.RelativePath)
+		if err != nil {
+			continue
+		}
+		if _, err := os.Stat(target); err == nil {
+			conflicts = append(conflicts, cmd.Name)
+		}
+	}
+	return conflicts
+}
+
+// Extract writes the archived command files into targetDir using the same conflict
+// handling as remote imports: existing files are skipped unless OverwriteExisting is set,
+// in which case they are backed up first when CreateBackups is set.
+func (b *Bundle) Extract(targetDir string, options remote.ImportOptions) *remote.ImportResult {
+	result := &remote.ImportResult{
+		Imported: make([]string, 0),
+		Files:    make([]string, 0),
+		Skipped:  make([]string, 0),
+		Failed:   make([]string, 0),
+		Errors:   make([]string, 0),
+	}
+
+	for _, cmd := range b.Manifest.Commands {
+		if err := b.extractCommand(cmd, targetDir, options, result); err != nil {
+			result.Failed = append(result.Failed, cmd.Name)
+			result.Errors = append(result.Errors, fmt.Sprintf("%s: %s", cmd.Name, err.Error()))
+		}
+	}
+
+	return result
+}
+
+// extractCommand writes a single command file with conflict resolution
+func (b *Bundle) extractCommand(cmd ManifestCommand, targetDir string, options remote.ImportOptions, result *remote.ImportResult) error {
+	data, ok := b.files[cmd.RelativePath]
+	if !ok {
+		return fmt.Errorf("file missing from archive: %s", cmd.RelativePath)
+	}
+
+	target, err := safeJoin(targetDir, cmd.RelativePath)
+	if err != nil {
+		return err
+	}
+
+	if _, err := os.Stat(target); err == nil {
+		if !options.OverwriteExisting {
+			result.Skipped = append(result.Skipped, cmd.Name)
+			return nil
+		}
+		if options.CreateBackups {
+			if err := remote.BackupFile(target); err != nil {
+				return fmt.Errorf("failed to create backup: %w", err)
+			}
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+	if err := os.WriteFile(target, data, 0644); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+
+	result.Imported = append(result.Imported, cmd.Name)
+	result.Files = append(result.Files, filepath.FromSlash(cmd.RelativePath))
+	return nil
+}
+
+// RestoreState reapplies the archived display name, location, enabled state and provenance
+// to every extracted command. The caller is responsible for saving the config.
+func (b *Bundle) RestoreState(manager *commands.Manager, result *remote.ImportResult) []error {
+	imported := make(map[string]bool, len(result.Imported))
+	for _, name := range result.Imported {
+		imported[name] = true
+	}
+
+	cmds, err := manager.ScanCommands()
+	if err != nil {
+		return []error{err}
+	}
+	byPath := make(map[string]commands.Command, len(cmds))
+	for _, cmd := range cmds {
+		byPath[filepath.ToSlash(cmd.RelativePath)] = cmd
+	}
+
+	var errs []error
+	for _, archived := range b.Manifest.Commands {
+		if !imported[archived.Name] {
+			continue
+		}
+		cmd, exists := byPath[archived.RelativePath]
+		if !exists {
+			continue
+		}
+
+		if archived.Source != "" {
+			manager.RecordSource(filepath.FromSlash(archived.RelativePath), archived.Source)
+			cmd.Source = archived.Source
+		}
+
+		if archived.DisplayName != "" && archived.DisplayName != cmd.DisplayName {
+			if err := manager.RenameCommand(cmd, archived.DisplayName); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", archived.Name, err))
+				continue
+			}
+			cmd.DisplayName = archived.DisplayName
+		}
+
+		if location, err := manager.ParseSymlinkLocation(archived.SymlinkLocation); err == nil {
+			if err := manager.SetSymlinkLocation(cmd, location); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", archived.Name, err))
+				continue
+			}
+			cmd.SymlinkLocation = location
+		}
+
+		switch {
+		case archived.Enabled && !cmd.Enabled:
+			if err := manager.EnableCommand(cmd); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", archived.Name, err))
+			}
+		case !archived.Enabled && cmd.Enabled:
+			if err := manager.DisableCommand(cmd); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", archived.Name, err))
+			}
+		}
+	}
+
+	return errs
+}
+
+// safeJoin joins a slash-separated archive path onto dir, rejecting paths that escape it
+func safeJoin(dir, relativePath string) (string, error) {
+	cleaned := path.Clean(relativePath)
+	if cleaned == "." || path.IsAbs(cleaned) || cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return "", fmt.Errorf("unsafe path in archive: %s", relativePath)
+	}
+	return filepath.Join(dir, filepath.FromSlash(cleaned)), nil
+}
@@ -11,6 +11,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/shel-corp/Claude-command-manager/internal/logging"
 	"github.com/shel-corp/Claude-command-manager/internal/remote"
 )
 
@@ -418,13 +419,13 @@ func (m *Manager) refreshAll(registryManager *remote.RegistryManager, githubClie
 	// Refresh registry first
 	if err := m.refreshRegistry(registryManager); err != nil {
 		// Log error but continue
-		fmt.Printf("Background cache refresh - registry error: %v\n", err)
+		logging.Warnf("background cache refresh: registry: %v", err)
 	}
 
 	// Refresh repositories concurrently
 	if err := m.refreshRepositories(githubClient); err != nil {
 		// Log error but continue
-		fmt.Printf("Background cache refresh - repositories error: %v\n", err)
+		logging.Warnf("background cache refresh: repositories: %v", err)
 	}
 
 	m.mu.Lock()
@@ -0,0 +1,176 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/shel-corp/Claude-command-manager/internal/apperr"
+	"github.com/shel-corp/Claude-command-manager/internal/config"
+)
+
+// DesiredState declares how the commands in a library should be configured.
+// It is read from YAML or JSON (JSON documents are valid YAML).
+type DesiredState struct {
+	Commands []DesiredCommand `yaml:"commands"`
+	Prune    bool             `yaml:"prune"` // Disable enabled commands that are not listed
+}
+
+// DesiredCommand is the wanted configuration of a single command. Fields left
+// empty keep their current value; listed commands are enabled unless enabled: false.
+type DesiredCommand struct {
+	Name        string `yaml:"name"`
+	Enabled     *bool  `yaml:"enabled"`
+	DisplayName string `yaml:"display_name"`
+	Location    string `yaml:"location"`
+}
+
+// StateChange is one difference between the library and a desired state
+type StateChange struct {
+	Command string // Command name
+	Field   string // "enabled", "location" or "display name"
+	From    string
+	To      string
+}
+
+// String formats the change for diff output
+func (c StateChange) String() string {
+	return fmt.Sprintf("%s: %s %s → %s", c.Command, c.Field, c.From, c.To)
+}
+
+// ParseDesiredState decodes and validates a desired-state document
+func ParseDesiredState(data []byte) (*DesiredState, error) {
+	state := &DesiredState{}
+	if err := yaml.Unmarshal(data, state); err != nil {
+		return nil, apperr.New(apperr.KindValidation, "invalid state document: %v", err)
+	}
+
+	seen := make(map[string]bool)
+	for i, desired := range state.Commands {
+		if desired.Name == "" {
+			return nil, apperr.New(apperr.KindValidation, "commands[%d]: name is required", i)
+		}
+		if seen[desired.Name] {
+			return nil, apperr.New(apperr.KindValidation, "command %s is listed more than once", desired.Name)
+		}
+		seen[desired.Name] = true
+	}
+	return state, nil
+}
+
+// DiffState returns the changes needed to bring the library in line with state
+func (m *Manager) DiffState(state *DesiredState) ([]StateChange, error) {
+	plans, err := m.planState(state)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []StateChange
+	for _, plan := range plans {
+		changes = append(changes, plan.changes()...)
+	}
+	return changes, nil
+}
+
+// ApplyState reconciles the library with state and returns the changes made. The
+// changes are applied all or nothing, as with ApplyBatch.
+// The caller is responsible for saving the configuration.
+func (m *Manager) ApplyState(state *DesiredState) ([]StateChange, error) {
+	plans, err := m.planState(state)
+	if err != nil {
+		return nil, err
+	}
+	cmds, err := m.ScanCommands()
+	if err != nil {
+		return nil, err
+	}
+	if err := m.applyPlans(plans, cmds); err != nil {
+		return nil, err
+	}
+
+	var applied []StateChange
+	for _, plan := range plans {
+		applied = append(applied, plan.changes()...)
+	}
+	return applied, nil
+}
+
+// statePlan is the target configuration for one command that needs changes
+type statePlan struct {
+	cmd         Command
+	enabled     bool
+	displayName string
+	location    config.SymlinkLocation
+}
+
+// target returns the command as it is once the plan is applied
+func (p statePlan) target() Command {
+	cmd := p.cmd
+	cmd.Enabled, cmd.DisplayName, cmd.SymlinkLocation = p.enabled, p.displayName, p.location
+	return cmd
+}
+
+func (p statePlan) changes() []StateChange {
+	var changes []StateChange
+	if p.cmd.Enabled != p.enabled {
+		changes = append(changes, StateChange{p.cmd.Name, "enabled", strconv.FormatBool(p.cmd.Enabled), strconv.FormatBool(p.enabled)})
+	}
+	if p.cmd.SymlinkLocation != p.location {
+		changes = append(changes, StateChange{p.cmd.Name, "location", string(p.cmd.SymlinkLocation), string(p.location)})
+	}
+	if p.cmd.DisplayName != p.displayName {
+		changes = append(changes, StateChange{p.cmd.Name, "display name", p.cmd.DisplayName, p.displayName})
+	}
+	return changes
+}
+
+// planState compares the library with state; every listed command must exist
+func (m *Manager) planState(state *DesiredState) ([]statePlan, error) {
+	cmds, err := m.ScanCommands()
+	if err != nil {
+		return nil, err
+	}
+
+	byName := make(map[string]Command, len(cmds))
+	for _, cmd := range cmds {
+		byName[cmd.Name] = cmd
+	}
+
+	var plans []statePlan
+	listed := make(map[string]bool, len(state.Commands))
+	for _, desired := range state.Commands {
+		cmd, ok := byName[desired.Name]
+		if !ok {
+			return nil, apperr.New(apperr.KindNotFound, "command not found: %s", desired.Name)
+		}
+		listed[desired.Name] = true
+
+		plan := statePlan{cmd: cmd, enabled: true, displayName: cmd.DisplayName, location: cmd.SymlinkLocation}
+		if desired.Enabled != nil {
+			plan.enabled = *desired.Enabled
+		}
+		if desired.DisplayName != "" {
+			plan.displayName = desired.DisplayName
+		}
+		if desired.Location != "" {
+			location, err := m.ParseSymlinkLocation(desired.Location)
+			if err != nil {
+				return nil, apperr.Wrap(apperr.KindValidation, fmt.Errorf("command %s: %w", desired.Name, err))
+			}
+			plan.location = location
+		}
+		if len(plan.changes()) > 0 {
+			plans = append(plans, plan)
+		}
+	}
+
+	if state.Prune {
+		for _, cmd := range cmds {
+			if !listed[cmd.Name] && cmd.Enabled {
+				plans = append(plans, statePlan{cmd: cmd, enabled: false, displayName: cmd.DisplayName, location: cmd.SymlinkLocation})
+			}
+		}
+	}
+	return plans, nil
+}
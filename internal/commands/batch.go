@@ -0,0 +1,190 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/shel-corp/Claude-command-manager/internal/apperr"
+	"github.com/shel-corp/Claude-command-manager/internal/config"
+)
+
+// BatchChange is the wanted configuration of one command in ApplyBatch. Fields left
+// empty keep their current value.
+type BatchChange struct {
+	Name        string                 // Command name
+	Enabled     *bool                  // Enable or disable; nil keeps the current state
+	DisplayName string                 // New display name
+	Location    config.SymlinkLocation // New symlink location
+}
+
+// ApplyBatch applies enable, disable, rename and location changes to several commands as
+// one operation. Every resulting symlink path is checked before anything is touched; then
+// old links are removed and new ones created, and if any step fails the links are put back
+// as they were and the configuration is left unchanged. On success the configuration is
+// updated in memory and the changes made are returned; the caller saves it.
+func (m *Manager) ApplyBatch(changes []BatchChange) ([]StateChange, error) {
+	cmds, err := m.ScanCommands()
+	if err != nil {
+		return nil, err
+	}
+	byName := make(map[string]Command, len(cmds))
+	for _, cmd := range cmds {
+		byName[cmd.Name] = cmd
+	}
+
+	var plans []statePlan
+	seen := make(map[string]bool, len(changes))
+	for _, change := range changes {
+		cmd, ok := byName[change.Name]
+		if !ok {
+			return nil, apperr.New(apperr.KindNotFound, "command not found: %s", change.Name)
+		}
+		if seen[change.Name] {
+			return nil, apperr.New(apperr.KindValidation, "command %s is changed more than once", change.Name)
+		}
+		seen[change.Name] = true
+
+		plan := statePlan{cmd: cmd, enabled: cmd.Enabled, displayName: cmd.DisplayName, location: cmd.SymlinkLocation}
+		if change.Enabled != nil {
+			plan.enabled = *change.Enabled
+		}
+		if change.DisplayName != "" {
+			plan.displayName = change.DisplayName
+		}
+		if change.Location != "" {
+			location, err := m.ParseSymlinkLocation(string(change.Location))
+			if err != nil {
+				return nil, apperr.Wrap(apperr.KindValidation, fmt.Errorf("command %s: %w", change.Name, err))
+			}
+			plan.location = location
+		}
+		if len(plan.changes()) > 0 {
+			plans = append(plans, plan)
+		}
+	}
+
+	if err := m.applyPlans(plans, cmds); err != nil {
+		return nil, err
+	}
+	var applied []StateChange
+	for _, plan := range plans {
+		applied = append(applied, plan.changes()...)
+	}
+	return applied, nil
+}
+
+// movesLink reports whether the plan takes away the command's current link
+func (p statePlan) movesLink(m *Manager) bool {
+	return p.cmd.Enabled && (!p.enabled || m.SymlinkPath(p.cmd) != m.SymlinkPath(p.target()))
+}
+
+// linkOp is a symlink removed or created by applyPlans, kept to undo it
+type linkOp struct {
+	path   string
+	target string
+}
+
+// applyPlans moves the symlinks of the planned commands all or nothing, then records the
+// plans in the configuration. cmds is the whole library, for checking name collisions.
+func (m *Manager) applyPlans(plans []statePlan, cmds []Command) error {
+	planned := make(map[string]bool, len(plans))
+	vacated := make(map[string]bool) // Old links the batch removes
+	for _, plan := range plans {
+		planned[plan.cmd.Name] = true
+		if plan.movesLink(m) && m.CheckSymlinkLocation(plan.cmd.SymlinkLocation) == nil {
+			vacated[m.SymlinkPath(plan.cmd)] = true
+		}
+	}
+
+	// Links the batch needs: each must be free once the batch's own old links are gone
+	taken := make(map[string]string)
+	for _, cmd := range cmds {
+		if cmd.Enabled && !planned[cmd.Name] && m.CheckSymlinkLocation(cmd.SymlinkLocation) == nil {
+			taken[m.SymlinkPath(cmd)] = cmd.RelativePath
+		}
+	}
+	for _, plan := range plans {
+		if !plan.enabled {
+			continue
+		}
+		if err := m.CheckSymlinkLocation(plan.location); err != nil {
+			return apperr.Wrap(apperr.KindNotFound, fmt.Errorf("%s: %w", plan.cmd.Name, err))
+		}
+		path := m.SymlinkPath(plan.target())
+		if owner, ok := taken[path]; ok {
+			return apperr.New(apperr.KindConflict, "%s: %s is already used by command %s", plan.cmd.Name, path, owner)
+		}
+		taken[path] = plan.cmd.RelativePath
+		if _, err := os.Lstat(path); err == nil && !vacated[path] && !m.hasValidSymlink(plan.target()) {
+			return apperr.New(apperr.KindConflict, "%s: %s is already used by a file ccm does not manage", plan.cmd.Name, path)
+		}
+	}
+
+	var removed, created []linkOp
+	rollback := func(err error) error {
+		for i := len(created) - 1; i >= 0; i-- {
+			os.Remove(created[i].path)
+		}
+		for i := len(removed) - 1; i >= 0; i-- {
+			os.Symlink(removed[i].target, removed[i].path)
+		}
+		return err
+	}
+
+	// Remove every old link first, so commands can swap names or locations
+	for _, plan := range plans {
+		// A link in a target no longer defined cannot be found; moving away leaves it be
+		if !plan.movesLink(m) || m.CheckSymlinkLocation(plan.cmd.SymlinkLocation) != nil {
+			continue
+		}
+		oldPath := m.SymlinkPath(plan.cmd)
+		info, err := os.Lstat(oldPath)
+		if err != nil {
+			continue // Already gone
+		}
+		if info.Mode()&os.ModeSymlink == 0 {
+			return rollback(apperr.New(apperr.KindConflict, "%s: %s is not a symlink", plan.cmd.Name, oldPath))
+		}
+		target, _ := os.Readlink(oldPath)
+		if err := os.Remove(oldPath); err != nil {
+			return rollback(fmt.Errorf("%s: failed to remove symlink: %w", plan.cmd.Name, err))
+		}
+		removed = append(removed, linkOp{oldPath, target})
+	}
+
+	for _, plan := range plans {
+		target := plan.target()
+		if !plan.enabled || m.hasValidSymlink(target) {
+			continue
+		}
+		source, err := filepath.Abs(target.FilePath)
+		if err != nil {
+			return rollback(fmt.Errorf("%s: failed to get absolute path: %w", plan.cmd.Name, err))
+		}
+		path := m.SymlinkPath(target)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return rollback(fmt.Errorf("%s: failed to create symlink directory: %w", plan.cmd.Name, err))
+		}
+		if err := os.Symlink(source, path); err != nil {
+			return rollback(fmt.Errorf("%s: failed to create symlink: %w", plan.cmd.Name, err))
+		}
+		created = append(created, linkOp{path, source})
+	}
+
+	for _, plan := range plans {
+		cmd := plan.target()
+		m.configManager.SetCommand(cmd.Name, config.CommandConfig{
+			Enabled:         cmd.Enabled,
+			OriginalName:    cmd.Name,
+			DisplayName:     cmd.DisplayName,
+			SourcePath:      cmd.FilePath,
+			RelativePath:    cmd.RelativePath,
+			SymlinkLocation: cmd.SymlinkLocation,
+			Source:          cmd.Source,
+			Pinned:          cmd.Pinned,
+			Tags:            cmd.LocalTags,
+		})
+	}
+	return nil
+}
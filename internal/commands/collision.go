@@ -0,0 +1,106 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/shel-corp/Claude-command-manager/internal/apperr"
+)
+
+// NameCollision is something already using the slash command name a command would be
+// linked under: another enabled command, or a file ccm does not manage
+type NameCollision struct {
+	Command   Command  // Command being enabled or renamed
+	Path      string   // Symlink path it needs
+	Other     *Command // Library command already using the name; nil for an unmanaged file
+	Suggested string   // A free display name the command could be renamed to
+}
+
+func (c *NameCollision) Error() string {
+	owner := "a file ccm does not manage"
+	if c.Other != nil {
+		owner = "command " + c.Other.RelativePath
+	}
+	return fmt.Sprintf("/%s is already used by %s (%s); rename it first, e.g. to %s",
+		c.Command.QualifiedName(), owner, c.Path, c.Suggested)
+}
+
+// Kind reports collisions as conflicts to the CLI
+func (c *NameCollision) Kind() apperr.Kind {
+	return apperr.KindConflict
+}
+
+// CheckNameCollision reports whether cmd can be linked under its display name: nothing may be
+// at its symlink path except its own link, and no other enabled command may use that path.
+// cmds is the library, as already scanned by the caller. It returns nil when the name is free.
+func (m *Manager) CheckNameCollision(cmd Command, cmds []Command) *NameCollision {
+	collision := m.nameCollision(cmd, cmds)
+	if collision != nil {
+		collision.Suggested = m.suggestFreeName(cmd, cmds)
+	}
+	return collision
+}
+
+// configuredCommands returns the commands recorded in the config, which covers every
+// enabled one: enough to check a name without scanning the library for each command
+// enabled or renamed
+func (m *Manager) configuredCommands() []Command {
+	entries := m.configManager.GetAllCommands()
+	cmds := make([]Command, 0, len(entries))
+	for name, entry := range entries {
+		cmds = append(cmds, Command{
+			Name:            name,
+			DisplayName:     entry.DisplayName,
+			FilePath:        entry.SourcePath,
+			RelativePath:    entry.RelativePath,
+			Enabled:         entry.Enabled,
+			SymlinkLocation: entry.SymlinkLocation,
+		})
+	}
+	return cmds
+}
+
+// nameCollision checks cmd's symlink path against the other commands and the filesystem
+func (m *Manager) nameCollision(cmd Command, cmds []Command) *NameCollision {
+	path := m.SymlinkPath(cmd)
+	for i, other := range cmds {
+		if other.Name != cmd.Name && other.Enabled && m.SymlinkPath(other) == path {
+			return &NameCollision{Command: cmd, Path: path, Other: &cmds[i]}
+		}
+	}
+
+	if _, err := os.Lstat(path); err != nil {
+		return nil
+	}
+	target, isLink := linkTarget(path)
+	if source, err := filepath.Abs(cmd.FilePath); err == nil && isLink && target == source {
+		return nil // Already linked
+	}
+	collision := &NameCollision{Command: cmd, Path: path}
+	for i, other := range cmds {
+		if source, err := filepath.Abs(other.FilePath); err == nil && isLink && target == source {
+			collision.Other = &cmds[i]
+		}
+	}
+	return collision
+}
+
+// suggestFreeName returns the first of name-2, name-3, ... that cmd could be linked under
+func (m *Manager) suggestFreeName(cmd Command, cmds []Command) string {
+	base := cmd.DisplayName
+	if i := strings.LastIndex(base, "-"); i > 0 {
+		if _, err := strconv.Atoi(base[i+1:]); err == nil {
+			base = base[:i]
+		}
+	}
+	for n := 2; ; n++ {
+		probe := cmd
+		probe.DisplayName = fmt.Sprintf("%s-%d", base, n)
+		if m.nameCollision(probe, cmds) == nil {
+			return probe.DisplayName
+		}
+	}
+}
@@ -5,10 +5,17 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
 	"sort"
 	"strings"
+	"sync"
+	"time"
 
+	"gopkg.in/yaml.v3"
+
+	"github.com/shel-corp/Claude-command-manager/internal/apperr"
 	"github.com/shel-corp/Claude-command-manager/internal/config"
+	"github.com/shel-corp/Claude-command-manager/internal/logging"
 )
 
 // isExcludedFile checks if a file should be excluded from command scanning
@@ -18,15 +25,50 @@ func isExcludedFile(filename string) bool {
 	return strings.ToUpper(nameWithoutExt) == nameWithoutExt
 }
 
+// scanWorkers is how many command files ScanCommands parses at once
+var scanWorkers = min(runtime.NumCPU(), 8)
+
+// trashDirName is the hidden directory inside a library where deleted commands are kept
+const trashDirName = ".trash"
+
 // Command represents a single command with its metadata
 type Command struct {
 	Name            string                 // Original filename without .md
 	DisplayName     string                 // Display name (can be renamed)
 	Description     string                 // From YAML frontmatter
+	Model           string                 // Target model from YAML frontmatter (optional)
+	Tags            []string               // Tags from YAML frontmatter, then LocalTags
+	LocalTags       []string               // Tags kept in the config rather than the file
+	Source          string                 // Repository the command was imported from (empty for local commands)
+	Pinned          bool                   // Kept at the top of the library list regardless of sort
 	Enabled         bool                   // Whether it's currently enabled
 	FilePath        string                 // Full path to the .md file
 	RelativePath    string                 // Path relative to commands directory (e.g., "subdir/command.md")
 	SymlinkLocation config.SymlinkLocation // Where the command should be symlinked
+	ModTime         time.Time              // Last modification time of the .md file
+}
+
+// linkDir is the directory commands are linked into under each symlink target. Claude
+// treats it as a namespace, so every command's name starts with "cl:".
+const linkDir = "cl"
+
+// Namespace returns the command's subdirectory in the library as a Claude namespace,
+// e.g. "git" for git/commit.md or "git:hooks" for git/hooks/pre.md; "" at the top level
+func (c Command) Namespace() string {
+	dir := filepath.Dir(c.RelativePath)
+	if dir == "." {
+		return ""
+	}
+	return strings.ReplaceAll(filepath.ToSlash(dir), "/", ":")
+}
+
+// QualifiedName returns the name Claude invokes the command by: the display name prefixed
+// with linkDir and the namespace, e.g. "cl:git:commit" for git/commit.md or "cl:review"
+func (c Command) QualifiedName() string {
+	if namespace := c.Namespace(); namespace != "" {
+		return linkDir + ":" + namespace + ":" + c.DisplayName
+	}
+	return linkDir + ":" + c.DisplayName
 }
 
 // Manager handles command operations
@@ -35,6 +77,20 @@ type Manager struct {
 	userCommandsDir      string // ~/.claude/commands/
 	projectCommandsDir   string // <project>/.claude/commands/
 	configManager        *config.Manager
+	defaultLocation      config.SymlinkLocation // Location for commands without a saved one
+	targets              map[string]string      // Custom symlink target name -> directory
+
+	scanMu    sync.Mutex
+	scanCache map[string]scanEntry // Frontmatter parsed by earlier scans, by file path
+}
+
+// scanEntry is the frontmatter of a command file as of its last modification
+type scanEntry struct {
+	modTime     time.Time
+	size        int64
+	description string
+	model       string
+	tags        []string
 }
 
 // NewManager creates a new command manager
@@ -44,9 +100,48 @@ func NewManager(commandsDir, userCommandsDir, projectCommandsDir string, configM
 		userCommandsDir:    userCommandsDir,
 		projectCommandsDir: projectCommandsDir,
 		configManager:      configManager,
+		defaultLocation:    config.SymlinkLocationUser,
 	}
 }
 
+// SetDefaultSymlinkLocation sets where commands without a saved location are symlinked
+func (m *Manager) SetDefaultSymlinkLocation(location config.SymlinkLocation) {
+	m.defaultLocation = location
+}
+
+// SetSymlinkTargets sets the custom directories commands can be symlinked into, by name
+func (m *Manager) SetSymlinkTargets(targets map[string]string) {
+	m.targets = targets
+}
+
+// SymlinkLocations returns every location a command can be symlinked to: user, project,
+// then the custom targets by name
+func (m *Manager) SymlinkLocations() []config.SymlinkLocation {
+	locations := []config.SymlinkLocation{config.SymlinkLocationUser, config.SymlinkLocationProject}
+	names := make([]string, 0, len(m.targets))
+	for name := range m.targets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		locations = append(locations, config.SymlinkLocation(name))
+	}
+	return locations
+}
+
+// ParseSymlinkLocation converts "user", "project" or a custom target name to a SymlinkLocation
+func (m *Manager) ParseSymlinkLocation(value string) (config.SymlinkLocation, error) {
+	locations := m.SymlinkLocations()
+	names := make([]string, len(locations))
+	for i, location := range locations {
+		if string(location) == value {
+			return location, nil
+		}
+		names[i] = string(location)
+	}
+	return "", apperr.New(apperr.KindValidation, "invalid symlink location %q (expected %s)", value, strings.Join(names, ", "))
+}
+
 // ScanCommands discovers all .md files in the commands directory
 func (m *Manager) ScanCommands() ([]Command, error) {
 	if _, err := os.Stat(m.commandsDir); os.IsNotExist(err) {
@@ -54,12 +149,19 @@ func (m *Manager) ScanCommands() ([]Command, error) {
 	}
 
 	var commands []Command
+	var infos []os.FileInfo // File info of each command, for parseScanned
+	seen := make(map[string]bool)
 	
 	err := filepath.Walk(m.commandsDir, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
 			return err
 		}
 
+		// Skip hidden directories such as the trash
+		if info.IsDir() && path != m.commandsDir && strings.HasPrefix(info.Name(), ".") {
+			return filepath.SkipDir
+		}
+
 		if !info.IsDir() && strings.HasSuffix(info.Name(), ".md") && !isExcludedFile(info.Name()) {
 			name := strings.TrimSuffix(info.Name(), ".md")
 			
@@ -70,16 +172,21 @@ func (m *Manager) ScanCommands() ([]Command, error) {
 			}
 			
 			// Use relative path + name as unique identifier to handle duplicate filenames in different directories
-			uniqueName := strings.ReplaceAll(relativePath, string(filepath.Separator), "_")
-			uniqueName = strings.TrimSuffix(uniqueName, ".md")
+			uniqueName := commandName(relativePath)
 			
 			// Get configuration using unique name
 			cmdConfig, exists := m.configManager.GetCommand(uniqueName)
 			displayName := name // Display name remains just the filename for user friendliness
 			enabled := false
-			symlinkLocation := config.SymlinkLocationUser // Default to user
+			symlinkLocation := m.defaultLocation
+			source := ""
+			pinned := false
+			var localTags []string
 			
 			if exists {
+				source = cmdConfig.Source
+				pinned = cmdConfig.Pinned
+				localTags = cmdConfig.Tags
 				displayName = cmdConfig.DisplayName
 				enabled = cmdConfig.Enabled
 				symlinkLocation = cmdConfig.SymlinkLocation
@@ -89,17 +196,21 @@ func (m *Manager) ScanCommands() ([]Command, error) {
 				}
 			}
 
-			// Parse description from file
-			description := m.parseDescription(path)
+			// Frontmatter is parsed afterwards, several files at a time
+			seen[path] = true
+			infos = append(infos, info)
 
 			commands = append(commands, Command{
 				Name:            uniqueName,
 				DisplayName:     displayName,
-				Description:     description,
+				Source:          source,
+				Pinned:          pinned,
+				LocalTags:       localTags,
 				Enabled:         enabled,
 				FilePath:        path,
 				RelativePath:    relativePath,
 				SymlinkLocation: symlinkLocation,
+				ModTime:         info.ModTime(),
 			})
 		}
 
@@ -109,6 +220,8 @@ func (m *Manager) ScanCommands() ([]Command, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to scan commands directory: %w", err)
 	}
+	m.parseScanned(commands, infos)
+	m.pruneScanCache(seen)
 
 	// Sort commands by name for consistent ordering
 	sort.Slice(commands, func(i, j int) bool {
@@ -118,8 +231,97 @@ func (m *Manager) ScanCommands() ([]Command, error) {
 	return commands, nil
 }
 
+// Signature describes the command files ScanCommands would find by path, size and
+// modification time, so changes made outside ccm can be noticed without a full scan
+func (m *Manager) Signature() string {
+	var b strings.Builder
+	filepath.Walk(m.commandsDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return nil // Missing or unreadable; a later signature picks it up
+		}
+		if info.IsDir() && path != m.commandsDir && strings.HasPrefix(info.Name(), ".") {
+			return filepath.SkipDir
+		}
+		if !info.IsDir() && strings.HasSuffix(info.Name(), ".md") && !isExcludedFile(info.Name()) {
+			fmt.Fprintf(&b, "%s:%d:%d;", path, info.Size(), info.ModTime().UnixNano())
+		}
+		return nil
+	})
+	return b.String()
+}
+
+// parseScanned fills in the frontmatter of scanned commands on up to scanWorkers goroutines;
+// files unchanged since the previous scan come from the cache without being read
+func (m *Manager) parseScanned(cmds []Command, infos []os.FileInfo) {
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for range min(scanWorkers, len(cmds)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				var tags []string
+				cmds[i].Description, cmds[i].Model, tags = m.scanFrontmatter(cmds[i].FilePath, infos[i])
+				cmds[i].Tags = EditTags(tags, cmds[i].LocalTags, nil)
+			}
+		}()
+	}
+	for i := range cmds {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+}
+
+// scanFrontmatter returns the parsed frontmatter of a command file, re-reading it only
+// when its modification time or size changed since the previous scan
+func (m *Manager) scanFrontmatter(path string, info os.FileInfo) (string, string, []string) {
+	m.scanMu.Lock()
+	entry, ok := m.scanCache[path]
+	m.scanMu.Unlock()
+	if ok && entry.modTime.Equal(info.ModTime()) && entry.size == info.Size() {
+		return entry.description, entry.model, entry.tags
+	}
+
+	description, model, tags := m.parseFrontmatter(path)
+
+	m.scanMu.Lock()
+	defer m.scanMu.Unlock()
+	if m.scanCache == nil {
+		m.scanCache = make(map[string]scanEntry)
+	}
+	m.scanCache[path] = scanEntry{
+		modTime:     info.ModTime(),
+		size:        info.Size(),
+		description: description,
+		model:       model,
+		tags:        tags,
+	}
+	return description, model, tags
+}
+
+// pruneScanCache forgets files that were not found by the latest scan
+func (m *Manager) pruneScanCache(seen map[string]bool) {
+	m.scanMu.Lock()
+	defer m.scanMu.Unlock()
+	for path := range m.scanCache {
+		if !seen[path] {
+			delete(m.scanCache, path)
+		}
+	}
+}
+
 // EnableCommand enables a command by creating a symlink and updating config
 func (m *Manager) EnableCommand(cmd Command) error {
+	// Find a taken name before touching the filesystem, so the error can suggest another
+	if collision := m.CheckNameCollision(cmd, m.configuredCommands()); collision != nil {
+		return collision
+	}
+
+	if err := m.CheckSymlinkLocation(cmd.SymlinkLocation); err != nil {
+		return err
+	}
+
 	// Ensure symlink directory exists
 	symlinkDir := m.getSymlinkDir(cmd.SymlinkLocation)
 	if err := os.MkdirAll(symlinkDir, 0755); err != nil {
@@ -139,6 +341,9 @@ func (m *Manager) EnableCommand(cmd Command) error {
 		SourcePath:      cmd.FilePath,
 		RelativePath:    cmd.RelativePath,
 		SymlinkLocation: cmd.SymlinkLocation,
+		Source:          cmd.Source,
+		Pinned:          cmd.Pinned,
+		Tags:            cmd.LocalTags,
 	})
 
 	return nil
@@ -159,6 +364,9 @@ func (m *Manager) DisableCommand(cmd Command) error {
 		SourcePath:      cmd.FilePath,
 		RelativePath:    cmd.RelativePath,
 		SymlinkLocation: cmd.SymlinkLocation,
+		Source:          cmd.Source,
+		Pinned:          cmd.Pinned,
+		Tags:            cmd.LocalTags,
 	})
 
 	return nil
@@ -172,6 +380,12 @@ func (m *Manager) RenameCommand(cmd Command, newDisplayName string) error {
 
 	// If command is enabled, update the symlink
 	if cmd.Enabled {
+		renamed := cmd
+		renamed.DisplayName = newDisplayName
+		if collision := m.CheckNameCollision(renamed, m.configuredCommands()); collision != nil {
+			return collision
+		}
+
 		// Remove old symlink
 		if err := m.removeSymlink(cmd); err != nil {
 			return fmt.Errorf("failed to remove old symlink: %w", err)
@@ -195,42 +409,228 @@ func (m *Manager) RenameCommand(cmd Command, newDisplayName string) error {
 		SourcePath:      cmd.FilePath,
 		RelativePath:    cmd.RelativePath,
 		SymlinkLocation: cmd.SymlinkLocation,
+		Source:          cmd.Source,
+		Pinned:          cmd.Pinned,
+		Tags:            cmd.LocalTags,
 	})
 
 	return nil
 }
 
-// getSymlinkDir returns the appropriate symlink directory based on location
+// DeleteCommand removes a command from the library by disabling it, moving its file
+// into the library's trash directory, and removing its configuration entry. The entry is
+// kept in the trash so RestoreCommand can bring the command back as it was.
+// Returns the path the file was moved to.
+func (m *Manager) DeleteCommand(cmd Command) (string, error) {
+	// Remove symlink first so no dangling link is left behind
+	if cmd.Enabled {
+		if err := m.removeSymlink(cmd); err != nil {
+			return "", fmt.Errorf("failed to remove symlink: %w", err)
+		}
+	}
+
+	// Move file into a timestamped trash directory, preserving its relative path
+	entryDir := filepath.Join(m.GetTrashDir(), time.Now().Format(trashTimeFormat))
+	trashPath := filepath.Join(entryDir, cmd.RelativePath)
+	if err := os.MkdirAll(filepath.Dir(trashPath), 0755); err != nil {
+		return "", fmt.Errorf("failed to create trash directory: %w", err)
+	}
+
+	if err := os.Rename(cmd.FilePath, trashPath); err != nil {
+		// Restore symlink on failure
+		if cmd.Enabled {
+			m.createSymlink(cmd)
+		}
+		return "", fmt.Errorf("failed to move command to trash: %w", err)
+	}
+
+	// Keep the configuration for `ccm trash restore`; the file itself is already safe, so a
+	// failure only costs the restore its display name, location and tags
+	if err := recordTrashedConfig(entryDir, cmd); err != nil {
+		logging.Warnf("failed to record the configuration of %s in the trash: %v", cmd.Name, err)
+	}
+
+	// Remove configuration entry
+	m.configManager.DeleteCommand(cmd.Name)
+
+	return trashPath, nil
+}
+
+// CreateCommand writes a new command file into the library and registers it in the config.
+// relativePath may include subdirectories (e.g. "git/commit"); the .md extension is optional.
+func (m *Manager) CreateCommand(relativePath, content string) (Command, error) {
+	relativePath = filepath.Clean(strings.TrimSuffix(relativePath, ".md") + ".md")
+	if filepath.IsAbs(relativePath) || strings.HasPrefix(relativePath, "..") {
+		return Command{}, apperr.New(apperr.KindValidation, "command path must be inside the library: %s", relativePath)
+	}
+
+	fileName := filepath.Base(relativePath)
+	name := strings.TrimSuffix(fileName, ".md")
+	if name == "" || strings.HasPrefix(name, ".") {
+		return Command{}, apperr.New(apperr.KindValidation, "invalid command name: %s", name)
+	}
+	if isExcludedFile(fileName) {
+		return Command{}, apperr.New(apperr.KindValidation, "all-uppercase names are reserved for documentation files: %s", name)
+	}
+
+	filePath := filepath.Join(m.commandsDir, relativePath)
+	if _, err := os.Stat(filePath); err == nil {
+		return Command{}, apperr.New(apperr.KindConflict, "command already exists: %s", relativePath)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+		return Command{}, fmt.Errorf("failed to create command directory: %w", err)
+	}
+	if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+		return Command{}, fmt.Errorf("failed to write command file: %w", err)
+	}
+
+	uniqueName := commandName(relativePath)
+	cmd := Command{
+		Name:            uniqueName,
+		DisplayName:     name,
+		FilePath:        filePath,
+		RelativePath:    relativePath,
+		SymlinkLocation: m.defaultLocation,
+	}
+	cmd.Description, cmd.Model, cmd.Tags = m.parseFrontmatter(filePath)
+
+	m.configManager.SetCommand(cmd.Name, config.CommandConfig{
+		Enabled:         false,
+		OriginalName:    cmd.Name,
+		DisplayName:     cmd.DisplayName,
+		SourcePath:      cmd.FilePath,
+		RelativePath:    cmd.RelativePath,
+		SymlinkLocation: cmd.SymlinkLocation,
+		Source:          cmd.Source,
+		Pinned:          cmd.Pinned,
+		Tags:            cmd.LocalTags,
+	})
+
+	return cmd, nil
+}
+
+// WriteCommandContent replaces the contents of a command's file, keeping its permissions
+func (m *Manager) WriteCommandContent(cmd Command, content string) error {
+	info, err := os.Stat(cmd.FilePath)
+	if err != nil {
+		return fmt.Errorf("failed to stat %s: %w", cmd.RelativePath, err)
+	}
+	if err := os.WriteFile(cmd.FilePath, []byte(content), info.Mode().Perm()); err != nil {
+		return fmt.Errorf("failed to write %s: %w", cmd.RelativePath, err)
+	}
+	return nil
+}
+
+// RecordSource stores the repository a command file was imported from.
+// relativePath is the file path relative to the library, e.g. "commit.md".
+func (m *Manager) RecordSource(relativePath, source string) {
+	uniqueName := commandName(relativePath)
+
+	cmdConfig, exists := m.configManager.GetCommand(uniqueName)
+	if !exists {
+		cmdConfig = config.CommandConfig{
+			OriginalName:    uniqueName,
+			DisplayName:     strings.TrimSuffix(filepath.Base(relativePath), ".md"),
+			SourcePath:      filepath.Join(m.commandsDir, relativePath),
+			RelativePath:    relativePath,
+			SymlinkLocation: m.defaultLocation,
+		}
+	}
+	cmdConfig.Source = source
+
+	m.configManager.SetCommand(uniqueName, cmdConfig)
+}
+
+// SetPinned pins a command to the top of the library list, or unpins it
+func (m *Manager) SetPinned(cmd Command, pinned bool) {
+	cmdConfig, exists := m.configManager.GetCommand(cmd.Name)
+	if !exists {
+		cmdConfig = config.CommandConfig{
+			Enabled:         cmd.Enabled,
+			OriginalName:    cmd.Name,
+			DisplayName:     cmd.DisplayName,
+			SourcePath:      cmd.FilePath,
+			RelativePath:    cmd.RelativePath,
+			SymlinkLocation: cmd.SymlinkLocation,
+			Source:          cmd.Source,
+			Tags:            cmd.LocalTags,
+		}
+	}
+	cmdConfig.Pinned = pinned
+
+	m.configManager.SetCommand(cmd.Name, cmdConfig)
+}
+
+// GetCommandsDir returns the library directory commands are scanned from
+func (m *Manager) GetCommandsDir() string {
+	return m.commandsDir
+}
+
+// GetTrashDir returns the directory where deleted commands are kept
+func (m *Manager) GetTrashDir() string {
+	return filepath.Join(m.commandsDir, trashDirName)
+}
+
+// CheckSymlinkLocation returns an error if location is a custom target that is no longer
+// defined in the settings, so there is no directory to link the command into
+func (m *Manager) CheckSymlinkLocation(location config.SymlinkLocation) error {
+	switch location {
+	case config.SymlinkLocationProject, config.SymlinkLocationUser, "":
+		return nil
+	}
+	if _, ok := m.targets[string(location)]; ok {
+		return nil
+	}
+	return apperr.New(apperr.KindNotFound, "symlink target %q is no longer defined in the settings", location)
+}
+
+// getSymlinkDir returns the appropriate symlink directory based on location. A target no
+// longer defined gives the user directory, for display only: everything that touches
+// links checks CheckSymlinkLocation first.
 func (m *Manager) getSymlinkDir(location config.SymlinkLocation) string {
 	switch location {
 	case config.SymlinkLocationProject:
 		return m.projectCommandsDir
-	default: // config.SymlinkLocationUser or empty
+	case config.SymlinkLocationUser, "":
 		return m.userCommandsDir
 	}
-}
-
-// createSymlink creates a symlink for the command
-func (m *Manager) createSymlink(cmd Command) error {
-	sourcePath, err := filepath.Abs(cmd.FilePath)
-	if err != nil {
-		return fmt.Errorf("failed to get absolute path: %w", err)
+	if dir, ok := m.targets[string(location)]; ok {
+		return dir
 	}
+	return m.userCommandsDir
+}
 
+// SymlinkPath returns where the command's symlink lives, mirroring its
+// subdirectory under the cl/ directory of the configured location
+func (m *Manager) SymlinkPath(cmd Command) string {
 	symlinkBaseDir := m.getSymlinkDir(cmd.SymlinkLocation)
 	
-	// Create the cl/ subdirectory structure
 	relativeDir := filepath.Dir(cmd.RelativePath)
 	var symlinkDir string
 	if relativeDir == "." {
 		// Command is in root of commands directory
-		symlinkDir = filepath.Join(symlinkBaseDir, "cl")
+		symlinkDir = filepath.Join(symlinkBaseDir, linkDir)
 	} else {
 		// Command is in a subdirectory
-		symlinkDir = filepath.Join(symlinkBaseDir, "cl", relativeDir)
+		symlinkDir = filepath.Join(symlinkBaseDir, linkDir, relativeDir)
 	}
 	
-	targetPath := filepath.Join(symlinkDir, cmd.DisplayName+".md")
+	return filepath.Join(symlinkDir, cmd.DisplayName+".md")
+}
+
+// createSymlink creates a symlink for the command
+func (m *Manager) createSymlink(cmd Command) error {
+	if err := m.CheckSymlinkLocation(cmd.SymlinkLocation); err != nil {
+		return err
+	}
+	sourcePath, err := filepath.Abs(cmd.FilePath)
+	if err != nil {
+		return fmt.Errorf("failed to get absolute path: %w", err)
+	}
+
+	targetPath := m.SymlinkPath(cmd)
+	symlinkDir := filepath.Dir(targetPath)
 	
 	// Ensure symlink directory exists
 	if err := os.MkdirAll(symlinkDir, 0755); err != nil {
@@ -250,7 +650,7 @@ func (m *Manager) createSymlink(cmd Command) error {
 				}
 			}
 		}
-		return fmt.Errorf("target file already exists: %s", targetPath)
+		return apperr.New(apperr.KindConflict, "target file already exists: %s", targetPath)
 	}
 
 	if err := os.Symlink(sourcePath, targetPath); err != nil {
@@ -262,20 +662,10 @@ func (m *Manager) createSymlink(cmd Command) error {
 
 // removeSymlink removes a symlink for the command
 func (m *Manager) removeSymlink(cmd Command) error {
-	symlinkBaseDir := m.getSymlinkDir(cmd.SymlinkLocation)
-	
-	// Build the cl/ subdirectory structure path
-	relativeDir := filepath.Dir(cmd.RelativePath)
-	var symlinkDir string
-	if relativeDir == "." {
-		// Command is in root of commands directory
-		symlinkDir = filepath.Join(symlinkBaseDir, "cl")
-	} else {
-		// Command is in a subdirectory
-		symlinkDir = filepath.Join(symlinkBaseDir, "cl", relativeDir)
+	if err := m.CheckSymlinkLocation(cmd.SymlinkLocation); err != nil {
+		return err
 	}
-	
-	targetPath := filepath.Join(symlinkDir, cmd.DisplayName+".md")
+	targetPath := m.SymlinkPath(cmd)
 
 	// Check if it exists and is a symlink
 	if info, err := os.Lstat(targetPath); err == nil {
@@ -292,20 +682,31 @@ func (m *Manager) removeSymlink(cmd Command) error {
 	return nil
 }
 
-// ToggleSymlinkLocation toggles the symlink location between user and project
+// ToggleSymlinkLocation moves the command to the next location: user, project, then each custom target
 func (m *Manager) ToggleSymlinkLocation(cmd Command) error {
-	// Determine new location
-	var newLocation config.SymlinkLocation
-	if cmd.SymlinkLocation == config.SymlinkLocationUser {
-		newLocation = config.SymlinkLocationProject
-	} else {
-		newLocation = config.SymlinkLocationUser
+	locations := m.SymlinkLocations()
+	newLocation := locations[0]
+	for i, location := range locations {
+		if location == cmd.SymlinkLocation {
+			newLocation = locations[(i+1)%len(locations)]
+			break
+		}
+	}
+
+	return m.SetSymlinkLocation(cmd, newLocation)
+}
+
+// SetSymlinkLocation sets where the command is symlinked, moving the symlink if it is enabled
+func (m *Manager) SetSymlinkLocation(cmd Command, newLocation config.SymlinkLocation) error {
+	if cmd.SymlinkLocation == newLocation {
+		return nil // No change needed
 	}
 
 	// If command is enabled, move the symlink
 	if cmd.Enabled {
-		// Remove old symlink
-		if err := m.removeSymlink(cmd); err != nil {
+		// Remove old symlink; one in a target no longer defined cannot be found, and moving
+		// the command elsewhere is how it gets a working link again
+		if err := m.removeSymlink(cmd); err != nil && m.CheckSymlinkLocation(cmd.SymlinkLocation) == nil {
 			return fmt.Errorf("failed to remove old symlink: %w", err)
 		}
 
@@ -327,16 +728,24 @@ func (m *Manager) ToggleSymlinkLocation(cmd Command) error {
 		SourcePath:      cmd.FilePath,
 		RelativePath:    cmd.RelativePath,
 		SymlinkLocation: newLocation,
+		Source:          cmd.Source,
+		Pinned:          cmd.Pinned,
+		Tags:            cmd.LocalTags,
 	})
 
 	return nil
 }
 
-// parseDescription extracts the description from YAML frontmatter
-func (m *Manager) parseDescription(filePath string) string {
+// parseFrontmatter extracts the description, model and tags from YAML frontmatter
+func (m *Manager) parseFrontmatter(filePath string) (string, string, []string) {
+	description := "No description available"
+	hasDescription := false
+	model := ""
+	var tags []string
+
 	file, err := os.Open(filePath)
 	if err != nil {
-		return "No description available"
+		return description, model, tags
 	}
 	defer file.Close()
 
@@ -361,18 +770,43 @@ func (m *Manager) parseDescription(filePath string) string {
 			// Look for description field
 			if strings.HasPrefix(line, "description:") {
 				// Extract description value
-				description := strings.TrimPrefix(line, "description:")
-				description = strings.TrimSpace(description)
+				value := strings.TrimSpace(strings.TrimPrefix(line, "description:"))
 				// Remove quotes if present
-				description = strings.Trim(description, `"'`)
-				if description != "" {
-					return description
+				value = strings.Trim(value, `"'`)
+				// The first description wins when a file repeats the key
+				if value != "" && !hasDescription {
+					description = value
+					hasDescription = true
+				}
+			}
+			
+			// Look for model field
+			if strings.HasPrefix(line, "model:") {
+				value := strings.TrimSpace(strings.TrimPrefix(line, "model:"))
+				model = strings.Trim(value, `"'`)
+			}
+			
+			// Look for tags field, either "tags: [a, b]" or "tags: a, b"
+			if strings.HasPrefix(line, "tags:") {
+				value := strings.TrimSpace(strings.TrimPrefix(line, "tags:"))
+				// A flow list may quote tags that contain commas or YAML indicators
+				var list []string
+				if strings.HasPrefix(value, "[") && yaml.Unmarshal([]byte(value), &list) == nil {
+					tags = append(tags, list...)
+					continue
+				}
+				value = strings.Trim(value, "[]")
+				for _, tag := range strings.Split(value, ",") {
+					tag = strings.Trim(strings.TrimSpace(tag), `"'`)
+					if tag != "" {
+						tags = append(tags, tag)
+					}
 				}
 			}
 		}
 	}
 
-	return "No description available"
+	return description, model, tags
 }
 
 // CleanupBrokenSymlinks removes any broken symlinks in both user and project command directories
@@ -381,6 +815,9 @@ func (m *Manager) CleanupBrokenSymlinks() error {
 	
 	// Clean up both directories
 	dirs := []string{m.userCommandsDir, m.projectCommandsDir}
+	for _, location := range m.SymlinkLocations()[2:] {
+		dirs = append(dirs, m.getSymlinkDir(location))
+	}
 	
 	for _, dir := range dirs {
 		if _, err := os.Stat(dir); os.IsNotExist(err) {
@@ -388,7 +825,7 @@ func (m *Manager) CleanupBrokenSymlinks() error {
 		}
 
 		// Check for the cl/ subdirectory
-		clDir := filepath.Join(dir, "cl")
+		clDir := filepath.Join(dir, linkDir)
 		if _, err := os.Stat(clDir); os.IsNotExist(err) {
 			// Also check the old flat structure for backward compatibility
 			removed, err := m.cleanupSymlinksInDir(dir)
@@ -467,7 +904,7 @@ func (m *Manager) cleanupSymlinksRecursive(dir string) ([]string, error) {
 				} else {
 					// Store relative path from base dir for reporting
 					relPath, _ := filepath.Rel(dir, path)
-					removed = append(removed, filepath.Join("cl", relPath))
+					removed = append(removed, filepath.Join(linkDir, relPath))
 				}
 			}
 		}
@@ -0,0 +1,80 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/shel-corp/Claude-command-manager/internal/apperr"
+)
+
+// DuplicateCommand copies a command's file under newName and registers the copy, disabled.
+// A plain name puts the copy next to the original; a name with a slash (e.g. "git/wip") is
+// a path in the library. The copy's description says which command it was copied from,
+// so the two can be told apart until the new prompt is edited.
+func (m *Manager) DuplicateCommand(cmd Command, newName string) (Command, error) {
+	newName = strings.TrimSuffix(strings.TrimSpace(newName), ".md")
+	if newName == "" {
+		return Command{}, apperr.New(apperr.KindValidation, "new command name cannot be empty")
+	}
+	relativePath := filepath.FromSlash(newName)
+	if !strings.ContainsAny(newName, `/\`) {
+		relativePath = filepath.Join(filepath.Dir(cmd.RelativePath), newName)
+	}
+
+	data, err := os.ReadFile(cmd.FilePath)
+	if err != nil {
+		return Command{}, fmt.Errorf("failed to read %s: %w", cmd.RelativePath, err)
+	}
+
+	description := "Copy of " + cmd.DisplayName
+	if original := frontmatterDescription(string(data)); original != "" {
+		description = fmt.Sprintf("%s (copy of %s)", original, cmd.DisplayName)
+	}
+	content, err := setFrontmatterLine(string(data), "description", "description: "+FrontmatterString(description))
+	if err != nil {
+		return Command{}, fmt.Errorf("%s: %w", cmd.RelativePath, err)
+	}
+
+	duplicate, err := m.CreateCommand(relativePath, content)
+	if err != nil || len(cmd.LocalTags) == 0 {
+		return duplicate, err
+	}
+	// Tags kept in the config describe the prompt too
+	duplicate.LocalTags = cmd.LocalTags
+	duplicate.Tags = EditTags(duplicate.Tags, cmd.LocalTags, nil)
+	m.SetLocalTags(duplicate, cmd.LocalTags)
+	return duplicate, nil
+}
+
+// frontmatterDescription returns the description in content's frontmatter, joining a
+// folded or literal block (description: >) into one line
+func frontmatterDescription(content string) string {
+	fields, _ := SplitFrontmatter(content)
+	for _, field := range fields {
+		if field.Key != "description" {
+			continue
+		}
+		value := field.Value
+		if strings.HasPrefix(value, ">") || strings.HasPrefix(value, "|") {
+			value = strings.TrimLeft(value, ">|+-0123456789")
+		}
+		return strings.TrimSpace(value)
+	}
+	return ""
+}
+
+// FrontmatterString quotes a frontmatter value when YAML would otherwise misread it
+func FrontmatterString(value string) string {
+	if value == "" {
+		return `""`
+	}
+	if !strings.ContainsAny(value, ":#") && !strings.ContainsAny(value[:1], `-?,[]{}&*!|>'"%@`+"`") {
+		return value
+	}
+	if strings.ContainsAny(value, `"\`) {
+		return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+	}
+	return `"` + value + `"`
+}
@@ -0,0 +1,23 @@
+package commands
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// EditorCommand builds a command that opens path in $VISUAL or $EDITOR, falling back to vi.
+// Editors configured with arguments (e.g. "code --wait") are supported.
+func EditorCommand(path string) *exec.Cmd {
+	// A variable holding only whitespace counts as unset
+	editor := strings.TrimSpace(os.Getenv("VISUAL"))
+	if editor == "" {
+		editor = strings.TrimSpace(os.Getenv("EDITOR"))
+	}
+	if editor == "" {
+		editor = "vi"
+	}
+
+	parts := strings.Fields(editor)
+	return exec.Command(parts[0], append(parts[1:], path)...)
+}
@@ -0,0 +1,45 @@
+package commands
+
+import "strings"
+
+// FrontmatterField is a single top-level key/value pair from YAML frontmatter
+type FrontmatterField struct {
+	Key   string
+	Value string
+}
+
+// SplitFrontmatter separates YAML frontmatter from the body of a command file.
+// Fields are returned in file order; content without frontmatter is returned as the body.
+func SplitFrontmatter(content string) ([]FrontmatterField, string) {
+	lines := strings.Split(content, "\n")
+	if len(lines) == 0 || strings.TrimSpace(lines[0]) != "---" {
+		return nil, content
+	}
+
+	var fields []FrontmatterField
+	for i := 1; i < len(lines); i++ {
+		line := strings.TrimRight(lines[i], "\r")
+		if strings.TrimSpace(line) == "---" {
+			return fields, strings.TrimLeft(strings.Join(lines[i+1:], "\n"), "\n")
+		}
+
+		// Indented lines continue the previous field's value
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(fields) > 0 {
+			last := &fields[len(fields)-1]
+			last.Value = strings.TrimSpace(last.Value + " " + strings.TrimSpace(line))
+			continue
+		}
+
+		key, value, found := strings.Cut(line, ":")
+		if !found || strings.TrimSpace(key) == "" {
+			continue
+		}
+		fields = append(fields, FrontmatterField{
+			Key:   strings.TrimSpace(key),
+			Value: strings.Trim(strings.TrimSpace(value), `"'`),
+		})
+	}
+
+	// Unterminated frontmatter - treat the whole file as body
+	return nil, content
+}
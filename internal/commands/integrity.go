@@ -0,0 +1,333 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/shel-corp/Claude-command-manager/internal/config"
+)
+
+// IntegrityReport describes commands whose symlinks on disk diverge from the config
+type IntegrityReport struct {
+	MissingSymlinks []Command   // Enabled commands with nothing at their symlink path
+	WrongTargets    []Command   // Enabled commands whose symlink points at another library file
+	ForeignFiles    []Command   // Enabled commands whose symlink path holds a file ccm did not create
+	StrayLinks      []StrayLink // Links to library commands at paths the config does not expect
+	MissingSources  []string    // Enabled config entries whose source file no longer exists
+	LostTargets     []Command   // Enabled commands linked into a custom target no longer in the settings
+}
+
+// StrayLink is a symlink to a library command that is not where the config puts it, such
+// as a link renamed by hand or one left behind for a disabled command
+type StrayLink struct {
+	Command  Command
+	Path     string
+	Adopt    bool                   // The link is a valid place for the command; the config takes it over
+	Location config.SymlinkLocation // Location the link is in, when Adopt is set
+}
+
+// IsHealthy returns true if the config and the filesystem agree
+func (r IntegrityReport) IsHealthy() bool {
+	return len(r.MissingSymlinks) == 0 && len(r.WrongTargets) == 0 && len(r.ForeignFiles) == 0 &&
+		len(r.StrayLinks) == 0 && len(r.MissingSources) == 0 && len(r.LostTargets) == 0
+}
+
+// Summary returns a concise description of the problems found
+func (r IntegrityReport) Summary() string {
+	var parts []string
+	if n := len(r.MissingSymlinks); n > 0 {
+		parts = append(parts, fmt.Sprintf("%d enabled %s missing %s symlink%s",
+			n, plural(n, "command is", "commands are"), plural(n, "its", "their"), plural(n, "", "s")))
+	}
+	if n := len(r.WrongTargets); n > 0 {
+		parts = append(parts, fmt.Sprintf("%d %s to the wrong file", n, plural(n, "symlink points", "symlinks point")))
+	}
+	if n := len(r.ForeignFiles); n > 0 {
+		parts = append(parts, fmt.Sprintf("%d enabled %s shadowed by another file", n, plural(n, "command is", "commands are")))
+	}
+	if n := len(r.StrayLinks); n > 0 {
+		parts = append(parts, fmt.Sprintf("%d %s renamed or left behind", n, plural(n, "symlink was", "symlinks were")))
+	}
+	if n := len(r.MissingSources); n > 0 {
+		parts = append(parts, fmt.Sprintf("%d enabled %s no source file",
+			n, plural(n, "command has", "commands have")))
+	}
+	if n := len(r.LostTargets); n > 0 {
+		parts = append(parts, fmt.Sprintf("%d enabled %s linked into a target that is no longer defined",
+			n, plural(n, "command is", "commands are")))
+	}
+	return strings.Join(parts, "; ")
+}
+
+// Details describes each problem and how RepairIntegrity fixes it, one line per problem
+func (r IntegrityReport) Details(m *Manager) []string {
+	var lines []string
+	for _, cmd := range r.MissingSymlinks {
+		lines = append(lines, fmt.Sprintf("%s: no symlink at %s; it will be created", cmd.QualifiedName(), m.SymlinkPath(cmd)))
+	}
+	for _, cmd := range r.WrongTargets {
+		target, _ := linkTarget(m.SymlinkPath(cmd))
+		lines = append(lines, fmt.Sprintf("%s: %s points at %s; it will be relinked", cmd.QualifiedName(), m.SymlinkPath(cmd), target))
+	}
+	for _, cmd := range r.ForeignFiles {
+		path := m.SymlinkPath(cmd)
+		lines = append(lines, fmt.Sprintf("%s: %s is not ccm's link; it will be moved to %s", cmd.QualifiedName(), path, filepath.Base(foreignBackupPath(path))))
+	}
+	for _, stray := range r.StrayLinks {
+		if stray.Adopt {
+			lines = append(lines, fmt.Sprintf("%s: linked as %s; the config will follow the link", stray.Command.QualifiedName(), stray.Path))
+		} else {
+			lines = append(lines, fmt.Sprintf("%s: extra link %s; it will be removed", stray.Command.QualifiedName(), stray.Path))
+		}
+	}
+	for _, name := range r.MissingSources {
+		lines = append(lines, fmt.Sprintf("%s: source file is gone; its config entry will be removed", name))
+	}
+	for _, cmd := range r.LostTargets {
+		lines = append(lines, fmt.Sprintf("%s: target %q is no longer defined; define it again in the settings or move the command", cmd.QualifiedName(), cmd.SymlinkLocation))
+	}
+	return lines
+}
+
+// CheckIntegrity compares the config against the symlinks in every location: enabled
+// commands must be linked to their source file, and links into the library must belong
+// to an enabled command
+func (m *Manager) CheckIntegrity() (IntegrityReport, error) {
+	var report IntegrityReport
+
+	cmds, err := m.ScanCommands()
+	if err != nil {
+		return report, err
+	}
+
+	scanned := make(map[string]bool, len(cmds))
+	bySource := make(map[string]Command, len(cmds))
+	expected := make(map[string]bool, len(cmds)) // Symlink paths of enabled commands
+	for _, cmd := range cmds {
+		scanned[cmd.Name] = true
+		if source, err := filepath.Abs(cmd.FilePath); err == nil {
+			bySource[source] = cmd
+		}
+		if !cmd.Enabled {
+			continue
+		}
+		if m.CheckSymlinkLocation(cmd.SymlinkLocation) != nil {
+			report.LostTargets = append(report.LostTargets, cmd)
+			continue
+		}
+
+		path := m.SymlinkPath(cmd)
+		expected[path] = true
+		info, err := os.Lstat(path)
+		switch {
+		case err != nil:
+			report.MissingSymlinks = append(report.MissingSymlinks, cmd)
+		case m.hasValidSymlink(cmd):
+		case info.Mode()&os.ModeSymlink != 0 && m.linksIntoLibrary(path):
+			report.WrongTargets = append(report.WrongTargets, cmd)
+		default:
+			report.ForeignFiles = append(report.ForeignFiles, cmd)
+		}
+	}
+
+	// A command whose target is gone may still have a link elsewhere worth adopting
+	report.StrayLinks = m.findStrayLinks(bySource, expected, append(report.MissingSymlinks, report.LostTargets...))
+	adopted := make(map[string]bool)
+	for _, stray := range report.StrayLinks {
+		if stray.Adopt {
+			adopted[stray.Command.Name] = true
+		}
+	}
+	// An adopted link takes the place of the missing one
+	report.MissingSymlinks = slices.DeleteFunc(report.MissingSymlinks, func(cmd Command) bool { return adopted[cmd.Name] })
+	report.LostTargets = slices.DeleteFunc(report.LostTargets, func(cmd Command) bool { return adopted[cmd.Name] })
+
+	for name, cmdConfig := range m.configManager.GetAllCommands() {
+		if cmdConfig.Enabled && !scanned[name] {
+			report.MissingSources = append(report.MissingSources, name)
+		}
+	}
+	sort.Strings(report.MissingSources)
+
+	return report, nil
+}
+
+// findStrayLinks walks the cl/ directory of every location for links to library commands
+// that are not at the command's configured path. Paths that belong to an enabled command
+// are left to the relinking of wrong targets. The first stray link of a disabled command,
+// or of an enabled one whose link is missing, is adopted; any others are extra.
+func (m *Manager) findStrayLinks(bySource map[string]Command, expected map[string]bool, missing []Command) []StrayLink {
+	var strays []StrayLink
+	adoptable := make(map[string]bool)
+	for _, cmd := range bySource {
+		adoptable[cmd.Name] = !cmd.Enabled
+	}
+	for _, cmd := range missing {
+		adoptable[cmd.Name] = true
+	}
+	walked := make(map[string]bool)
+	for _, location := range m.SymlinkLocations() {
+		clDir := filepath.Join(m.getSymlinkDir(location), linkDir)
+		if walked[clDir] {
+			continue
+		}
+		walked[clDir] = true
+
+		filepath.Walk(clDir, func(path string, info os.FileInfo, err error) error {
+			if err != nil || info.Mode()&os.ModeSymlink == 0 {
+				return nil
+			}
+			target, ok := linkTarget(path)
+			if !ok {
+				return nil
+			}
+			cmd, ok := bySource[target]
+			if !ok || expected[path] {
+				return nil
+			}
+
+			stray := StrayLink{Command: cmd, Path: path}
+			if adoptable[cmd.Name] {
+				if location, ok := m.linkLocation(cmd, path); ok {
+					stray.Adopt, stray.Location = true, location
+					adoptable[cmd.Name] = false
+				}
+			}
+			strays = append(strays, stray)
+			return nil
+		})
+	}
+	return strays
+}
+
+// linkLocation finds the location whose symlink path for cmd, under the link's file name,
+// is path: the link was renamed in place rather than moved to another directory
+func (m *Manager) linkLocation(cmd Command, path string) (config.SymlinkLocation, bool) {
+	probe := cmd
+	probe.DisplayName = strings.TrimSuffix(filepath.Base(path), ".md")
+	for _, location := range m.SymlinkLocations() {
+		probe.SymlinkLocation = location
+		if m.SymlinkPath(probe) == path {
+			return location, true
+		}
+	}
+	return "", false
+}
+
+// RepairIntegrity brings the filesystem and the config back in line: stray links are adopted
+// or removed, wrong and missing symlinks are recreated, files shadowing a command are moved
+// aside, and config entries whose source is gone are dropped. Commands in a target no longer
+// defined are left for the user, who decides where they belong.
+// Returns the number of problems fixed; the caller is responsible for saving the config.
+func (m *Manager) RepairIntegrity(report IntegrityReport) (int, error) {
+	fixed := 0
+
+	for _, stray := range report.StrayLinks {
+		if stray.Adopt {
+			cmd := stray.Command
+			if cmd.Enabled {
+				m.removeSymlink(cmd) // Anything left at the old path is not a working link
+			}
+			cmd.DisplayName = strings.TrimSuffix(filepath.Base(stray.Path), ".md")
+			cmd.SymlinkLocation = stray.Location
+			if err := m.EnableCommand(cmd); err != nil {
+				return fixed, fmt.Errorf("failed to adopt %s for %s: %w", stray.Path, stray.Command.DisplayName, err)
+			}
+		} else if err := os.Remove(stray.Path); err != nil {
+			return fixed, fmt.Errorf("failed to remove extra link %s: %w", stray.Path, err)
+		}
+		fixed++
+	}
+
+	for _, cmd := range report.ForeignFiles {
+		path := m.SymlinkPath(cmd)
+		if err := os.Rename(path, foreignBackupPath(path)); err != nil {
+			return fixed, fmt.Errorf("failed to move %s aside: %w", path, err)
+		}
+		if err := m.EnableCommand(cmd); err != nil {
+			return fixed, fmt.Errorf("failed to restore symlink for %s: %w", cmd.DisplayName, err)
+		}
+		fixed++
+	}
+
+	for _, cmd := range append(append([]Command(nil), report.WrongTargets...), report.MissingSymlinks...) {
+		// Clear a stale symlink pointing elsewhere before recreating it
+		if err := m.removeSymlink(cmd); err != nil {
+			return fixed, err
+		}
+		if err := m.EnableCommand(cmd); err != nil {
+			return fixed, fmt.Errorf("failed to restore symlink for %s: %w", cmd.DisplayName, err)
+		}
+		fixed++
+	}
+
+	for _, name := range report.MissingSources {
+		m.configManager.DeleteCommand(name)
+		fixed++
+	}
+
+	return fixed, nil
+}
+
+// foreignBackupPath returns where a file shadowing a command is moved: next to it with a
+// .bak suffix, which Claude does not load, and a timestamp if that name is taken
+func foreignBackupPath(path string) string {
+	backup := path + ".bak"
+	if _, err := os.Lstat(backup); err == nil {
+		backup = path + "." + time.Now().Format(trashTimeFormat) + ".bak"
+	}
+	return backup
+}
+
+// linkTarget returns the absolute path a symlink points at, resolving relative links
+// against the link's directory
+func linkTarget(path string) (string, bool) {
+	link, err := os.Readlink(path)
+	if err != nil {
+		return "", false
+	}
+	if !filepath.IsAbs(link) {
+		link = filepath.Join(filepath.Dir(path), link)
+	}
+	return filepath.Clean(link), true
+}
+
+// linksIntoLibrary reports whether the symlink at path points inside the commands directory
+func (m *Manager) linksIntoLibrary(path string) bool {
+	target, ok := linkTarget(path)
+	if !ok {
+		return false
+	}
+	library, err := filepath.Abs(m.commandsDir)
+	if err != nil {
+		return false
+	}
+	rel, err := filepath.Rel(library, target)
+	return err == nil && !strings.HasPrefix(rel, "..")
+}
+
+// hasValidSymlink reports whether the command's symlink exists and points at its source file
+func (m *Manager) hasValidSymlink(cmd Command) bool {
+	link, ok := linkTarget(m.SymlinkPath(cmd))
+	if !ok {
+		return false
+	}
+	sourceAbs, err := filepath.Abs(cmd.FilePath)
+	if err != nil {
+		return false
+	}
+	return link == sourceAbs
+}
+
+// plural picks the singular or plural form based on n
+func plural(n int, singular, pluralForm string) string {
+	if n == 1 {
+		return singular
+	}
+	return pluralForm
+}
@@ -0,0 +1,292 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/shel-corp/Claude-command-manager/internal/models"
+)
+
+// LintSeverity orders lint findings from merely informative to breaking
+type LintSeverity int
+
+const (
+	LintInfo    LintSeverity = iota // Worth knowing; the command works as is
+	LintWarning                     // Likely a mistake, or something Claude handles poorly
+	LintError                       // The command is broken or cannot be invoked
+)
+
+func (s LintSeverity) String() string {
+	switch s {
+	case LintError:
+		return "error"
+	case LintWarning:
+		return "warning"
+	}
+	return "info"
+}
+
+// MarshalText writes the severity by name in JSON reports
+func (s LintSeverity) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// LintIssue is a problem found in a command file
+type LintIssue struct {
+	RelativePath string       `json:"path"` // Command file, relative to the commands directory
+	Severity     LintSeverity `json:"severity"`
+	Rule         string       `json:"rule"` // Check that found it: frontmatter, description, allowed-tools, model, arguments, name or body
+	Message      string       `json:"message"`
+}
+
+// knownFrontmatterKeys are the keys Claude reads from a command, plus the tags ccm keeps
+var knownFrontmatterKeys = map[string]bool{
+	"description": true, "argument-hint": true, "allowed-tools": true, "model": true,
+	"disable-model-invocation": true, "tags": true,
+}
+
+// knownTools are the built-in tools allowed-tools can name; MCP tools (mcp__server__tool)
+// are accepted as well
+var knownTools = map[string]bool{
+	"Bash": true, "BashOutput": true, "Edit": true, "ExitPlanMode": true, "Glob": true, "Grep": true,
+	"KillShell": true, "LS": true, "MultiEdit": true, "NotebookEdit": true, "NotebookRead": true, "Read": true,
+	"SlashCommand": true, "Task": true, "TodoWrite": true, "WebFetch": true, "WebSearch": true, "Write": true,
+}
+
+var (
+	// lintToolPattern matches a tool name with an optional (specifier), e.g. Bash(git diff:*)
+	lintToolPattern = regexp.MustCompile(`^([A-Za-z][A-Za-z0-9_-]*)(?:\((.*)\))?$`)
+	// lintNamePattern matches the names that can be typed after a slash
+	lintNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]*$`)
+	// lintPositionalPattern finds positional arguments such as $1
+	lintPositionalPattern = regexp.MustCompile(`\$[1-9]`)
+	// lintMisspelledArgumentsPattern finds near misses of $ARGUMENTS that Claude leaves as typed
+	lintMisspelledArgumentsPattern = regexp.MustCompile(`\$\{ARGUMENTS\}|\$(?i:arguments?)\b`)
+	// yamlLinePattern finds the line number in a YAML error, relative to the frontmatter
+	yamlLinePattern = regexp.MustCompile(`^yaml: line (\d+): `)
+)
+
+// Lint checks every command in the library and returns the issues found, by file and
+// then most severe first
+func (m *Manager) Lint() ([]LintIssue, error) {
+	cmds, err := m.ScanCommands()
+	if err != nil {
+		return nil, err
+	}
+
+	var issues []LintIssue
+	byName := make(map[string][]Command)
+	for _, cmd := range cmds {
+		byName[cmd.QualifiedName()] = append(byName[cmd.QualifiedName()], cmd)
+		data, err := os.ReadFile(cmd.FilePath)
+		if err != nil {
+			issues = append(issues, LintIssue{cmd.RelativePath, LintError, "body", fmt.Sprintf("cannot read file: %v", err)})
+			continue
+		}
+		issues = append(issues, LintCommand(cmd, string(data))...)
+	}
+
+	for name, same := range byName {
+		if len(same) < 2 {
+			continue
+		}
+		for _, cmd := range same {
+			issues = append(issues, LintIssue{cmd.RelativePath, LintWarning, "name",
+				fmt.Sprintf("/%s is also the name of %d other command(s); only one of them can be enabled at a time", name, len(same)-1)})
+		}
+	}
+
+	sort.SliceStable(issues, func(i, j int) bool {
+		if issues[i].RelativePath != issues[j].RelativePath {
+			return issues[i].RelativePath < issues[j].RelativePath
+		}
+		return issues[i].Severity > issues[j].Severity
+	})
+	return issues, nil
+}
+
+// LintCommand checks a single command's content: its frontmatter, the keys Claude reads
+// from it, its use of arguments and its name
+func LintCommand(cmd Command, content string) []LintIssue {
+	var issues []LintIssue
+	report := func(severity LintSeverity, rule, format string, args ...any) {
+		issues = append(issues, LintIssue{cmd.RelativePath, severity, rule, fmt.Sprintf(format, args...)})
+	}
+
+	lintName(cmd, report)
+
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+	body := content
+	var doc map[string]any
+	switch block, ok := frontmatterBlock(content); {
+	case strings.TrimSpace(strings.SplitN(content, "\n", 2)[0]) != "---":
+		report(LintWarning, "frontmatter", "no frontmatter; Claude shows no description for the command")
+	case !ok:
+		report(LintError, "frontmatter", "frontmatter is not closed with ---; Claude reads the whole file as the prompt")
+		return issues
+	default:
+		_, body = SplitFrontmatter(content)
+		if err := yaml.Unmarshal([]byte(block), &doc); err != nil {
+			report(LintError, "frontmatter", "invalid YAML: %s", yamlErrorMessage(err))
+			return issues
+		}
+		var keys []string
+		for key := range doc {
+			if !knownFrontmatterKeys[key] {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			report(LintInfo, "frontmatter", "unknown key %q is ignored by Claude", key)
+		}
+	}
+
+	if strings.TrimSpace(body) == "" {
+		report(LintError, "body", "the prompt is empty")
+	}
+
+	description := strings.TrimSpace(frontmatterText(doc["description"]))
+	switch {
+	case doc != nil && description == "":
+		report(LintWarning, "description", "description is missing or empty")
+	case strings.HasPrefix(strings.ToUpper(description), "TODO"):
+		report(LintWarning, "description", "description is still a placeholder: %q", description)
+	case len(description) > 200:
+		report(LintInfo, "description", "description is %d characters; long descriptions are cut off in the / menu", len(description))
+	}
+
+	lintAllowedTools(doc["allowed-tools"], report)
+
+	if model := strings.TrimSpace(frontmatterText(doc["model"])); model != "" && models.IsDeprecated(model) {
+		report(LintWarning, "model", "model %q is deprecated", model)
+	}
+
+	usesArguments := strings.Contains(body, "$ARGUMENTS") || lintPositionalPattern.MatchString(body)
+	hint := strings.TrimSpace(frontmatterText(doc["argument-hint"]))
+	switch {
+	case hint != "" && !usesArguments:
+		report(LintWarning, "arguments", "argument-hint is set but the prompt never uses $ARGUMENTS or $1-$9")
+	case hint == "" && lintPositionalPattern.MatchString(body):
+		report(LintInfo, "arguments", "the prompt uses positional arguments; an argument-hint tells users what to pass")
+	}
+	for _, miss := range uniqueMatches(lintMisspelledArgumentsPattern.FindAllString(body, -1)) {
+		if miss != "$ARGUMENTS" {
+			report(LintWarning, "arguments", "%s is not substituted; use $ARGUMENTS", miss)
+		}
+	}
+
+	return issues
+}
+
+// lintName checks that the command and its namespace directories can be typed after a slash
+func lintName(cmd Command, report func(LintSeverity, string, string, ...any)) {
+	if strings.ContainsAny(cmd.DisplayName, " \t") {
+		report(LintError, "name", "name %q contains whitespace and cannot be invoked as a slash command", cmd.DisplayName)
+	} else if !lintNamePattern.MatchString(cmd.DisplayName) {
+		report(LintWarning, "name", "name %q should only use letters, digits, '-', '_' and '.'", cmd.DisplayName)
+	}
+	if len(cmd.DisplayName) > 64 {
+		report(LintInfo, "name", "name is %d characters long", len(cmd.DisplayName))
+	}
+	if namespace := cmd.Namespace(); namespace != "" {
+		for _, dir := range strings.Split(namespace, ":") {
+			if !lintNamePattern.MatchString(dir) {
+				report(LintWarning, "name", "namespace directory %q should only use letters, digits, '-', '_' and '.'", dir)
+			}
+		}
+	}
+}
+
+// lintAllowedTools checks each entry of allowed-tools, written either as a comma-separated
+// string or as a YAML list
+func lintAllowedTools(value any, report func(LintSeverity, string, string, ...any)) {
+	var tools []string
+	switch v := value.(type) {
+	case nil:
+		return
+	case []any:
+		for _, item := range v {
+			tools = append(tools, strings.TrimSpace(frontmatterText(item)))
+		}
+	default:
+		text := frontmatterText(v)
+		if strings.Count(text, "(") != strings.Count(text, ")") {
+			report(LintError, "allowed-tools", "unbalanced parentheses in %q", text)
+			return
+		}
+		tools = splitTools(text)
+	}
+
+	for _, tool := range tools {
+		match := lintToolPattern.FindStringSubmatch(tool)
+		switch {
+		case tool == "":
+			report(LintWarning, "allowed-tools", "empty entry in allowed-tools")
+		case match == nil:
+			report(LintError, "allowed-tools", "%q is not a tool name or Tool(specifier)", tool)
+		case strings.Contains(tool, "(") && strings.TrimSpace(match[2]) == "":
+			report(LintError, "allowed-tools", "%q has an empty specifier; drop the parentheses to allow the whole tool", tool)
+		case !knownTools[match[1]] && !strings.HasPrefix(match[1], "mcp__"):
+			report(LintWarning, "allowed-tools", "unknown tool %q", match[1])
+		}
+	}
+}
+
+// splitTools splits a comma-separated tool list on commas outside parentheses,
+// e.g. "Bash(git add:*), Read"
+func splitTools(value string) []string {
+	var tools []string
+	depth, start := 0, 0
+	for i, r := range value {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				tools = append(tools, strings.TrimSpace(value[start:i]))
+				start = i + 1
+			}
+		}
+	}
+	return append(tools, strings.TrimSpace(value[start:]))
+}
+
+// frontmatterText renders a decoded YAML scalar as text; nil is ""
+func frontmatterText(value any) string {
+	if value == nil {
+		return ""
+	}
+	return fmt.Sprint(value)
+}
+
+// yamlErrorMessage rewrites a YAML error's line number to count from the top of the file
+func yamlErrorMessage(err error) string {
+	message := err.Error()
+	if match := yamlLinePattern.FindStringSubmatch(message); match != nil {
+		line, _ := strconv.Atoi(match[1])
+		return fmt.Sprintf("line %d: %s", line+1, strings.TrimPrefix(message, match[0]))
+	}
+	return strings.TrimPrefix(message, "yaml: ")
+}
+
+// uniqueMatches returns matches without repeats, in order of first appearance
+func uniqueMatches(matches []string) []string {
+	seen := make(map[string]bool, len(matches))
+	var unique []string
+	for _, match := range matches {
+		if !seen[match] {
+			seen[match] = true
+			unique = append(unique, match)
+		}
+	}
+	return unique
+}
@@ -0,0 +1,116 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/shel-corp/Claude-command-manager/internal/apperr"
+)
+
+// MetaKeys are the frontmatter keys Claude reads from a command, in display order
+var MetaKeys = []string{"description", "argument-hint", "allowed-tools", "model"}
+
+// ReadMeta returns the values of MetaKeys in the command's frontmatter; missing keys are "".
+// A list value, such as allowed-tools written as a YAML sequence, is joined with commas.
+func ReadMeta(cmd Command) (map[string]string, error) {
+	data, err := os.ReadFile(cmd.FilePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", cmd.RelativePath, err)
+	}
+
+	meta := make(map[string]string, len(MetaKeys))
+	for _, key := range MetaKeys {
+		meta[key] = ""
+	}
+
+	var doc map[string]any
+	if block, ok := frontmatterBlock(string(data)); ok && yaml.Unmarshal([]byte(block), &doc) == nil {
+		for key := range meta {
+			switch value := doc[key].(type) {
+			case nil:
+			case []any:
+				items := make([]string, len(value))
+				for i, item := range value {
+					items[i] = fmt.Sprint(item)
+				}
+				meta[key] = strings.Join(items, ", ")
+			default:
+				meta[key] = strings.TrimSpace(fmt.Sprint(value))
+			}
+		}
+		return meta, nil
+	}
+
+	// Frontmatter YAML cannot parse, such as an unquoted "TODO: describe": read it line by line
+	fields, _ := SplitFrontmatter(string(data))
+	for _, field := range fields {
+		if _, ok := meta[field.Key]; ok {
+			meta[field.Key] = field.Value
+		}
+	}
+	meta["description"] = frontmatterDescription(string(data))
+	return meta, nil
+}
+
+// frontmatterBlock returns the text between content's frontmatter delimiters
+func frontmatterBlock(content string) (string, bool) {
+	lines := strings.Split(content, "\n")
+	if strings.TrimSpace(lines[0]) != "---" {
+		return "", false
+	}
+	for i := 1; i < len(lines); i++ {
+		if strings.TrimSpace(lines[i]) == "---" {
+			return strings.Join(lines[1:i], "\n"), true
+		}
+	}
+	return "", false
+}
+
+// SetMeta rewrites the given frontmatter keys of a command, leaving its other keys and
+// its body untouched. An empty value removes the key; values are quoted when YAML needs it.
+func (m *Manager) SetMeta(cmd Command, values map[string]string) error {
+	for key, value := range values {
+		if !slices.Contains(MetaKeys, key) {
+			return apperr.New(apperr.KindValidation, "unknown frontmatter key %q (expected one of: %s)", key, strings.Join(MetaKeys, ", "))
+		}
+		if strings.ContainsAny(value, "\r\n") {
+			return apperr.New(apperr.KindValidation, "%s must be a single line", key)
+		}
+	}
+
+	info, err := os.Stat(cmd.FilePath)
+	if err != nil {
+		return fmt.Errorf("failed to stat %s: %w", cmd.RelativePath, err)
+	}
+	data, err := os.ReadFile(cmd.FilePath)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", cmd.RelativePath, err)
+	}
+
+	content := string(data)
+	for _, key := range MetaKeys {
+		value, ok := values[key]
+		if !ok {
+			continue
+		}
+		line := ""
+		if value = strings.TrimSpace(value); value != "" {
+			line = key + ": " + FrontmatterString(value)
+		}
+		if content, err = setFrontmatterLine(content, key, line); err != nil {
+			return fmt.Errorf("%s: %w", cmd.RelativePath, err)
+		}
+	}
+
+	if content == string(data) {
+		return nil
+	}
+	if err := os.WriteFile(cmd.FilePath, []byte(content), info.Mode().Perm()); err != nil {
+		return fmt.Errorf("failed to write %s: %w", cmd.RelativePath, err)
+	}
+	return nil
+}
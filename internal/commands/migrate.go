@@ -0,0 +1,113 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/shel-corp/Claude-command-manager/internal/apperr"
+)
+
+// FrontmatterChange describes a single pending frontmatter key rewrite in a command file
+type FrontmatterChange struct {
+	Command    Command // Command whose file will be rewritten
+	LineNumber int     // 1-based line number of the rewritten key
+	OldLine    string  // Line as it currently appears
+	NewLine    string  // Line after the rewrite
+	Conflict   bool    // True if the new key already exists; the file is left untouched
+	newContent string
+}
+
+// PlanFrontmatterKeyRename finds every command whose frontmatter contains oldKey
+// and prepares a rewrite of that key to newKey without touching any files
+func (m *Manager) PlanFrontmatterKeyRename(oldKey, newKey string) ([]FrontmatterChange, error) {
+	oldKey = strings.TrimSuffix(strings.TrimSpace(oldKey), ":")
+	newKey = strings.TrimSuffix(strings.TrimSpace(newKey), ":")
+	if oldKey == "" || newKey == "" {
+		return nil, apperr.New(apperr.KindValidation, "frontmatter keys cannot be empty")
+	}
+	if oldKey == newKey {
+		return nil, apperr.New(apperr.KindValidation, "old and new keys are identical: %s", oldKey)
+	}
+
+	cmds, err := m.ScanCommands()
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []FrontmatterChange
+	for _, cmd := range cmds {
+		data, err := os.ReadFile(cmd.FilePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", cmd.RelativePath, err)
+		}
+
+		change, ok := planKeyRename(string(data), oldKey, newKey)
+		if !ok {
+			continue
+		}
+		change.Command = cmd
+		changes = append(changes, change)
+	}
+
+	return changes, nil
+}
+
+// ApplyFrontmatterChanges writes planned changes to disk, skipping conflicts.
+// It returns the number of files rewritten.
+func (m *Manager) ApplyFrontmatterChanges(changes []FrontmatterChange) (int, error) {
+	applied := 0
+	for _, change := range changes {
+		if change.Conflict {
+			continue
+		}
+
+		info, err := os.Stat(change.Command.FilePath)
+		if err != nil {
+			return applied, fmt.Errorf("failed to stat %s: %w", change.Command.RelativePath, err)
+		}
+
+		if err := os.WriteFile(change.Command.FilePath, []byte(change.newContent), info.Mode().Perm()); err != nil {
+			return applied, fmt.Errorf("failed to write %s: %w", change.Command.RelativePath, err)
+		}
+		applied++
+	}
+
+	return applied, nil
+}
+
+// planKeyRename rewrites the top-level oldKey line within the YAML frontmatter of content
+func planKeyRename(content, oldKey, newKey string) (FrontmatterChange, bool) {
+	lines := strings.Split(content, "\n")
+	if len(lines) == 0 || strings.TrimSpace(lines[0]) != "---" {
+		return FrontmatterChange{}, false
+	}
+
+	change := FrontmatterChange{}
+	found := false
+	for i := 1; i < len(lines); i++ {
+		line := strings.TrimRight(lines[i], "\r")
+		if strings.TrimSpace(line) == "---" {
+			break
+		}
+
+		// Only top-level keys are considered; indented lines belong to nested values
+		if strings.HasPrefix(line, newKey+":") {
+			change.Conflict = true
+		}
+		if !found && strings.HasPrefix(line, oldKey+":") {
+			found = true
+			change.LineNumber = i + 1
+			change.OldLine = line
+			change.NewLine = newKey + strings.TrimPrefix(line, oldKey)
+			lines[i] = newKey + strings.TrimPrefix(lines[i], oldKey)
+		}
+	}
+
+	if !found {
+		return FrontmatterChange{}, false
+	}
+
+	change.newContent = strings.Join(lines, "\n")
+	return change, true
+}
@@ -0,0 +1,95 @@
+package commands
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/shel-corp/Claude-command-manager/internal/config"
+)
+
+// PlannedChange describes one file, symlink or config change an operation would make
+type PlannedChange struct {
+	Action string // e.g. "create symlink", "move file", "update config"
+	Path   string // File, symlink or config file affected
+	Detail string // Human-readable specifics such as the symlink target
+}
+
+// String formats the change for dry-run output
+func (c PlannedChange) String() string {
+	if c.Detail == "" {
+		return fmt.Sprintf("%-15s %s", c.Action, c.Path)
+	}
+	return fmt.Sprintf("%-15s %s (%s)", c.Action, c.Path, c.Detail)
+}
+
+// PlanEnable returns the changes EnableCommand would make without touching disk
+func (m *Manager) PlanEnable(cmd Command) []PlannedChange {
+	var changes []PlannedChange
+	if !cmd.Enabled {
+		changes = append(changes, m.planCreateSymlink(cmd))
+	}
+	return append(changes, m.planConfigUpdate(cmd, "enabled"))
+}
+
+// PlanDisable returns the changes DisableCommand would make without touching disk
+func (m *Manager) PlanDisable(cmd Command) []PlannedChange {
+	var changes []PlannedChange
+	if cmd.Enabled {
+		changes = append(changes, m.planRemoveSymlink(cmd))
+	}
+	return append(changes, m.planConfigUpdate(cmd, "disabled"))
+}
+
+// PlanRename returns the changes RenameCommand would make without touching disk
+func (m *Manager) PlanRename(cmd Command, newDisplayName string) []PlannedChange {
+	if cmd.DisplayName == newDisplayName {
+		return nil
+	}
+
+	var changes []PlannedChange
+	if cmd.Enabled {
+		renamed := cmd
+		renamed.DisplayName = newDisplayName
+		changes = append(changes, m.planRemoveSymlink(cmd), m.planCreateSymlink(renamed))
+	}
+	return append(changes, m.planConfigUpdate(cmd, fmt.Sprintf("display name %s → %s", cmd.DisplayName, newDisplayName)))
+}
+
+// PlanSetSymlinkLocation returns the changes SetSymlinkLocation would make without touching disk
+func (m *Manager) PlanSetSymlinkLocation(cmd Command, newLocation config.SymlinkLocation) []PlannedChange {
+	if cmd.SymlinkLocation == newLocation {
+		return nil
+	}
+
+	var changes []PlannedChange
+	if cmd.Enabled {
+		moved := cmd
+		moved.SymlinkLocation = newLocation
+		changes = append(changes, m.planRemoveSymlink(cmd), m.planCreateSymlink(moved))
+	}
+	return append(changes, m.planConfigUpdate(cmd, fmt.Sprintf("symlink location %s → %s", cmd.SymlinkLocation, newLocation)))
+}
+
+// PlanDelete returns the changes DeleteCommand would make without touching disk
+func (m *Manager) PlanDelete(cmd Command) []PlannedChange {
+	var changes []PlannedChange
+	if cmd.Enabled {
+		changes = append(changes, m.planRemoveSymlink(cmd))
+	}
+
+	trashPath := filepath.Join(m.GetTrashDir(), "<timestamp>", cmd.RelativePath)
+	changes = append(changes, PlannedChange{Action: "move file", Path: cmd.FilePath, Detail: "to " + trashPath})
+	return append(changes, PlannedChange{Action: "remove config", Path: m.configManager.Path(), Detail: cmd.Name})
+}
+
+func (m *Manager) planCreateSymlink(cmd Command) PlannedChange {
+	return PlannedChange{Action: "create symlink", Path: m.SymlinkPath(cmd), Detail: "→ " + cmd.FilePath}
+}
+
+func (m *Manager) planRemoveSymlink(cmd Command) PlannedChange {
+	return PlannedChange{Action: "remove symlink", Path: m.SymlinkPath(cmd)}
+}
+
+func (m *Manager) planConfigUpdate(cmd Command, detail string) PlannedChange {
+	return PlannedChange{Action: "update config", Path: m.configManager.Path(), Detail: cmd.Name + ": " + detail}
+}
@@ -0,0 +1,249 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/shel-corp/Claude-command-manager/internal/apperr"
+	"github.com/shel-corp/Claude-command-manager/internal/config"
+)
+
+// TagChange is the tag set of one command before and after a bulk tag edit
+type TagChange struct {
+	Command Command
+	Before  []string
+	After   []string
+}
+
+// ParseTagEdit reads a list of tag changes such as "+review -draft docs": a leading
+// - removes the tag, a leading + (or none) adds it. Tags are separated by spaces or commas.
+func ParseTagEdit(input string) (add, remove []string, err error) {
+	fields := strings.FieldsFunc(input, func(r rune) bool {
+		return r == ' ' || r == ',' || r == '\t'
+	})
+	for _, field := range fields {
+		removing := strings.HasPrefix(field, "-")
+		tag := strings.TrimLeft(field, "+-")
+		if tag == "" {
+			continue
+		}
+		if strings.ContainsAny(tag, `[]:#"'`) {
+			return nil, nil, apperr.New(apperr.KindValidation, "invalid tag %q", tag)
+		}
+		if removing {
+			remove = append(remove, tag)
+		} else {
+			add = append(add, tag)
+		}
+	}
+	return add, remove, nil
+}
+
+// EditTags returns tags with add appended (skipping ones already present) and remove dropped
+func EditTags(tags, add, remove []string) []string {
+	removed := make(map[string]bool, len(remove))
+	for _, tag := range remove {
+		removed[tag] = true
+	}
+
+	var result []string
+	seen := make(map[string]bool)
+	for _, tag := range append(append([]string{}, tags...), add...) {
+		if removed[tag] || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		result = append(result, tag)
+	}
+	return result
+}
+
+// PlanTagEdit returns the commands whose tags change when add and remove are applied
+func PlanTagEdit(cmds []Command, add, remove []string) []TagChange {
+	var changes []TagChange
+	for _, cmd := range cmds {
+		after := EditTags(cmd.Tags, add, remove)
+		if strings.Join(after, ",") != strings.Join(cmd.Tags, ",") {
+			changes = append(changes, TagChange{Command: cmd, Before: cmd.Tags, After: after})
+		}
+	}
+	return changes
+}
+
+// AllTags returns the distinct tags used by cmds in sorted order
+func AllTags(cmds []Command) []string {
+	seen := make(map[string]bool)
+	var tags []string
+	for _, cmd := range cmds {
+		for _, tag := range cmd.Tags {
+			if !seen[tag] {
+				seen[tag] = true
+				tags = append(tags, tag)
+			}
+		}
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+// ApplyTagChanges records the new tag set of each command. Added tags are kept in the
+// config, so command files (which may belong to an imported repository) are left alone;
+// a removed tag is dropped from the frontmatter only if the file has it.
+// It returns the number of commands changed; the caller is responsible for saving the config.
+func (m *Manager) ApplyTagChanges(changes []TagChange) (int, error) {
+	applied := 0
+	for _, change := range changes {
+		cmd := change.Command
+		after := make(map[string]bool, len(change.After))
+		for _, tag := range change.After {
+			after[tag] = true
+		}
+
+		_, _, fileTags := m.parseFrontmatter(cmd.FilePath)
+		var keptFileTags []string
+		inFile := make(map[string]bool, len(fileTags))
+		for _, tag := range fileTags {
+			if after[tag] {
+				keptFileTags = append(keptFileTags, tag)
+				inFile[tag] = true
+			}
+		}
+		if len(keptFileTags) != len(fileTags) {
+			if err := m.writeFrontmatterTags(cmd, keptFileTags); err != nil {
+				return applied, err
+			}
+		}
+
+		var localTags []string
+		for _, tag := range change.After {
+			if !inFile[tag] {
+				localTags = append(localTags, tag)
+			}
+		}
+		m.SetLocalTags(cmd, localTags)
+		applied++
+	}
+
+	return applied, nil
+}
+
+// SetLocalTags replaces the tags a command keeps in the config
+func (m *Manager) SetLocalTags(cmd Command, tags []string) {
+	cmdConfig, exists := m.configManager.GetCommand(cmd.Name)
+	if !exists {
+		cmdConfig = config.CommandConfig{
+			Enabled:         cmd.Enabled,
+			OriginalName:    cmd.Name,
+			DisplayName:     cmd.DisplayName,
+			SourcePath:      cmd.FilePath,
+			RelativePath:    cmd.RelativePath,
+			SymlinkLocation: cmd.SymlinkLocation,
+			Source:          cmd.Source,
+			Pinned:          cmd.Pinned,
+		}
+	}
+	cmdConfig.Tags = tags
+
+	m.configManager.SetCommand(cmd.Name, cmdConfig)
+}
+
+// writeFrontmatterTags rewrites the tags key of a command file
+func (m *Manager) writeFrontmatterTags(cmd Command, tags []string) error {
+	info, err := os.Stat(cmd.FilePath)
+	if err != nil {
+		return fmt.Errorf("failed to stat %s: %w", cmd.RelativePath, err)
+	}
+	data, err := os.ReadFile(cmd.FilePath)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", cmd.RelativePath, err)
+	}
+
+	content, err := setFrontmatterTags(string(data), tags)
+	if err != nil {
+		return fmt.Errorf("%s: %w", cmd.RelativePath, err)
+	}
+	if err := os.WriteFile(cmd.FilePath, []byte(content), info.Mode().Perm()); err != nil {
+		return fmt.Errorf("failed to write %s: %w", cmd.RelativePath, err)
+	}
+	return nil
+}
+
+// setFrontmatterTags replaces the top-level tags key in content's frontmatter (including a
+// block list), adding frontmatter if there is none and dropping the key if tags is empty.
+// Tags are written as a flow list, quoted where YAML needs it.
+func setFrontmatterTags(content string, tags []string) (string, error) {
+	tagsLine := ""
+	if len(tags) > 0 {
+		list := &yaml.Node{Kind: yaml.SequenceNode, Style: yaml.FlowStyle}
+		for _, tag := range tags {
+			list.Content = append(list.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: tag})
+		}
+		data, err := yaml.Marshal(map[string]*yaml.Node{"tags": list})
+		if err != nil {
+			return "", err
+		}
+		tagsLine = strings.TrimSuffix(string(data), "\n")
+	}
+	return setFrontmatterLine(content, "tags", tagsLine)
+}
+
+// isValueContinuation reports whether a frontmatter line continues the value of the key
+// above it: an indented line or a "- item" block list entry
+func isValueContinuation(line string) bool {
+	return strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t") ||
+		line == "-" || strings.HasPrefix(line, "- ")
+}
+
+// setFrontmatterLine replaces the top-level key in content's frontmatter with line (along
+// with any indented or list continuation lines), appending line if the key is missing, adding
+// frontmatter if there is none and dropping the key if line is empty
+func setFrontmatterLine(content, key, line string) (string, error) {
+	lines := strings.Split(content, "\n")
+	if len(lines) == 0 || strings.TrimSpace(lines[0]) != "---" {
+		if line == "" {
+			return content, nil
+		}
+		return "---\n" + line + "\n---\n" + content, nil
+	}
+	if strings.HasSuffix(lines[0], "\r") && line != "" {
+		line += "\r"
+	}
+
+	end, keyStart := -1, -1
+	for i := 1; i < len(lines); i++ {
+		current := strings.TrimRight(lines[i], "\r")
+		if strings.TrimSpace(current) == "---" {
+			end = i
+			break
+		}
+		if keyStart < 0 && strings.HasPrefix(current, key+":") {
+			keyStart = i
+		}
+	}
+	if end < 0 {
+		return "", apperr.New(apperr.KindValidation, "unterminated frontmatter")
+	}
+
+	var replacement []string
+	if line != "" {
+		replacement = []string{line}
+	}
+
+	var result []string
+	if keyStart < 0 {
+		result = append(append(append(result, lines[:end]...), replacement...), lines[end:]...)
+	} else {
+		// Indented lines after the key belong to its value (a block list or folded text),
+		// and so do list items at column 0, which YAML allows under a mapping key
+		keyEnd := keyStart + 1
+		for keyEnd < end && isValueContinuation(strings.TrimRight(lines[keyEnd], "\r")) {
+			keyEnd++
+		}
+		result = append(append(append(result, lines[:keyStart]...), replacement...), lines[keyEnd:]...)
+	}
+	return strings.Join(result, "\n"), nil
+}
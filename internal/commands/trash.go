@@ -0,0 +1,202 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/shel-corp/Claude-command-manager/internal/apperr"
+	"github.com/shel-corp/Claude-command-manager/internal/config"
+)
+
+// trashTimeFormat names the per-deletion directories inside the trash
+const trashTimeFormat = "20060102_150405"
+
+// trashMetaFile keeps the configuration of the commands in a trash entry, so a restored
+// command gets back its display name, enabled state and symlink location
+const trashMetaFile = ".ccm-trash.json"
+
+// TrashedCommand is a deleted command waiting in the library's trash
+type TrashedCommand struct {
+	Name         string                // Unique command name it had in the library
+	RelativePath string                // Path it had relative to the commands directory
+	FilePath     string                // Full path of the file in the trash
+	DeletedAt    time.Time             // When it was deleted (zero if the entry name is not a timestamp)
+	Config       *config.CommandConfig // Configuration at deletion; nil for files trashed without one
+	entryDir     string
+}
+
+// commandName derives the unique command name from a path relative to the commands directory
+func commandName(relativePath string) string {
+	return strings.TrimSuffix(strings.ReplaceAll(relativePath, string(filepath.Separator), "_"), ".md")
+}
+
+// recordTrashedConfig stores a deleted command's configuration next to its file in the trash
+func recordTrashedConfig(entryDir string, cmd Command) error {
+	metaPath := filepath.Join(entryDir, trashMetaFile)
+	meta := readTrashMeta(entryDir)
+	meta[cmd.RelativePath] = config.CommandConfig{
+		Enabled:         cmd.Enabled,
+		OriginalName:    cmd.Name,
+		DisplayName:     cmd.DisplayName,
+		RelativePath:    cmd.RelativePath,
+		SymlinkLocation: cmd.SymlinkLocation,
+		Source:          cmd.Source,
+		Pinned:          cmd.Pinned,
+		Tags:            cmd.LocalTags,
+	}
+	data, err := json.MarshalIndent(meta, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(metaPath, data, 0644)
+}
+
+// readTrashMeta returns the recorded configurations of a trash entry, by relative path
+func readTrashMeta(entryDir string) map[string]config.CommandConfig {
+	meta := make(map[string]config.CommandConfig)
+	if data, err := os.ReadFile(filepath.Join(entryDir, trashMetaFile)); err == nil {
+		json.Unmarshal(data, &meta)
+	}
+	return meta
+}
+
+// ListTrash returns the commands in the library's trash, most recently deleted first
+func (m *Manager) ListTrash() ([]TrashedCommand, error) {
+	entries, err := os.ReadDir(m.GetTrashDir())
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read trash: %w", err)
+	}
+
+	var trashed []TrashedCommand
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		entryDir := filepath.Join(m.GetTrashDir(), entry.Name())
+		deletedAt, _ := time.ParseInLocation(trashTimeFormat, entry.Name(), time.Local)
+		meta := readTrashMeta(entryDir)
+
+		filepath.Walk(entryDir, func(path string, info os.FileInfo, err error) error {
+			if err != nil || info.IsDir() || !strings.HasSuffix(info.Name(), ".md") {
+				return nil
+			}
+			relativePath, err := filepath.Rel(entryDir, path)
+			if err != nil {
+				return nil
+			}
+			item := TrashedCommand{
+				Name:         commandName(relativePath),
+				RelativePath: relativePath,
+				FilePath:     path,
+				DeletedAt:    deletedAt,
+				entryDir:     entryDir,
+			}
+			if cfg, ok := meta[relativePath]; ok {
+				item.Config = &cfg
+			}
+			trashed = append(trashed, item)
+			return nil
+		})
+	}
+
+	sort.SliceStable(trashed, func(i, j int) bool {
+		if !trashed[i].DeletedAt.Equal(trashed[j].DeletedAt) {
+			return trashed[i].DeletedAt.After(trashed[j].DeletedAt)
+		}
+		return trashed[i].Name < trashed[j].Name
+	})
+	return trashed, nil
+}
+
+// RestoreCommand moves a trashed command back to where it was in the library and
+// restores its configuration, re-creating its symlink if it was enabled
+func (m *Manager) RestoreCommand(item TrashedCommand) (Command, error) {
+	dest := filepath.Join(m.commandsDir, item.RelativePath)
+	if _, err := os.Stat(dest); err == nil {
+		return Command{}, apperr.New(apperr.KindConflict, "a command already exists at %s; rename or delete it first", item.RelativePath)
+	}
+	if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+		return Command{}, fmt.Errorf("failed to create command directory: %w", err)
+	}
+	if err := os.Rename(item.FilePath, dest); err != nil {
+		return Command{}, fmt.Errorf("failed to restore command from trash: %w", err)
+	}
+	m.pruneTrashEntry(item)
+
+	cmd := Command{
+		Name:            item.Name,
+		DisplayName:     strings.TrimSuffix(filepath.Base(item.RelativePath), ".md"),
+		FilePath:        dest,
+		RelativePath:    item.RelativePath,
+		SymlinkLocation: m.defaultLocation,
+	}
+	if cfg := item.Config; cfg != nil {
+		cmd.DisplayName = cfg.DisplayName
+		cmd.Enabled = cfg.Enabled
+		cmd.Source = cfg.Source
+		cmd.Pinned = cfg.Pinned
+		cmd.LocalTags = cfg.Tags
+		if cfg.SymlinkLocation != "" {
+			cmd.SymlinkLocation = cfg.SymlinkLocation
+		}
+	}
+	if cmd.Enabled {
+		if err := m.createSymlink(cmd); err != nil {
+			cmd.Enabled = false // Restored, but left disabled; the symlink path is likely taken
+		}
+	}
+
+	m.configManager.SetCommand(cmd.Name, config.CommandConfig{
+		Enabled:         cmd.Enabled,
+		OriginalName:    cmd.Name,
+		DisplayName:     cmd.DisplayName,
+		SourcePath:      cmd.FilePath,
+		RelativePath:    cmd.RelativePath,
+		SymlinkLocation: cmd.SymlinkLocation,
+		Source:          cmd.Source,
+		Pinned:          cmd.Pinned,
+		Tags:            cmd.LocalTags,
+	})
+	return cmd, nil
+}
+
+// pruneTrashEntry forgets a restored command's recorded configuration and removes the
+// directories it leaves empty in the trash
+func (m *Manager) pruneTrashEntry(item TrashedCommand) {
+	meta := readTrashMeta(item.entryDir)
+	delete(meta, item.RelativePath)
+	metaPath := filepath.Join(item.entryDir, trashMetaFile)
+	if len(meta) == 0 {
+		os.Remove(metaPath)
+	} else if data, err := json.MarshalIndent(meta, "", "  "); err == nil {
+		os.WriteFile(metaPath, data, 0644)
+	}
+
+	// os.Remove only succeeds on empty directories
+	for dir := filepath.Dir(item.FilePath); strings.HasPrefix(dir, item.entryDir); dir = filepath.Dir(dir) {
+		if os.Remove(dir) != nil {
+			break
+		}
+	}
+}
+
+// EmptyTrash permanently deletes everything in the library's trash and returns the
+// number of commands removed
+func (m *Manager) EmptyTrash() (int, error) {
+	trashed, err := m.ListTrash()
+	if err != nil {
+		return 0, err
+	}
+	if err := os.RemoveAll(m.GetTrashDir()); err != nil {
+		return 0, fmt.Errorf("failed to empty trash: %w", err)
+	}
+	return len(trashed), nil
+}
@@ -0,0 +1,271 @@
+package completion
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/shel-corp/Claude-command-manager/internal/apperr"
+	"github.com/shel-corp/Claude-command-manager/internal/remote"
+)
+
+// Command is the hidden verb the shell scripts call to get candidates for the current word
+const Command = "__complete"
+
+// Verbs are the top-level commands offered for the first word
+var Verbs = []string{
+	"list", "status", "enable", "disable", "rename", "duplicate", "set-meta", "tag", "usage", "lint", "doctor", "move", "delete", "trash", "new", "show", "edit",
+	"sync", "apply", "export", "import-archive", "migrate", "import", "imports", "browse",
+	"registry", "target", "theme", "config", "report", "completion", "version", "help",
+}
+
+var (
+	globalFlags        = []string{"--library", "--dry-run", "--verbose", "--plain"}
+	libraries          = []string{"user", "project"}
+	registryCommands   = []string{"list", "add", "remove", "update"}
+	registryAddFlags   = []string{"--category", "--description", "--tags"}
+	importFlags        = []string{"--output", "--select"}
+	importOutputs      = []string{"text", "json"}
+	importsCommands    = []string{"list", "retry", "clear"}
+	targetCommands     = []string{"list", "add", "remove"}
+	trashCommands      = []string{"list", "restore", "empty"}
+	setMetaFlags       = []string{"--description", "--argument-hint", "--allowed-tools", "--model"}
+	completionCommands = []string{"bash", "zsh", "fish"}
+)
+
+// Registry supplies the dynamic candidates: category keys and repository URLs
+type Registry interface {
+	GetAvailableCategories() map[string]string
+	GetAllRepositories() []remote.CuratedRepository
+	IsCustomRepository(repoURL string) bool
+}
+
+// Complete returns the candidates for the last of words, the arguments after "ccm"
+// (the last one being the partially typed word). loadRegistry is only called when a
+// candidate needs registry data and may return nil if none is available.
+func Complete(words []string, loadRegistry func() Registry) []string {
+	current, prev := "", ""
+	if len(words) > 0 {
+		current = words[len(words)-1]
+		words = words[:len(words)-1]
+	}
+	if len(words) > 0 {
+		prev = words[len(words)-1]
+	}
+	if prev == "--library" {
+		return libraries
+	}
+
+	args := stripGlobalFlags(words)
+	if len(args) == 0 {
+		if strings.HasPrefix(current, "-") {
+			return globalFlags
+		}
+		return Verbs
+	}
+
+	switch args[0] {
+	case "registry":
+		if len(args) == 1 {
+			return registryCommands
+		}
+		switch args[1] {
+		case "add":
+			if prev == "--category" {
+				return categoryKeys(loadRegistry())
+			}
+			if strings.HasPrefix(current, "-") {
+				return registryAddFlags
+			}
+		case "remove":
+			if len(args) == 2 {
+				return repositoryURLs(loadRegistry(), true)
+			}
+		case "list":
+			return []string{"--custom"}
+		}
+	case "list":
+		if strings.HasPrefix(current, "-") {
+			return []string{"--model", "--tag"}
+		}
+	case "usage":
+		if strings.HasPrefix(current, "-") {
+			return []string{"--all"}
+		}
+	case "doctor":
+		if strings.HasPrefix(current, "-") {
+			return []string{"--fix", "--yes"}
+		}
+	case "lint":
+		if prev == "--output" {
+			return importOutputs
+		}
+		if strings.HasPrefix(current, "-") {
+			return []string{"--output"}
+		}
+	case "import", "browse":
+		if prev == "--output" {
+			return importOutputs
+		}
+		if prev == "--select" {
+			return nil
+		}
+		if strings.HasPrefix(current, "-") {
+			if args[0] == "import" {
+				return importFlags
+			}
+			return nil
+		}
+		return importSources(loadRegistry())
+	case "imports":
+		if len(args) == 1 {
+			return importsCommands
+		}
+	case "target":
+		if len(args) == 1 {
+			return targetCommands
+		}
+	case "trash":
+		if len(args) == 1 {
+			return trashCommands
+		}
+	case "set-meta":
+		if strings.HasPrefix(current, "-") {
+			return setMetaFlags
+		}
+	case "completion":
+		if len(args) == 1 {
+			return completionCommands
+		}
+	}
+	return nil
+}
+
+// stripGlobalFlags removes global flags (and the --library value) that may precede the verb
+func stripGlobalFlags(words []string) []string {
+	var args []string
+	for i := 0; i < len(words); i++ {
+		switch {
+		case words[i] == "--library":
+			i++
+		case strings.HasPrefix(words[i], "--library="), words[i] == "--dry-run", words[i] == "--verbose", words[i] == "--plain":
+		default:
+			args = append(args, words[i])
+		}
+	}
+	return args
+}
+
+// categoryKeys returns the sorted keys of bundled and user categories
+func categoryKeys(reg Registry) []string {
+	if reg == nil {
+		return nil
+	}
+	var keys []string
+	for key := range reg.GetAvailableCategories() {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// repositoryURLs returns the registry's repository URLs, optionally only custom ones
+func repositoryURLs(reg Registry, customOnly bool) []string {
+	if reg == nil {
+		return nil
+	}
+	seen := make(map[string]bool)
+	var urls []string
+	for _, repo := range reg.GetAllRepositories() {
+		if seen[repo.URL] || (customOnly && !reg.IsCustomRepository(repo.URL)) {
+			continue
+		}
+		seen[repo.URL] = true
+		urls = append(urls, repo.URL)
+	}
+	sort.Strings(urls)
+	return urls
+}
+
+// importSources offers registry repositories without the scheme, which import accepts
+// and which avoids shells splitting the word at the colon
+func importSources(reg Registry) []string {
+	urls := repositoryURLs(reg, false)
+	for i, url := range urls {
+		urls[i] = strings.TrimPrefix(strings.TrimPrefix(url, "https://"), "http://")
+	}
+	return urls
+}
+
+// Script returns the completion script for shell
+func Script(shell string) (string, error) {
+	switch shell {
+	case "bash":
+		return bashScript, nil
+	case "zsh":
+		return zshScript, nil
+	case "fish":
+		return fishScript, nil
+	}
+	return "", apperr.New(apperr.KindValidation, "unsupported shell %q (use %s)", shell, strings.Join(completionCommands, ", "))
+}
+
+// Install explains how to enable completion for shell
+func Install(shell string) string {
+	switch shell {
+	case "bash":
+		return "echo 'source <(ccm completion bash)' >> ~/.bashrc"
+	case "zsh":
+		return "echo 'source <(ccm completion zsh)' >> ~/.zshrc"
+	case "fish":
+		return "ccm completion fish > ~/.config/fish/completions/ccm.fish"
+	}
+	return fmt.Sprintf("ccm completion %s", shell)
+}
+
+// bashScript splits the line on whitespace only so URLs are passed whole, then trims
+// the part before the last colon because bash replaces only the text after it
+const bashScript = `# bash completion for ccm
+_ccm() {
+	local line="${COMP_LINE:0:COMP_POINT}"
+	local -a words
+	read -r -a words <<< "$line"
+	[[ "$line" == *" " ]] && words+=("")
+	local cur="${words[${#words[@]}-1]}"
+
+	local IFS=$'\n'
+	COMPREPLY=($(compgen -W "$(ccm __complete "${words[@]:1}" 2>/dev/null)" -- "$cur"))
+	if [[ "$cur" == *:* ]]; then
+		local prefix="${cur%"${cur##*:}"}"
+		COMPREPLY=("${COMPREPLY[@]#"$prefix"}")
+	fi
+}
+complete -o default -F _ccm ccm
+`
+
+const zshScript = `#compdef ccm
+_ccm() {
+	local -a candidates
+	candidates=(${(f)"$(ccm __complete "${(@)words[2,CURRENT]}" 2>/dev/null)"})
+	if (( ${#candidates} )); then
+		compadd -a candidates
+	else
+		_files
+	fi
+}
+if [ "$funcstack[1]" = "_ccm" ]; then
+	_ccm "$@"
+else
+	compdef _ccm ccm
+fi
+`
+
+const fishScript = `# fish completion for ccm
+function __ccm_complete
+	set -l tokens (commandline -opc)
+	set -l current (commandline -ct)
+	ccm __complete $tokens[2..-1] "$current" 2>/dev/null
+end
+complete -c ccm -n 'test (count (__ccm_complete)) -gt 0' -f
+complete -c ccm -a '(__ccm_complete)'
+`
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+
+	"github.com/shel-corp/Claude-command-manager/internal/apperr"
 )
 
 // SymlinkLocation represents where a command should be symlinked
@@ -15,6 +17,22 @@ const (
 	SymlinkLocationProject SymlinkLocation = "project" // <project>/.claude/commands/
 )
 
+// ParseSymlinkLocation converts "user" or "project" to a SymlinkLocation
+func ParseSymlinkLocation(value string) (SymlinkLocation, error) {
+	switch SymlinkLocation(value) {
+	case SymlinkLocationUser, SymlinkLocationProject:
+		return SymlinkLocation(value), nil
+	default:
+		return "", apperr.New(apperr.KindValidation, "invalid symlink location %q (expected user or project)", value)
+	}
+}
+
+// IsCustom reports whether the location names a custom target from the settings
+// rather than the built-in user or project directories
+func (l SymlinkLocation) IsCustom() bool {
+	return l != "" && l != SymlinkLocationUser && l != SymlinkLocationProject
+}
+
 // CommandConfig represents the configuration for a single command
 type CommandConfig struct {
 	Enabled         bool            `json:"enabled"`
@@ -23,6 +41,9 @@ type CommandConfig struct {
 	SourcePath      string          `json:"source_path"`
 	RelativePath    string          `json:"relative_path"`
 	SymlinkLocation SymlinkLocation `json:"symlink_location"`
+	Source          string          `json:"source,omitempty"` // Repository the command was imported from
+	Pinned          bool            `json:"pinned,omitempty"` // Kept at the top of the library list
+	Tags            []string        `json:"tags,omitempty"`   // Tags added in ccm, kept out of the command file
 }
 
 // Config represents the entire configuration file structure
@@ -91,6 +112,11 @@ func (m *Manager) Save() error {
 	return nil
 }
 
+// Path returns the location of the configuration file
+func (m *Manager) Path() string {
+	return m.configPath
+}
+
 // GetCommand returns the configuration for a specific command
 func (m *Manager) GetCommand(name string) (CommandConfig, bool) {
 	cmd, exists := m.config.Commands[name]
@@ -0,0 +1,164 @@
+// Package logging writes leveled diagnostics to ccm's log file so warnings never
+// land on the terminal while the TUI owns the screen.
+package logging
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Level orders log messages by severity
+type Level int
+
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+// maxLogSize is the size at which the log file is rotated to ccm.log.1
+const maxLogSize = 5 * 1024 * 1024
+
+var levelNames = map[Level]string{
+	LevelDebug: "DEBUG",
+	LevelInfo:  "INFO",
+	LevelWarn:  "WARN",
+	LevelError: "ERROR",
+}
+
+func (l Level) String() string {
+	if name, ok := levelNames[l]; ok {
+		return name
+	}
+	return fmt.Sprintf("LEVEL(%d)", int(l))
+}
+
+var (
+	mu     sync.Mutex
+	level            = LevelInfo
+	output io.Writer = io.Discard
+	mirror io.Writer
+	file   *os.File
+)
+
+// DefaultLogPath returns the location of the ccm log file
+func DefaultLogPath() string {
+	homeDir, _ := os.UserHomeDir()
+	return filepath.Join(homeDir, ".config", "claude_command_manager", "ccm.log")
+}
+
+// DebugRequested reports whether CCM_DEBUG asks for debug logging
+func DebugRequested() bool {
+	switch strings.ToLower(strings.TrimSpace(os.Getenv("CCM_DEBUG"))) {
+	case "1", "true", "yes", "on":
+		return true
+	}
+	return false
+}
+
+// Init opens the log file at path for appending and sets the minimum level written.
+// Until Init succeeds, messages are discarded.
+func Init(path string, minLevel Level) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create log directory: %w", err)
+	}
+	rotate(path)
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if file != nil {
+		file.Close()
+	}
+	file = f
+	output = f
+	level = minLevel
+	return nil
+}
+
+// SetLevel changes the minimum level written
+func SetLevel(minLevel Level) {
+	mu.Lock()
+	defer mu.Unlock()
+	level = minLevel
+}
+
+// SetMirror additionally copies every written message to w (nil to stop).
+// Only use this when the TUI is not running.
+func SetMirror(w io.Writer) {
+	mu.Lock()
+	defer mu.Unlock()
+	mirror = w
+}
+
+// Close flushes and closes the log file
+func Close() error {
+	mu.Lock()
+	defer mu.Unlock()
+	output = io.Discard
+	if file == nil {
+		return nil
+	}
+	err := file.Close()
+	file = nil
+	return err
+}
+
+// Debugf logs detail that is only useful when diagnosing a problem
+func Debugf(format string, args ...interface{}) { logf(LevelDebug, format, args...) }
+
+// Infof logs a notable but expected event
+func Infof(format string, args ...interface{}) { logf(LevelInfo, format, args...) }
+
+// Warnf logs a problem ccm recovered from
+func Warnf(format string, args ...interface{}) { logf(LevelWarn, format, args...) }
+
+// Errorf logs a failure
+func Errorf(format string, args ...interface{}) { logf(LevelError, format, args...) }
+
+func logf(msgLevel Level, format string, args ...interface{}) {
+	mu.Lock()
+	defer mu.Unlock()
+	if msgLevel < level {
+		return
+	}
+
+	message := strings.TrimRight(fmt.Sprintf(format, args...), "\n")
+	line := fmt.Sprintf("%s %-5s %s\n", time.Now().Format(time.RFC3339), msgLevel, message)
+	io.WriteString(output, line)
+	if mirror != nil {
+		fmt.Fprintf(mirror, "%s: %s\n", strings.ToLower(msgLevel.String()), message)
+	}
+}
+
+// Tail returns up to the last n lines of the log file at path
+func Tail(path string, n int) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	lines := strings.Split(strings.TrimRight(string(data), "\n"), "\n")
+	if len(lines) == 1 && lines[0] == "" {
+		return nil, nil
+	}
+	return lines[max(len(lines)-n, 0):], nil
+}
+
+// rotate moves an oversized log aside so the file does not grow without bound
+func rotate(path string) {
+	info, err := os.Stat(path)
+	if err != nil || info.Size() < maxLogSize {
+		return
+	}
+	os.Rename(path, path+".1")
+}
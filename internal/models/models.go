@@ -0,0 +1,58 @@
+package models
+
+import "strings"
+
+// deprecatedModels lists model identifiers that Anthropic has retired or deprecated
+var deprecatedModels = map[string]bool{
+	"claude-instant-1":           true,
+	"claude-instant-1.2":         true,
+	"claude-1":                   true,
+	"claude-2":                   true,
+	"claude-2.0":                 true,
+	"claude-2.1":                 true,
+	"claude-3-sonnet-20240229":   true,
+	"claude-3-opus-20240229":     true,
+	"claude-3-5-sonnet-20240620": true,
+	"claude-3-5-sonnet-20241022": true,
+}
+
+// IsDeprecated reports whether a model name refers to a deprecated model
+func IsDeprecated(model string) bool {
+	return deprecatedModels[strings.ToLower(strings.TrimSpace(model))]
+}
+
+// ShortName returns a compact display name for a model identifier
+// e.g. "claude-3-5-haiku-20241022" -> "3-5-haiku", "sonnet" -> "sonnet"
+func ShortName(model string) string {
+	name := strings.ToLower(strings.TrimSpace(model))
+	name = strings.TrimPrefix(name, "claude-")
+
+	// Drop trailing date stamps like -20241022
+	if idx := strings.LastIndex(name, "-"); idx != -1 {
+		suffix := name[idx+1:]
+		if len(suffix) == 8 && strings.Trim(suffix, "0123456789") == "" {
+			name = name[:idx]
+		}
+	}
+
+	return name
+}
+
+// Badge returns a display badge for a model, or an empty string if no model is set
+func Badge(model string) string {
+	if strings.TrimSpace(model) == "" {
+		return ""
+	}
+	if IsDeprecated(model) {
+		return "⚠️ " + ShortName(model)
+	}
+	return "🤖 " + ShortName(model)
+}
+
+// DeprecationWarning returns a user-facing warning for deprecated models, or empty string
+func DeprecationWarning(model string) string {
+	if !IsDeprecated(model) {
+		return ""
+	}
+	return "model '" + model + "' is deprecated - update the command's frontmatter"
+}
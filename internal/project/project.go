@@ -0,0 +1,355 @@
+package project
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/shel-corp/Claude-command-manager/internal/commands"
+	"github.com/shel-corp/Claude-command-manager/internal/config"
+	"github.com/shel-corp/Claude-command-manager/internal/logging"
+	"github.com/shel-corp/Claude-command-manager/internal/remote"
+)
+
+// FileName is the pinned project configuration committed inside the .claude directory
+const FileName = "ccm.yaml"
+
+// LocalFileName holds per-machine exceptions to the pinned configuration; it is added to
+// .claude/.gitignore when first written, as it is not meant to be committed
+const LocalFileName = "ccm.local.yaml"
+
+// Config declares the commands, repositories and settings a project expects
+type Config struct {
+	Commands     []PinnedCommand    `yaml:"commands"`
+	Repositories []PinnedRepository `yaml:"repositories"`
+	Settings     Settings           `yaml:"settings"`
+
+	path       string
+	localPath  string
+	exceptions []string // Command names whose drift the user chose to ignore on this machine
+}
+
+// localConfig is the structure of the per-machine exceptions file
+type localConfig struct {
+	Ignore []string `yaml:"ignore"`
+}
+
+// PinnedCommand is a command that must be enabled for the project.
+// It may be written as a plain name or as a mapping with a location.
+type PinnedCommand struct {
+	Name     string `yaml:"name"`               // Command name as shown by `ccm list`, e.g. git_commit
+	Location string `yaml:"location,omitempty"` // "user" or "project"; defaults to settings.default_location
+}
+
+// PinnedRepository is a remote repository the project's commands come from
+type PinnedRepository struct {
+	URL      string   `yaml:"url"`
+	Commands []string `yaml:"commands,omitempty"` // Commands to import and enable; empty means import all
+}
+
+// Settings holds project-wide defaults
+type Settings struct {
+	DefaultLocation string `yaml:"default_location,omitempty"` // Symlink location for pinned commands (default: project)
+	DefaultView     string `yaml:"default_view,omitempty"`     // Saved view to apply to the project library
+}
+
+// UnmarshalYAML accepts either "- name" or "- name: x / location: y"
+func (p *PinnedCommand) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		p.Name = node.Value
+		return nil
+	}
+
+	type plain PinnedCommand
+	return node.Decode((*plain)(p))
+}
+
+// Load reads the pinned configuration from claudeDir. Returns nil if the project has none.
+func Load(claudeDir string) (*Config, error) {
+	path := filepath.Join(claudeDir, FileName)
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", FileName, err)
+	}
+
+	cfg := &Config{path: path, localPath: filepath.Join(claudeDir, LocalFileName)}
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", FileName, err)
+	}
+
+	if localData, err := os.ReadFile(cfg.localPath); err == nil {
+		var local localConfig
+		if err := yaml.Unmarshal(localData, &local); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", LocalFileName, err)
+		}
+		cfg.exceptions = local.Ignore
+	} else if !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read %s: %w", LocalFileName, err)
+	}
+
+	if cfg.Settings.DefaultLocation != "" {
+		if _, err := config.ParseSymlinkLocation(cfg.Settings.DefaultLocation); err != nil {
+			return nil, fmt.Errorf("%s: default_location: %w", FileName, err)
+		}
+	}
+	for _, pinned := range cfg.Commands {
+		if pinned.Location != "" {
+			if _, err := config.ParseSymlinkLocation(pinned.Location); err != nil {
+				return nil, fmt.Errorf("%s: command %s: %w", FileName, pinned.Name, err)
+			}
+		}
+	}
+
+	return cfg, nil
+}
+
+// Path returns the file the configuration was loaded from
+func (c *Config) Path() string {
+	return c.path
+}
+
+// Exceptions returns the command names whose drift is ignored on this machine
+func (c *Config) Exceptions() []string {
+	return c.exceptions
+}
+
+// AddExceptions remembers that drift for the given commands should be ignored on this machine
+func (c *Config) AddExceptions(names ...string) error {
+	for _, name := range names {
+		if !c.isException(name) {
+			c.exceptions = append(c.exceptions, name)
+		}
+	}
+
+	data, err := yaml.Marshal(localConfig{Ignore: c.exceptions})
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s: %w", LocalFileName, err)
+	}
+	_, statErr := os.Stat(c.localPath)
+	if err := os.WriteFile(c.localPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", LocalFileName, err)
+	}
+	if os.IsNotExist(statErr) {
+		if err := ignoreLocalFile(filepath.Dir(c.localPath)); err != nil {
+			logging.Warnf("failed to add %s to .gitignore: %v", LocalFileName, err)
+		}
+	}
+	return nil
+}
+
+// ignoreLocalFile adds LocalFileName to the .gitignore in dir (the .claude directory) so
+// the per-machine exceptions are not committed along with ccm.yaml
+func ignoreLocalFile(dir string) error {
+	path := filepath.Join(dir, ".gitignore")
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		if line = strings.TrimSpace(line); line == LocalFileName || line == "/"+LocalFileName {
+			return nil
+		}
+	}
+
+	content := string(data)
+	if content != "" && !strings.HasSuffix(content, "\n") {
+		content += "\n"
+	}
+	return os.WriteFile(path, []byte(content+LocalFileName+"\n"), 0644)
+}
+
+// isException reports whether drift for a command is ignored on this machine
+func (c *Config) isException(name string) bool {
+	for _, exception := range c.exceptions {
+		if exception == name {
+			return true
+		}
+	}
+	return false
+}
+
+// locationFor returns the symlink location a pinned command should use
+func (c *Config) locationFor(location string) config.SymlinkLocation {
+	if location == "" {
+		location = c.Settings.DefaultLocation
+	}
+	if location == "" {
+		return config.SymlinkLocationProject
+	}
+	return config.SymlinkLocation(location)
+}
+
+// required returns every command the configuration expects, including those listed under repositories
+func (c *Config) required() []requirement {
+	var reqs []requirement
+	seen := make(map[string]bool)
+
+	for _, pinned := range c.Commands {
+		if pinned.Name == "" || seen[pinned.Name] {
+			continue
+		}
+		seen[pinned.Name] = true
+		reqs = append(reqs, requirement{name: pinned.Name, location: c.locationFor(pinned.Location)})
+	}
+
+	for _, repo := range c.Repositories {
+		for _, name := range repo.Commands {
+			if seen[name] {
+				// Pinned explicitly as well - remember where it can be imported from
+				for i := range reqs {
+					if reqs[i].name == name && reqs[i].repository == "" {
+						reqs[i].repository = repo.URL
+					}
+				}
+				continue
+			}
+			seen[name] = true
+			reqs = append(reqs, requirement{name: name, location: c.locationFor(""), repository: repo.URL})
+		}
+	}
+
+	return reqs
+}
+
+// requirement is a single command the project expects to be enabled
+type requirement struct {
+	name       string
+	location   config.SymlinkLocation
+	repository string
+}
+
+// DriftKind describes how local state differs from the pinned configuration
+type DriftKind int
+
+const (
+	DriftMissing   DriftKind = iota // Pinned command is not in the library
+	DriftDisabled                   // Pinned command exists but is not enabled
+	DriftMisplaced                  // Pinned command is enabled at a different location
+	DriftExtra                      // Command is enabled but not declared by the project
+)
+
+// DriftItem is a single difference between the pinned configuration and the library
+type DriftItem struct {
+	Kind       DriftKind
+	Name       string
+	Command    *commands.Command      // Local command (nil when missing)
+	Location   config.SymlinkLocation // Location the project expects
+	Repository string                 // Repository a missing command can be imported from
+}
+
+// Describe returns a one-line description of the difference
+func (d DriftItem) Describe() string {
+	switch d.Kind {
+	case DriftMissing:
+		if d.Name == "" {
+			return fmt.Sprintf("commands from %s have not been imported", d.Repository)
+		}
+		if d.Repository != "" {
+			return fmt.Sprintf("%s is missing (available from %s)", d.Name, d.Repository)
+		}
+		return fmt.Sprintf("%s is missing from the library", d.Name)
+	case DriftDisabled:
+		return fmt.Sprintf("%s is disabled (should be enabled in %s)", d.Name, d.Location)
+	case DriftMisplaced:
+		return fmt.Sprintf("%s is enabled in %s (should be %s)", d.Name, d.Command.SymlinkLocation, d.Location)
+	case DriftExtra:
+		return fmt.Sprintf("%s is enabled but not declared in %s", d.Name, FileName)
+	}
+	return d.Name
+}
+
+// Drift lists every difference between the pinned configuration and the library
+type Drift []DriftItem
+
+// Summary returns a concise description such as "3 commands out of sync: 2 disabled, 1 missing"
+func (d Drift) Summary() string {
+	if len(d) == 0 {
+		return "in sync"
+	}
+
+	counts := make(map[DriftKind]int)
+	for _, item := range d {
+		counts[item.Kind]++
+	}
+
+	var parts []string
+	if n := counts[DriftDisabled]; n > 0 {
+		parts = append(parts, fmt.Sprintf("%d disabled", n))
+	}
+	if n := counts[DriftMissing]; n > 0 {
+		parts = append(parts, fmt.Sprintf("%d missing", n))
+	}
+	if n := counts[DriftMisplaced]; n > 0 {
+		parts = append(parts, fmt.Sprintf("%d in the wrong location", n))
+	}
+	if n := counts[DriftExtra]; n > 0 {
+		parts = append(parts, fmt.Sprintf("%d enabled but not declared", n))
+	}
+
+	noun := "commands"
+	if len(d) == 1 {
+		noun = "command"
+	}
+	return fmt.Sprintf("%d %s out of sync: %s", len(d), noun, strings.Join(parts, ", "))
+}
+
+// Check compares the library against the pinned configuration
+func (c *Config) Check(cmds []commands.Command) Drift {
+	byName := make(map[string]*commands.Command, len(cmds))
+	sources := make(map[string]bool)
+	for i := range cmds {
+		byName[cmds[i].Name] = &cmds[i]
+		if cmds[i].Source != "" {
+			sources[cmds[i].Source] = true
+		}
+	}
+
+	var drift Drift
+	required := c.required()
+	declared := make(map[string]bool, len(required))
+	for _, req := range required {
+		declared[req.name] = true
+		if c.isException(req.name) {
+			continue
+		}
+
+		cmd, exists := byName[req.name]
+		switch {
+		case !exists:
+			drift = append(drift, DriftItem{Kind: DriftMissing, Name: req.name, Location: req.location, Repository: req.repository})
+		case !cmd.Enabled:
+			drift = append(drift, DriftItem{Kind: DriftDisabled, Name: req.name, Command: cmd, Location: req.location})
+		case cmd.SymlinkLocation != req.location:
+			drift = append(drift, DriftItem{Kind: DriftMisplaced, Name: req.name, Command: cmd, Location: req.location})
+		}
+	}
+
+	// Enabled commands the project does not declare, only when it declares a command set
+	if len(required) > 0 {
+		for i := range cmds {
+			cmd := &cmds[i]
+			if cmd.Enabled && !declared[cmd.Name] && !c.isException(cmd.Name) {
+				drift = append(drift, DriftItem{Kind: DriftExtra, Name: cmd.Name, Command: cmd, Location: cmd.SymlinkLocation})
+			}
+		}
+	}
+
+	// Repositories pinned without a command list only need to have been imported
+	for _, repo := range c.Repositories {
+		if len(repo.Commands) > 0 {
+			continue
+		}
+		parsed, err := remote.ParseGitHubURL(repo.URL)
+		if err != nil || !sources[parsed.FullName()] {
+			drift = append(drift, DriftItem{Kind: DriftMissing, Repository: repo.URL})
+		}
+	}
+
+	return drift
+}
@@ -0,0 +1,178 @@
+package project
+
+import (
+	"fmt"
+
+	"github.com/shel-corp/Claude-command-manager/internal/commands"
+	"github.com/shel-corp/Claude-command-manager/internal/remote"
+)
+
+// ReconcileResult summarizes what a reconciliation changed
+type ReconcileResult struct {
+	Fixed      []string // Descriptions of items brought in line
+	Unresolved []string // Descriptions of items that could not be fixed, with reasons
+}
+
+// ReconcilePlan is a reconciliation whose missing commands were imported; applying it
+// records their sources and enables and disables commands in the library
+type ReconcilePlan struct {
+	Result  ReconcileResult   // Imports done so far, and items that could not be imported
+	Apply   []DriftItem       // Items left to bring in line in the library configuration
+	Sources map[string]string // Repository of each imported file, by path
+}
+
+// Reconcile brings the library in line with the given drift items: missing commands are
+// imported from their repositories, pinned commands are enabled at the expected location,
+// and undeclared extras are disabled.
+// The caller is responsible for saving the config afterwards.
+func Reconcile(manager *commands.Manager, drift Drift) ReconcileResult {
+	return ImportMissing(manager.GetCommandsDir(), drift).ApplyTo(manager)
+}
+
+// ImportMissing imports the missing commands among the drift items into commandsDir,
+// grouped by repository. It only writes command files, so it can run in the background
+// while the library configuration is in use.
+func ImportMissing(commandsDir string, drift Drift) ReconcilePlan {
+	plan := ReconcilePlan{Sources: make(map[string]string)}
+
+	// Import missing commands first, grouped by repository
+	byRepo := make(map[string][]DriftItem)
+	var repoOrder []string
+	for _, item := range drift {
+		switch {
+		case item.Kind != DriftMissing:
+			plan.Apply = append(plan.Apply, item)
+		case item.Repository == "":
+			plan.Result.Unresolved = append(plan.Result.Unresolved, item.Describe()+": no repository to import it from")
+		default:
+			if _, exists := byRepo[item.Repository]; !exists {
+				repoOrder = append(repoOrder, item.Repository)
+			}
+			byRepo[item.Repository] = append(byRepo[item.Repository], item)
+		}
+	}
+
+	for _, repoURL := range repoOrder {
+		items := byRepo[repoURL]
+		if err := importFromRepository(commandsDir, repoURL, items, plan.Sources); err != nil {
+			for _, item := range items {
+				plan.Result.Unresolved = append(plan.Result.Unresolved, fmt.Sprintf("%s: %v", item.Describe(), err))
+			}
+			continue
+		}
+		for _, item := range items {
+			if item.Name == "" {
+				plan.Result.Fixed = append(plan.Result.Fixed, fmt.Sprintf("imported commands from %s", repoURL))
+				continue
+			}
+			plan.Apply = append(plan.Apply, item)
+		}
+	}
+	return plan
+}
+
+// ApplyTo records the sources of the imported commands and enables or disables commands
+// to match the plan, returning the whole reconciliation's result.
+// The caller is responsible for saving the config afterwards.
+func (p ReconcilePlan) ApplyTo(manager *commands.Manager) ReconcileResult {
+	result := p.Result
+	for file, repo := range p.Sources {
+		manager.RecordSource(file, repo)
+	}
+	if len(p.Apply) == 0 {
+		return result
+	}
+
+	cmds, err := manager.ScanCommands()
+	if err != nil {
+		for _, item := range p.Apply {
+			result.Unresolved = append(result.Unresolved, fmt.Sprintf("%s: %v", item.Describe(), err))
+		}
+		return result
+	}
+	byName := make(map[string]commands.Command, len(cmds))
+	for _, cmd := range cmds {
+		byName[cmd.Name] = cmd
+	}
+
+	for _, item := range p.Apply {
+		cmd, exists := byName[item.Name]
+		if exists && item.Kind == DriftExtra {
+			if cmd.Enabled {
+				if err := manager.DisableCommand(cmd); err != nil {
+					result.Unresolved = append(result.Unresolved, fmt.Sprintf("%s: %v", item.Describe(), err))
+					continue
+				}
+			}
+			result.Fixed = append(result.Fixed, fmt.Sprintf("%s disabled", item.Name))
+			continue
+		}
+
+		if !exists {
+			result.Unresolved = append(result.Unresolved, fmt.Sprintf("%s: not found in %s", item.Name, item.Repository))
+			continue
+		}
+
+		if err := manager.SetSymlinkLocation(cmd, item.Location); err != nil {
+			result.Unresolved = append(result.Unresolved, fmt.Sprintf("%s: %v", item.Describe(), err))
+			continue
+		}
+		cmd.SymlinkLocation = item.Location
+
+		if !cmd.Enabled {
+			if err := manager.EnableCommand(cmd); err != nil {
+				result.Unresolved = append(result.Unresolved, fmt.Sprintf("%s: %v", item.Describe(), err))
+				continue
+			}
+		}
+
+		result.Fixed = append(result.Fixed, fmt.Sprintf("%s enabled in %s", item.Name, item.Location))
+	}
+
+	return result
+}
+
+// importFromRepository imports the named commands (or all commands for an unnamed item)
+// from a repository into commandsDir, adding the imported files to sources
+func importFromRepository(commandsDir, repoURL string, items []DriftItem, sources map[string]string) error {
+	repo, err := remote.ParseGitHubURL(repoURL)
+	if err != nil {
+		return err
+	}
+
+	client := remote.NewGitHubClient()
+	if err := client.FetchCommands(repo); err != nil {
+		return fmt.Errorf("failed to fetch commands: %w", err)
+	}
+
+	wanted := make(map[string]bool)
+	importAll := false
+	for _, item := range items {
+		if item.Name == "" {
+			importAll = true
+		}
+		wanted[item.Name] = true
+	}
+
+	selected := make([]remote.RemoteCommand, 0, len(repo.Commands))
+	for _, cmd := range repo.Commands {
+		if importAll || wanted[cmd.Name] {
+			cmd.Selected = true
+			selected = append(selected, cmd)
+		}
+	}
+
+	importer := remote.NewImporter(commandsDir)
+	result, err := importer.ImportCommands(repo, selected, remote.GetDefaultImportOptions(commandsDir))
+	if err != nil {
+		return err
+	}
+	if len(result.Failed) > 0 {
+		return fmt.Errorf("import failed: %s", result.Errors[0])
+	}
+
+	for _, file := range result.Files {
+		sources[file] = repo.FullName()
+	}
+	return nil
+}
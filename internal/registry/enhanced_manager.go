@@ -3,8 +3,10 @@ package registry
 import (
 	"context"
 	"fmt"
+	"sort"
 	"time"
 
+	"github.com/shel-corp/Claude-command-manager/internal/logging"
 	"github.com/shel-corp/Claude-command-manager/internal/remote"
 )
 
@@ -16,8 +18,18 @@ type EnhancedRegistryManager struct {
 	merged         *MergedRegistry
 	loadedAt       time.Time
 	cacheManager   remote.CacheManager
+	popular        map[string]bool // Repository URLs with a popular badge
 }
 
+// TrendingCategoryKey is the key of the virtual category listing recently popular repositories
+const TrendingCategoryKey = "_trending"
+
+// FavoritesCategoryKey is the key of the virtual category listing the user's starred repositories
+const FavoritesCategoryKey = "_favorites"
+
+// trendingLimit caps how many repositories the trending category shows
+const trendingLimit = 10
+
 // NewEnhancedRegistryManager creates a new enhanced registry manager
 func NewEnhancedRegistryManager() (*EnhancedRegistryManager, error) {
 	// Initialize bundled registry manager
@@ -46,7 +58,7 @@ func (erm *EnhancedRegistryManager) LoadRegistries() error {
 	// Load bundled registry
 	if err := erm.bundledManager.LoadRegistry(); err != nil {
 		// Log warning but continue - user registry might still work
-		fmt.Printf("Warning: failed to load bundled registry: %v\n", err)
+		logging.Warnf("failed to load bundled registry: %v", err)
 	}
 
 	// Load user registry
@@ -73,16 +85,45 @@ func (erm *EnhancedRegistryManager) mergeRegistries() error {
 
 	erm.merged = merged
 	erm.loadedAt = time.Now()
+	erm.popular = remote.PopularRepositories(erm.merger.GetAllRepositories())
 
 	// Validate merge and log warnings
 	warnings := erm.merger.ValidateMerge()
 	for _, warning := range warnings {
-		fmt.Printf("Registry merge warning: %s\n", warning)
+		logging.Warnf("registry merge: %s", warning)
 	}
 
 	return nil
 }
 
+// LoadCachedRegistries loads and merges the registries from the cache and local files only,
+// for callers such as shell completion that must not wait on the network
+func (erm *EnhancedRegistryManager) LoadCachedRegistries() error {
+	if err := erm.bundledManager.LoadCachedRegistry(); err != nil {
+		logging.Debugf("no cached bundled registry: %v", err)
+	}
+
+	if err := erm.userManager.Load(); err != nil {
+		return fmt.Errorf("failed to load user registry: %w", err)
+	}
+
+	return erm.mergeRegistries()
+}
+
+// RefreshRegistries reloads the bundled registry and its download stats, bypassing the cache
+func (erm *EnhancedRegistryManager) RefreshRegistries() (*remote.RefreshResult, error) {
+	result, err := erm.bundledManager.RefreshRegistry()
+	if err != nil {
+		return nil, fmt.Errorf("failed to refresh bundled registry: %w", err)
+	}
+
+	if err := erm.userManager.Load(); err != nil {
+		return nil, fmt.Errorf("failed to load user registry: %w", err)
+	}
+
+	return result, erm.mergeRegistries()
+}
+
 // LoadRegistriesWithCache loads registries with cache support (for background refresh)
 func (erm *EnhancedRegistryManager) LoadRegistriesWithCache(ctx context.Context) error {
 	return erm.LoadRegistries()
@@ -139,9 +180,93 @@ func (erm *EnhancedRegistryManager) GetCategoryRepositories(categoryKey string)
 		return nil
 	}
 
+	switch categoryKey {
+	case TrendingCategoryKey:
+		return erm.GetTrendingRepositories()
+	case FavoritesCategoryKey:
+		return erm.GetStarredRepositories()
+	}
+
 	return erm.merger.GetCategoryRepositories(categoryKey)
 }
 
+// IsPopular reports whether a repository is among the most downloaded in the registry
+func (erm *EnhancedRegistryManager) IsPopular(repoURL string) bool {
+	return erm.popular[repoURL]
+}
+
+// GetTrendingRepositories returns the repositories with the most downloads this week
+func (erm *EnhancedRegistryManager) GetTrendingRepositories() []remote.CuratedRepository {
+	if !erm.IsLoaded() {
+		return nil
+	}
+
+	return remote.TrendingRepositories(erm.merger.GetAllRepositories(), trendingLimit)
+}
+
+// TrendingCategory returns the virtual trending category, or false when no repository has recent downloads
+func (erm *EnhancedRegistryManager) TrendingCategory() (remote.RepositoryCategory, bool) {
+	trending := erm.GetTrendingRepositories()
+	if len(trending) == 0 {
+		return remote.RepositoryCategory{}, false
+	}
+
+	return remote.RepositoryCategory{
+		Name:         "Trending",
+		Description:  "Repositories with the most imports this week",
+		Icon:         "📈",
+		Repositories: trending,
+	}, true
+}
+
+// IsStarred reports whether a repository is one of the user's favorites
+func (erm *EnhancedRegistryManager) IsStarred(repoURL string) bool {
+	return erm.userManager.IsStarred(repoURL)
+}
+
+// ToggleStar stars or unstars a repository, returning whether it is now starred
+func (erm *EnhancedRegistryManager) ToggleStar(repoURL string) (bool, error) {
+	return erm.userManager.ToggleStar(repoURL)
+}
+
+// GetStarredRepositories returns the starred repositories in the order they were starred
+func (erm *EnhancedRegistryManager) GetStarredRepositories() []remote.CuratedRepository {
+	if !erm.IsLoaded() {
+		return nil
+	}
+
+	byURL := make(map[string]remote.CuratedRepository)
+	for _, repo := range erm.merger.GetAllRepositories() {
+		if _, seen := byURL[repo.URL]; !seen {
+			byURL[repo.URL] = repo
+		}
+	}
+
+	var starred []remote.CuratedRepository
+	for _, url := range erm.userManager.GetRegistry().Starred {
+		// Stars of repositories no longer in any registry are kept but not listed
+		if repo, ok := byURL[url]; ok {
+			starred = append(starred, repo)
+		}
+	}
+	return starred
+}
+
+// FavoritesCategory returns the virtual favorites category, or false when nothing is starred
+func (erm *EnhancedRegistryManager) FavoritesCategory() (remote.RepositoryCategory, bool) {
+	starred := erm.GetStarredRepositories()
+	if len(starred) == 0 {
+		return remote.RepositoryCategory{}, false
+	}
+
+	return remote.RepositoryCategory{
+		Name:         "Favorites",
+		Description:  "Repositories you starred",
+		Icon:         "⭐",
+		Repositories: starred,
+	}, true
+}
+
 // GetAllRepositories returns all repositories from merged registry
 func (erm *EnhancedRegistryManager) GetAllRepositories() []remote.CuratedRepository {
 	if !erm.IsLoaded() {
@@ -157,7 +282,12 @@ func (erm *EnhancedRegistryManager) SearchRepositories(query string) []remote.Cu
 		return nil
 	}
 
-	return erm.merger.SearchRepositories(query)
+	// Starred repositories float to the top, otherwise keeping the merger's order
+	results := erm.merger.SearchRepositories(query)
+	sort.SliceStable(results, func(i, j int) bool {
+		return erm.IsStarred(results[i].URL) && !erm.IsStarred(results[j].URL)
+	})
+	return results
 }
 
 // GetLoadTime returns when the registries were loaded
@@ -186,6 +316,8 @@ func (erm *EnhancedRegistryManager) AddCustomRepository(input RepositoryInput) e
 		); err != nil {
 			return fmt.Errorf("failed to create category: %w", err)
 		}
+	} else if err := erm.ensureUserCategory(categoryKey); err != nil {
+		return err
 	}
 
 	// Create user repository
@@ -208,6 +340,21 @@ func (erm *EnhancedRegistryManager) AddCustomRepository(input RepositoryInput) e
 	return erm.mergeRegistries()
 }
 
+// ensureUserCategory makes sure an existing (possibly bundled) category can hold user repositories
+func (erm *EnhancedRegistryManager) ensureUserCategory(categoryKey string) error {
+	if _, exists := erm.userManager.GetCategories()[categoryKey]; exists {
+		return nil
+	}
+
+	category, exists := erm.GetCategories()[categoryKey]
+	if !exists {
+		return fmt.Errorf("category '%s' does not exist", categoryKey)
+	}
+
+	// Mirror the bundled category so the merge folds user repositories into it
+	return erm.userManager.AddCategory(categoryKey, category.Name, category.Description, category.Icon)
+}
+
 // RemoveCustomRepository removes a custom repository from the user registry
 func (erm *EnhancedRegistryManager) RemoveCustomRepository(repoURL string) error {
 	if !erm.userManager.IsLoaded() {
@@ -264,6 +411,8 @@ func (erm *EnhancedRegistryManager) UpdateCustomRepository(repoURL string, input
 		); err != nil {
 			return fmt.Errorf("failed to create category: %w", err)
 		}
+	} else if err := erm.ensureUserCategory(newCategoryKey); err != nil {
+		return err
 	}
 
 	if oldCategoryKey != newCategoryKey {
@@ -0,0 +1,110 @@
+package registry
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Weights used when scoring how well a repository fits a category
+const (
+	suggestTagWeight  = 3 // Repository topic matches a tag used in the category
+	suggestNameWeight = 2 // Word appears in the category key or name
+	suggestTextWeight = 1 // Word appears in the category or its repositories' descriptions
+)
+
+// suggestStopWords are ignored when comparing text
+var suggestStopWords = map[string]bool{
+	"a": true, "an": true, "and": true, "the": true, "for": true, "of": true, "to": true,
+	"in": true, "with": true, "on": true, "by": true, "your": true, "my": true, "is": true,
+	"claude": true, "command": true, "commands": true, "code": true,
+}
+
+// SuggestCategory returns the existing category that best matches a repository's
+// name, description and topics. It returns an empty key when nothing matches.
+func (erm *EnhancedRegistryManager) SuggestCategory(name, description string, topics []string) string {
+	words := suggestWords(repoName(name) + " " + description)
+	topicSet := make(map[string]bool)
+	for _, topic := range topics {
+		topic = strings.ToLower(topic)
+		topicSet[topic] = true
+		for word := range suggestWords(topic) {
+			words[word] = true
+		}
+	}
+
+	scores := make(map[string]int)
+	for key, category := range erm.GetCategories() {
+		nameWords := suggestWords(key + " " + category.Name)
+		textWords := suggestWords(category.Description)
+		tags := make(map[string]bool)
+		for _, repo := range category.Repositories {
+			for word := range suggestWords(repoName(repo.Name) + " " + repo.Description) {
+				textWords[word] = true
+			}
+			for _, tag := range repo.Tags {
+				tags[strings.ToLower(tag)] = true
+				for word := range suggestWords(tag) {
+					textWords[word] = true
+				}
+			}
+		}
+
+		score := 0
+		for topic := range topicSet {
+			if tags[topic] {
+				score += suggestTagWeight
+			}
+		}
+		for word := range words {
+			switch {
+			case nameWords[word]:
+				score += suggestNameWeight
+			case textWords[word]:
+				score += suggestTextWeight
+			}
+		}
+		if score > 0 {
+			scores[key] = score
+		}
+	}
+
+	// Highest score wins; ties break alphabetically for a stable suggestion
+	keys := make([]string, 0, len(scores))
+	for key := range scores {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if scores[keys[i]] != scores[keys[j]] {
+			return scores[keys[i]] > scores[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+	if len(keys) == 0 {
+		return ""
+	}
+	return keys[0]
+}
+
+// suggestWords splits text into lowercase words, dropping stop words and very short tokens
+func suggestWords(text string) map[string]bool {
+	words := make(map[string]bool)
+	fields := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for _, field := range fields {
+		if len(field) < 3 || suggestStopWords[field] {
+			continue
+		}
+		words[strings.TrimSuffix(field, "s")] = true
+	}
+	return words
+}
+
+// repoName drops the owner from an "owner/repo" name, which says nothing about the category
+func repoName(name string) string {
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		return name[i+1:]
+	}
+	return name
+}
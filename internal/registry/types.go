@@ -11,6 +11,7 @@ type UserRegistry struct {
 	Version     string                      `yaml:"version"`
 	LastUpdated string                      `yaml:"last_updated"`
 	Categories  map[string]UserCategory     `yaml:"categories"`
+	Starred     []string                    `yaml:"starred,omitempty"` // URLs of favorite repositories, from any registry
 }
 
 // UserCategory represents a user-defined category
@@ -8,6 +8,8 @@ import (
 	"time"
 
 	"gopkg.in/yaml.v3"
+
+	"github.com/shel-corp/Claude-command-manager/internal/apperr"
 )
 
 // UserRegistryManager handles the user's personal repository registry
@@ -119,12 +121,12 @@ func (urm *UserRegistryManager) AddCategory(key, name, description, icon string)
 	// Validate key
 	key = strings.ToLower(strings.ReplaceAll(key, " ", "_"))
 	if key == "" {
-		return fmt.Errorf("category key cannot be empty")
+		return apperr.New(apperr.KindValidation, "category key cannot be empty")
 	}
 
 	// Check if category already exists
 	if _, exists := urm.registry.Categories[key]; exists {
-		return fmt.Errorf("category '%s' already exists", key)
+		return apperr.New(apperr.KindConflict, "category '%s' already exists", key)
 	}
 
 	// Add the category
@@ -148,13 +150,13 @@ func (urm *UserRegistryManager) AddRepository(categoryKey string, repo UserRepos
 	// Check if category exists
 	category, exists := urm.registry.Categories[categoryKey]
 	if !exists {
-		return fmt.Errorf("category '%s' does not exist", categoryKey)
+		return apperr.New(apperr.KindNotFound, "category '%s' does not exist", categoryKey)
 	}
 
 	// Check if repository already exists in this category
 	for _, existingRepo := range category.Repositories {
 		if existingRepo.URL == repo.URL {
-			return fmt.Errorf("repository with URL '%s' already exists in category '%s'", repo.URL, categoryKey)
+			return apperr.New(apperr.KindConflict, "repository with URL '%s' already exists in category '%s'", repo.URL, categoryKey)
 		}
 	}
 
@@ -179,7 +181,7 @@ func (urm *UserRegistryManager) RemoveRepository(categoryKey, repoURL string) er
 
 	category, exists := urm.registry.Categories[categoryKey]
 	if !exists {
-		return fmt.Errorf("category '%s' does not exist", categoryKey)
+		return apperr.New(apperr.KindNotFound, "category '%s' does not exist", categoryKey)
 	}
 
 	// Find and remove the repository
@@ -192,7 +194,7 @@ func (urm *UserRegistryManager) RemoveRepository(categoryKey, repoURL string) er
 		}
 	}
 
-	return fmt.Errorf("repository with URL '%s' not found in category '%s'", repoURL, categoryKey)
+	return apperr.New(apperr.KindNotFound, "repository with URL '%s' not found in category '%s'", repoURL, categoryKey)
 }
 
 // UpdateRepository updates an existing repository
@@ -203,7 +205,7 @@ func (urm *UserRegistryManager) UpdateRepository(categoryKey, repoURL string, up
 
 	category, exists := urm.registry.Categories[categoryKey]
 	if !exists {
-		return fmt.Errorf("category '%s' does not exist", categoryKey)
+		return apperr.New(apperr.KindNotFound, "category '%s' does not exist", categoryKey)
 	}
 
 	// Find and update the repository
@@ -221,7 +223,7 @@ func (urm *UserRegistryManager) UpdateRepository(categoryKey, repoURL string, up
 		}
 	}
 
-	return fmt.Errorf("repository with URL '%s' not found in category '%s'", repoURL, categoryKey)
+	return apperr.New(apperr.KindNotFound, "repository with URL '%s' not found in category '%s'", repoURL, categoryKey)
 }
 
 // GetAllRepositories returns all repositories from all categories
@@ -284,7 +286,7 @@ func (urm *UserRegistryManager) FindRepository(repoURL string) (*UserRepository,
 		}
 	}
 
-	return nil, "", fmt.Errorf("repository not found")
+	return nil, "", apperr.New(apperr.KindNotFound, "repository not found")
 }
 
 // HasRepository checks if a repository URL exists in the user registry
@@ -293,6 +295,40 @@ func (urm *UserRegistryManager) HasRepository(repoURL string) bool {
 	return err == nil
 }
 
+// IsStarred reports whether a repository is one of the user's favorites
+func (urm *UserRegistryManager) IsStarred(repoURL string) bool {
+	if !urm.IsLoaded() {
+		return false
+	}
+	for _, starred := range urm.registry.Starred {
+		if starred == repoURL {
+			return true
+		}
+	}
+	return false
+}
+
+// ToggleStar stars or unstars a repository and saves the registry, returning whether it is now starred
+func (urm *UserRegistryManager) ToggleStar(repoURL string) (bool, error) {
+	if !urm.IsLoaded() {
+		return false, fmt.Errorf("registry not loaded")
+	}
+
+	starred := !urm.IsStarred(repoURL)
+	if starred {
+		urm.registry.Starred = append(urm.registry.Starred, repoURL)
+	} else {
+		kept := urm.registry.Starred[:0]
+		for _, url := range urm.registry.Starred {
+			if url != repoURL {
+				kept = append(kept, url)
+			}
+		}
+		urm.registry.Starred = kept
+	}
+	return starred, urm.Save()
+}
+
 // GetRegistryPath returns the path to the user registry file
 func (urm *UserRegistryManager) GetRegistryPath() string {
 	return urm.registryPath
@@ -0,0 +1,199 @@
+package remote
+
+import (
+	"regexp"
+	"strings"
+)
+
+// CodeBlock represents a fenced code block or inline shell snippet found in a command
+type CodeBlock struct {
+	Language string   `json:"language"` // Language tag from the fence (may be empty)
+	Content  string   `json:"content"`  // Raw block content
+	Line     int      `json:"line"`     // 1-based line number where the block starts
+	IsShell  bool     `json:"is_shell"` // Whether the block contains shell commands
+	Inline   bool     `json:"inline"`   // Whether this is an inline !`cmd` snippet
+	Executes bool     `json:"executes"` // Whether Claude would run it with Bash tool permissions
+	Warnings []string `json:"warnings"` // Suspicious patterns detected in the block
+}
+
+// CommandAnalysis summarizes the executable content of a command before import
+type CommandAnalysis struct {
+	Blocks       []CodeBlock `json:"blocks"`
+	AllowedTools []string    `json:"allowed_tools"` // From YAML frontmatter
+	BashAllowed  bool        `json:"bash_allowed"`  // Whether allowed-tools grants Bash
+	ShellCount   int         `json:"shell_count"`   // Number of shell blocks/snippets
+	ExecuteCount int         `json:"execute_count"` // Number of snippets that would run with Bash permissions
+	WarningCount int         `json:"warning_count"` // Total suspicious patterns detected
+}
+
+// shellLanguages lists fence languages treated as shell
+var shellLanguages = map[string]bool{
+	"bash":    true,
+	"sh":      true,
+	"shell":   true,
+	"zsh":     true,
+	"console": true,
+	"fish":    true,
+}
+
+// suspiciousPatterns lists content patterns that warrant reviewer attention
+var suspiciousPatterns = []struct {
+	pattern string
+	message string
+}{
+	{`(?i)curl.*\|.*sh`, "potential remote code execution"},
+	{`(?i)wget.*\|.*sh`, "potential remote code execution"},
+	{`(?i)rm\s+-rf\s+/`, "dangerous file deletion"},
+	{`(?i)sudo\s+rm`, "privileged file deletion"},
+	{`(?i)format\s+c:`, "potential disk formatting"},
+	{`(?i):\(\)\{.*\}`, "potential fork bomb"},
+}
+
+// inlineBashPattern matches Claude's inline bash execution syntax: !`command`
+var inlineBashPattern = regexp.MustCompile("!`([^`]+)`")
+
+// AnalyzeCommand extracts code blocks from command content and flags shell snippets
+func AnalyzeCommand(content string) CommandAnalysis {
+	analysis := CommandAnalysis{
+		Blocks:       make([]CodeBlock, 0),
+		AllowedTools: extractAllowedTools(content),
+	}
+
+	for _, tool := range analysis.AllowedTools {
+		if tool == "Bash" || strings.HasPrefix(tool, "Bash(") {
+			analysis.BashAllowed = true
+			break
+		}
+	}
+
+	lines := strings.Split(content, "\n")
+	inFence := false
+	var current CodeBlock
+	var body []string
+
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+
+		if strings.HasPrefix(trimmed, "```") {
+			if !inFence {
+				inFence = true
+				current = CodeBlock{
+					Language: strings.ToLower(strings.TrimSpace(strings.TrimPrefix(trimmed, "```"))),
+					Line:     i + 1,
+				}
+				body = nil
+			} else {
+				inFence = false
+				current.Content = strings.Join(body, "\n")
+				current.IsShell = shellLanguages[current.Language]
+				analysis.addBlock(current)
+			}
+			continue
+		}
+
+		if inFence {
+			body = append(body, line)
+			continue
+		}
+
+		// Inline !`cmd` snippets are executed before the prompt is sent
+		for _, match := range inlineBashPattern.FindAllStringSubmatch(line, -1) {
+			analysis.addBlock(CodeBlock{
+				Language: "bash",
+				Content:  match[1],
+				Line:     i + 1,
+				IsShell:  true,
+				Inline:   true,
+			})
+		}
+	}
+
+	// Unterminated fence - still report what we found
+	if inFence {
+		current.Content = strings.Join(body, "\n")
+		current.IsShell = shellLanguages[current.Language]
+		analysis.addBlock(current)
+	}
+
+	return analysis
+}
+
+// addBlock records a block and updates the analysis counters
+func (a *CommandAnalysis) addBlock(block CodeBlock) {
+	block.Warnings = detectSuspiciousContent(block.Content)
+
+	if block.IsShell {
+		a.ShellCount++
+		// Inline snippets always run; fenced shell only runs when Bash is permitted
+		block.Executes = block.Inline || a.BashAllowed
+		if block.Executes {
+			a.ExecuteCount++
+		}
+	}
+
+	a.WarningCount += len(block.Warnings)
+	a.Blocks = append(a.Blocks, block)
+}
+
+// HasRisk returns true if any snippet would execute or contains suspicious patterns
+func (a CommandAnalysis) HasRisk() bool {
+	return a.ExecuteCount > 0 || a.WarningCount > 0
+}
+
+// detectSuspiciousContent returns messages for all suspicious patterns found in content
+func detectSuspiciousContent(content string) []string {
+	var warnings []string
+	for _, pattern := range suspiciousPatterns {
+		matched, err := regexp.MatchString(pattern.pattern, content)
+		if err != nil {
+			continue // Skip regex errors
+		}
+		if matched {
+			warnings = append(warnings, pattern.message)
+		}
+	}
+	return warnings
+}
+
+// extractAllowedTools parses the allowed-tools field from YAML frontmatter
+func extractAllowedTools(content string) []string {
+	yamlPattern := regexp.MustCompile(`(?s)^---\s*\n(.*?)\n---`)
+	matches := yamlPattern.FindStringSubmatch(content)
+	if len(matches) < 2 {
+		return nil
+	}
+
+	toolsPattern := regexp.MustCompile(`(?m)^allowed-tools:\s*(.+)$`)
+	toolMatches := toolsPattern.FindStringSubmatch(matches[1])
+	if len(toolMatches) < 2 {
+		return nil
+	}
+
+	value := strings.TrimSpace(toolMatches[1])
+	value = strings.Trim(value, `[]"'`)
+
+	// Split on commas that are not inside parentheses, e.g. Bash(git add:*), Read
+	var tools []string
+	depth := 0
+	start := 0
+	for i, r := range value {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				if tool := strings.Trim(strings.TrimSpace(value[start:i]), `"'`); tool != "" {
+					tools = append(tools, tool)
+				}
+				start = i + 1
+			}
+		}
+	}
+	if tool := strings.Trim(strings.TrimSpace(value[start:]), `"'`); tool != "" {
+		tools = append(tools, tool)
+	}
+
+	return tools
+}
@@ -0,0 +1,149 @@
+package remote
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shel-corp/Claude-command-manager/internal/apperr"
+	"github.com/shel-corp/Claude-command-manager/internal/logging"
+)
+
+// GHLoginCommand is what users run to authenticate the GitHub CLI
+const GHLoginCommand = "gh auth login"
+
+// anonymousAPIBase is the public REST endpoint used when gh cannot make requests
+const anonymousAPIBase = "https://api.github.com/"
+
+// GHAuthState describes whether the GitHub CLI can be used for API requests
+type GHAuthState int
+
+const (
+	GHReady            GHAuthState = iota // Installed and logged in
+	GHNotInstalled                        // gh is not on PATH
+	GHNotAuthenticated                    // gh is installed but not logged in
+)
+
+// GHAuthStatus is the result of checking the GitHub CLI
+type GHAuthStatus struct {
+	State  GHAuthState
+	Detail string // Output from gh explaining the problem, if any
+}
+
+// Ready reports whether gh can make authenticated API requests
+func (s GHAuthStatus) Ready() bool {
+	return s.State == GHReady
+}
+
+// Message explains the status and what to do about it
+func (s GHAuthStatus) Message() string {
+	switch s.State {
+	case GHNotInstalled:
+		return "GitHub CLI (gh) is not installed. Install it from https://cli.github.com/ and run '" + GHLoginCommand + "'."
+	case GHNotAuthenticated:
+		return "GitHub CLI is not logged in. Run '" + GHLoginCommand + "' to authenticate."
+	}
+	return "GitHub CLI is logged in."
+}
+
+var (
+	ghAuthMu      sync.Mutex
+	ghAuthStatus  GHAuthStatus
+	ghAuthChecked bool
+)
+
+// CheckGHAuth reports whether gh is installed and logged in. The result is
+// remembered for the rest of the run; use RecheckGHAuth after the user fixes it.
+func CheckGHAuth() GHAuthStatus {
+	ghAuthMu.Lock()
+	defer ghAuthMu.Unlock()
+
+	if !ghAuthChecked {
+		ghAuthStatus = detectGHAuth()
+		ghAuthChecked = true
+	}
+	return ghAuthStatus
+}
+
+// RecheckGHAuth runs the gh checks again, replacing the remembered result
+func RecheckGHAuth() GHAuthStatus {
+	ghAuthMu.Lock()
+	ghAuthChecked = false
+	ghAuthMu.Unlock()
+	return CheckGHAuth()
+}
+
+func detectGHAuth() GHAuthStatus {
+	if err := exec.Command("gh", "--version").Run(); err != nil {
+		return GHAuthStatus{State: GHNotInstalled}
+	}
+
+	// Only github.com matters; a logged-out GitHub Enterprise host would fail a plain status
+	output, err := exec.Command("gh", "auth", "status", "--hostname", "github.com").CombinedOutput()
+	if err != nil {
+		logging.Debugf("gh auth status: %s", strings.TrimSpace(string(output)))
+		return GHAuthStatus{State: GHNotAuthenticated, Detail: strings.TrimSpace(string(output))}
+	}
+	return GHAuthStatus{State: GHReady}
+}
+
+// ghAPI performs a GET request against the GitHub API. It goes through gh when it is
+// logged in and falls back to anonymous REST requests, which work for public repositories.
+// Canceling ctx stops the request and returns ctx.Err().
+func ghAPI(ctx context.Context, apiURL string) ([]byte, error) {
+	if CheckGHAuth().Ready() {
+		logging.Debugf("gh api %s", apiURL)
+		output, err := exec.CommandContext(ctx, "gh", "api", apiURL).Output()
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if err != nil {
+			return nil, newGHError(err)
+		}
+		return output, nil
+	}
+	return anonymousAPI(ctx, apiURL)
+}
+
+// anonymousAPI requests a GitHub API path without credentials
+func anonymousAPI(ctx context.Context, apiURL string) ([]byte, error) {
+	logging.Debugf("anonymous GET %s", apiURL)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, anonymousAPIBase+strings.TrimPrefix(apiURL, "/"), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	client := &http.Client{Timeout: 15 * time.Second}
+	resp, err := client.Do(req)
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	if err != nil {
+		return nil, apperr.Wrap(apperr.KindNetwork, fmt.Errorf("GitHub API request failed: %w", err))
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, apperr.Wrap(apperr.KindNetwork, fmt.Errorf("failed to read GitHub API response: %w", err))
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		message := fmt.Sprintf("GitHub API error: %s", resp.Status)
+		switch resp.StatusCode {
+		case http.StatusForbidden, http.StatusTooManyRequests:
+			message += " (anonymous rate limit reached; run '" + GHLoginCommand + "' for higher limits)"
+		case http.StatusNotFound:
+			message += " (private repositories require '" + GHLoginCommand + "')"
+		}
+		return nil, &GitHubAPIError{Message: message, StatusCode: resp.StatusCode}
+	}
+	return body, nil
+}
@@ -0,0 +1,249 @@
+package remote
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// FailedImport is a command that could not be imported and is waiting to be retried
+type FailedImport struct {
+	Owner           string             `json:"owner"`
+	Repo            string             `json:"repo"`
+	Branch          string             `json:"branch"`
+	Command         string             `json:"command"`             // Command name as shown in the repository
+	Path            string             `json:"path"`                // Full path of the command file in the repository
+	TargetDirectory string             `json:"target_directory"`    // Library the command was being imported into
+	Conflict        ConflictResolution `json:"conflict,omitempty"`  // How the original import resolved an existing command
+	Overwrite       bool               `json:"overwrite,omitempty"` // Queues written before Conflict: the import overwrote existing files
+	Reason          string             `json:"reason"`              // Most recent error message
+	Attempts        int                `json:"attempts"`
+	FirstFailed     time.Time          `json:"first_failed"`
+	LastFailed      time.Time          `json:"last_failed"`
+}
+
+// Repository returns the remote repository the failed command belongs to
+func (f FailedImport) Repository() *RemoteRepository {
+	return &RemoteRepository{Owner: f.Owner, Repo: f.Repo, Branch: f.Branch}
+}
+
+// resolution is how a retry resolves the command if it already exists, the same way the
+// original import did
+func (f FailedImport) resolution() ConflictResolution {
+	switch {
+	case f.Conflict != "":
+		return f.Conflict
+	case f.Overwrite:
+		return ConflictOverwrite
+	}
+	return ConflictSkip
+}
+
+// key identifies a queue entry; the same command imported into two libraries is tracked twice
+func (f FailedImport) key() string {
+	return strings.Join([]string{f.Owner, f.Repo, f.Path, f.TargetDirectory}, "\x00")
+}
+
+// FailureQueue persists failed imports across runs so they can be retried later
+type FailureQueue struct {
+	path  string
+	Items []FailedImport `json:"items"`
+}
+
+// RetryOutcome is the result of retrying the queued failures for one repository and library
+type RetryOutcome struct {
+	Repository      *RemoteRepository
+	TargetDirectory string
+	Result          *ImportResult
+}
+
+// GetFailureQueuePath returns the location of the persistent failed-imports queue
+func GetFailureQueuePath() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(homeDir, ".config", "claude_command_manager", "failed_imports.json"), nil
+}
+
+// LoadFailureQueue reads the failed-imports queue, returning an empty queue if none exists
+func LoadFailureQueue() (*FailureQueue, error) {
+	path, err := GetFailureQueuePath()
+	if err != nil {
+		return nil, err
+	}
+
+	queue := &FailureQueue{path: path}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return queue, nil
+		}
+		return nil, fmt.Errorf("failed to read failed-imports queue: %w", err)
+	}
+
+	if err := json.Unmarshal(data, queue); err != nil {
+		return nil, fmt.Errorf("failed to parse failed-imports queue: %w", err)
+	}
+	return queue, nil
+}
+
+// Save writes the queue to disk
+func (q *FailureQueue) Save() error {
+	if err := os.MkdirAll(filepath.Dir(q.path), 0755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(q, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(q.path, data, 0644)
+}
+
+// RecordResult updates the queue from an import: failures are added (or have their
+// attempt count bumped) and commands that imported or were skipped are cleared
+func (q *FailureQueue) RecordResult(repo *RemoteRepository, selected []RemoteCommand, options ImportOptions, result *ImportResult) {
+	if result == nil {
+		return
+	}
+
+	reasons := make(map[string]string, len(result.Failed))
+	for i, name := range result.Failed {
+		if i < len(result.Errors) {
+			reasons[name] = strings.TrimPrefix(result.Errors[i], name+": ")
+		}
+	}
+
+	now := time.Now()
+	for _, command := range selected {
+		if !command.Selected {
+			continue
+		}
+
+		entry := FailedImport{
+			Owner:           repo.Owner,
+			Repo:            repo.Repo,
+			Branch:          repo.Branch,
+			Command:         command.Name,
+			Path:            command.Path,
+			TargetDirectory: options.TargetDirectory,
+			Conflict:        options.ConflictFor(command.Name),
+		}
+
+		reason, failed := reasons[command.Name]
+		if !failed {
+			q.remove(entry.key())
+			continue
+		}
+
+		if existing := q.find(entry.key()); existing != nil {
+			existing.Conflict, existing.Overwrite = entry.Conflict, false
+			existing.Reason = reason
+			existing.Attempts++
+			existing.LastFailed = now
+			continue
+		}
+
+		entry.Reason = reason
+		entry.Attempts = 1
+		entry.FirstFailed = now
+		entry.LastFailed = now
+		q.Items = append(q.Items, entry)
+	}
+}
+
+// BatchFailure is the result of an import that failed as a whole before any command was
+// attempted (network or auth trouble): every selected command failed with err, so
+// RecordResult queues them all for a retry
+func BatchFailure(selected []RemoteCommand, err error) *ImportResult {
+	result := &ImportResult{}
+	for _, command := range selected {
+		if command.Selected {
+			result.Failed = append(result.Failed, command.Name)
+			result.Errors = append(result.Errors, fmt.Sprintf("%s: %s", command.Name, err.Error()))
+		}
+	}
+	return result
+}
+
+// Retry re-attempts every queued failure, optionally limited to one "owner/repo",
+// and updates the queue with the outcome. The caller is responsible for saving the queue.
+func (q *FailureQueue) Retry(repoFilter string) []RetryOutcome {
+	type batchKey struct {
+		owner, repo, branch, target string
+	}
+
+	var order []batchKey
+	batches := make(map[batchKey][]FailedImport)
+	for _, item := range q.Items {
+		if repoFilter != "" && !strings.EqualFold(item.Repository().FullName(), repoFilter) {
+			continue
+		}
+		key := batchKey{item.Owner, item.Repo, item.Branch, item.TargetDirectory}
+		if _, exists := batches[key]; !exists {
+			order = append(order, key)
+		}
+		batches[key] = append(batches[key], item)
+	}
+
+	var outcomes []RetryOutcome
+	for _, key := range order {
+		items := batches[key]
+		repo := items[0].Repository()
+
+		options := GetDefaultImportOptions(key.target)
+		options.Resolutions = make(map[string]ConflictResolution, len(items))
+		selected := make([]RemoteCommand, 0, len(items))
+		for _, item := range items {
+			selected = append(selected, RemoteCommand{Name: item.Command, Path: item.Path, Selected: true})
+			options.Resolutions[item.Command] = item.resolution()
+		}
+
+		importer := NewImporter(key.target)
+		result, err := importer.ImportCommands(repo, selected, options)
+		if err != nil {
+			result = BatchFailure(selected, err)
+		}
+
+		q.RecordResult(repo, selected, options, result)
+		outcomes = append(outcomes, RetryOutcome{Repository: repo, TargetDirectory: key.target, Result: result})
+	}
+
+	return outcomes
+}
+
+// Clear removes queued failures, optionally limited to one "owner/repo", and returns how many were removed
+func (q *FailureQueue) Clear(repoFilter string) int {
+	kept := q.Items[:0]
+	for _, item := range q.Items {
+		if repoFilter != "" && !strings.EqualFold(item.Repository().FullName(), repoFilter) {
+			kept = append(kept, item)
+		}
+	}
+	removed := len(q.Items) - len(kept)
+	q.Items = kept
+	return removed
+}
+
+func (q *FailureQueue) find(key string) *FailedImport {
+	for i := range q.Items {
+		if q.Items[i].key() == key {
+			return &q.Items[i]
+		}
+	}
+	return nil
+}
+
+func (q *FailureQueue) remove(key string) {
+	for i := range q.Items {
+		if q.Items[i].key() == key {
+			q.Items = append(q.Items[:i], q.Items[i+1:]...)
+			return
+		}
+	}
+}
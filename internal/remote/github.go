@@ -1,13 +1,19 @@
 package remote
 
 import (
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"net/url"
 	"os/exec"
 	"regexp"
 	"strings"
 	"time"
+
+	"github.com/shel-corp/Claude-command-manager/internal/apperr"
+	"github.com/shel-corp/Claude-command-manager/internal/logging"
+	"github.com/shel-corp/Claude-command-manager/internal/version"
 )
 
 // isExcludedFile checks if a file should be excluded from command scanning
@@ -30,6 +36,7 @@ type GitHubContent struct {
 // GitHubClient handles GitHub API interactions using gh command
 type GitHubClient struct{
 	cacheManager CacheManager // For repository caching
+	ctx          context.Context // Cancels in-flight requests; nil means never
 }
 
 // RepositoryCacheManager interface for repository caching operations
@@ -50,6 +57,39 @@ func (c *GitHubClient) SetCacheManager(cacheManager CacheManager) {
 	c.cacheManager = cacheManager
 }
 
+// SetContext makes the client's requests stop when ctx is canceled
+func (c *GitHubClient) SetContext(ctx context.Context) {
+	c.ctx = ctx
+}
+
+// context returns the context requests run under
+func (c *GitHubClient) context() context.Context {
+	if c.ctx == nil {
+		return context.Background()
+	}
+	return c.ctx
+}
+
+// ghStatusPattern extracts the HTTP status gh reports on failed API calls, e.g. "(HTTP 404)"
+var ghStatusPattern = regexp.MustCompile(`HTTP (\d{3})`)
+
+// newGHError converts a failed `gh api` invocation into a GitHubAPIError carrying the HTTP status
+func newGHError(err error) error {
+	exitErr, ok := err.(*exec.ExitError)
+	if !ok {
+		return fmt.Errorf("failed to execute gh command: %w", err)
+	}
+
+	stderr := strings.TrimSpace(string(exitErr.Stderr))
+	apiErr := &GitHubAPIError{Message: "GitHub API error: " + stderr}
+	if match := ghStatusPattern.FindStringSubmatch(stderr); match != nil {
+		fmt.Sscanf(match[1], "%d", &apiErr.StatusCode)
+	} else if strings.Contains(stderr, "gh auth login") {
+		apiErr.StatusCode = 401
+	}
+	return apiErr
+}
+
 // CheckGHInstalled verifies that gh command is available
 func (c *GitHubClient) CheckGHInstalled() error {
 	cmd := exec.Command("gh", "--version")
@@ -66,10 +106,6 @@ func (c *GitHubClient) FetchCommands(repo *RemoteRepository) error {
 
 // FetchCommandsWithCache fetches commands with optional cache support
 func (c *GitHubClient) FetchCommandsWithCache(repo *RemoteRepository, useCache bool) error {
-	if err := c.CheckGHInstalled(); err != nil {
-		return err
-	}
-
 	// Try cache first if enabled
 	if useCache && c.cacheManager != nil && c.cacheManager.IsEnabled() {
 		repoKey := c.generateRepoKey(repo)
@@ -95,7 +131,7 @@ func (c *GitHubClient) FetchCommandsWithCache(repo *RemoteRepository, useCache b
 		repoKey := c.generateRepoKey(repo)
 		if err := c.cacheRepositoryData(repoKey, repo, commands); err != nil {
 			// Log error but don't fail
-			fmt.Printf("Warning: failed to cache repository data: %v\n", err)
+			logging.Warnf("failed to cache repository data: %v", err)
 		}
 	}
 
@@ -113,6 +149,19 @@ func (c *GitHubClient) generateRepoKey(repo *RemoteRepository) string {
 	return fmt.Sprintf("%s_%s_%s_%s", repo.Owner, repo.Repo, repo.Branch, strings.ReplaceAll(repo.Path, "/", "_"))
 }
 
+// CachedCommandCount returns how many commands the cache holds for a repository without
+// going to the network; ok is false if the repository has not been cached
+func (c *GitHubClient) CachedCommandCount(repo *RemoteRepository) (count int, ok bool) {
+	if c.cacheManager == nil || !c.cacheManager.IsEnabled() {
+		return 0, false
+	}
+	cached, commands, _, _, _, err := c.getCachedRepositoryData(c.generateRepoKey(repo))
+	if err != nil || cached == nil {
+		return 0, false
+	}
+	return len(commands), true
+}
+
 // getCachedRepositoryData retrieves cached repository data
 func (c *GitHubClient) getCachedRepositoryData(repoKey string) (*RemoteRepository, []RemoteCommand, time.Time, bool, string, error) {
 	if rm, ok := c.cacheManager.(RepositoryCacheManager); ok {
@@ -150,13 +199,9 @@ func (c *GitHubClient) fetchCommandsRecursive(repo *RemoteRepository, subPath st
 	apiURL := repo.BuildGitHubAPIURL(subPath)
 	
 	// Fetch directory contents
-	cmd := exec.Command("gh", "api", apiURL)
-	output, err := cmd.Output()
+	output, err := ghAPI(c.context(), apiURL)
 	if err != nil {
-		if exitErr, ok := err.(*exec.ExitError); ok {
-			return nil, fmt.Errorf("GitHub API error: %s", string(exitErr.Stderr))
-		}
-		return nil, fmt.Errorf("failed to execute gh command: %w", err)
+		return nil, err
 	}
 
 	// Parse JSON response
@@ -199,13 +244,9 @@ func (c *GitHubClient) FetchCommandContent(repo *RemoteRepository, command *Remo
 	// Build API URL for the specific file
 	apiURL := fmt.Sprintf("repos/%s/%s/contents/%s?ref=%s", repo.Owner, repo.Repo, command.Path, repo.Branch)
 	
-	cmd := exec.Command("gh", "api", apiURL)
-	output, err := cmd.Output()
+	output, err := ghAPI(c.context(), apiURL)
 	if err != nil {
-		if exitErr, ok := err.(*exec.ExitError); ok {
-			return fmt.Errorf("GitHub API error: %s", string(exitErr.Stderr))
-		}
-		return fmt.Errorf("failed to execute gh command: %w", err)
+		return err
 	}
 
 	// Parse JSON response
@@ -224,8 +265,11 @@ func (c *GitHubClient) FetchCommandContent(repo *RemoteRepository, command *Remo
 		command.Content = string(decoded)
 	} else if content.DownloadURL != "" {
 		// Fallback to download URL
-		downloadCmd := exec.Command("curl", "-s", content.DownloadURL)
+		downloadCmd := exec.CommandContext(c.context(), "curl", "-s", content.DownloadURL)
 		downloadOutput, err := downloadCmd.Output()
+		if err := c.context().Err(); err != nil {
+			return err
+		}
 		if err != nil {
 			return fmt.Errorf("failed to download file content: %w", err)
 		}
@@ -234,8 +278,9 @@ func (c *GitHubClient) FetchCommandContent(repo *RemoteRepository, command *Remo
 		return fmt.Errorf("no content available for file: %s", command.Path)
 	}
 
-	// Extract description from YAML frontmatter
+	// Extract description and model from YAML frontmatter
 	command.Description = extractDescription(command.Content)
+	command.Model = extractFrontmatterField(command.Content, "model")
 
 	return nil
 }
@@ -276,71 +321,111 @@ func extractDescription(content string) string {
 	return "No description available"
 }
 
-// ValidateRepository checks if the repository and commands path exist
-func (c *GitHubClient) ValidateRepository(repo *RemoteRepository) error {
-	if err := c.CheckGHInstalled(); err != nil {
-		return err
+// extractFrontmatterField extracts a single field value from YAML frontmatter
+func extractFrontmatterField(content, field string) string {
+	yamlPattern := regexp.MustCompile(`(?s)^---\s*\n(.*?)\n---`)
+	matches := yamlPattern.FindStringSubmatch(content)
+	if len(matches) < 2 {
+		return ""
+	}
+
+	fieldPattern := regexp.MustCompile(`(?m)^` + regexp.QuoteMeta(field) + `:\s*(.+)$`)
+	fieldMatches := fieldPattern.FindStringSubmatch(matches[1])
+	if len(fieldMatches) < 2 {
+		return ""
 	}
 
+	return strings.Trim(strings.TrimSpace(fieldMatches[1]), `"'`)
+}
+
+// ValidateRepository checks if the repository and commands path exist
+func (c *GitHubClient) ValidateRepository(repo *RemoteRepository) error {
 	// Try to fetch the repository info first
 	repoURL := fmt.Sprintf("repos/%s/%s", repo.Owner, repo.Repo)
-	cmd := exec.Command("gh", "api", repoURL)
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("repository not found or not accessible: %s/%s", repo.Owner, repo.Repo)
+	if _, err := ghAPI(c.context(), repoURL); err != nil {
+		return apperr.Wrap(apperr.KindOf(err), fmt.Errorf("repository not found or not accessible: %s/%s: %w", repo.Owner, repo.Repo, err))
 	}
 
 	// Check if the commands directory exists
 	apiURL := repo.BuildGitHubAPIURL("")
-	cmd = exec.Command("gh", "api", apiURL)
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("commands directory not found at path: %s", repo.Path)
+	if _, err := ghAPI(c.context(), apiURL); err != nil {
+		return apperr.Wrap(apperr.KindOf(err), fmt.Errorf("commands directory not found at path: %s", repo.Path))
 	}
 
 	return nil
 }
 
-// GetRepositoryInfo detects the current Git repository information
-func GetRepositoryInfo() (*RemoteRepository, error) {
-	// Get the remote URL
-	cmd := exec.Command("git", "remote", "get-url", "origin")
-	output, err := cmd.Output()
+// RepositoryDetails holds descriptive metadata about a GitHub repository
+type RepositoryDetails struct {
+	Description string   `json:"description"`
+	Topics      []string `json:"topics"`
+}
+
+// FetchRepositoryDetails retrieves the description and topics of a repository
+func (c *GitHubClient) FetchRepositoryDetails(repo *RemoteRepository) (*RepositoryDetails, error) {
+	apiURL := fmt.Sprintf("repos/%s/%s", repo.Owner, repo.Repo)
+	output, err := ghAPI(c.context(), apiURL)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get git remote URL (make sure you're in a git repository): %w", err)
+		return nil, err
 	}
-	
-	remoteURL := strings.TrimSpace(string(output))
-	if remoteURL == "" {
-		return nil, fmt.Errorf("no git remote URL found")
+
+	var details RepositoryDetails
+	if err := json.Unmarshal(output, &details); err != nil {
+		return nil, fmt.Errorf("failed to parse GitHub API response: %w", err)
 	}
-	
-	// Parse the GitHub URL to extract owner and repo
-	repo, err := ParseGitHubURL(remoteURL)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse git remote URL '%s': %w", remoteURL, err)
+	return &details, nil
+}
+
+// Repository that ccm issue reports are filed against
+const (
+	issueOwner = "shel-corp"
+	issueRepo  = "Claude-command-manager"
+)
+
+// IssueTypes maps the issue types accepted by ccm report to their GitHub labels
+var IssueTypes = map[string]string{
+	"bug":      "bug",
+	"feature":  "enhancement",
+	"question": "question",
+}
+
+// IssueTypeNames lists the issue types in the order they are offered
+var IssueTypeNames = []string{"bug", "feature", "question"}
+
+// IssueTemplates are the starting issue bodies for each issue type
+var IssueTemplates = map[string]string{
+	"bug":      "## What happened\n\n\n## Steps to reproduce\n1. \n\n## What I expected\n\n",
+	"feature":  "## Problem\n\n\n## Proposed solution\n\n\n## Alternatives considered\n\n",
+	"question": "## Question\n\n\n## What I tried\n\n",
+}
+
+// DefaultIssueRepository returns the ccm repository, which issue reports are filed against
+func DefaultIssueRepository() *RemoteRepository {
+	return &RemoteRepository{Owner: issueOwner, Repo: issueRepo}
+}
+
+// IssueRepository returns the repository issue reports are filed against: spec, as
+// owner/repo, or the ccm repository when spec is empty
+func IssueRepository(spec string) (*RemoteRepository, error) {
+	if spec == "" {
+		return DefaultIssueRepository(), nil
 	}
-	
-	// Validate that this is the expected repository
-	expectedOwner := "shel-corp"
-	expectedRepo := "Claude-command-manager"
-	
-	if repo.Owner != expectedOwner || repo.Repo != expectedRepo {
-		return nil, fmt.Errorf("unexpected repository: %s/%s (expected: %s/%s)", 
-			repo.Owner, repo.Repo, expectedOwner, expectedRepo)
+	owner, repo, ok := strings.Cut(spec, "/")
+	if !ok || owner == "" || repo == "" || strings.Contains(repo, "/") {
+		return nil, apperr.New(apperr.KindValidation, "invalid issue repository %q (expected owner/repo)", spec)
 	}
-	
-	return repo, nil
+	return &RemoteRepository{Owner: owner, Repo: repo}, nil
 }
 
 // CreateGitHubIssue creates a GitHub issue using the gh CLI
-func CreateGitHubIssue(repo *RemoteRepository, title, body string) error {
+func CreateGitHubIssue(repo *RemoteRepository, title, body string, extraLabels ...string) (string, error) {
 	// Check if gh CLI is available
 	if err := exec.Command("gh", "--version").Run(); err != nil {
-		return fmt.Errorf("GitHub CLI (gh) is required but not installed. Please install it from https://cli.github.com/")
+		return "", fmt.Errorf("GitHub CLI (gh) is required but not installed. Please install it from https://cli.github.com/")
 	}
 	
 	// Prepare the issue body with additional context
-	enhancedBody := body + "\n\n---\n\n**Submitted via ccm** 🤖\n\n" +
-		"This issue was reported through the Claude Command Manager (ccm) application."
+	enhancedBody := IssueBody(body)
 	
 	repoSpec := fmt.Sprintf("%s/%s", repo.Owner, repo.Repo)
 	
@@ -348,17 +433,18 @@ func CreateGitHubIssue(repo *RemoteRepository, title, body string) error {
 	createLabelsIfNeeded(repoSpec)
 	
 	// Create the issue using gh CLI
+	labels := IssueLabels(extraLabels...)
 	cmd := exec.Command("gh", "issue", "create", 
 		"--repo", repoSpec,
 		"--title", title,
 		"--body", enhancedBody,
-		"--label", "user-report,ccm-generated")
+		"--label", strings.Join(labels, ","))
 	
 	output, err := cmd.CombinedOutput()
 	if err != nil {
 		// If it failed due to labels, try again without labels
 		if strings.Contains(string(output), "not found") && strings.Contains(string(output), "label") {
-			fmt.Printf("Warning: Could not add labels, creating issue without labels...\n")
+			logging.Warnf("could not add labels to issue in %s, creating it without labels", repoSpec)
 			cmd = exec.Command("gh", "issue", "create", 
 				"--repo", repoSpec,
 				"--title", title,
@@ -366,14 +452,92 @@ func CreateGitHubIssue(repo *RemoteRepository, title, body string) error {
 			
 			output, err = cmd.CombinedOutput()
 			if err != nil {
-				return fmt.Errorf("failed to create GitHub issue: %w\n\nOutput: %s", err, string(output))
+				return "", fmt.Errorf("failed to create GitHub issue: %w\n\nOutput: %s", err, string(output))
 			}
 		} else {
-			return fmt.Errorf("failed to create GitHub issue: %w\n\nOutput: %s", err, string(output))
+			return "", fmt.Errorf("failed to create GitHub issue: %w\n\nOutput: %s", err, string(output))
 		}
 	}
 	
-	return nil
+	// gh prints the new issue's URL as its last line
+	return issueURLFromOutput(string(output), repo), nil
+}
+
+// IssueBody is the body CreateGitHubIssue files: the given body followed by a footer
+// saying it came from ccm and which build sent it
+func IssueBody(body string) string {
+	return body + "\n\n---\n\n**Submitted via ccm** 🤖\n\n" +
+		"This issue was reported through the Claude Command Manager (ccm) application.\n\n" +
+		issueBuildInfo()
+}
+
+// IssueLabels are the labels CreateGitHubIssue applies: ccm's own, then extraLabels
+func IssueLabels(extraLabels ...string) []string {
+	return append([]string{"user-report", "ccm-generated"}, extraLabels...)
+}
+
+// ExistingIssue is an issue already filed in a repository
+type ExistingIssue struct {
+	Number int    `json:"number"`
+	Title  string `json:"title"`
+	State  string `json:"state"` // "open" or "closed"
+	URL    string `json:"html_url"`
+}
+
+// issueSearchWords caps how many words of a title are searched for
+const issueSearchWords = 5
+
+// issueStopWords are left out of similar issue searches because nearly every title has them
+var issueStopWords = map[string]bool{
+	"the": true, "and": true, "for": true, "with": true, "when": true, "not": true,
+	"does": true, "doesn't": true, "from": true, "this": true, "that": true, "can't": true,
+	"cannot": true, "into": true, "should": true, "after": true, "before": true,
+}
+
+// FindSimilarIssues searches repo for issues whose titles share words with title, best
+// matches first. Issues of either state are returned, so closed duplicates show up too.
+func FindSimilarIssues(ctx context.Context, repo *RemoteRepository, title string, limit int) ([]ExistingIssue, error) {
+	var words []string
+	seen := make(map[string]bool)
+	for _, word := range strings.Fields(strings.ToLower(title)) {
+		word = strings.Trim(word, ".,:;!?()[]{}\"'`")
+		if len(word) < 3 || issueStopWords[word] || seen[word] {
+			continue
+		}
+		seen[word] = true
+		words = append(words, word)
+		if len(words) == issueSearchWords {
+			break
+		}
+	}
+	if len(words) == 0 {
+		return nil, nil
+	}
+
+	query := fmt.Sprintf("%s in:title repo:%s/%s is:issue", strings.Join(words, " OR "), repo.Owner, repo.Repo)
+	output, err := ghAPI(ctx, fmt.Sprintf("search/issues?q=%s&per_page=%d", url.QueryEscape(query), limit))
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Items []ExistingIssue `json:"items"`
+	}
+	if err := json.Unmarshal(output, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse GitHub API response: %w", err)
+	}
+	return result.Items, nil
+}
+
+// issueURLFromOutput extracts the issue URL printed by gh, falling back to the issues page
+func issueURLFromOutput(output string, repo *RemoteRepository) string {
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	for i := len(lines) - 1; i >= 0; i-- {
+		if line := strings.TrimSpace(lines[i]); strings.HasPrefix(line, "https://") {
+			return line
+		}
+	}
+	return fmt.Sprintf("https://github.com/%s/%s/issues", repo.Owner, repo.Repo)
 }
 
 // createLabelsIfNeeded creates the required labels if they don't exist
@@ -400,4 +564,21 @@ func createLabelsIfNeeded(repoSpec string) {
 			createCmd.Run() // Ignore errors - labels are optional
 		}
 	}
-}
\ No newline at end of file
+}
+// issueBuildInfo describes the running build so reports identify the exact binary
+func issueBuildInfo() string {
+	info := version.Get()
+	lines := []string{"**Version:** " + info.Version}
+	if info.Commit != "" {
+		commit := info.Commit
+		if info.Modified {
+			commit += "-dirty"
+		}
+		lines = append(lines, "**Commit:** "+commit)
+	}
+	if info.Date != "" {
+		lines = append(lines, "**Built:** "+info.Date)
+	}
+	lines = append(lines, "**Platform:** "+info.Platform+" ("+info.GoVersion+")")
+	return strings.Join(lines, "\n")
+}
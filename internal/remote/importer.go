@@ -1,6 +1,7 @@
 package remote
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -25,29 +26,64 @@ func NewImporter(targetDir string) *Importer {
 	}
 }
 
-// ImportCommands imports selected commands from a remote repository
+// ImportCommands imports selected commands from a remote repository. If options.Context
+// is canceled it stops early and returns the partial result along with the context's error.
 func (i *Importer) ImportCommands(repo *RemoteRepository, selectedCommands []RemoteCommand, options ImportOptions) (*ImportResult, error) {
 	result := &ImportResult{
 		Imported: make([]string, 0),
+		Files:    make([]string, 0),
 		Skipped:  make([]string, 0),
 		Failed:   make([]string, 0),
 		Errors:   make([]string, 0),
 	}
 
 	// Ensure target directory exists
-	if err := os.MkdirAll(options.TargetDirectory, 0755); err != nil {
-		return nil, fmt.Errorf("failed to create target directory: %w", err)
+	if !options.DryRun {
+		if err := os.MkdirAll(options.TargetDirectory, 0755); err != nil {
+			return nil, fmt.Errorf("failed to create target directory: %w", err)
+		}
+	}
+
+	ctx := options.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	i.client.SetContext(ctx)
+
+	total := 0
+	for _, command := range selectedCommands {
+		if command.Selected {
+			total++
+		}
+	}
+	done := 0
+	progress := func(name string) {
+		if options.Progress != nil {
+			options.Progress(done, total, name)
+		}
 	}
+	defer func() {
+		done = total
+		progress("")
+	}()
 
 	// Process each selected command
 	for _, command := range selectedCommands {
 		if !command.Selected {
 			continue
 		}
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		progress(command.Name)
+		done++
 
 		// Fetch command content if not already loaded
 		if command.Content == "" {
 			if err := i.client.FetchCommandContent(repo, &command); err != nil {
+				if ctx.Err() != nil {
+					return result, ctx.Err()
+				}
 				result.Failed = append(result.Failed, command.Name)
 				result.Errors = append(result.Errors, fmt.Sprintf("%s: %s", command.Name, err.Error()))
 				continue
@@ -72,15 +108,27 @@ func (i *Importer) importSingleCommand(command RemoteCommand, options ImportOpti
 
 	// Check if file already exists
 	if _, err := os.Stat(targetPath); err == nil {
-		if !options.OverwriteExisting {
+		resolution := options.ConflictFor(command.Name)
+		result.recordConflict(command.Name, resolution)
+		switch resolution {
+		case ConflictSkip:
 			result.Skipped = append(result.Skipped, command.Name)
 			return nil
-		}
 
-		// Create backup if requested
-		if options.CreateBackups {
-			if err := i.createBackup(targetPath); err != nil {
-				return fmt.Errorf("failed to create backup: %w", err)
+		case ConflictRename:
+			safeFilename = freeFilename(options.TargetDirectory, sanitizeFilename(command.Name))
+			targetPath = filepath.Join(options.TargetDirectory, safeFilename)
+			if result.Renamed == nil {
+				result.Renamed = make(map[string]string)
+			}
+			result.Renamed[command.Name] = strings.TrimSuffix(safeFilename, ".md")
+
+		default:
+			// Create backup if requested
+			if options.CreateBackups && !options.DryRun {
+				if err := i.createBackup(targetPath); err != nil {
+					return fmt.Errorf("failed to create backup: %w", err)
+				}
 			}
 		}
 	}
@@ -93,16 +141,37 @@ func (i *Importer) importSingleCommand(command RemoteCommand, options ImportOpti
 	}
 
 	// Write the command file
+	if options.DryRun {
+		result.Imported = append(result.Imported, command.Name)
+		result.Files = append(result.Files, safeFilename)
+		return nil
+	}
 	if err := os.WriteFile(targetPath, []byte(command.Content), 0644); err != nil {
 		return fmt.Errorf("failed to write file: %w", err)
 	}
 
 	result.Imported = append(result.Imported, command.Name)
+	result.Files = append(result.Files, safeFilename)
 	return nil
 }
 
+// freeFilename returns the first "<name>-N.md", from N = 2, that does not exist in dir
+func freeFilename(dir, name string) string {
+	for n := 2; ; n++ {
+		filename := fmt.Sprintf("%s-%d.md", name, n)
+		if _, err := os.Stat(filepath.Join(dir, filename)); os.IsNotExist(err) {
+			return filename
+		}
+	}
+}
+
 // createBackup creates a backup of an existing file
 func (i *Importer) createBackup(filePath string) error {
+	return BackupFile(filePath)
+}
+
+// BackupFile copies an existing file to a timestamped .backup_ sibling before it is overwritten
+func BackupFile(filePath string) error {
 	timestamp := time.Now().Format("20060102_150405")
 	backupPath := fmt.Sprintf("%s.backup_%s", filePath, timestamp)
 	
@@ -142,27 +211,8 @@ func (i *Importer) validateCommandContent(content string) error {
 
 // checkForSuspiciousContent scans for potentially malicious patterns
 func (i *Importer) checkForSuspiciousContent(content string) error {
-	// List of suspicious patterns to check for
-	suspiciousPatterns := []struct {
-		pattern string
-		message string
-	}{
-		{`(?i)curl.*\|.*sh`, "potential remote code execution"},
-		{`(?i)wget.*\|.*sh`, "potential remote code execution"},
-		{`(?i)rm\s+-rf\s+/`, "dangerous file deletion"},
-		{`(?i)sudo\s+rm`, "privileged file deletion"},
-		{`(?i)format\s+c:`, "potential disk formatting"},
-		{`(?i):\(\)\{.*\}`, "potential fork bomb"},
-	}
-
-	for _, pattern := range suspiciousPatterns {
-		matched, err := regexp.MatchString(pattern.pattern, content)
-		if err != nil {
-			continue // Skip regex errors
-		}
-		if matched {
-			return fmt.Errorf("suspicious content detected: %s", pattern.message)
-		}
+	if warnings := detectSuspiciousContent(content); len(warnings) > 0 {
+		return fmt.Errorf("suspicious content detected: %s", warnings[0])
 	}
 
 	return nil
@@ -225,8 +275,7 @@ func sanitizeFilename(filename string) string {
 // CheckLocalExists checks which remote commands already exist locally
 func (i *Importer) CheckLocalExists(commands []RemoteCommand, localDir string) error {
 	for idx := range commands {
-		safeFilename := sanitizeFilename(commands[idx].Name) + ".md"
-		localPath := filepath.Join(localDir, safeFilename)
+		localPath := LocalPath(localDir, commands[idx].Name)
 		
 		if _, err := os.Stat(localPath); err == nil {
 			commands[idx].LocalExists = true
@@ -238,6 +287,11 @@ func (i *Importer) CheckLocalExists(commands []RemoteCommand, localDir string) e
 	return nil
 }
 
+// LocalPath is the file a command of the given name is imported to in localDir
+func LocalPath(localDir, name string) string {
+	return filepath.Join(localDir, sanitizeFilename(name)+".md")
+}
+
 // GetDefaultImportOptions returns default import options
 func GetDefaultImportOptions(targetDir string) ImportOptions {
 	return ImportOptions{
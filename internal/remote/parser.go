@@ -5,6 +5,8 @@ import (
 	"net/url"
 	"regexp"
 	"strings"
+
+	"github.com/shel-corp/Claude-command-manager/internal/apperr"
 )
 
 // ParseGitHubURL parses various GitHub URL formats and extracts repository information
@@ -23,7 +25,7 @@ func ParseGitHubURL(rawURL string) (*RemoteRepository, error) {
 		// Split by slash to get owner/repo
 		pathComponents := strings.Split(parts, "/")
 		if len(pathComponents) < 2 {
-			return nil, fmt.Errorf("invalid SSH GitHub URL format: missing owner/repo")
+			return nil, apperr.New(apperr.KindValidation, "invalid SSH GitHub URL format: missing owner/repo")
 		}
 		
 		owner = pathComponents[0]
@@ -40,18 +42,18 @@ func ParseGitHubURL(rawURL string) (*RemoteRepository, error) {
 
 		parsedURL, err := url.Parse(rawURL)
 		if err != nil {
-			return nil, fmt.Errorf("invalid URL format: %w", err)
+			return nil, apperr.New(apperr.KindValidation, "invalid URL format: %w", err)
 		}
 
 		// Validate it's a GitHub URL
 		if parsedURL.Host != "github.com" && parsedURL.Host != "www.github.com" {
-			return nil, fmt.Errorf("only GitHub URLs are supported, got: %s", parsedURL.Host)
+			return nil, apperr.New(apperr.KindValidation, "only GitHub URLs are supported, got: %s", parsedURL.Host)
 		}
 
 		// Extract path components
 		pathParts := strings.Split(strings.Trim(parsedURL.Path, "/"), "/")
 		if len(pathParts) < 2 {
-			return nil, fmt.Errorf("invalid GitHub URL: missing owner/repo")
+			return nil, apperr.New(apperr.KindValidation, "invalid GitHub URL: missing owner/repo")
 		}
 
 		owner = pathParts[0]
@@ -86,10 +88,10 @@ func ParseGitHubURL(rawURL string) (*RemoteRepository, error) {
 
 	// Validate owner and repo names (GitHub naming rules)
 	if err := validateGitHubName(owner); err != nil {
-		return nil, fmt.Errorf("invalid owner name '%s': %w", owner, err)
+		return nil, apperr.New(apperr.KindValidation, "invalid owner name '%s': %w", owner, err)
 	}
 	if err := validateGitHubName(repo); err != nil {
-		return nil, fmt.Errorf("invalid repository name '%s': %w", repo, err)
+		return nil, apperr.New(apperr.KindValidation, "invalid repository name '%s': %w", repo, err)
 	}
 
 	return &RemoteRepository{
@@ -141,4 +143,8 @@ func (r *RemoteRepository) BuildGitHubAPIURL(subPath string) string {
 // BuildWebURL creates the web URL for viewing the repository in browser
 func (r *RemoteRepository) BuildWebURL() string {
 	return fmt.Sprintf("https://github.com/%s/%s/tree/%s/%s", r.Owner, r.Repo, r.Branch, r.Path)
-}
\ No newline at end of file
+}
+// FullName returns the "owner/repo" identifier for the repository
+func (r *RemoteRepository) FullName() string {
+	return fmt.Sprintf("%s/%s", r.Owner, r.Repo)
+}
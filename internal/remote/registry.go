@@ -11,12 +11,15 @@ import (
 	"time"
 
 	"gopkg.in/yaml.v3"
+
+	"github.com/shel-corp/Claude-command-manager/internal/logging"
 )
 
 // RepositoryRegistry represents the complete repository registry
 type RepositoryRegistry struct {
 	Version     string                       `yaml:"version"`
 	LastUpdated string                       `yaml:"last_updated"`
+	StatsURL    string                       `yaml:"stats_url,omitempty"` // Optional companion endpoint with download counts
 	Categories  map[string]RepositoryCategory `yaml:"categories"`
 }
 
@@ -39,6 +42,10 @@ type CuratedRepository struct {
 	Language    string   `yaml:"language,omitempty"`
 	Difficulty  string   `yaml:"difficulty,omitempty"`
 	LastChecked string   `yaml:"last_checked,omitempty"`
+
+	// Usage counts, from the registry itself or its stats endpoint
+	Downloads       int `yaml:"downloads,omitempty"`
+	RecentDownloads int `yaml:"recent_downloads,omitempty"`
 	
 	// Runtime fields for UI
 	CategoryKey  string `yaml:"-"`
@@ -89,21 +96,91 @@ func (rm *RegistryManager) LoadRegistry() error {
 	}
 
 	// Cache miss or expired - load from file
+	registry, err := rm.loadRegistryFile()
+	if err != nil {
+		return err
+	}
+
+	// Download counts are optional; the registry is still usable without them
+	if registry.StatsURL != "" {
+		if doc, err := FetchRepositoryStats(registry.StatsURL); err == nil {
+			registry.ApplyStats(doc)
+		}
+	}
+
+	rm.setRegistry(registry)
+	return nil
+}
+
+// LoadCachedRegistry loads the registry without touching the network: the cached copy is
+// used even when expired, falling back to the registry file without download stats
+func (rm *RegistryManager) LoadCachedRegistry() error {
+	if rm.cacheManager != nil && rm.cacheManager.IsEnabled() {
+		if cachedData, cachedAt, _, err := rm.cacheManager.GetRegistryCacheRaw(); err == nil && cachedData != nil {
+			registry := &RepositoryRegistry{}
+			if err := json.Unmarshal(cachedData, registry); err == nil {
+				rm.registry = registry
+				rm.loadedAt = cachedAt
+				rm.buildFlattenedList()
+				return nil
+			}
+		}
+	}
+
+	registry, err := rm.loadRegistryFile()
+	if err != nil {
+		return err
+	}
+	rm.registry = registry
+	rm.loadedAt = time.Now()
+	rm.buildFlattenedList()
+	return nil
+}
+
+// RefreshRegistry reloads the registry file and its stats, bypassing and then replacing the cache
+func (rm *RegistryManager) RefreshRegistry() (*RefreshResult, error) {
+	registry, err := rm.loadRegistryFile()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &RefreshResult{}
+	if registry.StatsURL != "" {
+		if doc, err := FetchRepositoryStats(registry.StatsURL); err != nil {
+			result.StatsError = err
+		} else {
+			registry.ApplyStats(doc)
+		}
+	}
+
+	rm.setRegistry(registry)
+	result.Repositories = len(rm.allRepos)
+	result.WithStats = registry.CountWithStats()
+	return result, nil
+}
+
+// loadRegistryFile reads and parses the bundled registry YAML
+func (rm *RegistryManager) loadRegistryFile() (*RepositoryRegistry, error) {
 	registryPath, err := rm.findRegistryFile()
 	if err != nil {
-		return fmt.Errorf("failed to find registry file: %w", err)
+		return nil, fmt.Errorf("failed to find registry file: %w", err)
 	}
+	logging.Debugf("loading registry from %s", registryPath)
 
 	data, err := os.ReadFile(registryPath)
 	if err != nil {
-		return fmt.Errorf("failed to read registry file: %w", err)
+		return nil, fmt.Errorf("failed to read registry file: %w", err)
 	}
 
 	registry := &RepositoryRegistry{}
 	if err := yaml.Unmarshal(data, registry); err != nil {
-		return fmt.Errorf("failed to parse registry YAML: %w", err)
+		return nil, fmt.Errorf("failed to parse registry YAML: %w", err)
 	}
+	return registry, nil
+}
 
+// setRegistry installs a freshly loaded registry and writes it to the cache
+func (rm *RegistryManager) setRegistry(registry *RepositoryRegistry) {
 	rm.registry = registry
 	rm.loadedAt = time.Now()
 	rm.buildFlattenedList()
@@ -113,11 +190,9 @@ func (rm *RegistryManager) LoadRegistry() error {
 		if err := rm.cacheManager.SetRegistryCache(*registry, ""); err != nil {
 			// Log error but don't fail - caching is optional
 			// In a real implementation, we'd use proper logging
-			fmt.Printf("Warning: failed to cache registry: %v\n", err)
+			logging.Warnf("failed to cache registry: %v", err)
 		}
 	}
-
-	return nil
 }
 
 // buildFlattenedList builds the flattened repository list for searching
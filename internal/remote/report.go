@@ -0,0 +1,66 @@
+package remote
+
+import "strings"
+
+// Per-command import statuses reported by ImportReport
+const (
+	ImportStatusImported = "imported"
+	ImportStatusSkipped  = "skipped"
+	ImportStatusFailed   = "failed"
+)
+
+// ImportReport is the machine-readable form of an ImportResult
+type ImportReport struct {
+	Repository string                `json:"repository"`
+	Target     string                `json:"target"` // Library directory the commands were imported into
+	DryRun     bool                  `json:"dry_run"`
+	Imported   int                   `json:"imported"`
+	Skipped    int                   `json:"skipped"`
+	Failed     int                   `json:"failed"`
+	Commands   []CommandImportStatus `json:"commands"`
+	Error      string                `json:"error,omitempty"` // Why the whole import failed
+}
+
+// CommandImportStatus is the outcome of importing a single command
+type CommandImportStatus struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`          // imported, skipped (already exists) or failed
+	File   string `json:"file,omitempty"`  // Written file, relative to the target directory
+	Error  string `json:"error,omitempty"` // Why the command failed
+
+	// Conflict is how the command was resolved when it already existed: skip, overwrite
+	// or rename (File then holds the new name)
+	Conflict ConflictResolution `json:"conflict,omitempty"`
+}
+
+// NewImportReport converts an import result into a per-command report
+func NewImportReport(repo *RemoteRepository, targetDir string, result *ImportResult, dryRun bool) ImportReport {
+	report := ImportReport{
+		Repository: repo.FullName(),
+		Target:     targetDir,
+		DryRun:     dryRun,
+		Imported:   len(result.Imported),
+		Skipped:    len(result.Skipped),
+		Failed:     len(result.Failed),
+		Commands:   make([]CommandImportStatus, 0, len(result.Imported)+len(result.Skipped)+len(result.Failed)),
+	}
+
+	for i, name := range result.Imported {
+		status := CommandImportStatus{Name: name, Status: ImportStatusImported, Conflict: result.Conflicts[name]}
+		if i < len(result.Files) {
+			status.File = result.Files[i]
+		}
+		report.Commands = append(report.Commands, status)
+	}
+	for _, name := range result.Skipped {
+		report.Commands = append(report.Commands, CommandImportStatus{Name: name, Status: ImportStatusSkipped, Conflict: result.Conflicts[name]})
+	}
+	for i, name := range result.Failed {
+		status := CommandImportStatus{Name: name, Status: ImportStatusFailed, Conflict: result.Conflicts[name]}
+		if i < len(result.Errors) {
+			status.Error = strings.TrimPrefix(result.Errors[i], name+": ")
+		}
+		report.Commands = append(report.Commands, status)
+	}
+	return report
+}
@@ -0,0 +1,154 @@
+package remote
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// popularShare is the fraction of repositories with download counts that earn a popular badge
+const popularShare = 0.25
+
+// RepositoryStats holds usage counts for a single registry repository
+type RepositoryStats struct {
+	Downloads       int `json:"downloads"`        // All-time imports
+	RecentDownloads int `json:"recent_downloads"` // Imports over the last seven days
+}
+
+// StatsDocument is the format served by a registry's companion stats endpoint
+type StatsDocument struct {
+	Updated      string                     `json:"updated"`
+	Repositories map[string]RepositoryStats `json:"repositories"` // Keyed by repository URL
+}
+
+// RefreshResult summarizes a forced registry refresh
+type RefreshResult struct {
+	Repositories int   // Repositories in the refreshed registry
+	WithStats    int   // Repositories that have download counts
+	StatsError   error // Set when the stats endpoint could not be read
+}
+
+// FetchRepositoryStats reads a stats document from an http(s) URL or a local file
+func FetchRepositoryStats(statsURL string) (*StatsDocument, error) {
+	var data []byte
+	var err error
+
+	if strings.HasPrefix(statsURL, "http://") || strings.HasPrefix(statsURL, "https://") {
+		client := &http.Client{Timeout: 10 * time.Second}
+		resp, getErr := client.Get(statsURL)
+		if getErr != nil {
+			return nil, fmt.Errorf("failed to fetch registry stats: %w", getErr)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("failed to fetch registry stats: %s", resp.Status)
+		}
+		data, err = io.ReadAll(resp.Body)
+	} else {
+		data, err = os.ReadFile(strings.TrimPrefix(statsURL, "file://"))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read registry stats: %w", err)
+	}
+
+	doc := &StatsDocument{}
+	if err := json.Unmarshal(data, doc); err != nil {
+		return nil, fmt.Errorf("failed to parse registry stats: %w", err)
+	}
+	return doc, nil
+}
+
+// ApplyStats copies download counts onto the matching registry repositories
+func (r *RepositoryRegistry) ApplyStats(doc *StatsDocument) {
+	if doc == nil || len(doc.Repositories) == 0 {
+		return
+	}
+
+	stats := make(map[string]RepositoryStats, len(doc.Repositories))
+	for url, repoStats := range doc.Repositories {
+		stats[normalizeStatsURL(url)] = repoStats
+	}
+
+	for key, category := range r.Categories {
+		for i, repo := range category.Repositories {
+			if repoStats, ok := stats[normalizeStatsURL(repo.URL)]; ok {
+				category.Repositories[i].Downloads = repoStats.Downloads
+				category.Repositories[i].RecentDownloads = repoStats.RecentDownloads
+			}
+		}
+		r.Categories[key] = category
+	}
+}
+
+// PopularRepositories returns the URLs of the most downloaded repositories
+func PopularRepositories(repos []CuratedRepository) map[string]bool {
+	var counted []CuratedRepository
+	for _, repo := range repos {
+		if repo.Downloads > 0 {
+			counted = append(counted, repo)
+		}
+	}
+	if len(counted) == 0 {
+		return nil
+	}
+
+	sort.SliceStable(counted, func(i, j int) bool {
+		return counted[i].Downloads > counted[j].Downloads
+	})
+
+	limit := int(float64(len(counted))*popularShare + 0.5)
+	if limit < 1 {
+		limit = 1
+	}
+
+	popular := make(map[string]bool, limit)
+	for _, repo := range counted[:limit] {
+		popular[repo.URL] = true
+	}
+	return popular
+}
+
+// TrendingRepositories returns repositories with recent downloads, most active first
+func TrendingRepositories(repos []CuratedRepository, limit int) []CuratedRepository {
+	var trending []CuratedRepository
+	for _, repo := range repos {
+		if repo.RecentDownloads > 0 {
+			trending = append(trending, repo)
+		}
+	}
+
+	sort.SliceStable(trending, func(i, j int) bool {
+		if trending[i].RecentDownloads != trending[j].RecentDownloads {
+			return trending[i].RecentDownloads > trending[j].RecentDownloads
+		}
+		return trending[i].Downloads > trending[j].Downloads
+	})
+
+	if limit > 0 && len(trending) > limit {
+		trending = trending[:limit]
+	}
+	return trending
+}
+
+// CountWithStats returns how many repositories in the registry have download counts
+func (r *RepositoryRegistry) CountWithStats() int {
+	count := 0
+	for _, category := range r.Categories {
+		for _, repo := range category.Repositories {
+			if repo.Downloads > 0 || repo.RecentDownloads > 0 {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+func normalizeStatsURL(url string) string {
+	return strings.ToLower(strings.TrimSuffix(strings.TrimSpace(url), "/"))
+}
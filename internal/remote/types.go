@@ -1,6 +1,11 @@
 package remote
 
-import "time"
+import (
+	"context"
+	"time"
+
+	"github.com/shel-corp/Claude-command-manager/internal/apperr"
+)
 
 // RemoteRepository represents a GitHub repository containing Claude commands
 type RemoteRepository struct {
@@ -18,26 +23,74 @@ type RemoteCommand struct {
 	Name        string `json:"name"`         // Filename without .md extension
 	Path        string `json:"path"`         // Full path in repository
 	Description string `json:"description"`  // From YAML frontmatter
+	Model       string `json:"model"`        // Target model from YAML frontmatter (optional)
 	Content     string `json:"content"`      // Full file content
 	Size        int64  `json:"size"`         // File size in bytes
 	LocalExists bool   `json:"local_exists"` // Whether command exists locally
 	Selected    bool   `json:"selected"`     // For multi-select UI
 }
 
+// ConflictResolution is what an import does with a command that already exists locally
+type ConflictResolution string
+
+const (
+	ConflictSkip      ConflictResolution = "skip"      // Keep the local command and leave the remote one out
+	ConflictOverwrite ConflictResolution = "overwrite" // Replace the local command, backing it up if requested
+	ConflictRename    ConflictResolution = "rename"    // Keep both: import under a free name such as foo-2
+)
+
+// ConflictResolutions lists the resolutions in the order they are offered
+var ConflictResolutions = []ConflictResolution{ConflictSkip, ConflictOverwrite, ConflictRename}
+
 // ImportOptions configures how commands are imported
 type ImportOptions struct {
 	OverwriteExisting bool   `json:"overwrite_existing"`
+	// Resolutions, if set, picks per command name what to do when it already exists;
+	// commands without an entry follow OverwriteExisting
+	Resolutions map[string]ConflictResolution `json:"resolutions,omitempty"`
 	TargetDirectory   string `json:"target_directory"`
 	CreateBackups     bool   `json:"create_backups"`
 	ValidateContent   bool   `json:"validate_content"`
+	DryRun            bool   `json:"dry_run"` // Report what would be imported without writing files
+
+	// Progress, if set, is called before each selected command is imported with the number
+	// already finished, and once more with done == total when the import ends
+	Progress func(done, total int, name string) `json:"-"`
+
+	// Context, if set, cancels the import between commands and any in-flight download.
+	// Commands imported before cancellation stay imported and are listed in the result.
+	Context context.Context `json:"-"`
+}
+
+// ConflictFor returns how the named command is imported if it already exists locally
+func (o ImportOptions) ConflictFor(name string) ConflictResolution {
+	if resolution, ok := o.Resolutions[name]; ok {
+		return resolution
+	}
+	if o.OverwriteExisting {
+		return ConflictOverwrite
+	}
+	return ConflictSkip
 }
 
 // ImportResult contains the results of a command import operation
 type ImportResult struct {
 	Imported  []string `json:"imported"`   // Successfully imported commands
+	Files     []string `json:"files"`      // Filenames written for imported commands, relative to the target directory
 	Skipped   []string `json:"skipped"`    // Skipped due to conflicts
 	Failed    []string `json:"failed"`     // Failed to import
 	Errors    []string `json:"errors"`     // Error messages
+
+	Conflicts map[string]ConflictResolution `json:"conflicts,omitempty"` // How each command that already existed was resolved
+	Renamed   map[string]string             `json:"renamed,omitempty"`   // New names of commands imported alongside an existing one
+}
+
+// recordConflict notes how a command that already existed was resolved
+func (r *ImportResult) recordConflict(name string, resolution ConflictResolution) {
+	if r.Conflicts == nil {
+		r.Conflicts = make(map[string]ConflictResolution)
+	}
+	r.Conflicts[name] = resolution
 }
 
 // GitHubAPIError represents errors from GitHub API calls
@@ -48,4 +101,18 @@ type GitHubAPIError struct {
 
 func (e *GitHubAPIError) Error() string {
 	return e.Message
+}
+
+// Kind classifies the error by its HTTP status so the CLI can report a matching exit code
+func (e *GitHubAPIError) Kind() apperr.Kind {
+	switch {
+	case e.StatusCode == 401 || e.StatusCode == 403:
+		return apperr.KindAuth
+	case e.StatusCode == 404:
+		return apperr.KindNotFound
+	case e.StatusCode == 422:
+		return apperr.KindValidation
+	default:
+		return apperr.KindNetwork
+	}
 }
\ No newline at end of file
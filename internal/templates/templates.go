@@ -0,0 +1,283 @@
+package templates
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/shel-corp/Claude-command-manager/internal/apperr"
+	"github.com/shel-corp/Claude-command-manager/internal/commands"
+)
+
+// Template is a skeleton used to scaffold a new command file
+type Template struct {
+	Name        string // Template identifier used on the command line
+	Description string // Short summary shown when listing templates
+	Content     string // text/template source for the command file
+	Builtin     bool   // Whether the template ships with ccm
+}
+
+// Values holds the fields substituted into a template
+type Values struct {
+	Name         string
+	Description  string
+	ArgumentHint string
+	AllowedTools string
+}
+
+// templateFuncs are available to every template; {{yaml .Description}} quotes a value
+// for the frontmatter where YAML would otherwise misread it
+var templateFuncs = template.FuncMap{
+	"yaml": commands.FrontmatterString,
+}
+
+// DefaultTemplate is used when no template is specified
+const DefaultTemplate = "basic"
+
+// builtinTemplates are always available; user templates with the same name take precedence
+var builtinTemplates = []Template{
+	{
+		Name:        "basic",
+		Description: "Plain prompt with description and argument hint",
+		Content: `---
+description: {{yaml .Description}}
+{{- if .ArgumentHint}}
+argument-hint: {{yaml .ArgumentHint}}
+{{- end}}
+{{- if .AllowedTools}}
+allowed-tools: {{yaml .AllowedTools}}
+{{- end}}
+---
+
+# {{.Name}}
+
+Describe what Claude should do here.
+
+$ARGUMENTS
+`,
+		Builtin: true,
+	},
+	{
+		Name:        "bash",
+		Description: "Prompt that gathers context with inline shell commands",
+		Content: `---
+description: {{yaml .Description}}
+{{- if .ArgumentHint}}
+argument-hint: {{yaml .ArgumentHint}}
+{{- end}}
+allowed-tools: {{if .AllowedTools}}{{yaml .AllowedTools}}{{else}}Bash(git status:*), Bash(git diff:*){{end}}
+---
+
+# {{.Name}}
+
+## Context
+
+- Current status: !` + "`git status`" + `
+- Current diff: !` + "`git diff HEAD`" + `
+
+## Task
+
+Describe what Claude should do with the context above.
+
+$ARGUMENTS
+`,
+		Builtin: true,
+	},
+	{
+		Name:        "with-args",
+		Description: "Prompt taking positional arguments ($1, $2) as well as $ARGUMENTS",
+		Content: `---
+description: {{yaml .Description}}
+argument-hint: {{if .ArgumentHint}}{{yaml .ArgumentHint}}{{else}}"<target> [notes]"{{end}}
+{{- if .AllowedTools}}
+allowed-tools: {{yaml .AllowedTools}}
+{{- end}}
+---
+
+# {{.Name}}
+
+Target: $1
+Notes: $2
+
+Describe what Claude should do with the target. The full argument string is:
+
+$ARGUMENTS
+`,
+		Builtin: true,
+	},
+	{
+		Name:        "agent-invoking",
+		Description: "Prompt that hands the work to a subagent through the Task tool",
+		Content: `---
+description: {{yaml .Description}}
+{{- if .ArgumentHint}}
+argument-hint: {{yaml .ArgumentHint}}
+{{- end}}
+allowed-tools: {{if .AllowedTools}}{{yaml .AllowedTools}}{{else}}Task, Read, Grep, Glob{{end}}
+---
+
+# {{.Name}}
+
+Use the Task tool to launch a subagent for this work, so its exploration stays out of
+the main conversation. Give the subagent:
+
+- The goal: describe what it should accomplish here
+- The input: $ARGUMENTS
+- What to return: a short summary of its findings and any files it changed
+
+When the subagent finishes, report its summary back to me.
+`,
+		Builtin: true,
+	},
+	{
+		Name:        "review",
+		Description: "Code review checklist for a file or change",
+		Content: `---
+description: {{yaml .Description}}
+argument-hint: {{if .ArgumentHint}}{{yaml .ArgumentHint}}{{else}}"[file or path]"{{end}}
+allowed-tools: {{if .AllowedTools}}{{yaml .AllowedTools}}{{else}}Read, Grep, Glob{{end}}
+---
+
+# {{.Name}}
+
+Review $ARGUMENTS and report on:
+
+1. Correctness and edge cases
+2. Error handling
+3. Readability and naming
+4. Test coverage
+`,
+		Builtin: true,
+	},
+}
+
+// GetTemplateDir returns the directory where user-defined templates are stored
+func GetTemplateDir() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(homeDir, ".config", "claude_command_manager", "templates"), nil
+}
+
+// List returns built-in templates merged with user templates, sorted by name
+func List() ([]Template, error) {
+	byName := make(map[string]Template)
+	for _, tmpl := range builtinTemplates {
+		byName[tmpl.Name] = tmpl
+	}
+
+	userTemplates, err := loadUserTemplates()
+	if err != nil {
+		return nil, err
+	}
+	for _, tmpl := range userTemplates {
+		byName[tmpl.Name] = tmpl
+	}
+
+	result := make([]Template, 0, len(byName))
+	for _, tmpl := range byName {
+		result = append(result, tmpl)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+
+	return result, nil
+}
+
+// Get looks up a template by name
+func Get(name string) (Template, error) {
+	all, err := List()
+	if err != nil {
+		return Template{}, err
+	}
+
+	for _, tmpl := range all {
+		if tmpl.Name == name {
+			return tmpl, nil
+		}
+	}
+
+	return Template{}, apperr.New(apperr.KindNotFound, "template not found: %s", name)
+}
+
+// Render executes the template with the given values
+func (t Template) Render(values Values) (string, error) {
+	if values.Description == "" {
+		values.Description = "TODO: describe this command"
+	}
+
+	parsed, err := template.New(t.Name).Funcs(templateFuncs).Parse(t.Content)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse template %s: %w", t.Name, err)
+	}
+
+	var buf bytes.Buffer
+	if err := parsed.Execute(&buf, values); err != nil {
+		return "", fmt.Errorf("failed to render template %s: %w", t.Name, err)
+	}
+
+	// A leading comment leaves a blank line that would push the frontmatter off the first line
+	return strings.TrimLeft(buf.String(), "\r\n"), nil
+}
+
+// loadUserTemplates reads *.md templates from the user template directory
+func loadUserTemplates() ([]Template, error) {
+	dir, err := GetTemplateDir()
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read template directory: %w", err)
+	}
+
+	var result []Template
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".md") {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read template %s: %w", entry.Name(), err)
+		}
+
+		result = append(result, Template{
+			Name:        strings.TrimSuffix(entry.Name(), ".md"),
+			Description: userTemplateDescription(string(data)),
+			Content:     string(data),
+		})
+	}
+
+	return result, nil
+}
+
+// userTemplateDescription reads a user template's summary from a leading template comment,
+// e.g. {{/* Bug triage with reproduction steps */}}, which renders as nothing
+func userTemplateDescription(content string) string {
+	first, _, _ := strings.Cut(strings.TrimLeft(content, " \t\r\n"), "\n")
+	first = strings.TrimSpace(first)
+	for _, trim := range []string{"{{- ", "{{-", "{{"} {
+		if rest, ok := strings.CutPrefix(first, trim); ok {
+			first = rest
+			break
+		}
+	}
+	if comment, ok := strings.CutPrefix(first, "/*"); ok {
+		comment, _, found := strings.Cut(comment, "*/")
+		if found && strings.TrimSpace(comment) != "" {
+			return strings.TrimSpace(comment)
+		}
+	}
+	return "User template"
+}
@@ -0,0 +1,38 @@
+package theme
+
+import (
+	"sync"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// ColorDepths are the values of the theme.colors setting. auto uses the depth the terminal
+// advertises through COLORTERM and TERM and drops color when NO_COLOR is set; the others
+// override the detection, NO_COLOR included.
+var ColorDepths = []string{"auto", "truecolor", "256", "16", "none"}
+
+// detectedColorProfile is the terminal's own color profile, read before any override
+var detectedColorProfile = sync.OnceValue(lipgloss.ColorProfile)
+
+// ColorProfile returns the color profile for a theme.colors value
+func ColorProfile(depth string) termenv.Profile {
+	switch depth {
+	case "truecolor":
+		return termenv.TrueColor
+	case "256":
+		return termenv.ANSI256
+	case "16":
+		return termenv.ANSI
+	case "none":
+		return termenv.Ascii
+	}
+	return detectedColorProfile()
+}
+
+// ApplyColorDepth makes lipgloss render at the color depth a theme.colors value allows.
+// Theme colors beyond that depth are mapped to the nearest color the terminal has, so a
+// 16-color terminal shows the closest ANSI colors instead of unsupported truecolor codes.
+func ApplyColorDepth(depth string) {
+	lipgloss.SetColorProfile(ColorProfile(depth))
+}
@@ -0,0 +1,328 @@
+package theme
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"gopkg.in/yaml.v3"
+
+	"github.com/shel-corp/Claude-command-manager/internal/apperr"
+)
+
+// schemeHexPattern matches the hex colors used by terminal schemes: with or without a
+// leading # and in Alacritty's 0x form
+var schemeHexPattern = regexp.MustCompile(`^(?:#|0[xX])?([0-9a-fA-F]{6}|[0-9a-fA-F]{3})$`)
+
+// ansiSlots are the terminal colors a converted theme is built from, keyed by the names
+// the iTerm2 and Alacritty readers both produce
+type ansiSlots map[string]string
+
+// ConvertScheme builds a theme from a terminal color scheme: a Base16 scheme (YAML), an
+// iTerm2 .itermcolors file or an Alacritty color configuration (TOML or YAML). source is
+// the file name or URL the scheme came from; its extension helps pick the format and its
+// base name names the theme when the scheme does not. Terminal schemes have a single
+// background, so the theme uses the same colors on light and dark terminals.
+func ConvertScheme(source string, data []byte) (Theme, error) {
+	file := path.Base(strings.ReplaceAll(source, "\\", "/"))
+	ext := strings.ToLower(path.Ext(file))
+
+	var t Theme
+	var err error
+	format := "alacritty" // Appended to an ID a built-in theme already uses
+	switch {
+	case ext == ".itermcolors" || bytes.Contains(data, []byte("<plist")):
+		t, err = convertITerm(data)
+		format = "iterm"
+	case ext == ".toml":
+		t, err = convertANSI(parseAlacrittyTOML(data), "Alacritty")
+	default:
+		var doc map[string]any
+		if yaml.Unmarshal(data, &doc) != nil || doc == nil {
+			return Theme{}, unknownSchemeError()
+		}
+		switch {
+		case doc["base00"] != nil || doc["palette"] != nil:
+			t, err = convertBase16(doc)
+			format = "base16"
+		case doc["colors"] != nil:
+			slots := ansiSlots{}
+			flattenScheme(doc["colors"], "", slots)
+			t, err = convertANSI(slots, "Alacritty")
+		default:
+			return Theme{}, unknownSchemeError()
+		}
+	}
+	if err != nil {
+		return Theme{}, err
+	}
+
+	if t.Name == "" {
+		t.Name = strings.TrimSuffix(file, path.Ext(file))
+	}
+	t.ID = schemeThemeID(t.Name)
+	if isBuiltinTheme(t.ID) {
+		t.ID += "-" + format // e.g. dracula-base16
+	}
+	if t.Description == "" {
+		t.Description = "Converted from " + file
+	}
+	if err := t.Validate(); err != nil {
+		return Theme{}, err
+	}
+	return t, nil
+}
+
+func unknownSchemeError() error {
+	return apperr.New(apperr.KindValidation, "unrecognized color scheme (expected Base16 YAML, an iTerm2 .itermcolors file or an Alacritty TOML/YAML config)")
+}
+
+// convertBase16 maps a Base16 scheme onto the theme following the Base16 styling
+// guidelines: base00-base07 run from background to foreground and base08-base0F are
+// accents. Both the classic layout (scheme: and baseXX: at the top level) and the
+// tinted-theming layout (name: and a palette: map) are accepted.
+func convertBase16(doc map[string]any) (Theme, error) {
+	palette := doc
+	if nested, ok := doc["palette"].(map[string]any); ok {
+		palette = nested
+	}
+	colors := make(map[string]string, len(palette))
+	for key, value := range palette {
+		if s, ok := value.(string); ok {
+			colors[strings.ToLower(key)] = s
+		}
+	}
+
+	var t Theme
+	slots := []struct {
+		key  string
+		slot *lipgloss.AdaptiveColor
+	}{
+		{"base00", &t.Background}, {"base01", &t.Surface}, {"base02", &t.Selection},
+		{"base03", &t.Muted}, {"base04", &t.Subtext}, {"base05", &t.Text}, {"base06", &t.SelectionText},
+		{"base08", &t.Danger}, {"base0a", &t.Warning}, {"base0b", &t.Success}, {"base0d", &t.Primary},
+	}
+	for _, s := range slots {
+		hex, ok := schemeHex(colors[s.key])
+		if !ok {
+			return Theme{}, apperr.New(apperr.KindValidation, "Base16 scheme has no valid %s color (got %q)", strings.Replace(s.key, "0a", "0A", 1), colors[s.key])
+		}
+		*s.slot = lipgloss.AdaptiveColor{Light: hex, Dark: hex}
+	}
+	t.Border, t.BorderVariant = t.Muted, t.Selection
+
+	for _, key := range []string{"scheme", "name"} {
+		if name, ok := doc[key].(string); ok && strings.TrimSpace(name) != "" {
+			t.Name = strings.TrimSpace(name)
+			break
+		}
+	}
+	if author, ok := doc["author"].(string); ok && strings.TrimSpace(author) != "" {
+		t.Description = "Base16 scheme by " + strings.TrimSpace(author)
+	}
+	return t, nil
+}
+
+// convertANSI maps a terminal's foreground, background and ANSI colors onto the theme:
+// blue is the primary color, green, red and yellow the status colors and bright black
+// the muted text and borders. The selection colors are used when the scheme has them.
+func convertANSI(colors ansiSlots, format string) (Theme, error) {
+	var t Theme
+	required := []struct {
+		key  string
+		slot *lipgloss.AdaptiveColor
+	}{
+		{"background", &t.Background}, {"foreground", &t.Text}, {"blue", &t.Primary},
+		{"green", &t.Success}, {"red", &t.Danger}, {"yellow", &t.Warning}, {"bright_black", &t.Muted},
+	}
+	for _, s := range required {
+		hex, ok := schemeHex(colors[s.key])
+		if !ok {
+			return Theme{}, apperr.New(apperr.KindValidation, "%s scheme has no valid %s color", format, strings.ReplaceAll(s.key, "_", " "))
+		}
+		*s.slot = lipgloss.AdaptiveColor{Light: hex, Dark: hex}
+	}
+	t.Border = t.Muted
+
+	optional := []struct {
+		key  string
+		slot *lipgloss.AdaptiveColor
+	}{
+		{"selection", &t.Selection}, {"selection_text", &t.SelectionText},
+	}
+	for _, s := range optional {
+		if hex, ok := schemeHex(colors[s.key]); ok {
+			*s.slot = lipgloss.AdaptiveColor{Light: hex, Dark: hex}
+		}
+	}
+	return t, nil
+}
+
+// iTermColorNames maps the .itermcolors entries ccm uses to their ansiSlots names
+var iTermColorNames = map[string]string{
+	"Background Color":    "background",
+	"Foreground Color":    "foreground",
+	"Ansi 1 Color":        "red",
+	"Ansi 2 Color":        "green",
+	"Ansi 3 Color":        "yellow",
+	"Ansi 4 Color":        "blue",
+	"Ansi 8 Color":        "bright_black",
+	"Selection Color":     "selection",
+	"Selected Text Color": "selection_text",
+}
+
+// plistNode is an element of an XML property list, decoded generically
+type plistNode struct {
+	XMLName xml.Name
+	Text    string      `xml:",chardata"`
+	Nodes   []plistNode `xml:",any"`
+}
+
+// convertITerm reads an iTerm2 .itermcolors property list: a dict of color names, each a
+// dict of Red, Green and Blue components between 0 and 1
+func convertITerm(data []byte) (Theme, error) {
+	var root plistNode
+	if err := xml.Unmarshal(data, &root); err != nil {
+		return Theme{}, apperr.New(apperr.KindValidation, "invalid iTerm2 color scheme: %w", err)
+	}
+	if len(root.Nodes) == 0 || root.Nodes[0].XMLName.Local != "dict" {
+		return Theme{}, apperr.New(apperr.KindValidation, "invalid iTerm2 color scheme: no color dictionary")
+	}
+
+	colors := ansiSlots{}
+	for key, value := range plistDict(root.Nodes[0]) {
+		name, ok := iTermColorNames[key]
+		if !ok || value.XMLName.Local != "dict" {
+			continue
+		}
+		var rgb [3]int
+		for i, component := range []string{"Red Component", "Green Component", "Blue Component"} {
+			c, ok := plistDict(value)[component]
+			if !ok {
+				continue
+			}
+			f, err := strconv.ParseFloat(strings.TrimSpace(c.Text), 64)
+			if err != nil {
+				return Theme{}, apperr.New(apperr.KindValidation, "invalid iTerm2 color %s: %w", key, err)
+			}
+			rgb[i] = int(min(max(f, 0), 1)*255 + 0.5)
+		}
+		colors[name] = fmt.Sprintf("#%02X%02X%02X", rgb[0], rgb[1], rgb[2])
+	}
+	return convertANSI(colors, "iTerm2")
+}
+
+// plistDict pairs the <key> elements of a plist <dict> with the values following them
+func plistDict(dict plistNode) map[string]plistNode {
+	entries := make(map[string]plistNode)
+	for i := 0; i+1 < len(dict.Nodes); i += 2 {
+		if dict.Nodes[i].XMLName.Local == "key" {
+			entries[strings.TrimSpace(dict.Nodes[i].Text)] = dict.Nodes[i+1]
+		}
+	}
+	return entries
+}
+
+// parseAlacrittyTOML reads the [colors.*] tables of an Alacritty TOML config. Only the
+// subset Alacritty color schemes use is understood: table headers and quoted string values.
+func parseAlacrittyTOML(data []byte) ansiSlots {
+	values := make(map[string]any)
+	section := ""
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			section = strings.Trim(line, "[] ")
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		if len(value) < 2 || (value[0] != '"' && value[0] != '\'') {
+			continue
+		}
+		if end := strings.IndexByte(value[1:], value[0]); end >= 0 {
+			values[section+"."+strings.TrimSpace(key)] = value[1 : end+1]
+		}
+	}
+
+	colors := make(map[string]any)
+	for key, value := range values {
+		if rest, ok := strings.CutPrefix(key, "colors."); ok {
+			colors[rest] = value
+		}
+	}
+	slots := ansiSlots{}
+	flattenScheme(colors, "", slots)
+	return slots
+}
+
+// flattenScheme collects the colors of an Alacritty colors table into slots: the primary
+// foreground and background, the normal ANSI colors by name, bright black and the
+// selection colors
+func flattenScheme(node any, prefix string, slots ansiSlots) {
+	switch v := node.(type) {
+	case map[string]any:
+		for key, value := range v {
+			flattenScheme(value, prefix+key+".", slots)
+		}
+	case string:
+		key := strings.TrimSuffix(prefix, ".")
+		switch key {
+		case "primary.background":
+			slots["background"] = v
+		case "primary.foreground":
+			slots["foreground"] = v
+		case "normal.red", "normal.green", "normal.yellow", "normal.blue":
+			slots[strings.TrimPrefix(key, "normal.")] = v
+		case "bright.black":
+			slots["bright_black"] = v
+		case "selection.background":
+			slots["selection"] = v
+		case "selection.text":
+			slots["selection_text"] = v
+		}
+	}
+}
+
+// schemeHex normalizes a scheme color to #RRGGBB, reporting false for anything that is
+// not a hex color, such as Alacritty's CellForeground placeholders
+func schemeHex(value string) (string, bool) {
+	match := schemeHexPattern.FindStringSubmatch(strings.TrimSpace(value))
+	if match == nil {
+		return "", false
+	}
+	hex := match[1]
+	if len(hex) == 3 {
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	}
+	return "#" + strings.ToUpper(hex), true
+}
+
+// schemeThemeID derives a theme ID from a scheme name, e.g. "Tomorrow Night" -> tomorrow-night
+func schemeThemeID(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			b.WriteRune(r)
+		case b.Len() > 0 && !strings.HasSuffix(b.String(), "-"):
+			b.WriteByte('-')
+		}
+	}
+	if id := strings.Trim(b.String(), "-"); id != "" {
+		return id
+	}
+	return "converted"
+}
@@ -0,0 +1,316 @@
+package theme
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/charmbracelet/lipgloss"
+	"gopkg.in/yaml.v3"
+
+	"github.com/shel-corp/Claude-command-manager/internal/apperr"
+)
+
+var (
+	themeIDPattern  = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+	hexColorPattern = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+	userThemesMu       sync.Mutex
+	userThemesCache    []Theme
+	userThemesProblems []UserThemeProblem
+	userThemesLoaded   bool
+)
+
+// themeFilePatterns match the theme files read from the user themes directory
+var themeFilePatterns = []string{"*.json", "*.yaml", "*.yml"}
+
+// UserThemeProblem is a file in the user themes directory that was skipped, and why
+type UserThemeProblem struct {
+	File string // Name of the file within the themes directory
+	Err  error
+}
+
+// UserThemesDir returns the directory custom themes are stored in
+func UserThemesDir() string {
+	return filepath.Join(filepath.Dir(DefaultConfigPath()), "themes")
+}
+
+// userThemes returns the custom themes from the user themes directory, loading them once
+func userThemes() []Theme {
+	userThemesMu.Lock()
+	defer userThemesMu.Unlock()
+
+	if !userThemesLoaded {
+		userThemesCache, userThemesProblems = loadUserThemes(UserThemesDir())
+		userThemesLoaded = true
+	}
+	return userThemesCache
+}
+
+// UserThemeProblems returns the theme files that could not be loaded from the user
+// themes directory, in file name order
+func UserThemeProblems() []UserThemeProblem {
+	userThemes()
+	userThemesMu.Lock()
+	defer userThemesMu.Unlock()
+	return userThemesProblems
+}
+
+// themeFiles lists the theme files in dir in file name order
+func themeFiles(dir string) []string {
+	var files []string
+	for _, pattern := range themeFilePatterns {
+		matches, err := filepath.Glob(filepath.Join(dir, pattern))
+		if err != nil {
+			continue
+		}
+		files = append(files, matches...)
+	}
+	sort.Strings(files)
+	return files
+}
+
+// ReloadUserThemes forgets the loaded custom themes so the next lookup rereads the directory
+func ReloadUserThemes() {
+	userThemesMu.Lock()
+	defer userThemesMu.Unlock()
+	userThemesLoaded = false
+}
+
+// UserThemesSignature fingerprints the theme files in the user themes directory;
+// it changes whenever a theme file is added, removed or modified
+func UserThemesSignature() string {
+	var b strings.Builder
+	for _, file := range themeFiles(UserThemesDir()) {
+		info, err := os.Stat(file)
+		if err != nil {
+			continue
+		}
+		fmt.Fprintf(&b, "%s:%d:%d;", filepath.Base(file), info.Size(), info.ModTime().UnixNano())
+	}
+	return b.String()
+}
+
+// loadUserThemes reads every valid theme file in dir. Files that cannot be read or
+// parsed, and themes whose ID is built in or already taken by an earlier file, are
+// skipped and reported as problems.
+func loadUserThemes(dir string) ([]Theme, []UserThemeProblem) {
+	var themes []Theme
+	var problems []UserThemeProblem
+	seen := make(map[string]string) // Theme ID -> file that defined it
+	for _, file := range themeFiles(dir) {
+		name := filepath.Base(file)
+		data, err := os.ReadFile(file)
+		if err != nil {
+			problems = append(problems, UserThemeProblem{File: name, Err: err})
+			continue
+		}
+		t, err := ParseTheme(data)
+		switch {
+		case err != nil:
+			problems = append(problems, UserThemeProblem{File: name, Err: err})
+			continue
+		case isBuiltinTheme(t.ID):
+			problems = append(problems, UserThemeProblem{File: name, Err: fmt.Errorf("theme id %q is reserved by a built-in theme", t.ID)})
+			continue
+		case seen[t.ID] != "":
+			problems = append(problems, UserThemeProblem{File: name, Err: fmt.Errorf("theme id %q is already defined by %s", t.ID, seen[t.ID])})
+			continue
+		}
+		seen[t.ID] = name
+		t.Custom = true
+		themes = append(themes, t)
+	}
+	return themes, problems
+}
+
+// ParseTheme decodes and validates a theme definition written as JSON or YAML. Both
+// use the same keys, e.g. primary: {light: "#0EA5E9", dark: "#38BDF8"}.
+func ParseTheme(data []byte) (Theme, error) {
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] != '{' {
+		// Re-encode YAML as JSON so both formats are read by the same field tags
+		var doc map[string]any
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return Theme{}, apperr.New(apperr.KindValidation, "invalid theme file: %w", err)
+		}
+		converted, err := json.Marshal(doc)
+		if err != nil {
+			return Theme{}, apperr.New(apperr.KindValidation, "invalid theme file: %w", err)
+		}
+		data = converted
+	}
+
+	var t Theme
+	if err := json.Unmarshal(data, &t); err != nil {
+		return Theme{}, apperr.New(apperr.KindValidation, "invalid theme file: %w", err)
+	}
+	if err := t.Validate(); err != nil {
+		return Theme{}, err
+	}
+	return t, nil
+}
+
+// Validate checks that a theme has an ID, a name and valid hex colors in every required slot
+func (t Theme) Validate() error {
+	if !themeIDPattern.MatchString(t.ID) {
+		return apperr.New(apperr.KindValidation, "invalid theme id %q (use lowercase letters, digits and dashes)", t.ID)
+	}
+	if strings.TrimSpace(t.Name) == "" {
+		return apperr.New(apperr.KindValidation, "theme %s has no name", t.ID)
+	}
+
+	required := []struct {
+		name  string
+		color lipgloss.AdaptiveColor
+	}{
+		{"primary", t.Primary}, {"success", t.Success}, {"danger", t.Danger}, {"warning", t.Warning},
+		{"muted", t.Muted}, {"background", t.Background}, {"text", t.Text}, {"border", t.Border},
+	}
+	for _, slot := range required {
+		if err := validateColor(slot.name, slot.color, true); err != nil {
+			return err
+		}
+	}
+
+	optional := []struct {
+		name  string
+		color lipgloss.AdaptiveColor
+	}{
+		{"surface", t.Surface}, {"selection", t.Selection}, {"selection_text", t.SelectionText},
+		{"subtext", t.Subtext}, {"border_variant", t.BorderVariant},
+	}
+	for _, slot := range optional {
+		if err := validateColor(slot.name, slot.color, false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateColor(name string, color lipgloss.AdaptiveColor, required bool) error {
+	if !required && color.Light == "" && color.Dark == "" {
+		return nil
+	}
+	for variant, value := range map[string]string{"Light": color.Light, "Dark": color.Dark} {
+		if !hexColorPattern.MatchString(value) {
+			return apperr.New(apperr.KindValidation, "theme color %s.%s must be a hex color like #1E293B, got %q", name, variant, value)
+		}
+	}
+	return nil
+}
+
+// ThemeFormats are the formats a theme can be exported in
+var ThemeFormats = []string{"json", "yaml"}
+
+// ExportTheme returns the definition of a theme as JSON or YAML, ready to be shared
+func ExportTheme(id, format string) ([]byte, error) {
+	t, ok := FindTheme(id)
+	if !ok {
+		return nil, apperr.New(apperr.KindNotFound, "unknown theme %q", id)
+	}
+	switch format {
+	case "json":
+		data, err := json.MarshalIndent(t, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return append(data, '\n'), nil
+	case "yaml":
+		return themeYAML(t)
+	}
+	return nil, apperr.New(apperr.KindValidation, "unknown theme format %q (use %s)", format, strings.Join(ThemeFormats, " or "))
+}
+
+// themeYAML encodes a theme as YAML with the keys of its JSON form, in the same order.
+// Colors are written inline as {light: ..., dark: ...} and empty optional slots are left out.
+func themeYAML(t Theme) ([]byte, error) {
+	data, err := json.Marshal(t)
+	if err != nil {
+		return nil, err
+	}
+	// JSON is valid YAML, so decoding it keeps the key order of the struct
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	root := doc.Content[0]
+	root.Style = 0
+	var content []*yaml.Node
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		key, value := root.Content[i], root.Content[i+1]
+		key.Style = 0
+		if value.Kind == yaml.MappingNode {
+			empty := true
+			for j := 0; j+1 < len(value.Content); j += 2 {
+				value.Content[j].Value = strings.ToLower(value.Content[j].Value)
+				value.Content[j].Style = 0
+				empty = empty && value.Content[j+1].Value == ""
+			}
+			if empty {
+				continue
+			}
+		} else if value.Tag == "!!str" {
+			value.Style = 0
+		}
+		content = append(content, key, value)
+	}
+	root.Content = content
+
+	var b bytes.Buffer
+	encoder := yaml.NewEncoder(&b)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(root); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// ImportTheme validates a theme definition and stores it in the user themes directory,
+// replacing an earlier import with the same ID
+func ImportTheme(data []byte) (Theme, error) {
+	t, err := ParseTheme(data)
+	if err != nil {
+		return Theme{}, err
+	}
+	return SaveTheme(t)
+}
+
+// SaveTheme stores a validated theme in the user themes directory as JSON, replacing an
+// earlier theme with the same ID
+func SaveTheme(t Theme) (Theme, error) {
+	if isBuiltinTheme(t.ID) {
+		return Theme{}, apperr.New(apperr.KindConflict, "theme id %q is reserved by a built-in theme", t.ID)
+	}
+
+	dir := UserThemesDir()
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return Theme{}, fmt.Errorf("failed to create themes directory: %w", err)
+	}
+
+	normalized, err := json.MarshalIndent(t, "", "  ")
+	if err != nil {
+		return Theme{}, err
+	}
+	if err := os.WriteFile(filepath.Join(dir, t.ID+".json"), append(normalized, '\n'), 0644); err != nil {
+		return Theme{}, fmt.Errorf("failed to save theme: %w", err)
+	}
+
+	ReloadUserThemes()
+	t.Custom = true
+	return t, nil
+}
+
+func isBuiltinTheme(id string) bool {
+	for _, t := range BuiltinThemes() {
+		if t.ID == id {
+			return true
+		}
+	}
+	return false
+}
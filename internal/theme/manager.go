@@ -8,20 +8,45 @@ import (
 	"sync"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/shel-corp/Claude-command-manager/internal/cache"
 )
 
 // AppConfig represents the main application configuration
 type AppConfig struct {
-	Theme ThemeSettings `json:"theme"`
-	// Future: Other settings can be added here
-	// UI      UISettings      `json:"ui"`
-	// Cache   CacheSettings   `json:"cache"`
+	Theme     ThemeSettings     `json:"theme"`
+	Views     ViewSettings      `json:"views"`
+	Cache     cache.CacheConfig `json:"cache"`
+	Import    ImportSettings    `json:"import"`
+	Library   LibrarySettings   `json:"library"`
+	Confirm   ConfirmSettings   `json:"confirm"`
+	Status    StatusSettings    `json:"status"`
+	Report    ReportSettings    `json:"report"`
+	Developer DeveloperSettings `json:"developer"`
 }
 
 // ThemeSettings represents theme-related configuration
 type ThemeSettings struct {
-	CurrentTheme string `json:"current_theme"`
-	AutoDetect   bool   `json:"auto_detect"` // Auto-detect light/dark based on terminal
+	CurrentTheme string            `json:"current_theme"`
+	AutoDetect   bool              `json:"auto_detect"`        // Auto-detect light/dark based on terminal
+	Profiles     map[string]string `json:"profiles,omitempty"` // Terminal profile, program or "tmux" -> theme ID
+	Plain        bool              `json:"plain,omitempty"`    // ASCII-only TUI for screen readers and limited fonts
+	Colors       string            `json:"colors,omitempty"`   // Color depth: auto, truecolor, 256, 16 or none
+}
+
+// LibraryView is a named combination of library filters, sort order and grouping
+type LibraryView struct {
+	Name         string `json:"name"`
+	ModelFilter  string `json:"model_filter,omitempty"`
+	StatusFilter string `json:"status_filter,omitempty"` // "", "enabled" or "disabled"
+	TagFilter    string `json:"tag_filter,omitempty"`
+	Sort         string `json:"sort,omitempty"`
+	Group        string `json:"group,omitempty"`
+}
+
+// ViewSettings holds saved library views and the default view per library mode
+type ViewSettings struct {
+	Saved    []LibraryView     `json:"saved"`
+	Defaults map[string]string `json:"defaults,omitempty"` // Library mode ("project"/"user") -> view name
 }
 
 // Settings is an alias for ThemeSettings to maintain backward compatibility
@@ -35,6 +60,7 @@ type Manager struct {
 	configPath   string
 	styles       *Styles // Cached theme-aware styles
 	appConfig    *AppConfig // Full app configuration
+	terminal     TerminalProfile // Terminal detected at startup, used to pick profile bindings
 }
 
 // Styles holds all theme-aware style functions
@@ -60,6 +86,14 @@ type Styles struct {
 	TextCol     lipgloss.AdaptiveColor
 	BorderCol   lipgloss.AdaptiveColor
 
+	// Surface and list colors
+	SurfaceCol       lipgloss.AdaptiveColor
+	SelectionCol     lipgloss.AdaptiveColor
+	SelectionTextCol lipgloss.AdaptiveColor
+	SubtextCol       lipgloss.AdaptiveColor
+	BorderVariantCol lipgloss.AdaptiveColor
+	BoldText         bool // Body and secondary text are bold as well as headings
+
 	// Lipgloss styles (for direct use)
 	BaseStyle        lipgloss.Style
 	HeaderStyle      lipgloss.Style
@@ -80,15 +114,15 @@ func NewManager(configPath string) *Manager {
 		AutoDetect:   true,
 	}
 
-	appConfig := &AppConfig{
-		Theme: settings,
-	}
+	appConfig := DefaultAppConfig()
+	appConfig.Theme = settings
 
 	manager := &Manager{
 		currentTheme: DefaultTheme,
 		settings:     settings,
 		configPath:   configPath,
 		appConfig:    appConfig,
+		terminal:     DetectTerminalProfile(),
 	}
 
 	// Generate initial styles
@@ -119,10 +153,12 @@ func (m *Manager) Load() error {
 	}
 
 	// Try to load as new unified config format first
-	var appConfig AppConfig
-	if err := json.Unmarshal(data, &appConfig); err == nil && appConfig.Theme.CurrentTheme != "" {
+	// Sections missing from older files keep their defaults
+	appConfig := DefaultAppConfig()
+	appConfig.Theme = ThemeSettings{}
+	if err := json.Unmarshal(data, appConfig); err == nil && appConfig.Theme.CurrentTheme != "" {
 		// Successfully loaded unified config
-		m.appConfig = &appConfig
+		m.appConfig = appConfig
 		m.settings = appConfig.Theme
 	} else {
 		// Fallback: try to load as legacy theme-only config
@@ -132,11 +168,12 @@ func (m *Manager) Load() error {
 		}
 		// Migrate legacy config to unified format
 		m.settings = legacySettings
-		m.appConfig = &AppConfig{Theme: legacySettings}
+		m.appConfig = DefaultAppConfig()
+		m.appConfig.Theme = legacySettings
 	}
 
-	// Apply the loaded theme
-	return m.applyTheme(m.settings.CurrentTheme)
+	// Apply the loaded theme, letting a binding for this terminal take precedence
+	return m.applyTheme(m.resolveThemeID())
 }
 
 // Save writes theme settings to disk
@@ -155,10 +192,9 @@ func (m *Manager) save() error {
 
 	// Update app config with current settings
 	if m.appConfig == nil {
-		m.appConfig = &AppConfig{Theme: m.settings}
-	} else {
-		m.appConfig.Theme = m.settings
+		m.appConfig = DefaultAppConfig()
 	}
+	m.appConfig.Theme = m.settings
 
 	data, err := json.MarshalIndent(m.appConfig, "", "  ")
 	if err != nil {
@@ -172,7 +208,8 @@ func (m *Manager) save() error {
 	return nil
 }
 
-// SetTheme changes the current theme and persists the change
+// SetTheme changes the current theme and persists the change. When a terminal profile
+// binding is active, the binding is updated so the choice sticks in this terminal.
 func (m *Manager) SetTheme(themeID string) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -181,10 +218,21 @@ func (m *Manager) SetTheme(themeID string) error {
 		return err
 	}
 
-	m.settings.CurrentTheme = themeID
+	if key, _, ok := m.profileBinding(); ok {
+		m.settings.Profiles[key] = themeID
+	} else {
+		m.settings.CurrentTheme = themeID
+	}
 	return m.save()
 }
 
+// ReapplyTheme looks up the active theme again, picking up edits to its file on disk
+func (m *Manager) ReapplyTheme() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.applyTheme(m.currentTheme.ID)
+}
+
 // GetCurrentTheme returns the currently active theme
 func (m *Manager) GetCurrentTheme() Theme {
 	m.mu.RLock()
@@ -216,7 +264,7 @@ func (m *Manager) applyTheme(themeID string) error {
 
 // generateStyles creates theme-aware style functions and colors
 func (m *Manager) generateStyles() {
-	theme := m.currentTheme
+	theme := m.currentTheme.WithDefaults()
 
 	// Extract adaptive colors for direct use
 	primary := theme.Primary
@@ -229,14 +277,14 @@ func (m *Manager) generateStyles() {
 	border := theme.Border
 
 	// Create lipgloss styles
-	baseStyle := lipgloss.NewStyle().Foreground(text)
+	baseStyle := lipgloss.NewStyle().Foreground(text).Bold(theme.Bold)
 	headerStyle := lipgloss.NewStyle().Foreground(primary).Bold(true).Padding(0, 1)
-	footerStyle := lipgloss.NewStyle().Foreground(muted).Italic(true).Padding(1, 0, 0, 0)
+	footerStyle := lipgloss.NewStyle().Foreground(muted).Bold(theme.Bold).Italic(true).Padding(1, 0, 0, 0)
 	highlightStyle := lipgloss.NewStyle().Foreground(primary).Bold(true)
 	successStyle := lipgloss.NewStyle().Foreground(success).Bold(true)
 	dangerStyle := lipgloss.NewStyle().Foreground(danger).Bold(true)
 	warningStyle := lipgloss.NewStyle().Foreground(warning).Bold(true)
-	subtleStyle := lipgloss.NewStyle().Foreground(muted)
+	subtleStyle := lipgloss.NewStyle().Foreground(muted).Bold(theme.Bold)
 	keyStyle := lipgloss.NewStyle().Foreground(primary).Bold(true).Width(12).Align(lipgloss.Right)
 
 	// Create style functions
@@ -262,6 +310,13 @@ func (m *Manager) generateStyles() {
 		TextCol:       text,
 		BorderCol:     border,
 
+		SurfaceCol:       theme.Surface,
+		SelectionCol:     theme.Selection,
+		SelectionTextCol: theme.SelectionText,
+		SubtextCol:       theme.Subtext,
+		BorderVariantCol: theme.BorderVariant,
+		BoldText:         theme.Bold,
+
 		// Lipgloss styles for direct use
 		BaseStyle:      baseStyle,
 		HeaderStyle:    headerStyle,
@@ -303,4 +358,83 @@ func (m *Manager) GetCurrentPreview() ThemePreview {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 	return m.currentTheme.GeneratePreview()
-}
\ No newline at end of file
+}
+// GetViews returns the saved library views
+func (m *Manager) GetViews() ViewSettings {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if m.appConfig == nil {
+		return ViewSettings{}
+	}
+	return m.appConfig.Views
+}
+
+// SaveView stores a library view, replacing any existing view with the same name, and persists the change
+func (m *Manager) SaveView(view LibraryView) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if view.Name == "" {
+		return fmt.Errorf("view name cannot be empty")
+	}
+	if m.appConfig == nil {
+		m.appConfig = DefaultAppConfig()
+		m.appConfig.Theme = m.settings
+	}
+
+	views := &m.appConfig.Views
+	for i, existing := range views.Saved {
+		if existing.Name == view.Name {
+			views.Saved[i] = view
+			return m.save()
+		}
+	}
+	views.Saved = append(views.Saved, view)
+	return m.save()
+}
+
+// DeleteView removes a saved library view and any defaults pointing at it, and persists the change
+func (m *Manager) DeleteView(name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.appConfig == nil {
+		return nil
+	}
+
+	views := &m.appConfig.Views
+	for i, existing := range views.Saved {
+		if existing.Name == name {
+			views.Saved = append(views.Saved[:i], views.Saved[i+1:]...)
+			break
+		}
+	}
+	for mode, viewName := range views.Defaults {
+		if viewName == name {
+			delete(views.Defaults, mode)
+		}
+	}
+	return m.save()
+}
+
+// SetDefaultView sets (or clears, with an empty name) the view applied when opening a library mode
+func (m *Manager) SetDefaultView(mode, name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.appConfig == nil {
+		m.appConfig = DefaultAppConfig()
+		m.appConfig.Theme = m.settings
+	}
+
+	views := &m.appConfig.Views
+	if name == "" {
+		delete(views.Defaults, mode)
+		return m.save()
+	}
+	if views.Defaults == nil {
+		views.Defaults = make(map[string]string)
+	}
+	views.Defaults[mode] = name
+	return m.save()
+}
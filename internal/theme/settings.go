@@ -0,0 +1,444 @@
+package theme
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/shel-corp/Claude-command-manager/internal/apperr"
+	"github.com/shel-corp/Claude-command-manager/internal/cache"
+)
+
+// ImportSettings controls defaults for importing commands from remote repositories
+type ImportSettings struct {
+	DefaultTarget string `json:"default_target"` // "user" or "project" library
+	CreateBackups bool   `json:"create_backups"` // Back up files before overwriting them
+}
+
+// LibrarySettings controls defaults for newly discovered commands
+type LibrarySettings struct {
+	DefaultSymlinkLocation string            `json:"default_symlink_location"` // "user", "project" or a target name
+	Targets                map[string]string `json:"targets,omitempty"`        // Custom symlink target name -> directory
+	Sort                   string            `json:"sort,omitempty"`           // Library sort order, one of LibrarySortOrders
+	SessionSummary         bool              `json:"session_summary"`          // List the session's changes when the TUI quits
+	Keymap                 string            `json:"keymap,omitempty"`         // TUI key binding profile, one of LibraryKeymaps
+	DetailPanel            bool              `json:"detail_panel"`             // Show the highlighted command beside the list on wide terminals
+	UsageStats             bool              `json:"usage_stats"`              // Count invocations in Claude's session transcripts (opt-in)
+}
+
+// LibrarySortOrders are the sort orders of the TUI library list
+var LibrarySortOrders = []string{"name", "status", "model", "modified", "location"}
+
+// LibraryKeymaps are the bundled key binding profiles of the TUI
+var LibraryKeymaps = []string{"default", "vim"}
+
+// ConfirmSettings controls which destructive actions ask for confirmation
+type ConfirmSettings struct {
+	Delete    bool `json:"delete"`
+	Overwrite bool `json:"overwrite"`
+}
+
+// StatusSettings controls how long TUI status messages stay on screen, in seconds per
+// message type; 0 keeps messages of that type until they are replaced
+type StatusSettings struct {
+	InfoSeconds    int `json:"info_seconds"`
+	SuccessSeconds int `json:"success_seconds"`
+	WarningSeconds int `json:"warning_seconds"`
+	ErrorSeconds   int `json:"error_seconds"`
+}
+
+// ReportSettings controls where issue reports are filed
+type ReportSettings struct {
+	Repository string `json:"repository,omitempty"` // "owner/repo"; empty for the official ccm repository
+}
+
+// DeveloperSettings holds options for theme authors and ccm developers
+type DeveloperSettings struct {
+	ThemeHotReload bool `json:"theme_hot_reload"` // Re-apply custom themes in the TUI when their files change
+}
+
+// ConfigKey is a single setting exposed through `ccm config get/set`
+type ConfigKey struct {
+	Key         string
+	Description string
+	get         func(*AppConfig) string
+	set         func(*AppConfig, string) error
+}
+
+// DefaultConfigPath returns the location of the unified application settings file
+func DefaultConfigPath() string {
+	homeDir, _ := os.UserHomeDir()
+	return filepath.Join(homeDir, ".config", "claude_command_manager", "config.json")
+}
+
+// DefaultAppConfig returns the application settings used when none are saved
+func DefaultAppConfig() *AppConfig {
+	return &AppConfig{
+		Theme: ThemeSettings{
+			CurrentTheme: DefaultTheme.ID,
+			AutoDetect:   true,
+		},
+		Cache: cache.DefaultCacheConfig(),
+		Import: ImportSettings{
+			DefaultTarget: "user",
+			CreateBackups: true,
+		},
+		Library: LibrarySettings{
+			DefaultSymlinkLocation: "user",
+			SessionSummary:         true,
+			DetailPanel:            true,
+		},
+		Confirm: ConfirmSettings{
+			Delete:    true,
+			Overwrite: true,
+		},
+		Status: StatusSettings{
+			InfoSeconds:    4,
+			SuccessSeconds: 4,
+			WarningSeconds: 8,
+			ErrorSeconds:   12,
+		},
+	}
+}
+
+// configKeys lists every setting in display order
+var configKeys = []ConfigKey{
+	{
+		Key:         "theme.current",
+		Description: "Active color theme (" + strings.Join(themeIDs(), ", ") + ")",
+		get:         func(c *AppConfig) string { return c.Theme.CurrentTheme },
+		set: func(c *AppConfig, v string) error {
+			if _, ok := FindTheme(v); ok {
+				c.Theme.CurrentTheme = v
+				return nil
+			}
+			return apperr.New(apperr.KindValidation, "unknown theme %q (available: %s)", v, strings.Join(themeIDs(), ", "))
+		},
+	},
+	{
+		Key:         "theme.auto_detect",
+		Description: "Detect light/dark terminal background",
+		get:         func(c *AppConfig) string { return strconv.FormatBool(c.Theme.AutoDetect) },
+		set:         func(c *AppConfig, v string) error { return parseBool(v, &c.Theme.AutoDetect) },
+	},
+	{
+		Key:         "theme.plain",
+		Description: "Draw the TUI with plain ASCII: no emoji, box drawing or ASCII art (also --plain or NO_EMOJI)",
+		get:         func(c *AppConfig) string { return strconv.FormatBool(c.Theme.Plain) },
+		set:         func(c *AppConfig, v string) error { return parseBool(v, &c.Theme.Plain) },
+	},
+	{
+		Key:         "theme.colors",
+		Description: "Color depth (" + strings.Join(ColorDepths, ", ") + "); auto follows the terminal and NO_COLOR",
+		get: func(c *AppConfig) string {
+			if c.Theme.Colors == "" {
+				return ColorDepths[0]
+			}
+			return c.Theme.Colors
+		},
+		set: func(c *AppConfig, v string) error {
+			v = strings.ToLower(v)
+			for _, depth := range ColorDepths {
+				if depth == v {
+					c.Theme.Colors = v
+					return nil
+				}
+			}
+			return apperr.New(apperr.KindValidation, "expected one of %s, got %q", strings.Join(ColorDepths, ", "), v)
+		},
+	},
+	{
+		Key:         "cache.enabled",
+		Description: "Cache repository data locally",
+		get:         func(c *AppConfig) string { return strconv.FormatBool(c.Cache.Enabled) },
+		set:         func(c *AppConfig, v string) error { return parseBool(v, &c.Cache.Enabled) },
+	},
+	{
+		Key:         "cache.directory",
+		Description: "Cache directory (empty for the default)",
+		get:         func(c *AppConfig) string { return c.Cache.Directory },
+		set: func(c *AppConfig, v string) error {
+			c.Cache.Directory = v
+			return nil
+		},
+	},
+	{
+		Key:         "cache.ttl_hours",
+		Description: "Hours before cached data is refreshed",
+		get:         func(c *AppConfig) string { return strconv.Itoa(c.Cache.TTLHours) },
+		set:         func(c *AppConfig, v string) error { return parsePositiveInt(v, &c.Cache.TTLHours) },
+	},
+	{
+		Key:         "cache.max_size_mb",
+		Description: "Maximum cache size in megabytes",
+		get:         func(c *AppConfig) string { return strconv.Itoa(c.Cache.MaxSizeMB) },
+		set:         func(c *AppConfig, v string) error { return parsePositiveInt(v, &c.Cache.MaxSizeMB) },
+	},
+	{
+		Key:         "cache.background_refresh",
+		Description: "Refresh cached data in the background",
+		get:         func(c *AppConfig) string { return strconv.FormatBool(c.Cache.BackgroundRefresh) },
+		set:         func(c *AppConfig, v string) error { return parseBool(v, &c.Cache.BackgroundRefresh) },
+	},
+	{
+		Key:         "cache.concurrent_workers",
+		Description: "Parallel workers used for cache refreshes",
+		get:         func(c *AppConfig) string { return strconv.Itoa(c.Cache.ConcurrentWorkers) },
+		set:         func(c *AppConfig, v string) error { return parsePositiveInt(v, &c.Cache.ConcurrentWorkers) },
+	},
+	{
+		Key:         "import.default_target",
+		Description: "Library remote imports go to (user, project)",
+		get:         func(c *AppConfig) string { return c.Import.DefaultTarget },
+		set:         func(c *AppConfig, v string) error { return parseLibrary(v, &c.Import.DefaultTarget) },
+	},
+	{
+		Key:         "import.create_backups",
+		Description: "Back up existing files before an import overwrites them",
+		get:         func(c *AppConfig) string { return strconv.FormatBool(c.Import.CreateBackups) },
+		set:         func(c *AppConfig, v string) error { return parseBool(v, &c.Import.CreateBackups) },
+	},
+	{
+		Key:         "library.default_symlink_location",
+		Description: "Where new commands are symlinked (user, project or a target)",
+		get:         func(c *AppConfig) string { return c.Library.DefaultSymlinkLocation },
+		set: func(c *AppConfig, v string) error {
+			return parseSymlinkLocation(c, v, &c.Library.DefaultSymlinkLocation)
+		},
+	},
+	{
+		Key:         "library.sort",
+		Description: "Library sort order (" + strings.Join(LibrarySortOrders, ", ") + ")",
+		get: func(c *AppConfig) string {
+			if c.Library.Sort == "" {
+				return LibrarySortOrders[0]
+			}
+			return c.Library.Sort
+		},
+		set: func(c *AppConfig, v string) error {
+			v = strings.ToLower(v)
+			for _, order := range LibrarySortOrders {
+				if order == v {
+					c.Library.Sort = v
+					return nil
+				}
+			}
+			return apperr.New(apperr.KindValidation, "expected one of %s, got %q", strings.Join(LibrarySortOrders, ", "), v)
+		},
+	},
+	{
+		Key:         "library.keymap",
+		Description: "TUI key binding profile (" + strings.Join(LibraryKeymaps, ", ") + ")",
+		get: func(c *AppConfig) string {
+			if c.Library.Keymap == "" {
+				return LibraryKeymaps[0]
+			}
+			return c.Library.Keymap
+		},
+		set: func(c *AppConfig, v string) error {
+			v = strings.ToLower(v)
+			for _, profile := range LibraryKeymaps {
+				if profile == v {
+					c.Library.Keymap = v
+					return nil
+				}
+			}
+			return apperr.New(apperr.KindValidation, "expected one of %s, got %q", strings.Join(LibraryKeymaps, ", "), v)
+		},
+	},
+	{
+		Key:         "library.detail_panel",
+		Description: "Show the highlighted command beside the library list on wide terminals",
+		get:         func(c *AppConfig) string { return strconv.FormatBool(c.Library.DetailPanel) },
+		set:         func(c *AppConfig, v string) error { return parseBool(v, &c.Library.DetailPanel) },
+	},
+	{
+		Key:         "library.usage_stats",
+		Description: "Count how often commands are used from Claude's session transcripts in ~/.claude/projects",
+		get:         func(c *AppConfig) string { return strconv.FormatBool(c.Library.UsageStats) },
+		set:         func(c *AppConfig, v string) error { return parseBool(v, &c.Library.UsageStats) },
+	},
+	{
+		Key:         "library.session_summary",
+		Description: "List the commands changed this session when quitting the TUI",
+		get:         func(c *AppConfig) string { return strconv.FormatBool(c.Library.SessionSummary) },
+		set:         func(c *AppConfig, v string) error { return parseBool(v, &c.Library.SessionSummary) },
+	},
+	{
+		Key:         "confirm.delete",
+		Description: "Ask before deleting a command",
+		get:         func(c *AppConfig) string { return strconv.FormatBool(c.Confirm.Delete) },
+		set:         func(c *AppConfig, v string) error { return parseBool(v, &c.Confirm.Delete) },
+	},
+	{
+		Key:         "confirm.overwrite",
+		Description: "Ask before an import overwrites existing commands",
+		get:         func(c *AppConfig) string { return strconv.FormatBool(c.Confirm.Overwrite) },
+		set:         func(c *AppConfig, v string) error { return parseBool(v, &c.Confirm.Overwrite) },
+	},
+	{
+		Key:         "report.repository",
+		Description: "GitHub repository issue reports are filed against, as owner/repo (empty for the official ccm repository)",
+		get:         func(c *AppConfig) string { return c.Report.Repository },
+		set:         func(c *AppConfig, v string) error { return parseRepository(v, &c.Report.Repository) },
+	},
+	{
+		Key:         "status.info_seconds",
+		Description: "Seconds info messages stay in the TUI (0 keeps them)",
+		get:         func(c *AppConfig) string { return strconv.Itoa(c.Status.InfoSeconds) },
+		set:         func(c *AppConfig, v string) error { return parseNonNegativeInt(v, &c.Status.InfoSeconds) },
+	},
+	{
+		Key:         "status.success_seconds",
+		Description: "Seconds success messages stay in the TUI (0 keeps them)",
+		get:         func(c *AppConfig) string { return strconv.Itoa(c.Status.SuccessSeconds) },
+		set:         func(c *AppConfig, v string) error { return parseNonNegativeInt(v, &c.Status.SuccessSeconds) },
+	},
+	{
+		Key:         "status.warning_seconds",
+		Description: "Seconds warnings stay in the TUI (0 keeps them)",
+		get:         func(c *AppConfig) string { return strconv.Itoa(c.Status.WarningSeconds) },
+		set:         func(c *AppConfig, v string) error { return parseNonNegativeInt(v, &c.Status.WarningSeconds) },
+	},
+	{
+		Key:         "status.error_seconds",
+		Description: "Seconds errors stay in the TUI (0 keeps them)",
+		get:         func(c *AppConfig) string { return strconv.Itoa(c.Status.ErrorSeconds) },
+		set:         func(c *AppConfig, v string) error { return parseNonNegativeInt(v, &c.Status.ErrorSeconds) },
+	},
+	{
+		Key:         "developer.theme_hot_reload",
+		Description: "Re-apply custom themes in the TUI when their files change",
+		get:         func(c *AppConfig) string { return strconv.FormatBool(c.Developer.ThemeHotReload) },
+		set:         func(c *AppConfig, v string) error { return parseBool(v, &c.Developer.ThemeHotReload) },
+	},
+}
+
+// ConfigKeys returns every setting available through `ccm config`
+func ConfigKeys() []ConfigKey {
+	return configKeys
+}
+
+// GetAppConfig returns a copy of the full application settings
+func (m *Manager) GetAppConfig() AppConfig {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if m.appConfig == nil {
+		return *DefaultAppConfig()
+	}
+	return *m.appConfig
+}
+
+// GetValue returns the current value of a setting as a string
+func (m *Manager) GetValue(key string) (string, error) {
+	configKey, err := lookupConfigKey(key)
+	if err != nil {
+		return "", err
+	}
+
+	cfg := m.GetAppConfig()
+	return configKey.get(&cfg), nil
+}
+
+// SetValue validates and stores a setting, then persists the change
+func (m *Manager) SetValue(key, value string) error {
+	configKey, err := lookupConfigKey(key)
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.appConfig == nil {
+		m.appConfig = DefaultAppConfig()
+		m.appConfig.Theme = m.settings
+	}
+	if err := configKey.set(m.appConfig, strings.TrimSpace(value)); err != nil {
+		return err
+	}
+
+	// Theme settings are mirrored in m.settings, which save() writes back
+	m.settings = m.appConfig.Theme
+	if err := m.applyTheme(m.resolveThemeID()); err != nil {
+		return err
+	}
+	return m.save()
+}
+
+func lookupConfigKey(key string) (ConfigKey, error) {
+	for _, configKey := range configKeys {
+		if configKey.Key == key {
+			return configKey, nil
+		}
+	}
+	return ConfigKey{}, apperr.New(apperr.KindValidation, "unknown setting %q (run 'ccm config list' to see all settings)", key)
+}
+
+func themeIDs() []string {
+	var ids []string
+	for _, t := range GetAllThemes() {
+		ids = append(ids, t.ID)
+	}
+	return ids
+}
+
+func parseBool(value string, dest *bool) error {
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		return apperr.New(apperr.KindValidation, "expected true or false, got %q", value)
+	}
+	*dest = parsed
+	return nil
+}
+
+func parsePositiveInt(value string, dest *int) error {
+	parsed, err := strconv.Atoi(value)
+	if err != nil || parsed <= 0 {
+		return apperr.New(apperr.KindValidation, "expected a positive number, got %q", value)
+	}
+	*dest = parsed
+	return nil
+}
+
+func parseNonNegativeInt(value string, dest *int) error {
+	parsed, err := strconv.Atoi(value)
+	if err != nil || parsed < 0 {
+		return apperr.New(apperr.KindValidation, "expected 0 or a positive number, got %q", value)
+	}
+	*dest = parsed
+	return nil
+}
+
+// repositoryNamePattern matches a GitHub owner or repository name
+var repositoryNamePattern = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+// parseRepository accepts owner/repo, or a github.com URL of a repository, and stores it
+// as owner/repo; empty clears the setting
+func parseRepository(value string, dest *string) error {
+	spec := strings.TrimPrefix(strings.TrimPrefix(value, "https://"), "http://")
+	spec = strings.TrimPrefix(strings.TrimPrefix(spec, "www."), "github.com/")
+	spec = strings.TrimSuffix(strings.TrimSuffix(spec, "/"), ".git")
+	if spec == "" {
+		*dest = ""
+		return nil
+	}
+
+	owner, repo, ok := strings.Cut(spec, "/")
+	if !ok || !repositoryNamePattern.MatchString(owner) || !repositoryNamePattern.MatchString(repo) {
+		return apperr.New(apperr.KindValidation, "expected owner/repo, got %q", value)
+	}
+	*dest = owner + "/" + repo
+	return nil
+}
+
+func parseLibrary(value string, dest *string) error {
+	switch strings.ToLower(value) {
+	case "user", "project":
+		*dest = strings.ToLower(value)
+		return nil
+	}
+	return apperr.New(apperr.KindValidation, "expected user or project, got %q", value)
+}
@@ -0,0 +1,102 @@
+package theme
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/shel-corp/Claude-command-manager/internal/apperr"
+)
+
+// targetNamePattern restricts custom symlink target names to simple identifiers
+var targetNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// ExpandTargetDir resolves a leading ~ in a symlink target directory
+func ExpandTargetDir(dir string) string {
+	if dir == "~" || strings.HasPrefix(dir, "~/") {
+		if homeDir, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(homeDir, strings.TrimPrefix(dir, "~"))
+		}
+	}
+	return dir
+}
+
+// TargetDirs returns the custom symlink targets with their directories expanded
+func (s LibrarySettings) TargetDirs() map[string]string {
+	dirs := make(map[string]string, len(s.Targets))
+	for name, dir := range s.Targets {
+		dirs[name] = ExpandTargetDir(dir)
+	}
+	return dirs
+}
+
+// TargetNames returns the custom symlink target names in sorted order
+func (s LibrarySettings) TargetNames() []string {
+	names := make([]string, 0, len(s.Targets))
+	for name := range s.Targets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// AddSymlinkTarget defines (or redefines) a named directory commands can be symlinked into,
+// and persists the change
+func (m *Manager) AddSymlinkTarget(name, dir string) error {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if !targetNamePattern.MatchString(name) {
+		return apperr.New(apperr.KindValidation, "invalid target name %q (use lowercase letters, digits, - and _)", name)
+	}
+	if name == "user" || name == "project" {
+		return apperr.New(apperr.KindValidation, "%q is a built-in location", name)
+	}
+	dir = strings.TrimSpace(dir)
+	if !filepath.IsAbs(ExpandTargetDir(dir)) {
+		return apperr.New(apperr.KindValidation, "target directory must be an absolute path or start with ~/, got %q", dir)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.appConfig == nil {
+		m.appConfig = DefaultAppConfig()
+		m.appConfig.Theme = m.settings
+	}
+	if m.appConfig.Library.Targets == nil {
+		m.appConfig.Library.Targets = make(map[string]string)
+	}
+	m.appConfig.Library.Targets[name] = dir
+	return m.save()
+}
+
+// RemoveSymlinkTarget deletes a named target and persists the change. Commands still set to
+// it can no longer be enabled or disabled and are reported as lost targets by the integrity
+// check, so callers should move them first.
+func (m *Manager) RemoveSymlinkTarget(name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.appConfig == nil || m.appConfig.Library.Targets[name] == "" {
+		return apperr.New(apperr.KindNotFound, "no symlink target named %q", name)
+	}
+	delete(m.appConfig.Library.Targets, name)
+	if m.appConfig.Library.DefaultSymlinkLocation == name {
+		m.appConfig.Library.DefaultSymlinkLocation = "user"
+	}
+	return m.save()
+}
+
+// parseSymlinkLocation accepts user, project or a defined target name for dest
+func parseSymlinkLocation(c *AppConfig, value string, dest *string) error {
+	value = strings.ToLower(value)
+	if _, ok := c.Library.Targets[value]; ok {
+		*dest = value
+		return nil
+	}
+	if err := parseLibrary(value, dest); err != nil {
+		return apperr.New(apperr.KindValidation, "expected user, project or a target name (%s), got %q", strings.Join(c.Library.TargetNames(), ", "), value)
+	}
+	return nil
+}
@@ -0,0 +1,159 @@
+package theme
+
+import (
+	"os"
+	"strings"
+
+	"github.com/shel-corp/Claude-command-manager/internal/apperr"
+)
+
+// TerminalProfile describes the terminal ccm is running in
+type TerminalProfile struct {
+	Program string // TERM_PROGRAM, e.g. "iTerm.app", "Apple_Terminal", "vscode"
+	Profile string // Named terminal profile, e.g. ITERM_PROFILE
+	Tmux    bool   // Running inside a tmux session
+}
+
+// DetectTerminalProfile reads the terminal program and profile from the environment
+func DetectTerminalProfile() TerminalProfile {
+	profile := TerminalProfile{
+		Program: os.Getenv("TERM_PROGRAM"),
+		Profile: os.Getenv("ITERM_PROFILE"),
+		Tmux:    os.Getenv("TMUX") != "",
+	}
+
+	// tmux replaces TERM_PROGRAM; iTerm2 still identifies itself through LC_TERMINAL
+	if profile.Program == "tmux" {
+		profile.Program = os.Getenv("LC_TERMINAL")
+	}
+	return profile
+}
+
+// Keys returns the binding names that match this terminal, most specific first
+func (p TerminalProfile) Keys() []string {
+	var keys []string
+	if p.Profile != "" {
+		keys = append(keys, p.Profile)
+	}
+	if p.Tmux {
+		keys = append(keys, "tmux")
+	}
+	if p.Program != "" {
+		keys = append(keys, p.Program)
+	}
+	return keys
+}
+
+// String describes the terminal for display
+func (p TerminalProfile) String() string {
+	var parts []string
+	if p.Program != "" {
+		parts = append(parts, "program "+p.Program)
+	}
+	if p.Profile != "" {
+		parts = append(parts, "profile "+p.Profile)
+	}
+	if p.Tmux {
+		parts = append(parts, "inside tmux")
+	}
+	if len(parts) == 0 {
+		return "unknown terminal"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// GetTerminalProfile returns the terminal detected when the manager was created
+func (m *Manager) GetTerminalProfile() TerminalProfile {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.terminal
+}
+
+// GetProfileBindings returns the theme bound to each terminal profile
+func (m *Manager) GetProfileBindings() map[string]string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	bindings := make(map[string]string, len(m.settings.Profiles))
+	for key, themeID := range m.settings.Profiles {
+		bindings[key] = themeID
+	}
+	return bindings
+}
+
+// ActiveProfileBinding returns the binding that overrides the current theme in this terminal, if any
+func (m *Manager) ActiveProfileBinding() (key, themeID string, ok bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.profileBinding()
+}
+
+// BindProfile uses themeID whenever ccm starts in a terminal matching key, and persists the change
+func (m *Manager) BindProfile(key, themeID string) error {
+	key = strings.TrimSpace(key)
+	if key == "" {
+		return apperr.New(apperr.KindValidation, "terminal profile name cannot be empty")
+	}
+	if _, ok := FindTheme(themeID); !ok {
+		return apperr.New(apperr.KindNotFound, "unknown theme %q (run 'ccm theme list')", themeID)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.settings.Profiles == nil {
+		m.settings.Profiles = make(map[string]string)
+	}
+	for existing := range m.settings.Profiles {
+		if strings.EqualFold(existing, key) {
+			delete(m.settings.Profiles, existing)
+		}
+	}
+	m.settings.Profiles[key] = themeID
+
+	if err := m.applyTheme(m.resolveThemeID()); err != nil {
+		return err
+	}
+	return m.save()
+}
+
+// UnbindProfile removes the theme bound to a terminal profile and persists the change
+func (m *Manager) UnbindProfile(key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	found := false
+	for existing := range m.settings.Profiles {
+		if strings.EqualFold(existing, key) {
+			delete(m.settings.Profiles, existing)
+			found = true
+		}
+	}
+	if !found {
+		return apperr.New(apperr.KindNotFound, "no theme is bound to terminal profile %q", key)
+	}
+
+	if err := m.applyTheme(m.resolveThemeID()); err != nil {
+		return err
+	}
+	return m.save()
+}
+
+// resolveThemeID returns the theme to show in this terminal (caller must hold lock)
+func (m *Manager) resolveThemeID() string {
+	if _, themeID, ok := m.profileBinding(); ok {
+		return themeID
+	}
+	return m.settings.CurrentTheme
+}
+
+// profileBinding finds the most specific binding for the detected terminal (caller must hold lock)
+func (m *Manager) profileBinding() (string, string, bool) {
+	for _, key := range m.terminal.Keys() {
+		for bound, themeID := range m.settings.Profiles {
+			if strings.EqualFold(bound, key) {
+				return bound, themeID, true
+			}
+		}
+	}
+	return "", "", false
+}
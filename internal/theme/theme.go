@@ -17,6 +17,17 @@ type Theme struct {
 	Background  lipgloss.AdaptiveColor `json:"background"`
 	Text        lipgloss.AdaptiveColor `json:"text"`
 	Border      lipgloss.AdaptiveColor `json:"border"`
+
+	// Surface and list slots; empty slots fall back to the base colors above
+	Surface       lipgloss.AdaptiveColor `json:"surface"`        // Footer and panel backgrounds
+	Selection     lipgloss.AdaptiveColor `json:"selection"`      // Background of the selected list card
+	SelectionText lipgloss.AdaptiveColor `json:"selection_text"` // Secondary text on the selected card
+	Subtext       lipgloss.AdaptiveColor `json:"subtext"`        // Secondary text on unselected cards
+	BorderVariant lipgloss.AdaptiveColor `json:"border_variant"` // Borders of unselected cards and the footer
+
+	Bold bool `json:"bold,omitempty"` // Draw body and secondary text in bold too, not just headings
+
+	Custom bool `json:"-"` // Loaded from the user themes directory
 }
 
 // Predefined themes following Charm design patterns
@@ -34,6 +45,12 @@ var (
 		Background:  lipgloss.AdaptiveColor{Light: "#FFFFFF", Dark: "#111827"},
 		Text:        lipgloss.AdaptiveColor{Light: "#1F2937", Dark: "#F9FAFB"},
 		Border:      lipgloss.AdaptiveColor{Light: "#E5E7EB", Dark: "#334155"},
+
+		Surface:       lipgloss.AdaptiveColor{Light: "#F1F5F9", Dark: "#0F172A"},
+		Selection:     lipgloss.AdaptiveColor{Light: "#E0F2FE", Dark: "#1E293B"},
+		SelectionText: lipgloss.AdaptiveColor{Light: "#334155", Dark: "#CBD5E1"},
+		Subtext:       lipgloss.AdaptiveColor{Light: "#6B7280", Dark: "#9CA3AF"},
+		BorderVariant: lipgloss.AdaptiveColor{Light: "#D1D5DB", Dark: "#374151"},
 	}
 
 	// MonochromeTheme - Professional grayscale theme
@@ -49,6 +66,12 @@ var (
 		Background:  lipgloss.AdaptiveColor{Light: "#F9FAFB", Dark: "#1F2937"},
 		Text:        lipgloss.AdaptiveColor{Light: "#111827", Dark: "#F9FAFB"},
 		Border:      lipgloss.AdaptiveColor{Light: "#D1D5DB", Dark: "#4B5563"},
+
+		Surface:       lipgloss.AdaptiveColor{Light: "#F3F4F6", Dark: "#111827"},
+		Selection:     lipgloss.AdaptiveColor{Light: "#E5E7EB", Dark: "#374151"},
+		SelectionText: lipgloss.AdaptiveColor{Light: "#1F2937", Dark: "#E5E7EB"},
+		Subtext:       lipgloss.AdaptiveColor{Light: "#6B7280", Dark: "#9CA3AF"},
+		BorderVariant: lipgloss.AdaptiveColor{Light: "#E5E7EB", Dark: "#374151"},
 	}
 
 	// SolarizedTheme - Warm, eye-friendly Solarized color scheme
@@ -64,6 +87,12 @@ var (
 		Background:  lipgloss.AdaptiveColor{Light: "#FDF6E3", Dark: "#002B36"},
 		Text:        lipgloss.AdaptiveColor{Light: "#657B83", Dark: "#839496"},
 		Border:      lipgloss.AdaptiveColor{Light: "#EEE8D5", Dark: "#073642"},
+
+		Surface:       lipgloss.AdaptiveColor{Light: "#EEE8D5", Dark: "#002B36"},
+		Selection:     lipgloss.AdaptiveColor{Light: "#EEE8D5", Dark: "#073642"},
+		SelectionText: lipgloss.AdaptiveColor{Light: "#586E75", Dark: "#93A1A1"},
+		Subtext:       lipgloss.AdaptiveColor{Light: "#93A1A1", Dark: "#657B83"},
+		BorderVariant: lipgloss.AdaptiveColor{Light: "#93A1A1", Dark: "#586E75"},
 	}
 
 	// DraculaTheme - Popular dark theme with purple accents
@@ -79,6 +108,12 @@ var (
 		Background:  lipgloss.AdaptiveColor{Light: "#F8F8F2", Dark: "#282A36"},
 		Text:        lipgloss.AdaptiveColor{Light: "#44475A", Dark: "#F8F8F2"},
 		Border:      lipgloss.AdaptiveColor{Light: "#6272A4", Dark: "#44475A"},
+
+		Surface:       lipgloss.AdaptiveColor{Light: "#F8F8F2", Dark: "#21222C"},
+		Selection:     lipgloss.AdaptiveColor{Light: "#E6E6F0", Dark: "#44475A"},
+		SelectionText: lipgloss.AdaptiveColor{Light: "#282A36", Dark: "#F8F8F2"},
+		Subtext:       lipgloss.AdaptiveColor{Light: "#6272A4", Dark: "#6272A4"},
+		BorderVariant: lipgloss.AdaptiveColor{Light: "#BFBFC9", Dark: "#44475A"},
 	}
 
 	// NordTheme - Cool, arctic-inspired color palette
@@ -94,6 +129,12 @@ var (
 		Background:  lipgloss.AdaptiveColor{Light: "#ECEFF4", Dark: "#2E3440"},
 		Text:        lipgloss.AdaptiveColor{Light: "#2E3440", Dark: "#ECEFF4"},
 		Border:      lipgloss.AdaptiveColor{Light: "#D8DEE9", Dark: "#3B4252"},
+
+		Surface:       lipgloss.AdaptiveColor{Light: "#E5E9F0", Dark: "#3B4252"},
+		Selection:     lipgloss.AdaptiveColor{Light: "#D8DEE9", Dark: "#434C5E"},
+		SelectionText: lipgloss.AdaptiveColor{Light: "#3B4252", Dark: "#E5E9F0"},
+		Subtext:       lipgloss.AdaptiveColor{Light: "#4C566A", Dark: "#D8DEE9"},
+		BorderVariant: lipgloss.AdaptiveColor{Light: "#D8DEE9", Dark: "#4C566A"},
 	}
 
 	// GruvboxMaterialTheme - Warm, earthy colors designed to be easy on the eyes
@@ -109,11 +150,57 @@ var (
 		Background:  lipgloss.AdaptiveColor{Light: "#fbf1c7", Dark: "#282828"}, // Light cream / Dark brown
 		Text:        lipgloss.AdaptiveColor{Light: "#3c3836", Dark: "#d4be98"}, // Dark brown / Light beige
 		Border:      lipgloss.AdaptiveColor{Light: "#928374", Dark: "#504945"}, // Gray / Dark gray (swapped from muted)
+
+		Surface:       lipgloss.AdaptiveColor{Light: "#f2e5bc", Dark: "#1d2021"}, // Soft cream / Hard dark
+		Selection:     lipgloss.AdaptiveColor{Light: "#ebdbb2", Dark: "#3c3836"}, // Cream / Dark brown
+		SelectionText: lipgloss.AdaptiveColor{Light: "#504945", Dark: "#ddc7a1"}, // Dark gray / Light beige
+		Subtext:       lipgloss.AdaptiveColor{Light: "#7c6f64", Dark: "#a89984"}, // Gray
+		BorderVariant: lipgloss.AdaptiveColor{Light: "#d5c4a1", Dark: "#504945"}, // Beige / Dark gray
+	}
+
+	// HighContrastTheme - Maximum contrast for low vision and bright terminals. Every
+	// color reaches at least 7:1 against the background, secondary text is near-black or
+	// near-white rather than gray, and all text is bold.
+	HighContrastTheme = Theme{
+		ID:          "high-contrast",
+		Name:        "High Contrast",
+		Description: "Bold, maximum-contrast colors for low vision and bright terminals",
+		Primary:     lipgloss.AdaptiveColor{Light: "#0000B0", Dark: "#FFFF00"}, // Navy / Yellow
+		Success:     lipgloss.AdaptiveColor{Light: "#005F00", Dark: "#00FF00"},
+		Danger:      lipgloss.AdaptiveColor{Light: "#A00000", Dark: "#FF8080"},
+		Warning:     lipgloss.AdaptiveColor{Light: "#6B3600", Dark: "#FFB000"},
+		Muted:       lipgloss.AdaptiveColor{Light: "#202020", Dark: "#E8E8E8"},
+		Background:  lipgloss.AdaptiveColor{Light: "#FFFFFF", Dark: "#000000"},
+		Text:        lipgloss.AdaptiveColor{Light: "#000000", Dark: "#FFFFFF"},
+		Border:      lipgloss.AdaptiveColor{Light: "#000000", Dark: "#FFFFFF"},
+
+		Surface:       lipgloss.AdaptiveColor{Light: "#FFFFFF", Dark: "#000000"},
+		Selection:     lipgloss.AdaptiveColor{Light: "#FFFF80", Dark: "#00005F"}, // Pale yellow / Deep blue
+		SelectionText: lipgloss.AdaptiveColor{Light: "#000000", Dark: "#FFFFFF"},
+		Subtext:       lipgloss.AdaptiveColor{Light: "#202020", Dark: "#E8E8E8"},
+		BorderVariant: lipgloss.AdaptiveColor{Light: "#404040", Dark: "#C0C0C0"},
+
+		Bold: true,
 	}
 )
 
-// GetAllThemes returns all available themes
-func GetAllThemes() []Theme {
+// WithDefaults fills unset surface and list slots from the base colors
+func (t Theme) WithDefaults() Theme {
+	fallback := func(slot *lipgloss.AdaptiveColor, base lipgloss.AdaptiveColor) {
+		if slot.Light == "" && slot.Dark == "" {
+			*slot = base
+		}
+	}
+	fallback(&t.Surface, t.Background)
+	fallback(&t.Selection, t.Border)
+	fallback(&t.SelectionText, t.Text)
+	fallback(&t.Subtext, t.Muted)
+	fallback(&t.BorderVariant, t.Border)
+	return t
+}
+
+// BuiltinThemes returns the themes shipped with ccm
+func BuiltinThemes() []Theme {
 	return []Theme{
 		DefaultTheme,
 		MonochromeTheme,
@@ -121,9 +208,15 @@ func GetAllThemes() []Theme {
 		DraculaTheme,
 		NordTheme,
 		GruvboxMaterialTheme,
+		HighContrastTheme,
 	}
 }
 
+// GetAllThemes returns all available themes: the built-in ones followed by user themes
+func GetAllThemes() []Theme {
+	return append(BuiltinThemes(), userThemes()...)
+}
+
 // GetThemeByID returns a theme by its ID, defaults to DefaultTheme if not found
 func GetThemeByID(id string) Theme {
 	for _, theme := range GetAllThemes() {
@@ -134,6 +227,16 @@ func GetThemeByID(id string) Theme {
 	return DefaultTheme
 }
 
+// FindTheme returns the theme with the given ID and whether it exists
+func FindTheme(id string) (Theme, bool) {
+	for _, theme := range GetAllThemes() {
+		if theme.ID == id {
+			return theme, true
+		}
+	}
+	return Theme{}, false
+}
+
 // GetThemeNames returns a slice of all theme names for UI display
 func GetThemeNames() []string {
 	themes := GetAllThemes()
@@ -157,8 +260,9 @@ func (t Theme) GeneratePreview() ThemePreview {
 	successBlock := lipgloss.NewStyle().Background(t.Success).Render("   ")
 	dangerBlock := lipgloss.NewStyle().Background(t.Danger).Render("   ")
 	warningBlock := lipgloss.NewStyle().Background(t.Warning).Render("   ")
+	selectionBlock := lipgloss.NewStyle().Background(t.WithDefaults().Selection).Render("   ")
 	
-	colorBar := primaryBlock + successBlock + dangerBlock + warningBlock
+	colorBar := primaryBlock + successBlock + dangerBlock + warningBlock + selectionBlock
 	
 	return ThemePreview{
 		Theme:    t,
@@ -0,0 +1,24 @@
+package tui
+
+import (
+	"os/exec"
+	"runtime"
+)
+
+// openInBrowser opens url with the system's default browser
+func openInBrowser(url string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", url)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		cmd = exec.Command("xdg-open", url)
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	go cmd.Wait() // Reap the launcher; the browser itself keeps running
+	return nil
+}
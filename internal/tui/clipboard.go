@@ -0,0 +1,59 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/atotto/clipboard"
+	"github.com/aymanbagabas/go-osc52/v2"
+
+	"github.com/shel-corp/Claude-command-manager/internal/commands"
+)
+
+// copyToClipboard puts text on the system clipboard. Over SSH, or when no clipboard tool
+// is installed, it asks the terminal to do it with an OSC 52 escape sequence instead,
+// which reaches the clipboard of the machine the terminal runs on.
+func copyToClipboard(text string) error {
+	if os.Getenv("SSH_TTY") == "" && !clipboard.Unsupported {
+		if err := clipboard.WriteAll(text); err == nil {
+			return nil
+		}
+	}
+
+	seq := osc52.New(text)
+	switch {
+	case os.Getenv("TMUX") != "":
+		seq = seq.Tmux()
+	case strings.HasPrefix(os.Getenv("TERM"), "screen"):
+		seq = seq.Screen()
+	}
+	// Stderr is the same terminal, but keeps the sequence out of the renderer's output
+	_, err := seq.WriteTo(os.Stderr)
+	return err
+}
+
+// copyCommand copies a command's body, without its frontmatter, or its full path to the clipboard
+func (m *Model) copyCommand(cmd *commands.Command, path bool) {
+	if cmd == nil {
+		return
+	}
+
+	text, what := cmd.FilePath, "path of "+cmd.DisplayName
+	if !path {
+		data, err := os.ReadFile(cmd.FilePath)
+		if err != nil {
+			m.setStatus(fmt.Sprintf("Failed to read %s: %v", cmd.DisplayName, err), StatusError)
+			return
+		}
+		_, body := commands.SplitFrontmatter(string(data))
+		text, what = body, "the body of "+filepath.Base(cmd.FilePath)
+	}
+
+	if err := copyToClipboard(text); err != nil {
+		m.setStatus(fmt.Sprintf("Failed to copy %s: %v", what, err), StatusError)
+		return
+	}
+	m.setStatus(fmt.Sprintf("📋 Copied %s to the clipboard", what), StatusSuccess)
+}
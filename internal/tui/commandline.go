@@ -0,0 +1,82 @@
+package tui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// commandLineAliases are vim-style shorthands accepted by the command line
+var commandLineAliases = map[string]string{
+	"q":  "quit",
+	"q!": "quit",
+	"qa": "quit",
+	"h":  "help",
+}
+
+// StartCommandLine opens the library command line, where actions are run by name
+func (m *Model) StartCommandLine() tea.Cmd {
+	input := textinput.New()
+	input.Prompt = ":"
+	input.Placeholder = "action name, or a line number"
+	input.CharLimit = 50
+	input.Width = 40
+	input.ShowSuggestions = true
+
+	var names []string
+	for _, action := range m.keys.actions() {
+		if action.name != "command" {
+			names = append(names, action.name)
+		}
+	}
+	input.SetSuggestions(names)
+
+	m.commandLine = input
+	m.commandLineActive = true
+	m.clearStatus()
+	return m.commandLine.Focus()
+}
+
+// handleCommandLineKeys handles keys while the command line is open
+func (m *Model) handleCommandLineKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m, m.Quit()
+	case "esc":
+		m.commandLineActive = false
+		return m, nil
+	case "enter":
+		m.commandLineActive = false
+		return m, m.runCommandLine(m.commandLine.Value())
+	}
+
+	var cmd tea.Cmd
+	m.commandLine, cmd = m.commandLine.Update(msg)
+	return m, cmd
+}
+
+// runCommandLine runs an action by name (":rename"), or jumps to a line (":12")
+func (m *Model) runCommandLine(line string) tea.Cmd {
+	name := strings.ReplaceAll(strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line), ":")), "-", "_")
+	if name == "" {
+		return nil
+	}
+	if n, err := strconv.Atoi(name); err == nil {
+		m.list.Select(min(max(n, 1), len(m.list.Items())) - 1)
+		return nil
+	}
+	if alias, ok := commandLineAliases[name]; ok {
+		name = alias
+	}
+
+	for _, action := range m.keys.actions() {
+		if action.name == name && name != "command" {
+			return m.runLibraryAction(name)
+		}
+	}
+	m.setStatus(fmt.Sprintf("Unknown action: %s (see the help screen for names)", name), StatusError)
+	return nil
+}
@@ -0,0 +1,149 @@
+package tui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/shel-corp/Claude-command-manager/internal/remote"
+)
+
+// importConflictState holds the choices on the screen that resolves selected remote
+// commands that already exist in the target library
+type importConflictState struct {
+	commands  []remote.RemoteCommand // Every selected command, passed on to the import
+	conflicts []string               // Names of the selected commands that already exist
+	choices   map[string]remote.ConflictResolution
+	cursor    int
+}
+
+// startImport imports the selected remote commands, asking first what to do with those
+// that already exist unless overwrite confirmations are turned off
+func (m *Model) startImport(selected []remote.RemoteCommand) tea.Cmd {
+	var conflicts []string
+	for _, command := range selected {
+		if command.LocalExists {
+			conflicts = append(conflicts, command.Name)
+		}
+	}
+
+	if len(conflicts) == 0 || !GetThemeManager().GetAppConfig().Confirm.Overwrite {
+		m.state = StateRemoteImport
+		return func() tea.Msg {
+			return RemoteImportMsg{Commands: selected}
+		}
+	}
+
+	choices := make(map[string]remote.ConflictResolution, len(conflicts))
+	for _, name := range conflicts {
+		choices[name] = remote.ConflictSkip
+	}
+	m.importConflicts = importConflictState{commands: selected, conflicts: conflicts, choices: choices}
+	m.state = StateImportConflicts
+	return nil
+}
+
+// cycleConflictResolution moves the choice for the highlighted command forward or backward
+func (m *Model) cycleConflictResolution(step int) {
+	state := &m.importConflicts
+	name := state.conflicts[state.cursor]
+	count := len(remote.ConflictResolutions)
+	i := slices.Index(remote.ConflictResolutions, state.choices[name])
+	state.choices[name] = remote.ConflictResolutions[(i+step+count)%count]
+}
+
+// handleImportConflictStateKeys handles keys on the import conflict screen
+func (m *Model) handleImportConflictStateKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	state := &m.importConflicts
+	switch msg.String() {
+	case "ctrl+c":
+		return m, m.Quit()
+
+	case "esc":
+		m.state = StateRemoteSelect
+		return m, nil
+
+	case "up", "k":
+		if state.cursor > 0 {
+			state.cursor--
+		}
+
+	case "down", "j":
+		if state.cursor < len(state.conflicts)-1 {
+			state.cursor++
+		}
+
+	case " ", "right", "l", "tab":
+		m.cycleConflictResolution(1)
+
+	case "left", "h", "shift+tab":
+		m.cycleConflictResolution(-1)
+
+	case "s", "o", "r":
+		state.choices[state.conflicts[state.cursor]] = conflictKeys[msg.String()]
+
+	case "S", "O", "R":
+		for _, name := range state.conflicts {
+			state.choices[name] = conflictKeys[strings.ToLower(msg.String())]
+		}
+
+	case "enter":
+		commands, resolutions := state.commands, state.choices
+		m.state = StateRemoteImport
+		return m, func() tea.Msg {
+			return RemoteImportMsg{Commands: commands, Resolutions: resolutions}
+		}
+	}
+	return m, nil
+}
+
+// conflictKeys are the keys that pick a resolution; the capital letters apply it to every command
+var conflictKeys = map[string]remote.ConflictResolution{
+	"s": remote.ConflictSkip,
+	"o": remote.ConflictOverwrite,
+	"r": remote.ConflictRename,
+}
+
+// conflictLabel describes a resolution on the conflict screen
+func conflictLabel(resolution remote.ConflictResolution) string {
+	switch resolution {
+	case remote.ConflictOverwrite:
+		return warningStyle.Render("[overwrite]")
+	case remote.ConflictRename:
+		return successStyle.Render("[keep both]")
+	}
+	return subtleStyle.Render("[skip]     ")
+}
+
+// importConflictsView renders the import conflict screen
+func (m *Model) importConflictsView() string {
+	state := m.importConflicts
+	header := "Resolve Import Conflicts"
+
+	target, _ := m.remoteImportTarget()
+	var content strings.Builder
+	content.WriteString(subtleStyle.Render(fmt.Sprintf("%d of the %d selected commands already exist in %s",
+		len(state.conflicts), len(state.commands), target)))
+	content.WriteString("\n\n")
+
+	for i, name := range state.conflicts {
+		cursor, label := "  ", name
+		if i == state.cursor {
+			cursor, label = "▶ ", highlightStyle.Render(name)
+		}
+		content.WriteString(fmt.Sprintf("%s%s  %s\n", cursor, conflictLabel(state.choices[name]), label))
+	}
+
+	content.WriteString("\n")
+	content.WriteString(subtleStyle.Render("Skip keeps the local command, overwrite replaces it"))
+	if GetThemeManager().GetAppConfig().Import.CreateBackups {
+		content.WriteString(subtleStyle.Render(" (backed up first)"))
+	}
+	content.WriteString(subtleStyle.Render(" and keep both imports it under a free name such as <name>-2."))
+
+	footer := "↑/↓: Select • Space/←/→: Change • s/o/r: Skip/Overwrite/Keep Both • S/O/R: All • Enter: Import • Esc: Back"
+
+	return centerView(header, content.String(), footer, m.width)
+}
@@ -0,0 +1,139 @@
+package tui
+
+import (
+	"cmp"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/shel-corp/Claude-command-manager/internal/models"
+)
+
+// splitPaneMinWidth is the narrowest terminal that shows the detail panel beside the library
+const splitPaneMinWidth = 120
+
+// splitPaneActive reports whether the library shows the highlighted command beside the list
+func (m *Model) splitPaneActive() bool {
+	if m.width < splitPaneMinWidth {
+		return false
+	}
+	if tm := GetThemeManager(); tm != nil {
+		return tm.GetAppConfig().Library.DetailPanel
+	}
+	return true
+}
+
+// splitPaneWidths divides the library content area between the list and the detail panel
+func (m *Model) splitPaneWidths() (listWidth, panelWidth int) {
+	available := m.width - 12 // Container margin and padding
+	listWidth = max(available*2/5, 40)
+	return listWidth, available - listWidth - 2
+}
+
+// libraryListView renders the library list, beside the detail panel when there is room for both
+func (m *Model) libraryListView() string {
+	if !m.splitPaneActive() {
+		return m.listView()
+	}
+
+	listWidth, panelWidth := m.splitPaneWidths()
+	list := m.list
+	list.SetWidth(listWidth)
+	listView := lipgloss.NewStyle().Width(listWidth).Render(listMarker + list.View())
+	panel := m.detailPanelView(panelWidth, max(lipgloss.Height(listView), 10))
+	return lipgloss.JoinHorizontal(lipgloss.Top, listView, "  ", panel)
+}
+
+// detailPanelView renders the highlighted command's details and content in a box of the given size
+func (m *Model) detailPanelView(width, height int) string {
+	inner := width - 4 // Border and padding
+	box := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(borderVariantColor).
+		Padding(0, 1).
+		Width(width - 2).
+		Height(height - 2)
+
+	cmd := m.GetSelectedCommand()
+	if cmd == nil {
+		return box.Render(subtleStyle.Render("No command highlighted"))
+	}
+
+	detail := m.detail
+	if detail.err != nil {
+		return box.Render(dangerStyle.Render(fmt.Sprintf("Failed to read %s: %v", cmd.DisplayName, detail.err)))
+	}
+	symlink := detail.symlink
+
+	status := "disabled"
+	if cmd.Enabled {
+		status = fmt.Sprintf("enabled (%s)", cmd.SymlinkLocation)
+	}
+
+	var lines []string
+	lines = append(lines, highlightStyle.Render(ansi.Truncate(cmd.DisplayName, inner, "…")))
+	if cmd.Description != "" {
+		description := lipgloss.NewStyle().Width(inner).Render(cmd.Description)
+		lines = append(lines, strings.Split(subtleStyle.Render(description), "\n")...)
+	}
+	lines = append(lines, "")
+	lines = append(lines, "Status: "+highlightStyle.Render(status))
+	if cmd.Enabled && !strings.HasPrefix(symlink, "→") {
+		lines = append(lines, warningStyle.Render(ansi.Truncate("⚠️  Symlink "+symlink, inner, "…")))
+	} else {
+		lines = append(lines, subtleStyle.Render(ansi.Truncate("Symlink "+symlink, inner, "…")))
+	}
+	if summary := m.usageSummary(*cmd); summary != "" {
+		lines = append(lines, subtleStyle.Render(ansi.Truncate("Usage: "+summary, inner, "…")))
+	}
+	for _, field := range detail.fields {
+		if field.Key == "description" {
+			continue // Shown above
+		}
+		lines = append(lines, ansi.Truncate(fmt.Sprintf("%s: %s", field.Key, field.Value), inner, "…"))
+	}
+	if warning := models.DeprecationWarning(cmd.Model); warning != "" {
+		lines = append(lines, warningStyle.Render(ansi.Truncate("⚠️  "+warning, inner, "…")))
+	}
+	lines = append(lines, subtleStyle.Render(strings.Repeat("─", inner)))
+	lines = append(lines, m.markdownLinesWidth(detail.body, inner)...)
+
+	// Keep to the height of the list; the full preview scrolls through the rest
+	if limit := height - 2; len(lines) > limit {
+		lines = append(lines[:limit-1], subtleStyle.Render(cmp.Or(keyHint(m.keys.Preview, "full preview"), "…")))
+	}
+	return box.Render(strings.Join(lines, "\n"))
+}
+
+// syncDetail reads the highlighted command for the detail panel when another command is
+// highlighted or it was enabled, disabled or moved. With checkDisk it also re-reads a file
+// that changed on disk, so the panel picks up edits made outside ccm.
+func (m *Model) syncDetail(checkDisk bool) {
+	if m.state != StateLibrary || !m.splitPaneActive() {
+		return
+	}
+	cmd := m.GetSelectedCommand()
+	if cmd == nil {
+		m.detail = libraryPreviewState{}
+		return
+	}
+
+	shown := m.detail.command
+	stale := shown.FilePath != cmd.FilePath || shown.Enabled != cmd.Enabled || shown.SymlinkLocation != cmd.SymlinkLocation
+	if !stale && checkDisk {
+		info, err := os.Stat(cmd.FilePath)
+		stale = err != nil || m.detail.err != nil || !info.ModTime().Equal(m.detail.modTime)
+	}
+	if !stale {
+		return
+	}
+
+	preview, err := m.loadLibraryPreview(*cmd)
+	if err != nil {
+		preview = libraryPreviewState{command: *cmd, err: err}
+	}
+	m.detail = preview
+}
@@ -0,0 +1,39 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/shel-corp/Claude-command-manager/internal/logging"
+	"github.com/shel-corp/Claude-command-manager/internal/remote"
+	"github.com/shel-corp/Claude-command-manager/internal/version"
+)
+
+// diagnosticLogLines is how many lines of ccm.log are attached to a reported issue
+const diagnosticLogLines = 50
+
+// issueDiagnostics describes the environment for a reported issue as a collapsed
+// markdown section: ccm version, platform, terminal size, GitHub CLI status and the
+// end of the log
+func issueDiagnostics(width, height int) string {
+	info := version.Get()
+
+	var b strings.Builder
+	b.WriteString("<details>\n<summary>Diagnostics</summary>\n\n")
+	fmt.Fprintf(&b, "- ccm: %s\n", info.Short())
+	fmt.Fprintf(&b, "- Platform: %s (%s)\n", info.Platform, info.GoVersion)
+	fmt.Fprintf(&b, "- Terminal: %dx%d\n", width, height)
+	fmt.Fprintf(&b, "- gh: %s\n", remote.CheckGHAuth().Message())
+
+	lines, err := logging.Tail(logging.DefaultLogPath(), diagnosticLogLines)
+	switch {
+	case err != nil:
+		fmt.Fprintf(&b, "\nNo log: %v\n", err)
+	case len(lines) == 0:
+		b.WriteString("\nThe log is empty.\n")
+	default:
+		fmt.Fprintf(&b, "\nLast %d log lines:\n\n```\n%s\n```\n", len(lines), strings.Join(lines, "\n"))
+	}
+	b.WriteString("\n</details>")
+	return b.String()
+}
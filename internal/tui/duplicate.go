@@ -0,0 +1,102 @@
+package tui
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// StartDuplicate asks for the name of a copy of the selected command
+func (m *Model) StartDuplicate() {
+	cmd := m.GetSelectedCommand()
+	if cmd == nil {
+		return
+	}
+
+	m.state = StateDuplicate
+	m.duplicateIndex = m.selectedCommandIndex()
+	m.textInput.SetValue(strings.TrimSuffix(filepath.Base(cmd.RelativePath), ".md") + "-copy")
+	m.textInput.CursorEnd()
+	m.textInput.Focus()
+}
+
+// ConfirmDuplicate copies the command under the entered name and saves immediately
+func (m *Model) ConfirmDuplicate() tea.Cmd {
+	if m.duplicateIndex < 0 || m.duplicateIndex >= len(m.commands) {
+		m.state = StateLibrary
+		return nil
+	}
+	source := m.commands[m.duplicateIndex]
+
+	copied, err := m.getCurrentCommandManager().DuplicateCommand(source, strings.TrimSpace(m.textInput.Value()))
+	if err != nil {
+		m.validationErrors["name"] = err.Error()
+		return nil
+	}
+	m.logAction(actionCreated, copied.DisplayName)
+
+	m.state = StateLibrary
+	if err := m.getCurrentConfigManager().Save(); err != nil {
+		return func() tea.Msg {
+			return ErrorMsg{Error: err}
+		}
+	}
+	m.setStatus(fmt.Sprintf("Duplicated %s as %s (%s)", source.DisplayName, copied.DisplayName, copied.RelativePath), StatusSuccess)
+	return func() tea.Msg {
+		return RefreshMsg{}
+	}
+}
+
+// handleDuplicateStateKeys handles keys while naming a duplicate
+func (m *Model) handleDuplicateStateKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		if m.validateInput() {
+			return m, m.ConfirmDuplicate()
+		}
+		return m, nil
+
+	case "esc":
+		m.clearValidationErrors()
+		m.state = StateLibrary
+		return m, nil
+
+	case "ctrl+c":
+		return m, m.Quit()
+	}
+
+	m.clearValidationErrors()
+	var cmd tea.Cmd
+	m.textInput, cmd = m.textInput.Update(msg)
+	return m, cmd
+}
+
+// duplicateView renders the name input for a copy of a command
+func (m *Model) duplicateView() string {
+	header := "Duplicate Command"
+
+	var content strings.Builder
+	if m.duplicateIndex >= 0 && m.duplicateIndex < len(m.commands) {
+		cmd := m.commands[m.duplicateIndex]
+		content.WriteString(fmt.Sprintf("Copying: %s (%s)\n",
+			highlightStyle.Render(cmd.DisplayName), cmd.RelativePath))
+		content.WriteString(fmt.Sprintf("Description: %s\n\n",
+			subtleStyle.Render(cmd.Description)))
+	}
+
+	content.WriteString("Name of the copy (a/b puts it in a subdirectory):\n")
+	content.WriteString(m.textInput.View())
+	content.WriteString("\n")
+	content.WriteString(subtleStyle.Render("The copy starts disabled; its description notes what it was copied from."))
+
+	if errorMsg, hasError := m.validationErrors["name"]; hasError {
+		content.WriteString("\n")
+		content.WriteString(dangerStyle.Render("⚠️ " + errorMsg))
+	}
+
+	footer := "Enter: Duplicate • Esc: Back to Library • Ctrl+C: Quit"
+
+	return centerView(header, content.String(), footer, m.width)
+}
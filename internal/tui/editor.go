@@ -0,0 +1,133 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textarea"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/shel-corp/Claude-command-manager/internal/commands"
+)
+
+// maxInlineEditLines is the longest command that can be edited inside the TUI;
+// longer ones are better served by $EDITOR
+const maxInlineEditLines = 300
+
+// inlineEditorState holds the command being edited in the textarea
+type inlineEditorState struct {
+	command  commands.Command
+	original string
+	input    textarea.Model
+	discard  bool // Esc was pressed once with unsaved changes
+}
+
+// StartInlineEdit opens the selected command's file in a textarea
+func (m *Model) StartInlineEdit() tea.Cmd {
+	cmd := m.GetSelectedCommand()
+	if cmd == nil {
+		return nil
+	}
+
+	data, err := os.ReadFile(cmd.FilePath)
+	if err != nil {
+		m.setStatus(fmt.Sprintf("Failed to read %s: %v", cmd.DisplayName, err), StatusError)
+		return nil
+	}
+	content := strings.ReplaceAll(string(data), "\r\n", "\n")
+	if strings.Count(content, "\n") >= maxInlineEditLines {
+		m.setStatus(fmt.Sprintf("%s is too long to edit here, press %s to open it in $EDITOR", cmd.DisplayName, m.keys.Edit.Help().Key), StatusWarning)
+		return nil
+	}
+
+	input := textarea.New()
+	input.MaxHeight = maxInlineEditLines
+	input.SetValue(content)
+	if input.Value() != content {
+		// The textarea turns tabs into spaces and drops control characters, so saving
+		// would rewrite parts of the file nobody edited
+		m.setStatus(fmt.Sprintf("%s has tabs or control characters, press %s to open it in $EDITOR", cmd.DisplayName, m.keys.Edit.Help().Key), StatusWarning)
+		return nil
+	}
+	// Compare edits against the textarea's own copy, so an untouched file is never "modified"
+	m.inlineEditor = inlineEditorState{command: *cmd, original: input.Value(), input: input}
+	m.resizeInlineEditor()
+	m.clearStatus()
+	m.state = StateInlineEdit
+	return m.inlineEditor.input.Focus()
+}
+
+// resizeInlineEditor fits the textarea to the terminal
+func (m *Model) resizeInlineEditor() {
+	m.inlineEditor.input.SetWidth(min(m.width-8, 100))
+	m.inlineEditor.input.SetHeight(max(m.height-10, 5))
+}
+
+// SaveInlineEdit writes the edited content back to the command file
+func (m *Model) SaveInlineEdit() tea.Cmd {
+	editor := &m.inlineEditor
+	content := editor.input.Value()
+	if content == editor.original {
+		m.state = StateLibrary
+		m.setStatus("No changes to save", StatusInfo)
+		return nil
+	}
+	if !strings.HasSuffix(content, "\n") {
+		content += "\n"
+	}
+
+	if err := m.getCurrentCommandManager().WriteCommandContent(editor.command, content); err != nil {
+		m.setStatus(err.Error(), StatusError)
+		return nil
+	}
+	m.logAction(actionEdited, editor.command.DisplayName)
+	m.state = StateLibrary
+	m.setStatus(fmt.Sprintf("Saved %s", editor.command.DisplayName), StatusSuccess)
+	return func() tea.Msg {
+		return RefreshMsg{}
+	}
+}
+
+// handleInlineEditStateKeys handles keys while editing a command in the textarea
+func (m *Model) handleInlineEditStateKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m, m.Quit()
+	case "ctrl+s":
+		return m, m.SaveInlineEdit()
+	case "esc":
+		if m.inlineEditor.input.Value() != m.inlineEditor.original && !m.inlineEditor.discard {
+			m.inlineEditor.discard = true
+			m.setStatus("Unsaved changes, press Esc again to discard them", StatusWarning)
+			return m, nil
+		}
+		m.clearStatus()
+		m.state = StateLibrary
+		return m, nil
+	}
+
+	m.inlineEditor.discard = false
+	var cmd tea.Cmd
+	m.inlineEditor.input, cmd = m.inlineEditor.input.Update(msg)
+	return m, cmd
+}
+
+// inlineEditView renders the textarea editor
+func (m *Model) inlineEditView() string {
+	editor := m.inlineEditor
+	header := fmt.Sprintf("✏️  Edit: %s", editor.command.DisplayName)
+	if editor.input.Value() != editor.original {
+		header += " (modified)"
+	}
+
+	var content strings.Builder
+	content.WriteString(subtleStyle.Render(editor.command.FilePath))
+	content.WriteString("\n\n")
+	content.WriteString(editor.input.View())
+	content.WriteString("\n")
+	content.WriteString(m.renderStatusMessage())
+
+	footer := "Ctrl+S: Save • Esc: Cancel • Ctrl+C: Quit"
+	return centerView(header, content.String(), footer, m.width)
+}
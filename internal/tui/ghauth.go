@@ -0,0 +1,112 @@
+package tui
+
+import (
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/shel-corp/Claude-command-manager/internal/remote"
+)
+
+// ghAuthRecheckInterval is how often the auth screen checks whether gh has been fixed
+const ghAuthRecheckInterval = 3 * time.Second
+
+// ghAuthTickMsg asks for another gh status check while the auth screen is open
+type ghAuthTickMsg struct {
+	poll int // Generation of the polling that scheduled it
+}
+
+// ghAuthStatusMsg carries the result of a gh status check
+type ghAuthStatusMsg struct {
+	status remote.GHAuthStatus
+	poll   int // Generation of the polling that asked, or 0 for a check the user asked for
+}
+
+// watchGHAuth schedules the next gh status check of the given polling generation
+func watchGHAuth(poll int) tea.Cmd {
+	return tea.Tick(ghAuthRecheckInterval, func(time.Time) tea.Msg {
+		return ghAuthTickMsg{poll: poll}
+	})
+}
+
+// recheckGHAuth checks gh again off the update loop, since gh auth status may hit the network
+func recheckGHAuth(poll int) tea.Cmd {
+	return func() tea.Msg {
+		return ghAuthStatusMsg{status: remote.RecheckGHAuth(), poll: poll}
+	}
+}
+
+// handleGHAuthTick re-checks gh while the user is still on the auth screen. Only the
+// latest polling continues, so reopening the screen never leaves two running.
+func (m *Model) handleGHAuthTick(msg ghAuthTickMsg) (tea.Model, tea.Cmd) {
+	if m.state != StateGitHubAuth || msg.poll != m.ghAuthPoll {
+		return m, nil
+	}
+	return m, recheckGHAuth(msg.poll)
+}
+
+// handleGHAuthStatus continues into the repository browser once gh is usable
+func (m *Model) handleGHAuthStatus(msg ghAuthStatusMsg) (tea.Model, tea.Cmd) {
+	if m.state != StateGitHubAuth {
+		return m, nil
+	}
+
+	m.ghAuthStatus = msg.status
+	if !msg.status.Ready() {
+		// A check the user asked for leaves the polling to its own schedule
+		if msg.poll != m.ghAuthPoll {
+			return m, nil
+		}
+		return m, watchGHAuth(msg.poll)
+	}
+
+	m.setStatus("GitHub CLI is logged in", StatusSuccess)
+	return m, m.StartRemoteImport()
+}
+
+// handleGitHubAuthStateKeys handles keys on the GitHub CLI auth screen
+func (m *Model) handleGitHubAuthStateKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c", "q":
+		return m, m.Quit()
+	case "esc":
+		return m, m.ReturnToMain()
+	case "r":
+		return m, recheckGHAuth(0)
+	case "a":
+		m.ghAnonymous = true
+		m.setStatus("Using anonymous GitHub access (public repositories only)", StatusWarning)
+		return m, m.StartRemoteImport()
+	}
+	return m, nil
+}
+
+// gitHubAuthView explains how to make gh usable
+func (m *Model) gitHubAuthView() string {
+	header := "GitHub CLI not ready"
+
+	var content strings.Builder
+	content.WriteString(warningStyle.Render("⚠️  " + m.ghAuthStatus.Message()))
+	content.WriteString("\n\n")
+
+	if m.ghAuthStatus.State == remote.GHNotInstalled {
+		content.WriteString("Install the GitHub CLI from " + highlightStyle.Render("https://cli.github.com/") + ", then run:\n\n")
+	} else {
+		content.WriteString("Run this in another terminal:\n\n")
+	}
+	content.WriteString("    " + highlightStyle.Render(remote.GHLoginCommand))
+	content.WriteString("\n\n")
+	content.WriteString(subtleStyle.Render("ccm checks again every few seconds and continues automatically."))
+	content.WriteString("\n")
+	content.WriteString(subtleStyle.Render("Public repositories can also be browsed anonymously, with GitHub's lower rate limits."))
+
+	if detail := strings.TrimSpace(m.ghAuthStatus.Detail); detail != "" {
+		content.WriteString("\n\n")
+		content.WriteString(subtleStyle.Render(detail))
+	}
+	content.WriteString(m.renderStatusMessage())
+
+	footer := "a: Continue anonymously • r: Check now • Esc: Back • q: Quit"
+	return centerView(header, content.String(), footer, m.width)
+}
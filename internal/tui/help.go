@@ -0,0 +1,198 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// helpOverlay is the help box drawn over the current screen, listing only its keys
+type helpOverlay struct {
+	open   bool
+	offset int // First line shown when the list is taller than the screen
+}
+
+// helpSection is a titled group of key bindings on the help overlay
+type helpSection struct {
+	title    string
+	bindings []key.Binding
+	notes    []string // Shown under the bindings
+}
+
+// fixedKey describes a key that cannot be remapped, such as Esc, for the help overlay
+func fixedKey(label, desc string) key.Binding {
+	return key.NewBinding(key.WithKeys(strings.ToLower(label)), key.WithHelp(label, desc))
+}
+
+// helpSections lists the keys of the current screen, or nil if it has no help
+func (m *Model) helpSections() []helpSection {
+	k, r := m.keys, m.remoteKeys
+	navigate := fixedKey("↑/↓", "Move the highlight")
+	palette := fixedKey("Ctrl+P", "Search and run any action available on this screen")
+	forceQuit := fixedKey("Ctrl+C", "Force quit")
+
+	switch m.state {
+	case StateMainMenu:
+		return []helpSection{{
+			title:    "Main Menu",
+			bindings: []key.Binding{navigate, fixedKey("Enter", "Open the highlighted item"), k.Import, k.Fix, k.Reconcile, palette, k.Help, k.Quit, forceQuit},
+		}}
+
+	case StateLibrary:
+		var bindings []key.Binding
+		for _, action := range k.actions() {
+			bindings = append(bindings, *action.binding)
+		}
+		bindings = append(bindings, palette, fixedKey("Esc", "Clear the selection or filters, then go back to the main menu"), forceQuit)
+		return []helpSection{{
+			title:    "Command Library",
+			bindings: bindings,
+			notes: []string{
+				fmt.Sprintf("With a selection, %s and %s apply to every selected command.", k.Toggle.Help().Key, k.Location.Help().Key),
+				"Commands are .md files; enabled ones are symlinked into .claude/commands/.",
+				"All changes are saved immediately.",
+			},
+		}}
+
+	case StateRemoteBrowse:
+		if m.registryManager == nil || !m.registryManager.IsLoaded() {
+			return []helpSection{{
+				title:    "Repository Browser",
+				bindings: []key.Binding{r.CustomURL, fixedKey("Esc", "Back to the main menu"), forceQuit},
+			}}
+		}
+		switch m.browseMode {
+		case BrowseModeCategories:
+			return []helpSection{{
+				title:    "Repository Categories",
+				bindings: []key.Binding{navigate, r.Open, r.Search, r.CustomURL, r.Help, fixedKey("Esc", "Back to the main menu"), forceQuit},
+			}}
+		case BrowseModeRepositories:
+			return []helpSection{{
+				title:    "Repositories",
+				bindings: []key.Binding{navigate, r.Open, r.Star, r.Search, r.CustomURL, r.Help, fixedKey("Esc", "Back to the categories"), forceQuit},
+				notes:    []string{"Starred repositories are listed under Favorites."},
+			}}
+		case BrowseModeSearch:
+			return []helpSection{{
+				title:    "Repository Search",
+				bindings: []key.Binding{r.SwitchFocus, navigate, r.Open, r.Star, r.CustomURL, r.Help, fixedKey("Esc", "Clear the search, then leave it"), forceQuit},
+				notes:    []string{"Type in the search field to filter; Tab moves to the results."},
+			}}
+		}
+
+	case StateRemoteSelect:
+		return []helpSection{{
+			title:    "Repository Commands",
+			bindings: []key.Binding{navigate, r.Toggle, r.Preview, r.Analyze, r.SelectAll, r.SelectNone, r.Rename, r.Prefix, r.Import, r.Help, fixedKey("Esc", "Cancel and go back to the main menu"), forceQuit},
+			notes:    []string{"Renamed commands are imported as <new name>.md; the repository is not changed."},
+		}}
+
+	case StateSettings:
+		return []helpSection{{
+			title:    "Settings",
+			bindings: []key.Binding{navigate, fixedKey("Enter", "Open the highlighted setting"), palette, fixedKey("h/?", "Show the keys of the current screen"), fixedKey("Esc", "Back to the main menu"), fixedKey("q", "Quit"), forceQuit},
+			notes:    []string{"Settings are saved to config.json as soon as they change."},
+		}}
+	}
+	return nil
+}
+
+// openHelp shows the help overlay for the current screen, if it has one
+func (m *Model) openHelp() {
+	if len(m.helpSections()) > 0 {
+		m.help = helpOverlay{open: true}
+	}
+}
+
+// helpLines renders the help overlay's sections, one entry per line, within width
+func (m *Model) helpLines(width int) []string {
+	sections := m.helpSections()
+	keyWidth := 0
+	for _, section := range sections {
+		for _, binding := range section.bindings {
+			keyWidth = max(keyWidth, ansi.StringWidth(binding.Help().Key))
+		}
+	}
+
+	var lines []string
+	for i, section := range sections {
+		if i > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, highlightStyle.Render(section.title))
+		for _, binding := range section.bindings {
+			help := binding.Help()
+			if !binding.Enabled() || help.Key == "" {
+				continue
+			}
+			lines = append(lines, keyStyle.Width(keyWidth).Render(help.Key)+"  "+ansi.Truncate(help.Desc, width-keyWidth-2, "…"))
+		}
+		if len(section.notes) > 0 {
+			lines = append(lines, "")
+		}
+		for _, note := range section.notes {
+			lines = append(lines, subtleStyle.Render(ansi.Truncate(note, width, "…")))
+		}
+	}
+	return lines
+}
+
+// helpBodyHeight is how many help lines fit on screen inside the box
+func (m *Model) helpBodyHeight() int {
+	return max(m.height-6, 5) // Border, spacer and footer line
+}
+
+// helpWidth is the width of the help box
+func (m *Model) helpWidth() int {
+	return min(m.width-8, 80)
+}
+
+// handleHelpKeys scrolls the help overlay; any other key closes it
+func (m *Model) handleHelpKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	help := &m.help
+	page := m.helpBodyHeight()
+	maxOffset := max(len(m.helpLines(m.helpWidth()-2))-page, 0)
+	switch msg.String() {
+	case "ctrl+c":
+		return m, m.Quit()
+	case "up", "k":
+		help.offset--
+	case "down", "j":
+		help.offset++
+	case "pgup", "left":
+		help.offset -= page
+	case "pgdown", "right", " ":
+		help.offset += page
+	default:
+		help.open = false
+	}
+	help.offset = max(min(help.offset, maxOffset), 0)
+	return m, nil
+}
+
+// helpView renders the help box
+func (m *Model) helpView() string {
+	width := m.helpWidth()
+	lines := m.helpLines(width - 2)
+	page := m.helpBodyHeight()
+
+	footer := "Any key: Close"
+	if total := len(lines); total > page {
+		end := min(m.help.offset+page, total)
+		lines = lines[m.help.offset:end]
+		footer = fmt.Sprintf("↑/↓: Scroll (%d%%) • Any other key: Close", end*100/total)
+	}
+
+	content := strings.Join(lines, "\n") + "\n\n" + subtleStyle.Render(footer)
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(primaryColor).
+		Padding(0, 1).
+		Width(width).
+		Render(content)
+}
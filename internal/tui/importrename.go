@@ -0,0 +1,198 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/shel-corp/Claude-command-manager/internal/remote"
+)
+
+// remoteRenameState holds the name being typed for a remote command, or the prefix for
+// the selected ones, before they are imported
+type remoteRenameState struct {
+	indexes []int // Remote commands being renamed
+	prefix  bool  // Prepend the input to each name rather than replace it
+	input   textinput.Model
+	err     string
+}
+
+// remoteImportCommand returns remote command i as it will be imported: under its new
+// name if it was renamed, and with LocalExists checked for that name
+func (m *Model) remoteImportCommand(i int) remote.RemoteCommand {
+	cmd := m.remoteCommands[i]
+	if name, ok := m.remoteRenames[i]; ok {
+		cmd.Name = name
+		target, _ := m.remoteImportTarget()
+		_, err := os.Stat(remote.LocalPath(target, name))
+		cmd.LocalExists = err == nil
+	}
+	return cmd
+}
+
+// StartRemoteRename asks for a new name for the highlighted remote command, or with
+// prefix, for a prefix to add to the names of the selected ones (or the highlighted one)
+func (m *Model) StartRemoteRename(prefix bool) tea.Cmd {
+	item, ok := m.list.SelectedItem().(remoteCommandItem)
+	if !ok {
+		return nil
+	}
+
+	indexes := []int{item.index}
+	if prefix {
+		var selected []int
+		for i := range m.remoteCommands {
+			if m.remoteSelected[i] {
+				selected = append(selected, i)
+			}
+		}
+		if len(selected) > 0 {
+			indexes = selected
+		}
+	}
+
+	input := textinput.New()
+	input.CharLimit = 100
+	input.Width = 40
+	if prefix {
+		input.Placeholder = "team-"
+	} else {
+		input.SetValue(item.command.Name)
+		input.CursorEnd()
+	}
+
+	m.remoteRename = remoteRenameState{indexes: indexes, prefix: prefix, input: input}
+	m.state = StateRemoteRename
+	return m.remoteRename.input.Focus()
+}
+
+// remoteRenameNames returns the names the commands being renamed would get from the input
+func (m *Model) remoteRenameNames() map[int]string {
+	rename := m.remoteRename
+	value := strings.TrimSpace(rename.input.Value())
+	names := make(map[int]string, len(rename.indexes))
+	for _, i := range rename.indexes {
+		if rename.prefix {
+			names[i] = value + m.remoteImportCommand(i).Name
+		} else {
+			names[i] = value
+		}
+	}
+	return names
+}
+
+// validateRemoteRename reports why the new names cannot be used, or "" if they can
+func (m *Model) validateRemoteRename(names map[int]string) string {
+	taken := make(map[string]int)
+	for i := range m.remoteCommands {
+		if _, renaming := names[i]; !renaming && m.remoteSelected[i] {
+			taken[m.remoteImportCommand(i).Name] = i
+		}
+	}
+
+	for i, name := range names {
+		switch {
+		case name == "":
+			return "Name cannot be empty"
+		case len(name) > 100:
+			return "Name too long (max 100 characters)"
+		case filepath.Base(remote.LocalPath("", name)) != name+".md":
+			return fmt.Sprintf("%q cannot be a file name; avoid / \\ : * ? \" < > | and ..", name)
+		}
+		if other, exists := taken[name]; exists && other != i {
+			return fmt.Sprintf("Another selected command is already imported as %s", name)
+		}
+		taken[name] = i
+	}
+	return ""
+}
+
+// ConfirmRemoteRename saves the new names and goes back to the command list
+func (m *Model) ConfirmRemoteRename() {
+	names := m.remoteRenameNames()
+	if err := m.validateRemoteRename(names); err != "" {
+		m.remoteRename.err = err
+		return
+	}
+
+	for i, name := range names {
+		if name == m.remoteCommands[i].Name {
+			delete(m.remoteRenames, i)
+		} else {
+			m.remoteRenames[i] = name
+		}
+	}
+	m.state = StateRemoteSelect
+	m.updateRemoteCommandList()
+	if len(names) > 1 {
+		m.setStatus(fmt.Sprintf("%d commands will be imported with the prefix %s", len(names), strings.TrimSpace(m.remoteRename.input.Value())), StatusSuccess)
+	}
+}
+
+// handleRemoteRenameStateKeys handles keys while naming remote commands
+func (m *Model) handleRemoteRenameStateKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m, m.Quit()
+	case "esc":
+		m.state = StateRemoteSelect
+		return m, nil
+	case "enter":
+		m.ConfirmRemoteRename()
+		return m, nil
+	}
+
+	m.remoteRename.err = ""
+	var cmd tea.Cmd
+	m.remoteRename.input, cmd = m.remoteRename.input.Update(msg)
+	return m, cmd
+}
+
+// remoteRenameView renders the name or prefix input for remote commands
+func (m *Model) remoteRenameView() string {
+	rename := m.remoteRename
+	header := "Import Under Another Name"
+	label := "New name:"
+	if rename.prefix {
+		header = "Prefix Command Names"
+		label = "Prefix:"
+	}
+
+	var content strings.Builder
+	if len(rename.indexes) == 1 {
+		content.WriteString(fmt.Sprintf("Command: %s\n\n", highlightStyle.Render(m.remoteCommands[rename.indexes[0]].Name)))
+	} else {
+		content.WriteString(fmt.Sprintf("Commands: %s\n\n", highlightStyle.Render(fmt.Sprintf("%d selected", len(rename.indexes)))))
+	}
+	content.WriteString(label + "\n")
+	content.WriteString(rename.input.View())
+	content.WriteString("\n")
+
+	if rename.err != "" {
+		content.WriteString("\n")
+		content.WriteString(dangerStyle.Render("⚠️ " + rename.err))
+		content.WriteString("\n")
+	} else if value := strings.TrimSpace(rename.input.Value()); value != "" {
+		// Preview the resulting file names
+		target, _ := m.remoteImportTarget()
+		names := m.remoteRenameNames()
+		content.WriteString("\n")
+		for n, i := range rename.indexes {
+			if n == 5 {
+				content.WriteString(subtleStyle.Render(fmt.Sprintf("  …and %d more", len(rename.indexes)-n)))
+				content.WriteString("\n")
+				break
+			}
+			content.WriteString(subtleStyle.Render("  → " + remote.LocalPath(target, names[i])))
+			content.WriteString("\n")
+		}
+	}
+
+	footer := "Enter: Confirm • Esc: Back to Commands • Ctrl+C: Quit"
+
+	return centerView(header, content.String(), footer, m.width)
+}
@@ -0,0 +1,185 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/shel-corp/Claude-command-manager/internal/commands"
+	"github.com/shel-corp/Claude-command-manager/internal/models"
+)
+
+// commandInfoState holds the command shown on the info screen, as read when it opened
+type commandInfoState struct {
+	command     commands.Command
+	size        int64
+	modTime     time.Time
+	symlinkPath string
+	symlink     string // Where the symlink currently points, or a description of its absence
+}
+
+// StartCommandInfo shows where the selected command lives, its symlink and where it came from
+func (m *Model) StartCommandInfo() {
+	cmd := m.GetSelectedCommand()
+	if cmd == nil {
+		return
+	}
+
+	info, err := os.Stat(cmd.FilePath)
+	if err != nil {
+		m.setStatus(fmt.Sprintf("Failed to read %s: %v", cmd.DisplayName, err), StatusError)
+		return
+	}
+	symlinkPath := m.getCurrentCommandManager().SymlinkPath(*cmd)
+	m.commandInfo = commandInfoState{
+		command:     *cmd,
+		size:        info.Size(),
+		modTime:     info.ModTime(),
+		symlinkPath: symlinkPath,
+		symlink:     describeSymlink(symlinkPath, cmd.FilePath),
+	}
+	m.state = StateCommandInfo
+}
+
+// handleCommandInfoStateKeys handles keys on the command info screen
+func (m *Model) handleCommandInfoStateKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "I", "q":
+		m.state = StateLibrary
+	case "ctrl+c":
+		return m, m.Quit()
+	case "p":
+		m.StartLibraryPreview()
+	case "e":
+		m.state = StateLibrary
+		return m, m.EditSelectedCommand()
+	case "y", "Y":
+		m.copyCommand(&m.commandInfo.command, msg.String() == "Y")
+	}
+	return m, nil
+}
+
+// symlinkHealth describes whether the symlink matches the command's enabled state,
+// and whether that is a problem
+func symlinkHealth(enabled bool, symlink string) (string, bool) {
+	linked := strings.HasPrefix(symlink, "→")
+	switch {
+	case enabled && linked:
+		return "valid", false
+	case enabled:
+		return "broken: enabled but " + symlink, true
+	case linked:
+		return "stale: linked although disabled", true
+	}
+	return "not linked (disabled)", false
+}
+
+// formatFileSize renders a byte count for display
+func formatFileSize(size int64) string {
+	switch {
+	case size < 1024:
+		return fmt.Sprintf("%d bytes", size)
+	case size < 1024*1024:
+		return fmt.Sprintf("%.1f KB", float64(size)/1024)
+	}
+	return fmt.Sprintf("%.1f MB", float64(size)/(1024*1024))
+}
+
+// formatAge renders how long ago a time was, to the largest whole unit
+func formatAge(t time.Time) string {
+	age := time.Since(t)
+	switch {
+	case age < time.Minute:
+		return "just now"
+	case age < time.Hour:
+		return fmt.Sprintf("%d min ago", int(age.Minutes()))
+	case age < 24*time.Hour:
+		return fmt.Sprintf("%d hours ago", int(age.Hours()))
+	}
+	return fmt.Sprintf("%d days ago", int(age.Hours()/24))
+}
+
+// commandInfoView renders the command info screen
+func (m *Model) commandInfoView() string {
+	info := m.commandInfo
+	cmd := info.command
+	header := fmt.Sprintf("ℹ️  Command Info: %s", cmd.DisplayName)
+
+	row := func(label, value string) string {
+		if label != "" {
+			label += ":"
+		}
+		return fmt.Sprintf("%-11s %s\n", label, value)
+	}
+
+	var content strings.Builder
+	content.WriteString(row("Name", highlightStyle.Render(cmd.DisplayName)))
+	if cmd.Name != cmd.DisplayName {
+		content.WriteString(row("Original", cmd.Name))
+	}
+	if cmd.Description != "" {
+		content.WriteString(row("Summary", subtleStyle.Render(cmd.Description)))
+	}
+	content.WriteString("\n")
+
+	content.WriteString(row("File", cmd.RelativePath))
+	content.WriteString(row("Full path", subtleStyle.Render(cmd.FilePath)))
+	content.WriteString(row("Size", formatFileSize(info.size)))
+	content.WriteString(row("Modified", fmt.Sprintf("%s %s",
+		info.modTime.Format("2006-01-02 15:04"), subtleStyle.Render("("+formatAge(info.modTime)+")"))))
+	content.WriteString("\n")
+
+	status := "disabled"
+	if cmd.Enabled {
+		status = fmt.Sprintf("enabled (%s)", cmd.SymlinkLocation)
+	}
+	content.WriteString(row("Status", highlightStyle.Render(status)))
+	content.WriteString(row("Symlink", subtleStyle.Render(info.symlinkPath)))
+	content.WriteString(row("Target", subtleStyle.Render(strings.TrimPrefix(info.symlink, "→ "))))
+	if health, problem := symlinkHealth(cmd.Enabled, info.symlink); problem {
+		content.WriteString(row("Validity", warningStyle.Render("⚠️  "+health)))
+	} else {
+		content.WriteString(row("Validity", successStyle.Render(health)))
+	}
+	content.WriteString("\n")
+
+	if cmd.Source != "" {
+		content.WriteString(row("Source", fmt.Sprintf("%s %s", cmd.Source,
+			subtleStyle.Render("(https://github.com/"+cmd.Source+")"))))
+	} else {
+		content.WriteString(row("Source", subtleStyle.Render("local (not imported)")))
+	}
+	if cmd.Model != "" {
+		content.WriteString(row("Model", cmd.Model))
+		if warning := models.DeprecationWarning(cmd.Model); warning != "" {
+			content.WriteString(row("", warningStyle.Render("⚠️  "+warning)))
+		}
+	}
+	if len(cmd.Tags) > 0 {
+		content.WriteString(row("Tags", strings.Join(cmd.Tags, ", ")))
+	}
+	if cmd.Pinned {
+		content.WriteString(row("Pinned", "yes"))
+	}
+	if stats, ok := m.commandUsage(cmd); ok {
+		if stats.Total == 0 {
+			content.WriteString(row("Last used", subtleStyle.Render("never (in Claude's session transcripts)")))
+		} else {
+			content.WriteString(row("Last used", fmt.Sprintf("%s %s",
+				stats.LastUsed.Local().Format("2006-01-02 15:04"), subtleStyle.Render("("+formatAge(stats.LastUsed)+")"))))
+		}
+		content.WriteString(row("Uses", fmt.Sprintf("%d this month, %d in all", stats.ThisMonth, stats.Total)))
+	}
+	if dir := filepath.Dir(cmd.RelativePath); dir != "." {
+		content.WriteString(row("Namespace", filepath.ToSlash(dir)))
+	}
+
+	content.WriteString(m.renderStatusMessage())
+
+	footer := "p: Preview • e: Edit • y/Y: Copy Content/Path • I/Esc: Back • Ctrl+C: Quit"
+	return centerView(header, content.String(), footer, m.width)
+}
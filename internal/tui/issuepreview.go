@@ -0,0 +1,226 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/shel-corp/Claude-command-manager/internal/remote"
+)
+
+// issueDraft is a reported issue as it will be filed
+type issueDraft struct {
+	repo  *remote.RemoteRepository
+	title string
+	body  string // As typed, with diagnostics if attached; ccm's footer is added when filed
+	label string // Label of the issue type
+}
+
+// similarIssueLimit caps how many existing issues are offered as possible duplicates
+const similarIssueLimit = 5
+
+// issuePreviewState holds the issue shown for confirmation before it is submitted
+type issuePreviewState struct {
+	draft     issueDraft
+	scroll    int                    // First body line shown
+	searching bool                   // Looking for existing issues with similar titles
+	similar   []remote.ExistingIssue // Possible duplicates, best matches first
+	searchErr string
+}
+
+// similarIssuesMsg carries the existing issues whose titles resemble a draft's
+type similarIssuesMsg struct {
+	title  string // Title searched for, to drop results for an earlier draft
+	issues []remote.ExistingIssue
+	err    error
+}
+
+// StartIssuePreview shows the issue from the report form exactly as it will be filed,
+// and looks for existing issues it may duplicate
+func (m *Model) StartIssuePreview() tea.Cmd {
+	repo, err := remote.IssueRepository(GetThemeManager().GetAppConfig().Report.Repository)
+	if err != nil {
+		m.issueSubmitError = err.Error()
+		return nil
+	}
+
+	body := strings.TrimSpace(m.issueBodyInput.Value())
+	if m.issueDiagnostics {
+		body += "\n\n" + issueDiagnostics(m.width, m.height)
+	}
+	m.issuePreview = issuePreviewState{draft: issueDraft{
+		repo:  repo,
+		title: strings.TrimSpace(m.issueTitleInput.Value()),
+		body:  body,
+		label: remote.IssueTypes[m.issueType],
+	}, searching: true}
+	m.issueSubmitError = ""
+	m.clearStatus()
+	m.markdown = markdownCache{} // Re-render in case the theme changed
+	m.state = StateReportIssuePreview
+
+	title := m.issuePreview.draft.title
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		issues, err := remote.FindSimilarIssues(ctx, repo, title, similarIssueLimit)
+		return similarIssuesMsg{title: title, issues: issues, err: err}
+	}
+}
+
+// handleSimilarIssues shows the possible duplicates of the previewed issue
+func (m *Model) handleSimilarIssues(msg similarIssuesMsg) {
+	preview := &m.issuePreview
+	if msg.title != preview.draft.title {
+		return
+	}
+	preview.searching = false
+	preview.similar = msg.issues
+	preview.searchErr = ""
+	if msg.err != nil {
+		preview.searchErr = msg.err.Error()
+	}
+}
+
+// openSimilarIssue opens possible duplicate n (1-based) in the browser, or copies its URL
+// when no browser can be started, e.g. over SSH
+func (m *Model) openSimilarIssue(n int) {
+	similar := m.issuePreview.similar
+	if n < 1 || n > len(similar) {
+		return
+	}
+	issue := similar[n-1]
+	if err := openInBrowser(issue.URL); err != nil {
+		if err := copyToClipboard(issue.URL); err != nil {
+			m.setStatus(fmt.Sprintf("Could not open #%d: %s", issue.Number, issue.URL), StatusWarning)
+			return
+		}
+		m.setStatus(fmt.Sprintf("📋 No browser available; copied the URL of #%d", issue.Number), StatusInfo)
+		return
+	}
+	m.setStatus(fmt.Sprintf("Opened #%d in the browser", issue.Number), StatusSuccess)
+}
+
+// similarIssuesSection renders the possible duplicates of the previewed issue
+func (m *Model) similarIssuesSection() string {
+	preview := m.issuePreview
+	var section strings.Builder
+	switch {
+	case preview.searching:
+		section.WriteString(subtleStyle.Render("🔍 Checking for similar issues..."))
+		section.WriteString("\n")
+	case preview.searchErr != "":
+		section.WriteString(subtleStyle.Render(ansi.Truncate("Could not check for similar issues: "+preview.searchErr, contentWidth(m.width), "…")))
+		section.WriteString("\n")
+	case len(preview.similar) == 0:
+		section.WriteString(subtleStyle.Render("No similar issues found"))
+		section.WriteString("\n")
+	default:
+		section.WriteString(warningStyle.Render("⚠️ This may already be reported:"))
+		section.WriteString("\n")
+		for i, issue := range preview.similar {
+			state := successStyle.Render(issue.State)
+			if issue.State != "open" {
+				state = subtleStyle.Render(issue.State)
+			}
+			line := fmt.Sprintf("  %d. #%d %s", i+1, issue.Number, issue.Title)
+			section.WriteString(ansi.Truncate(line, contentWidth(m.width)-len(issue.State)-3, "…"))
+			section.WriteString(" (" + state + ")\n")
+		}
+	}
+	return section.String()
+}
+
+// issuePreviewBodyLines returns how many body lines fit below the title, labels and
+// possible duplicates
+func (m *Model) issuePreviewBodyLines() int {
+	lines := m.height - 17 - strings.Count(m.similarIssuesSection(), "\n")
+	if m.issueSubmitError != "" || m.issueSubmitting {
+		lines -= 3
+	}
+	if m.showStatus && m.statusMessage != "" {
+		lines -= 2
+	}
+	return max(lines, 5)
+}
+
+// handleIssuePreviewStateKeys handles keys while confirming a reported issue
+func (m *Model) handleIssuePreviewStateKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	preview := &m.issuePreview
+	page := m.issuePreviewBodyLines()
+	maxScroll := max(len(m.markdownLines(remote.IssueBody(preview.draft.body)))-page, 0)
+
+	switch msg.String() {
+	case "ctrl+c":
+		return m, m.Quit()
+	case "esc", "e":
+		if !m.issueSubmitting {
+			m.state = StateReportIssue
+		}
+	case "enter", "ctrl+s":
+		if !m.issueSubmitting {
+			return m, m.SubmitIssue()
+		}
+	case "up", "k":
+		preview.scroll = max(preview.scroll-1, 0)
+	case "down", "j":
+		preview.scroll = min(preview.scroll+1, maxScroll)
+	case "pgup", "b":
+		preview.scroll = max(preview.scroll-page, 0)
+	case "pgdown", " ", "f":
+		preview.scroll = min(preview.scroll+page, maxScroll)
+	case "1", "2", "3", "4", "5":
+		m.openSimilarIssue(int(msg.String()[0] - '0'))
+	}
+	return m, nil
+}
+
+// issuePreviewView renders the issue as it will be filed
+func (m *Model) issuePreviewView() string {
+	preview := m.issuePreview
+	draft := preview.draft
+	header := "Preview Issue"
+
+	var content strings.Builder
+	content.WriteString(fmt.Sprintf("Repository: %s\n", highlightStyle.Render(draft.repo.FullName())))
+	content.WriteString(fmt.Sprintf("Title: %s\n", highlightStyle.Render(draft.title)))
+	content.WriteString(fmt.Sprintf("Labels: %s\n", subtleStyle.Render(strings.Join(remote.IssueLabels(draft.label), ", "))))
+	content.WriteString("\n")
+	content.WriteString(m.similarIssuesSection())
+	content.WriteString("\n")
+	content.WriteString(strings.Repeat("─", m.previewWidth()))
+	content.WriteString("\n\n")
+
+	// Rendering to a new width can shorten the body below the scroll position
+	lines := m.markdownLines(remote.IssueBody(draft.body))
+	start := min(preview.scroll, len(lines)-1)
+	end := min(start+m.issuePreviewBodyLines(), len(lines))
+	for _, line := range lines[start:end] {
+		content.WriteString(line)
+		content.WriteString("\n")
+	}
+	if len(lines) > m.issuePreviewBodyLines() {
+		content.WriteString(subtleStyle.Render(fmt.Sprintf("lines %d-%d of %d", start+1, end, len(lines))))
+		content.WriteString("\n")
+	}
+
+	if m.issueSubmitError != "" {
+		content.WriteString("\n")
+		content.WriteString(dangerStyle.Render("Error: " + m.issueSubmitError))
+		content.WriteString("\n")
+	}
+	if m.issueSubmitting {
+		content.WriteString("\n📤 Submitting issue...\n")
+	}
+	content.WriteString(m.renderStatusMessage())
+
+	footer := "↑/↓: Scroll • Space/b: Page • Enter: Submit • e/Esc: Back to Edit • Ctrl+C: Quit"
+	if n := len(preview.similar); n > 0 {
+		footer = fmt.Sprintf("1-%d: Open Existing Issue • ", n) + footer
+	}
+	return centerView(header, content.String(), footer, m.width)
+}
@@ -0,0 +1,503 @@
+package tui
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/shel-corp/Claude-command-manager/internal/logging"
+	"github.com/shel-corp/Claude-command-manager/internal/theme"
+)
+
+// KeyMap holds the library key bindings. Ctrl+C (force quit) and Esc (back) are fixed.
+// A key may be a sequence of keys separated by spaces, such as "g g".
+type KeyMap struct {
+	Up            key.Binding
+	Down          key.Binding
+	PageUp        key.Binding
+	PageDown      key.Binding
+	Top           key.Binding
+	Bottom        key.Binding
+	Toggle        key.Binding
+	Select        key.Binding
+	SelectAll     key.Binding
+	SelectNone    key.Binding
+	Rename        key.Binding
+	Edit          key.Binding
+	EditInline    key.Binding
+	NewCommand    key.Binding
+	Preview       key.Binding
+	Info          key.Binding
+	Copy          key.Binding
+	CopyPath      key.Binding
+	Duplicate     key.Binding
+	Meta          key.Binding
+	Delete        key.Binding
+	Group         key.Binding
+	CollapseAll   key.Binding
+	Location      key.Binding
+	Pin           key.Binding
+	SwitchLibrary key.Binding
+	ModelFilter   key.Binding
+	StatusFilter  key.Binding
+	TagFilter     key.Binding
+	Sort          key.Binding
+	Views         key.Binding
+	Tags          key.Binding
+	Search        key.Binding
+	Import        key.Binding
+	Fix           key.Binding
+	Reconcile     key.Binding
+	Tasks         key.Binding
+	CommandLine   key.Binding
+	Help          key.Binding
+	Quit          key.Binding
+}
+
+// keyAction names a binding in the keybindings file; short is its footer label
+type keyAction struct {
+	name    string
+	short   string
+	binding *key.Binding
+}
+
+// actions lists every binding in help screen order
+func (k *KeyMap) actions() []keyAction {
+	return []keyAction{
+		{"up", "", &k.Up},
+		{"down", "", &k.Down},
+		{"page_up", "", &k.PageUp},
+		{"page_down", "", &k.PageDown},
+		{"top", "", &k.Top},
+		{"bottom", "", &k.Bottom},
+		{"toggle", "Toggle", &k.Toggle},
+		{"select", "Select", &k.Select},
+		{"select_all", "All", &k.SelectAll},
+		{"select_none", "None", &k.SelectNone},
+		{"rename", "Rename", &k.Rename},
+		{"edit", "Edit", &k.Edit},
+		{"edit_inline", "Edit Here", &k.EditInline},
+		{"new", "New", &k.NewCommand},
+		{"preview", "Preview", &k.Preview},
+		{"info", "Info", &k.Info},
+		{"copy", "Copy", &k.Copy},
+		{"copy_path", "Copy Path", &k.CopyPath},
+		{"duplicate", "Duplicate", &k.Duplicate},
+		{"meta", "Frontmatter", &k.Meta},
+		{"delete", "Delete", &k.Delete},
+		{"group", "Group", &k.Group},
+		{"collapse_all", "Collapse", &k.CollapseAll},
+		{"location", "Location", &k.Location},
+		{"pin", "Pin", &k.Pin},
+		{"switch_library", "Switch Library", &k.SwitchLibrary},
+		{"model_filter", "Model Filter", &k.ModelFilter},
+		{"status_filter", "Status", &k.StatusFilter},
+		{"tag_filter", "Tag Filter", &k.TagFilter},
+		{"sort", "Sort", &k.Sort},
+		{"views", "Views", &k.Views},
+		{"tags", "Tags", &k.Tags},
+		{"search", "Search", &k.Search},
+		{"import", "Import", &k.Import},
+		{"fix", "Fix", &k.Fix},
+		{"reconcile", "Reconcile", &k.Reconcile},
+		{"tasks", "Tasks", &k.Tasks},
+		{"command", "Command", &k.CommandLine},
+		{"help", "Help", &k.Help},
+		{"quit", "Quit", &k.Quit},
+	}
+}
+
+// DefaultKeyMap returns the built-in library key bindings
+func DefaultKeyMap() KeyMap {
+	binding := func(desc string, keys ...string) key.Binding {
+		return key.NewBinding(key.WithKeys(keys...), key.WithHelp(keyLabel(keys), desc))
+	}
+	return KeyMap{
+		Up:            binding("Navigate up", "up", "k"),
+		Down:          binding("Navigate down", "down", "j"),
+		PageUp:        binding("Previous page", "left", "pgup"),
+		PageDown:      binding("Next page", "right", "pgdown"),
+		Top:           binding("First command", "home"),
+		Bottom:        binding("Last command", "end"),
+		Toggle:        binding("Toggle command enabled/disabled (or collapse group)", "enter", "t"),
+		Select:        binding("Select command for bulk changes (Esc clears)", " "),
+		SelectAll:     binding("Select all shown commands", "a"),
+		SelectNone:    binding("Clear the selection", "n"),
+		Rename:        binding("Rename selected command", "r"),
+		Edit:          binding("Edit selected command in $EDITOR", "e"),
+		EditInline:    binding("Edit selected command here (Ctrl+S saves)", "E"),
+		NewCommand:    binding("Create a new command from a template", "N"),
+		Preview:       binding("Preview selected command (frontmatter, symlink and content)", "p"),
+		Info:          binding("Show file, symlink and source details of selected command", "I"),
+		Copy:          binding("Copy selected command's body to the clipboard", "y"),
+		CopyPath:      binding("Copy selected command's file path to the clipboard", "Y"),
+		Duplicate:     binding("Duplicate selected command under a new name", "D"),
+		Meta:          binding("Edit selected command's description, argument hint, allowed tools and model", "M"),
+		Delete:        binding("Delete selected command (moved to trash)", "d"),
+		Group:         binding("Cycle grouping (namespace, tag, source, status)", "g"),
+		CollapseAll:   binding("Collapse/expand all groups", "z"),
+		Location:      binding("Cycle symlink location (👤 user / 📁 project / 🔗 targets)", "l"),
+		Pin:           binding("Pin/unpin selected commands at the top of the list", "*"),
+		SwitchLibrary: binding("Switch library (👤 user / 📁 project)", "s"),
+		ModelFilter:   binding("Cycle model filter", "m"),
+		StatusFilter:  binding("Cycle status filter (all / enabled / disabled)", "f"),
+		TagFilter:     binding("Cycle tag filter", "#"),
+		Sort:          binding("Cycle sort order (name / status / model / modified / location)", "o"),
+		Views:         binding("Saved views (apply, save, set default)", "v"),
+		Tags:          binding("Add/remove tags on several commands at once", "T"),
+		Search:        binding("Search command names, descriptions and contents", "/"),
+		Import:        binding("Browse and import repository commands", "i"),
+		Fix:           binding("Fix symlinks that drifted from the config (missing, renamed, replaced)", "F"),
+		Reconcile:     binding("Reconcile project library with .claude/ccm.yaml", "P"),
+		Tasks:         binding("Show/hide background tasks (imports, loads, reports)", "b"),
+		CommandLine:   key.NewBinding(key.WithDisabled(), key.WithHelp("", "Run an action by name (:rename, :sort, :q)")),
+		Help:          binding("Show the keys of the current screen", "h", "?"),
+		Quit:          binding("Quit", "q"),
+	}
+}
+
+// VimKeyMap returns the vim profile: hjkl moves, gg/G jump to the ends and : runs an action by name.
+// Location, help and grouping move off l, h and g to make room.
+func VimKeyMap() KeyMap {
+	keys := DefaultKeyMap()
+	rebind := func(binding *key.Binding, keyNames ...string) {
+		binding.SetKeys(keyNames...)
+		binding.SetHelp(keyLabel(keyNames), binding.Help().Desc)
+		binding.SetEnabled(true)
+	}
+	rebind(&keys.PageUp, "h", "left", "ctrl+u", "pgup")
+	rebind(&keys.PageDown, "l", "right", "ctrl+d", "pgdown")
+	rebind(&keys.Top, "g g", "home")
+	rebind(&keys.Bottom, "G", "end")
+	rebind(&keys.Group, "=")
+	rebind(&keys.Location, "L")
+	rebind(&keys.CommandLine, ":")
+	rebind(&keys.Help, "?")
+	return keys
+}
+
+// RemoteKeyMap holds the key bindings of the repository browser and the remote command
+// list. They are fixed; the keybindings file only remaps the library.
+type RemoteKeyMap struct {
+	Open        key.Binding
+	Star        key.Binding
+	Search      key.Binding
+	CustomURL   key.Binding
+	SwitchFocus key.Binding
+	Toggle      key.Binding
+	Preview     key.Binding
+	Analyze     key.Binding
+	SelectAll   key.Binding
+	SelectNone  key.Binding
+	Rename      key.Binding
+	Prefix      key.Binding
+	Import      key.Binding
+	Help        key.Binding
+}
+
+// DefaultRemoteKeyMap returns the repository browser key bindings
+func DefaultRemoteKeyMap() RemoteKeyMap {
+	binding := func(desc string, keys ...string) key.Binding {
+		return key.NewBinding(key.WithKeys(keys...), key.WithHelp(keyLabel(keys), desc))
+	}
+	return RemoteKeyMap{
+		Open:        binding("Open the highlighted category or repository", "enter", " "),
+		Star:        binding("Star or unstar the repository", "*"),
+		Search:      key.NewBinding(key.WithKeys("/", "s"), key.WithHelp("/", "Search repositories (also s)")),
+		CustomURL:   binding("Import from a GitHub URL", "c"),
+		SwitchFocus: binding("Switch between the search field and the results", "tab"),
+		Toggle:      binding("Select or deselect the command for import", "enter"),
+		Preview:     binding("Preview the command", "p"),
+		Analyze:     binding("Analyze code blocks and shell snippets", "x"),
+		SelectAll:   binding("Select all commands", "a"),
+		SelectNone:  binding("Select no commands", "n"),
+		Rename:      binding("Import the highlighted command under another name", "r"),
+		Prefix:      binding("Add a prefix to the names of the selected commands", "R"),
+		Import:      binding("Import the selected commands", "i"),
+		Help:        binding("Show the keys of the current screen", "?"),
+	}
+}
+
+// keyMapProfiles are the bundled profiles selectable with library.keymap
+var keyMapProfiles = map[string]func() KeyMap{
+	"default": DefaultKeyMap,
+	"vim":     VimKeyMap,
+}
+
+// KeybindingsPath returns the file users remap keys in, next to the settings file
+func KeybindingsPath() string {
+	return filepath.Join(filepath.Dir(theme.DefaultConfigPath()), "keybindings.json")
+}
+
+// LoadKeyMap applies the keybindings file at path over the named profile. The file maps action
+// names to lists of keys, e.g. {"toggle": ["x"], "rename": ["R", "F2"]}. A missing file is
+// not an error; problems with individual entries are returned as warnings and skipped.
+func LoadKeyMap(profile, path string) (KeyMap, []string, error) {
+	newKeyMap, ok := keyMapProfiles[profile]
+	if !ok {
+		newKeyMap = DefaultKeyMap
+	}
+	keys := newKeyMap()
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return keys, nil, nil
+	}
+	if err != nil {
+		return keys, nil, fmt.Errorf("failed to read keybindings: %w", err)
+	}
+
+	var remap map[string][]string
+	if err := json.Unmarshal(data, &remap); err != nil {
+		return keys, nil, fmt.Errorf("invalid keybindings file %s: %w", path, err)
+	}
+
+	actions := make(map[string]keyAction)
+	for _, action := range keys.actions() {
+		actions[action.name] = action
+	}
+
+	var warnings []string
+	remapped := make(map[string]bool, len(remap))
+	names := make([]string, 0, len(remap))
+	for name := range remap {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		action, ok := actions[name]
+		if !ok {
+			warnings = append(warnings, fmt.Sprintf("unknown action %q", name))
+			continue
+		}
+		bound := make([]string, 0, len(remap[name]))
+		for _, k := range remap[name] {
+			if k = normalizeKey(k); k != "" {
+				bound = append(bound, k)
+			}
+		}
+		if len(bound) == 0 {
+			warnings = append(warnings, fmt.Sprintf("no keys given for %q", name))
+			continue
+		}
+		action.binding.SetKeys(bound...)
+		action.binding.SetHelp(keyLabel(bound), action.binding.Help().Desc)
+		action.binding.SetEnabled(true)
+		remapped[name] = true
+	}
+
+	return keys, append(warnings, keys.resolveConflicts(remapped)...), nil
+}
+
+// resolveConflicts unbinds keys already bound to another action and describes each one.
+// Actions in remapped (the ones the user's file binds) claim their keys first, so a remap
+// wins over a default; otherwise the earlier action keeps the key.
+func (k *KeyMap) resolveConflicts(remapped map[string]bool) []string {
+	var ordered []keyAction
+	for _, action := range k.actions() {
+		if remapped[action.name] {
+			ordered = append(ordered, action)
+		}
+	}
+	for _, action := range k.actions() {
+		if !remapped[action.name] {
+			ordered = append(ordered, action)
+		}
+	}
+
+	var conflicts []string
+	owner := make(map[string]string)
+	for _, action := range ordered {
+		var kept []string
+		for _, bound := range action.binding.Keys() {
+			if other, taken := owner[bound]; taken {
+				conflicts = append(conflicts, fmt.Sprintf("%s is bound to both %s and %s, keeping %s", keyLabel([]string{bound}), other, action.name, other))
+				continue
+			}
+			owner[bound] = action.name
+			kept = append(kept, bound)
+		}
+		if len(kept) < len(action.binding.Keys()) {
+			action.binding.SetKeys(kept...)
+			action.binding.SetHelp(keyLabel(kept), action.binding.Help().Desc)
+		}
+	}
+	return conflicts
+}
+
+// normalizeKey accepts key names as written by people ("Space", "Ctrl+X") and
+// returns them as bubbletea reports them
+func normalizeKey(k string) string {
+	if steps := strings.Fields(k); len(steps) > 1 {
+		for i, step := range steps {
+			steps[i] = normalizeKey(step)
+		}
+		return strings.Join(steps, " ")
+	}
+	k = strings.TrimSpace(k)
+	switch lower := strings.ToLower(k); {
+	case lower == "space":
+		return " "
+	case len(k) == 1:
+		return k // Single characters are case-sensitive
+	default:
+		return lower
+	}
+}
+
+// keyLabel formats keys for the help screen and footer
+func keyLabel(keys []string) string {
+	labels := make([]string, len(keys))
+	for i, k := range keys {
+		switch k {
+		case " ":
+			labels[i] = "Space"
+		case "up":
+			labels[i] = "↑"
+		case "down":
+			labels[i] = "↓"
+		case "left":
+			labels[i] = "←"
+		case "right":
+			labels[i] = "→"
+		default:
+			if len(k) > 1 {
+				// Named keys and combinations: enter -> Enter, ctrl+x -> Ctrl+X
+				parts := strings.Split(k, "+")
+				for j, part := range parts {
+					if len(part) == 1 {
+						parts[j] = strings.ToUpper(part)
+					} else {
+						parts[j] = strings.ToUpper(part[:1]) + part[1:]
+					}
+				}
+				k = strings.Join(parts, "+")
+			}
+			labels[i] = k
+		}
+	}
+	return strings.Join(labels, "/")
+}
+
+// keyHint renders a binding for a footer: "Enter/t: Toggle", or "" if it is unbound
+func keyHint(binding key.Binding, label string) string {
+	if !binding.Enabled() {
+		return ""
+	}
+	return binding.Help().Key + ": " + label
+}
+
+// joinHints joins footer hints, leaving out unbound ones
+func joinHints(hints ...string) string {
+	shown := hints[:0]
+	for _, hint := range hints {
+		if hint != "" {
+			shown = append(shown, hint)
+		}
+	}
+	return strings.Join(shown, " • ")
+}
+
+// action returns the name of the action bound to pressed, or "" if it is unbound
+func (k *KeyMap) action(pressed string) string {
+	for _, action := range k.actions() {
+		if key.Matches(keyPress(pressed), *action.binding) {
+			return action.name
+		}
+	}
+	return ""
+}
+
+// startsSequence reports whether pressed is the first key of a bound key sequence
+func (k *KeyMap) startsSequence(pressed string) bool {
+	for _, action := range k.actions() {
+		if !action.binding.Enabled() {
+			continue
+		}
+		for _, bound := range action.binding.Keys() {
+			if strings.HasPrefix(bound, pressed+" ") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// keyPress is a key, or a space-separated key sequence, matched against bindings
+type keyPress string
+
+func (k keyPress) String() string { return string(k) }
+
+// loadKeyMap loads the user's keybindings and points the list's own navigation keys at them,
+// reporting problems in the status line and falling back to the defaults
+func (m *Model) loadKeyMap() {
+	keys, warnings, err := LoadKeyMap(GetThemeManager().GetAppConfig().Library.Keymap, KeybindingsPath())
+	switch {
+	case err != nil:
+		logging.Warnf("%v", err)
+		m.setStatus(fmt.Sprintf("Keybindings not loaded: %v", err), StatusWarning)
+	case len(warnings) > 0:
+		logging.Warnf("keybindings: %s", strings.Join(warnings, "; "))
+		m.setStatus("Keybindings: "+strings.Join(warnings, "; "), StatusWarning)
+	}
+
+	m.keys = keys
+	m.list.KeyMap.CursorUp = keys.Up
+	m.list.KeyMap.CursorDown = keys.Down
+	// PgUp/PgDn/Home/End page and jump in every list, whatever else they are bound to
+	m.list.KeyMap.PrevPage = withKeys(keys.PageUp, "pgup")
+	m.list.KeyMap.NextPage = withKeys(keys.PageDown, "pgdown")
+	m.list.KeyMap.GoToStart = withKeys(keys.Top, "home")
+	m.list.KeyMap.GoToEnd = withKeys(keys.Bottom, "end")
+	// Keys no longer bound to quit must not reach the list's own quit binding
+	m.list.KeyMap.Quit.SetKeys(keys.Quit.Keys()...)
+}
+
+// withKeys returns a copy of binding that also responds to the given keys
+func withKeys(binding key.Binding, extra ...string) key.Binding {
+	keys := slices.Clone(binding.Keys())
+	for _, k := range extra {
+		if !slices.Contains(keys, k) {
+			keys = append(keys, k)
+		}
+	}
+	binding.SetKeys(keys...)
+	return binding
+}
+
+// keymapProfile returns the selected key binding profile
+func keymapProfile() string {
+	if profile := GetThemeManager().GetAppConfig().Library.Keymap; profile != "" {
+		return profile
+	}
+	return theme.LibraryKeymaps[0]
+}
+
+// CycleKeymapProfile switches to the next bundled key binding profile and saves the choice
+func (m *Model) CycleKeymapProfile() tea.Cmd {
+	current := keymapProfile()
+	next := theme.LibraryKeymaps[0]
+	for i, profile := range theme.LibraryKeymaps {
+		if profile == current {
+			next = theme.LibraryKeymaps[(i+1)%len(theme.LibraryKeymaps)]
+		}
+	}
+
+	if err := GetThemeManager().SetValue("library.keymap", next); err != nil {
+		m.setStatus(fmt.Sprintf("Failed to save key binding profile: %v", err), StatusError)
+		return nil
+	}
+	m.clearStatus()
+	m.loadKeyMap()
+	if m.statusMessage == "" {
+		m.setStatus(fmt.Sprintf("Using the %s key binding profile", next), StatusSuccess)
+	}
+	m.initSettingsMenu()
+	return nil
+}
@@ -0,0 +1,135 @@
+package tui
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// compactWidth is the narrowest terminal that gets the full layout. Below it the header,
+// list rows and footers are condensed so the TUI stays usable down to about 60 columns.
+const compactWidth = 80
+
+// compactLayout reports whether the terminal is too narrow for the full layout
+func (m *Model) compactLayout() bool {
+	return m.width > 0 && m.width < compactWidth
+}
+
+// contentWidth is the room left for content inside the margin and padding of leftMarginView
+func contentWidth(width int) int {
+	return max(width-8, 20)
+}
+
+// wrapHints breaks a footer of hints joined with " • " between hints rather than
+// inside them, so each line stays within width
+func wrapHints(footer string, width int) string {
+	lines := strings.Split(footer, "\n")
+	for i, line := range lines {
+		if ansi.StringWidth(line) <= width {
+			continue
+		}
+		var wrapped []string
+		current := ""
+		for _, hint := range strings.Split(line, " • ") {
+			switch {
+			case current == "":
+				current = hint
+			case ansi.StringWidth(current+" • "+hint) <= width:
+				current += " • " + hint
+			default:
+				wrapped = append(wrapped, current)
+				current = hint
+			}
+		}
+		lines[i] = strings.Join(append(wrapped, current), "\n")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// pageStatus describes the part of the list on screen, as "Page 2/5 (11-20 of 47)",
+// or "" when the whole list fits on one page
+func (m *Model) pageStatus() string {
+	paginator := m.list.Paginator
+	if paginator.TotalPages <= 1 {
+		return ""
+	}
+	total := len(m.list.VisibleItems())
+	start, end := paginator.GetSliceBounds(total)
+	return fmt.Sprintf("Page %d/%d (%d-%d of %d)", paginator.Page+1, paginator.TotalPages, start+1, end, total)
+}
+
+// pagedFooter puts the page status and the page keys in front of a list screen's footer
+// when the list spans several pages
+func (m *Model) pagedFooter(footer string) string {
+	status := m.pageStatus()
+	switch {
+	case status == "":
+		return footer
+	case m.compactLayout():
+		return status + " • " + footer
+	}
+	return status + " • PgUp/PgDn: Page • " + footer
+}
+
+// newListDelegate returns the list delegate for the layout: cards, or single-line rows
+func newListDelegate(compact bool) CustomDelegate {
+	delegate := NewCustomDelegate()
+	delegate.compact = compact
+	delegate.ShowDescription = true
+	if compact {
+		delegate.SetHeight(1)
+		delegate.SetSpacing(0)
+	} else {
+		delegate.SetHeight(5)  // Card: border, title and description, then a blank line
+		delegate.SetSpacing(1) // Add spacing between cards
+	}
+	return delegate
+}
+
+// applyLayout sizes the list for the terminal and switches its rows between cards and single lines
+func (m *Model) applyLayout() {
+	m.syncListRows()
+	m.list.SetWidth(contentWidth(m.width))
+	m.list.SetHeight(max(m.calculateAvailableHeight(), 3)) // Minimum height for list
+}
+
+// syncListRows draws the list as single-line rows on narrow terminals and in plain mode,
+// which has no box drawing for the cards
+func (m *Model) syncListRows() {
+	if compact := m.compactLayout() || m.plainMode(); compact != m.compactList {
+		m.compactList = compact
+		m.list.SetDelegate(newListDelegate(compact))
+	}
+}
+
+// renderCompact renders an item as a single line: cursor, title and as much of the
+// description as fits
+func (d CustomDelegate) renderCompact(w io.Writer, m list.Model, index int, item list.Item) {
+	isSelected := index == m.Index()
+
+	if header, ok := item.(groupHeaderItem); ok {
+		headerStyle := subtleStyle.Bold(true)
+		if isSelected {
+			headerStyle = highlightStyle
+		}
+		fmt.Fprint(w, headerStyle.Render(ansi.Truncate(header.Title(), m.Width(), "…")))
+		return
+	}
+
+	title := item.(interface{ Title() string }).Title()
+	desc := item.(interface{ Description() string }).Description()
+
+	cursor, titleStyle := "  ", lipgloss.NewStyle().Foreground(textColor).Bold(boldText)
+	if isSelected {
+		cursor, titleStyle = "▶ ", highlightStyle
+	}
+	line := titleStyle.Render(title)
+	if desc != "" {
+		line += subtleStyle.Render(" — " + desc)
+	}
+	fmt.Fprint(w, cursor+ansi.Truncate(line, m.Width()-lipgloss.Width(cursor), "…"))
+}
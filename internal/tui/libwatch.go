@@ -0,0 +1,148 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/fsnotify/fsnotify"
+
+	"github.com/shel-corp/Claude-command-manager/internal/commands"
+	"github.com/shel-corp/Claude-command-manager/internal/logging"
+)
+
+// libraryWatchSettle is how long the library directories must stay quiet before a change
+// is reported, so a git pull or an editor's save sequence causes a single reload
+const libraryWatchSettle = 200 * time.Millisecond
+
+// libraryChangedMsg reports that something changed in a watched library directory
+type libraryChangedMsg struct{}
+
+// libraryScannedMsg carries the signature of a library read after a change on disk
+type libraryScannedMsg struct {
+	manager   *commands.Manager
+	signature string
+}
+
+// newLibraryWatcher watches the given library directories and all their subdirectories
+// for changes made outside ccm, such as a git pull or an editor in another terminal.
+// It returns nil if the platform cannot watch files; the library is then reloaded by hand.
+func newLibraryWatcher(dirs ...string) *fsnotify.Watcher {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		logging.Warnf("failed to watch the library for changes: %v", err)
+		return nil
+	}
+	for _, dir := range dirs {
+		addLibraryDirs(watcher, dir)
+	}
+	return watcher
+}
+
+// addLibraryDirs watches dir and its subdirectories, skipping hidden ones such as .trash
+// the way ScanCommands does
+func addLibraryDirs(watcher *fsnotify.Watcher, dir string) {
+	filepath.WalkDir(dir, func(path string, entry os.DirEntry, err error) error {
+		if err != nil || !entry.IsDir() {
+			return nil
+		}
+		if path != dir && strings.HasPrefix(entry.Name(), ".") {
+			return filepath.SkipDir
+		}
+		if err := watcher.Add(path); err != nil {
+			logging.Warnf("failed to watch %s for changes: %v", path, err)
+		}
+		return nil
+	})
+}
+
+// waitForLibraryChange blocks until a watched library directory changes and has settled,
+// watching any directory created in the meantime
+func waitForLibraryChange(watcher *fsnotify.Watcher) tea.Cmd {
+	if watcher == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		var settle <-chan time.Time
+		for {
+			select {
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return nil
+				}
+				if event.Op == fsnotify.Chmod {
+					continue
+				}
+				if event.Has(fsnotify.Create) {
+					if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+						addLibraryDirs(watcher, event.Name)
+					}
+				}
+				settle = time.After(libraryWatchSettle)
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return nil
+				}
+				logging.Warnf("library watch: %v", err)
+			case <-settle:
+				return libraryChangedMsg{}
+			}
+		}
+	}
+}
+
+// handleLibraryChanged notes a change on disk and goes back to waiting for the next one.
+// The change is checked once the library screen is showing, so the list does not shift
+// under a dialog acting on the selected command.
+func (m *Model) handleLibraryChanged() (tea.Model, tea.Cmd) {
+	m.libraryPending = true
+	return m, waitForLibraryChange(m.libraryWatcher)
+}
+
+// checkLibrary reads the current library's signature in the background when a change
+// is pending and the library screen can take a reload
+func (m *Model) checkLibrary() tea.Cmd {
+	if !m.libraryPending || m.state != StateLibrary || m.list.SettingFilter() {
+		return nil
+	}
+	m.libraryPending = false
+	manager := m.getCurrentCommandManager()
+	return func() tea.Msg {
+		return libraryScannedMsg{manager: manager, signature: manager.Signature()}
+	}
+}
+
+// handleLibraryScanned reloads the library list and the detail panel when command files
+// were added, removed or edited on disk
+func (m *Model) handleLibraryScanned(msg libraryScannedMsg) (tea.Model, tea.Cmd) {
+	if msg.manager != m.getCurrentCommandManager() {
+		return m, nil // The other library is showing now and was read when it was opened
+	}
+	if m.state != StateLibrary || m.list.SettingFilter() {
+		m.libraryPending = true
+		return m, nil
+	}
+	m.syncDetail(true)
+	if msg.signature == m.librarySignature {
+		return m, nil
+	}
+
+	selected := ""
+	if item, ok := m.list.SelectedItem().(commandItem); ok {
+		selected = item.command.Name
+	}
+	if err := m.RefreshCommands(); err != nil {
+		m.notify("Failed to reload the library: "+err.Error(), StatusError)
+		return m, nil
+	}
+	for i, item := range m.list.Items() {
+		if ci, ok := item.(commandItem); ok && ci.command.Name == selected {
+			m.list.Select(i)
+			break
+		}
+	}
+	m.notify("Library changed on disk; reloaded", StatusInfo)
+	return m, nil
+}
@@ -0,0 +1,157 @@
+package tui
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/glamour"
+	"github.com/charmbracelet/glamour/ansi"
+	glamourstyles "github.com/charmbracelet/glamour/styles"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/shel-corp/Claude-command-manager/internal/commands"
+	"github.com/shel-corp/Claude-command-manager/internal/remote"
+)
+
+// minMarkdownWidth is the narrowest preview rendered with glamour. Narrower terminals
+// get the raw markdown, which wraps more predictably than styled blocks.
+const minMarkdownWidth = 50
+
+// markdownCache keeps the last rendered preview so scrolling doesn't re-render it
+type markdownCache struct {
+	source string
+	width  int
+	lines  []string
+}
+
+// previewWidth is the wrap width of preview content, matching the divider above it
+func (m *Model) previewWidth() int {
+	return min(contentWidth(m.width), 80)
+}
+
+// markdownLines returns a command body rendered for the preview, split into lines
+func (m *Model) markdownLines(body string) []string {
+	return m.markdownLinesWidth(body, m.previewWidth())
+}
+
+// markdownLinesWidth returns a command body rendered at width and split into lines. It
+// reads the cache filled by cacheMarkdown and only renders when that missed, so views
+// stay free of side effects.
+func (m *Model) markdownLinesWidth(body string, width int) []string {
+	if m.markdown.lines != nil && m.markdown.source == body && m.markdown.width == width {
+		return m.markdown.lines
+	}
+	return strings.Split(renderMarkdown(body, width), "\n")
+}
+
+// markdownSource returns the body the current screen shows as markdown and its width
+func (m *Model) markdownSource() (body string, width int, ok bool) {
+	switch m.state {
+	case StateLibraryPreview:
+		return m.libraryPreview.body, m.previewWidth(), true
+	case StateRemotePreview:
+		if m.previewCommand.Content == "" {
+			return "", 0, false
+		}
+		_, body := commands.SplitFrontmatter(m.previewCommand.Content)
+		return body, m.previewWidth(), true
+	case StateReportIssuePreview:
+		return remote.IssueBody(m.issuePreview.draft.body), m.previewWidth(), true
+	case StateLibrary:
+		if !m.splitPaneActive() || m.GetSelectedCommand() == nil || m.detail.err != nil {
+			return "", 0, false
+		}
+		_, panelWidth := m.splitPaneWidths()
+		return m.detail.body, panelWidth - 4, true
+	}
+	return "", 0, false
+}
+
+// cacheMarkdown renders the current screen's markdown into the cache after a resize,
+// theme change or new selection, so scrolling and redraws reuse it
+func (m *Model) cacheMarkdown() {
+	body, width, ok := m.markdownSource()
+	if !ok || (m.markdown.lines != nil && m.markdown.source == body && m.markdown.width == width) {
+		return
+	}
+	lines := strings.Split(renderMarkdown(body, width), "\n")
+	m.markdown = markdownCache{source: body, width: width, lines: lines}
+}
+
+// RenderMarkdown styles a command body for CLI output such as `ccm show`, the same way
+// the preview does: in the active theme's colors, at the color depth already applied
+func RenderMarkdown(body string, width int) string {
+	InitializeThemeManager()
+	lines := strings.Split(renderMarkdown(body, width), "\n")
+	for i, line := range lines {
+		// glamour pads every line to the full width, with styled spaces when colored
+		padding := trailingPadding.FindString(line)
+		lines[i] = strings.TrimSuffix(line, padding)
+		if strings.Contains(padding, "\x1b") {
+			lines[i] += "\x1b[0m"
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// trailingPadding matches the spaces, and the styling around them, at the end of a line
+var trailingPadding = regexp.MustCompile(`(?:\x1b\[[0-9;]*m| )+$`)
+
+// renderMarkdown styles markdown with glamour in the active theme's colors, falling back
+// to the plain text on narrow terminals or if rendering fails
+func renderMarkdown(body string, width int) string {
+	body = strings.TrimRight(body, "\n")
+	if width < minMarkdownWidth {
+		return body
+	}
+
+	renderer, err := glamour.NewTermRenderer(
+		glamour.WithStyles(markdownStyle()),
+		glamour.WithWordWrap(width),
+		glamour.WithColorProfile(lipgloss.ColorProfile()), // glamour defaults to truecolor
+	)
+	if err != nil {
+		return body
+	}
+	rendered, err := renderer.Render(body)
+	if err != nil {
+		return body
+	}
+	return strings.Trim(rendered, "\n")
+}
+
+// markdownStyle adapts glamour's light or dark style to the theme's colors
+func markdownStyle() ansi.StyleConfig {
+	dark := lipgloss.HasDarkBackground()
+	style := glamourstyles.LightStyleConfig
+	if dark {
+		style = glamourstyles.DarkStyleConfig
+	}
+	color := func(c lipgloss.AdaptiveColor) *string {
+		value := c.Light
+		if dark {
+			value = c.Dark
+		}
+		return &value
+	}
+
+	// The preview supplies its own margins and spacing
+	var margin uint
+	style.Document.Margin = &margin
+	style.Document.BlockPrefix = ""
+	style.Document.BlockSuffix = ""
+	style.Document.Color = color(textColor)
+
+	style.Heading.Color = color(primaryColor)
+	style.H1.Prefix = "# "
+	style.H1.Suffix = ""
+	style.H1.Color = color(primaryColor)
+	style.H1.BackgroundColor = nil
+	style.H6.Color = color(subtextColor)
+	style.Code.Color = color(successColor)
+	style.Link.Color = color(primaryColor)
+	style.LinkText.Color = color(primaryColor)
+	style.BlockQuote.Color = color(subtextColor)
+	style.HorizontalRule.Color = color(borderVariantColor)
+	return style
+}
@@ -0,0 +1,184 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/shel-corp/Claude-command-manager/internal/commands"
+	"github.com/shel-corp/Claude-command-manager/internal/models"
+)
+
+// metaLabels label the frontmatter editor's fields, one per commands.MetaKeys entry
+var metaLabels = []string{"Description", "Argument hint", "Allowed tools", "Model"}
+
+// metaEditorState holds the frontmatter editor for a single command
+type metaEditorState struct {
+	index    int               // Command being edited, in m.commands
+	inputs   []textinput.Model // One per commands.MetaKeys entry
+	original map[string]string // Values when the editor opened, to write only what changed
+	focus    int
+	err      string
+}
+
+// StartMetaEditor opens the frontmatter editor for the selected command
+func (m *Model) StartMetaEditor() tea.Cmd {
+	cmd := m.GetSelectedCommand()
+	if cmd == nil {
+		return nil
+	}
+	meta, err := commands.ReadMeta(*cmd)
+	if err != nil {
+		m.setStatus(err.Error(), StatusError)
+		return nil
+	}
+
+	placeholders := []string{
+		"what the command does",
+		"e.g. [file] [focus] (optional)",
+		"e.g. Bash(git diff:*), Read (optional)",
+		"e.g. sonnet or claude-opus-4-1 (optional)",
+	}
+	editor := metaEditorState{index: m.selectedCommandIndex(), original: meta}
+	for i, key := range commands.MetaKeys {
+		input := textinput.New()
+		input.Placeholder = placeholders[i]
+		input.CharLimit = 300
+		input.Width = 60
+		input.SetValue(meta[key])
+		editor.inputs = append(editor.inputs, input)
+	}
+
+	m.metaEditor = editor
+	m.state = StateEditMeta
+	m.clearStatus()
+	return m.metaEditor.inputs[0].Focus()
+}
+
+// focusMetaField moves the editor's focus to field i, wrapping around
+func (m *Model) focusMetaField(i int) tea.Cmd {
+	editor := &m.metaEditor
+	editor.inputs[editor.focus].Blur()
+	editor.focus = (i + len(editor.inputs)) % len(editor.inputs)
+	return editor.inputs[editor.focus].Focus()
+}
+
+// metaChanges returns the fields whose values differ from the command's frontmatter
+func (m *Model) metaChanges() map[string]string {
+	changes := make(map[string]string)
+	for i, key := range commands.MetaKeys {
+		if value := strings.TrimSpace(m.metaEditor.inputs[i].Value()); value != m.metaEditor.original[key] {
+			changes[key] = value
+		}
+	}
+	return changes
+}
+
+// SaveMeta writes the changed fields to the command's frontmatter and returns to the library
+func (m *Model) SaveMeta() tea.Cmd {
+	editor := &m.metaEditor
+	if editor.index < 0 || editor.index >= len(m.commands) {
+		m.state = StateLibrary
+		return nil
+	}
+	cmd := m.commands[editor.index]
+
+	changes := m.metaChanges()
+	if len(changes) == 0 {
+		m.state = StateLibrary
+		m.setStatus("No frontmatter changes to save", StatusInfo)
+		return nil
+	}
+	if err := m.getCurrentCommandManager().SetMeta(cmd, changes); err != nil {
+		editor.err = err.Error()
+		return nil
+	}
+	m.logAction(actionEdited, cmd.DisplayName)
+
+	m.state = StateLibrary
+	m.setStatus(fmt.Sprintf("Updated %s in %s", strings.Join(sortedMetaKeys(changes), ", "), cmd.DisplayName), StatusSuccess)
+	return func() tea.Msg {
+		return RefreshMsg{}
+	}
+}
+
+// sortedMetaKeys returns the keys of changes in commands.MetaKeys order
+func sortedMetaKeys(changes map[string]string) []string {
+	var keys []string
+	for _, key := range commands.MetaKeys {
+		if _, ok := changes[key]; ok {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// handleEditMetaStateKeys handles keys in the frontmatter editor
+func (m *Model) handleEditMetaStateKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	editor := &m.metaEditor
+	switch msg.String() {
+	case "ctrl+c":
+		return m, m.Quit()
+	case "esc":
+		m.state = StateLibrary
+		return m, nil
+	case "tab", "down":
+		return m, m.focusMetaField(editor.focus + 1)
+	case "shift+tab", "up":
+		return m, m.focusMetaField(editor.focus - 1)
+	case "enter", "ctrl+s":
+		return m, m.SaveMeta()
+	}
+
+	editor.err = ""
+	var cmd tea.Cmd
+	editor.inputs[editor.focus], cmd = editor.inputs[editor.focus].Update(msg)
+	return m, cmd
+}
+
+// editMetaView renders the frontmatter fields of the command being edited
+func (m *Model) editMetaView() string {
+	editor := m.metaEditor
+	header := "Edit Frontmatter"
+
+	var content strings.Builder
+	if editor.index >= 0 && editor.index < len(m.commands) {
+		cmd := m.commands[editor.index]
+		content.WriteString(fmt.Sprintf("Command: %s (%s)\n\n", highlightStyle.Render(cmd.QualifiedName()), cmd.RelativePath))
+	}
+
+	changes := m.metaChanges()
+	for i, key := range commands.MetaKeys {
+		label := metaLabels[i] + ":"
+		if i == editor.focus {
+			label = highlightStyle.Render(label)
+		} else {
+			label = subtleStyle.Render(label)
+		}
+		if _, changed := changes[key]; changed {
+			label += warningStyle.Render(" (changed)")
+		}
+		content.WriteString(label)
+		content.WriteString("\n")
+		content.WriteString(editor.inputs[i].View())
+		content.WriteString("\n")
+		if key == "model" {
+			if warning := models.DeprecationWarning(strings.TrimSpace(editor.inputs[i].Value())); warning != "" {
+				content.WriteString(warningStyle.Render("  " + warning))
+				content.WriteString("\n")
+			}
+		}
+		content.WriteString("\n")
+	}
+	content.WriteString(subtleStyle.Render("Only changed keys are rewritten; other keys and the prompt are kept. Clear a field to remove its key."))
+
+	if editor.err != "" {
+		content.WriteString("\n")
+		content.WriteString(dangerStyle.Render("⚠️ " + editor.err))
+	}
+
+	footer := "Tab/↑/↓: Next Field • Enter: Save • Esc: Cancel"
+	return centerView(header, content.String(), footer, m.width)
+}
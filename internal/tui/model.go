@@ -1,20 +1,35 @@
 package tui
 
 import (
+	"errors"
 	"fmt"
 	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"sort"
 	"strings"
 	
 	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/progress"
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textarea"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/fsnotify/fsnotify"
 
 	"github.com/shel-corp/Claude-command-manager/internal/cache"
 	"github.com/shel-corp/Claude-command-manager/internal/commands"
 	"github.com/shel-corp/Claude-command-manager/internal/config"
+	"github.com/shel-corp/Claude-command-manager/internal/logging"
+	"github.com/shel-corp/Claude-command-manager/internal/models"
+	"github.com/shel-corp/Claude-command-manager/internal/project"
 	"github.com/shel-corp/Claude-command-manager/internal/registry"
 	"github.com/shel-corp/Claude-command-manager/internal/remote"
+	"github.com/shel-corp/Claude-command-manager/internal/theme"
+	"github.com/shel-corp/Claude-command-manager/internal/usage"
 )
 
 // State represents the current application state
@@ -24,7 +39,18 @@ const (
 	StateMainMenu State = iota
 	StateLibrary
 	StateRename
-	StateHelp
+	StateDuplicate          // Name input for a copy of the selected command
+	StateEditMeta           // Frontmatter editor for the selected command
+	StateConfirmDelete      // Delete confirmation dialog
+	StateViews              // Saved library views quick menu
+	StateSaveView           // Name input for saving the current view
+	StateReconcile          // Per-item reconciliation with the pinned project configuration
+	StateTagEditor          // Bulk add/remove tags across selected commands
+	StateLibraryPreview     // Local command preview
+	StateContentSearch      // Full-text search of the library
+	StateCommandInfo        // Command file, symlink and source details
+	StateInlineEdit         // Multi-line editor for a command file
+	StateNewCommand         // New-command wizard
 	StateRemoteBrowse
 	StateRemoteURL
 	StateRemoteRepoDetails  // Repository details input
@@ -32,12 +58,18 @@ const (
 	StateRemoteLoading
 	StateRemoteSelect
 	StateRemotePreview      // Command preview
+	StateRemoteAnalysis     // Code block risk analysis
+	StateImportConflicts    // Per-command choice for selected commands that already exist
+	StateRemoteRename       // Name or prefix to import remote commands under
 	StateRemoteImport
 	StateRemoteResults
 	StateReportIssue        // Report issue form
+	StateReportIssuePreview // The issue as it will be filed, before submitting
 	StateSettings           // Settings menu
 	StateThemeSettings      // Theme picker
-	StateGeneralSettings    // General preferences (future)
+	StateCacheSettings      // Repository cache settings form
+	StateGitHubAuth         // GitHub CLI missing or not logged in
+	StateGeneralSettings    // General preferences form
 	StateAbout             // About/info screen (future)
 )
 
@@ -58,6 +90,88 @@ const (
 	LibraryModeUser                       // User's home command library
 )
 
+// GroupMode represents how the library list is grouped into sections
+type GroupMode int
+
+const (
+	GroupModeNone      GroupMode = iota // Flat list
+	GroupModeNamespace                  // By subdirectory within the library
+	GroupModeTag                        // By frontmatter tag
+	GroupModeSource                     // By repository the command was imported from
+	GroupModeStatus                     // By enabled/disabled state
+)
+
+// String returns a human-readable name for the group mode
+func (g GroupMode) String() string {
+	switch g {
+	case GroupModeNamespace:
+		return "namespace"
+	case GroupModeTag:
+		return "tag"
+	case GroupModeSource:
+		return "source"
+	case GroupModeStatus:
+		return "status"
+	default:
+		return "none"
+	}
+}
+
+// SortMode represents the order of commands in the library list
+type SortMode int
+
+const (
+	SortByName   SortMode = iota // Alphabetical by name
+	SortByStatus                 // Enabled commands first
+	SortByModel                  // Grouped by target model, unset last
+	SortByModified               // Most recently modified first
+	SortByLocation               // Grouped by symlink location: user, project, then targets
+)
+
+// String returns a human-readable name for the sort mode
+func (s SortMode) String() string {
+	switch s {
+	case SortByStatus:
+		return "status"
+	case SortByModel:
+		return "model"
+	case SortByModified:
+		return "modified"
+	case SortByLocation:
+		return "location"
+	default:
+		return "name"
+	}
+}
+
+// parseSortMode converts a saved sort name back to a SortMode
+func parseSortMode(name string) SortMode {
+	for mode := SortByName; mode <= SortByLocation; mode++ {
+		if mode.String() == name {
+			return mode
+		}
+	}
+	return SortByName
+}
+
+// defaultSortMode returns the sort order saved in the settings
+func defaultSortMode() SortMode {
+	if tm := GetThemeManager(); tm != nil {
+		return parseSortMode(tm.GetAppConfig().Library.Sort)
+	}
+	return SortByName
+}
+
+// parseGroupMode converts a saved group name back to a GroupMode
+func parseGroupMode(name string) GroupMode {
+	for mode := GroupModeNone; mode <= GroupModeStatus; mode++ {
+		if mode.String() == name {
+			return mode
+		}
+	}
+	return GroupModeNone
+}
+
 // StatusType represents the type of status message
 type StatusType int
 
@@ -74,13 +188,14 @@ type SettingsMode int
 const (
 	SettingsModeMain SettingsMode = iota // Main settings menu
 	SettingsModeThemes                   // Theme picker
-	SettingsModeGeneral                  // General preferences (future)
+	SettingsModeGeneral                  // General preferences
 	SettingsModeAbout                    // About screen (future)
 )
 
 // CustomDelegate is a custom list delegate that removes the active line indicator
 type CustomDelegate struct {
 	list.DefaultDelegate
+	compact bool // Single-line rows instead of cards
 }
 
 // NewCustomDelegate creates a new custom delegate
@@ -93,11 +208,29 @@ func NewCustomDelegate() CustomDelegate {
 
 // Render renders the list item with elegant styling and spacing
 func (d CustomDelegate) Render(w io.Writer, m list.Model, index int, item list.Item) {
+	if d.compact {
+		d.renderCompact(w, m, index, item)
+		return
+	}
+	
 	var str string
 	
 	// Check if this item is selected
 	isSelected := index == m.Index()
 	
+	// Group headers render as a single centered line instead of a card
+	if header, ok := item.(groupHeaderItem); ok {
+		headerStyle := subtleStyle.Bold(true)
+		if isSelected {
+			headerStyle = highlightStyle
+		}
+		centerStyle := lipgloss.NewStyle().
+			Width(m.Width()).
+			Align(lipgloss.Center)
+		fmt.Fprint(w, "\n"+centerStyle.Render(headerStyle.Render(header.Title()))+"\n")
+		return
+	}
+	
 	// Get the item content
 	title := item.(interface{ Title() string }).Title()
 	desc := item.(interface{ Description() string }).Description()
@@ -113,9 +246,9 @@ func (d CustomDelegate) Render(w io.Writer, m list.Model, index int, item list.I
 		cardStyle := lipgloss.NewStyle().
 			Width(contentWidth).
 			Align(lipgloss.Center).
-			Border(lipgloss.RoundedBorder()).
+			Border(selectedBorder).
 			BorderForeground(primaryColor).
-			Background(lipgloss.Color("#1E293B")).
+			Background(selectionColor).
 			Padding(0, 2).  // Reduced from (1, 3) to save vertical space 
 			Margin(0, 0)    // Reduced from (1, 0) to save vertical space
 		
@@ -125,7 +258,8 @@ func (d CustomDelegate) Render(w io.Writer, m list.Model, index int, item list.I
 			Align(lipgloss.Center)
 		
 		descStyle := lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#CBD5E1")).
+			Foreground(selectionTextColor).
+			Bold(boldText).
 			Italic(true).
 			Align(lipgloss.Center)
 		
@@ -149,16 +283,18 @@ func (d CustomDelegate) Render(w io.Writer, m list.Model, index int, item list.I
 			Width(contentWidth).
 			Align(lipgloss.Center).
 			Border(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color("#374151")).
+			BorderForeground(borderVariantColor).
 			Padding(0, 2).  // Reduced from (1, 3) to save vertical space
 			Margin(0, 0)    // Reduced from (1, 0) to save vertical space
 		
 		titleStyle := lipgloss.NewStyle().
 			Foreground(textColor).
+			Bold(boldText).
 			Align(lipgloss.Center)
 		
 		descStyle := lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#9CA3AF")).
+			Foreground(subtextColor).
+			Bold(boldText).
 			Align(lipgloss.Center)
 		
 		content := titleStyle.Render(title)
@@ -186,8 +322,10 @@ type Model struct {
 	textInput      textinput.Model   // Primary text input
 	searchInput    textinput.Model   // Dedicated search input
 	categoryInput  textinput.Model   // Category creation input
+	categoryDescInput textinput.Model // Category description input
+	repoTagsInput   textinput.Model   // Custom repository tags input
 	issueTitleInput textinput.Model  // Issue title input
-	issueBodyInput  textinput.Model  // Issue body input
+	issueBodyInput  textarea.Model   // Issue body input
 	
 	// Managers
 	commandManager     *commands.Manager
@@ -200,6 +338,17 @@ type Model struct {
 	state          State
 	commands       []commands.Command
 	libraryMode    LibraryMode
+	modelFilter    string   // Only show commands targeting this model (empty = all)
+	libraryModels  []string // Distinct models found in the current library
+	groupMode      GroupMode       // How the library list is grouped
+	collapsedGroups map[string]bool // Group keys whose sections are collapsed
+	statusFilter   string          // Only show "enabled" or "disabled" commands (empty = all)
+	tagFilter      string          // Only show commands with this tag (empty = all)
+	libraryTags    []string        // Distinct tags found in the current library
+	libraryAll     []commands.Command // Every command in the current library, before filters
+	sortMode       SortMode        // Order of commands in the library list
+	activeView     string          // Name of the saved view currently applied (empty = none)
+	viewCursor     int             // Selected entry in the saved views menu
 	
 	// UI state
 	width          int
@@ -209,27 +358,107 @@ type Model struct {
 	// Rename state
 	renameIndex    int
 	renameOriginal string
+	renameCollision *commands.NameCollision // Set when renaming to get past a taken name; the command is enabled afterwards
+	
+	// Duplicate state
+	duplicateIndex int
+	
+	// Frontmatter editor state
+	metaEditor metaEditorState
+	
+	// Delete state
+	deleteIndex    int
+	
+	// Integrity state - divergence between config and symlinks found at startup
+	projectIntegrity commands.IntegrityReport
+	userIntegrity    commands.IntegrityReport
+	
+	// Pinned project configuration (.claude/ccm.yaml) and how the project library differs from it
+	projectConfig    *project.Config
+	projectDrift     project.Drift
+	reconciling      bool
+	reconcileActions []ReconcileAction // Chosen action per drift item on the reconciliation screen
+	reconcileCursor  int
+	reconcileReturn  State
+	
+	// Bulk tag editor state
+	tagEditor        tagEditorState
+	
+	// Local command preview state
+	libraryPreview   libraryPreviewState
+	detail           libraryPreviewState // File read for the split-pane detail panel
+	usageScanner     *usage.Scanner // Reads Claude's session transcripts; nil without a home directory
+	usage            map[string]usage.Stats // Invocations by command name; nil while usage stats are off
+	markdown         markdownCache // Last rendered preview body
+	commandInfo      commandInfoState
+	contentSearch    contentSearchState
+	
+	// Inline command editor state
+	inlineEditor     inlineEditorState
+	
+	// New-command wizard state
+	newCommand       newCommandWizard
+	
+	// Library commands marked with space for bulk enable/disable/location changes, by name
+	librarySelected  map[string]bool
+	
+	// Changes made this session, summarized on quit
+	sessionLog       []sessionEntry
+	
+	// Library key bindings, from the keybindings file over the selected profile
+	keys              KeyMap
+	pendingKey        string // First key of a sequence such as "g g"
+	commandLine       textinput.Model
+	commandLineActive bool // Typing an action name after the command key
+	
+	// Ctrl+P command palette, drawn over the current screen
+	palette           commandPalette
+	
+	// Help overlay listing the keys of the current screen
+	help              helpOverlay
+	remoteKeys        RemoteKeyMap // Repository browser keys, listed by the help overlay
+	
+	// Where the list and footer were drawn in the last frame, for mouse clicks
+	layout            mouseLayout
+	compactList       bool // List rows are single lines for a narrow terminal or plain mode
+	plain             bool // ASCII-only drawing requested with --plain or NO_EMOJI
 	
 	// Remote import state
 	remoteURL       string
 	remoteRepo      *remote.RemoteRepository
 	remoteCommands  []remote.RemoteCommand
 	remoteLoading   bool
+	remoteLoadTask   int            // Background task loading remoteRepo
+	remoteImportTask int            // Background task importing the selected commands
+	spinner          spinner.Model  // Animates the loading screen
+	progressBar      progress.Model // Counts commands fetched and imported
 	remoteError     string
 	remoteSelected  map[int]bool
 	remoteConflicts []remote.RemoteCommand
 	remoteOptions   remote.ImportOptions
 	remoteResult    *remote.ImportResult
+	importConflicts importConflictState
+	remoteRenames   map[int]string // Names to import remote commands under, by index, when changed
+	remoteRename    remoteRenameState
 	
 	// Preview state
 	previewCommand  *remote.RemoteCommand
 	previousState   State  // State to return to after preview
 	
+	// Analysis state
+	analysisCommand  *remote.RemoteCommand   // Command being analyzed
+	analysisResult   *remote.CommandAnalysis // Extracted code blocks and risk flags
+	analysisReturn   State                   // State to return to after analysis
+	
 	// Custom repository input state
 	customRepoInput     registry.RepositoryInput
 	availableCategories map[string]string  // key -> name mapping
 	selectedCategoryKey string
 	isNewCategory       bool
+	suggestedCategoryKey string // Category suggested for the custom repository being added
+	repoDetailsField    int    // Repository details field: 0=description, 1=tags
+	categoryField       int // New category form field: 0=name, 1=description, 2=icon
+	categoryIconIndex   int // Selected entry in categoryIcons
 	
 	// Input validation state
 	validationErrors    map[string]string  // field -> error message
@@ -238,11 +467,19 @@ type Model struct {
 	statusMessage       string             // Status message to display
 	statusType          StatusType         // Type of status (info, success, error)
 	showStatus          bool               // Whether to show status message
+	statusSeq           int                // Bumped by setStatus so stale expiry timers are ignored
+	statusTimerSeq      int                // statusSeq the expiry timer was last started for
+	statusFading        bool               // Status is in its last moment before it clears
+	toasts              []toast            // Background notifications, oldest first
+	nextToastID         int
 	
 	// Report issue state
-	issueCurrentField   int                // Current field in report issue form (0=title, 1=body)
+	issueCurrentField   int                // Focused field of the report issue form (issueField*)
+	issueType           string             // bug, feature or question: picks the label and body template
+	issueDiagnostics    bool               // Append version, platform and log details to the issue
 	issueSubmitting     bool               // Whether currently submitting issue
 	issueSubmitError    string             // Error from issue submission
+	issuePreview        issuePreviewState  // The issue as it will be filed, shown before submitting
 	
 	// Repository browsing state
 	registryManager    *registry.EnhancedRegistryManager
@@ -256,15 +493,33 @@ type Model struct {
 	settingsMode       SettingsMode       // Current settings submenu
 	selectedThemeIndex int                // Selected theme in theme picker
 	themePreviewing    bool               // Whether currently previewing theme
+	themeHotReload     bool               // Watch custom theme files and re-apply them on change
+	themeSignature     string             // Fingerprint of the custom theme files last applied
+	librarySignature   string             // Fingerprint of the library's command files when last scanned
+	libraryWatcher     *fsnotify.Watcher  // Watches both libraries for changes made outside ccm (nil if unavailable)
+	libraryPending     bool               // A library directory changed and has not been checked yet
+	settingsForm       settingsForm       // Settings being edited on a settings form
+	
+	// Background task state
+	taskQueue     taskQueue // Long-running operations, run one at a time
+	showTaskPanel bool      // Whether the progress panel is expanded
+	taskTicking   bool      // Whether a panel redraw tick is scheduled
+	
+	// GitHub CLI state
+	ghAuthStatus remote.GHAuthStatus // Last gh check shown on the auth screen
+	ghAnonymous  bool                // User chose anonymous access to public repositories
+	ghAuthPoll   int                 // Generation of the auth screen's polling; older ticks are dropped
 }
 
 // commandItem implements list.Item for the Bubbles list component
 type commandItem struct {
 	command commands.Command
+	marked  bool   // Part of the bulk selection
+	usage   string // Usage summary from Claude's session transcripts; "" when off
 }
 
 func (i commandItem) FilterValue() string {
-	return i.command.DisplayName
+	return i.command.QualifiedName()
 }
 
 func (i commandItem) Title() string {
@@ -275,27 +530,70 @@ func (i commandItem) Title() string {
 	
 	// Add location decorator
 	var locationIcon string
-	switch i.command.SymlinkLocation {
-	case config.SymlinkLocationUser:
-		locationIcon = "👤" // User icon
-	case config.SymlinkLocationProject:
+	switch {
+	case i.command.SymlinkLocation == config.SymlinkLocationProject:
 		locationIcon = "📁" // Project folder icon
+	case i.command.SymlinkLocation.IsCustom():
+		locationIcon = "🔗" // Custom target, named after the command
 	default:
-		locationIcon = "👤" // Default to user
+		locationIcon = "👤" // User icon
 	}
 	
-	return status + " " + locationIcon + " " + i.command.DisplayName
+	title := status + " " + locationIcon + " " + i.command.QualifiedName()
+	if i.command.Pinned {
+		title = "📌 " + title
+	}
+	if i.marked {
+		title = "◆ " + title
+	}
+	if i.command.SymlinkLocation.IsCustom() {
+		title += " → " + string(i.command.SymlinkLocation)
+	}
+	if badge := models.Badge(i.command.Model); badge != "" {
+		title += "  " + badge
+	}
+	if len(i.command.Tags) > 0 {
+		title += "  #" + strings.Join(i.command.Tags, " #")
+	}
+	if i.usage != "" {
+		title += "  · " + i.usage
+	}
+	return title
 }
 
 func (i commandItem) Description() string {
 	return i.command.Description
 }
 
+// groupHeaderItem implements list.Item for a collapsible section header in the library
+type groupHeaderItem struct {
+	key       string
+	count     int
+	collapsed bool
+}
+
+func (i groupHeaderItem) FilterValue() string {
+	return ""
+}
+
+func (i groupHeaderItem) Title() string {
+	arrow := "▼"
+	if i.collapsed {
+		arrow = "▶"
+	}
+	return fmt.Sprintf("%s %s (%d)", arrow, i.key, i.count)
+}
+
+func (i groupHeaderItem) Description() string {
+	return ""
+}
+
 // remoteCommandItem implements list.Item for remote commands with selection support
 type remoteCommandItem struct {
-	command  remote.RemoteCommand
-	selected bool
-	index    int
+	command     remote.RemoteCommand // As it will be imported, under its new name if renamed
+	selected    bool
+	index       int
+	renamedFrom string // Name in the repository, if the command is imported under another
 }
 
 func (i remoteCommandItem) FilterValue() string {
@@ -315,13 +613,20 @@ func (i remoteCommandItem) Title() string {
 		conflictIcon = " ⚠️"
 	}
 	
-	return checkbox + " " + i.command.Name + conflictIcon
+	title := checkbox + " " + i.command.Name + conflictIcon
+	if badge := models.Badge(i.command.Model); badge != "" {
+		title += "  " + badge
+	}
+	return title
 }
 
 func (i remoteCommandItem) Description() string {
 	status := ""
+	if i.renamedFrom != "" {
+		status = "(renamed from " + i.renamedFrom + ") "
+	}
 	if i.command.LocalExists {
-		status = "(exists locally) "
+		status += "(exists locally) "
 	}
 	return status + i.command.Description
 }
@@ -330,6 +635,7 @@ func (i remoteCommandItem) Description() string {
 type categoryItem struct {
 	key      string
 	category remote.RepositoryCategory
+	counts   string // e.g. "14 repos, ~120 commands"
 }
 
 func (i categoryItem) FilterValue() string {
@@ -341,7 +647,10 @@ func (i categoryItem) Title() string {
 }
 
 func (i categoryItem) Description() string {
-	return i.category.Description
+	if i.counts == "" {
+		return i.category.Description
+	}
+	return fmt.Sprintf("%s (%s)", i.category.Description, i.counts)
 }
 
 // repositoryItem implements list.Item for curated repositories
@@ -349,13 +658,16 @@ type repositoryItem struct {
 	repository remote.CuratedRepository
 	selected   bool
 	index      int
+	popular    bool
+	starred    bool
 }
 
 // categorySelectionItem implements list.Item for category selection
 type categorySelectionItem struct {
-	key   string
-	name  string
-	isNew bool
+	key       string
+	name      string
+	isNew     bool
+	suggested bool
 }
 
 func (i categorySelectionItem) FilterValue() string {
@@ -366,6 +678,9 @@ func (i categorySelectionItem) Title() string {
 	if i.isNew {
 		return "➕ " + i.name
 	}
+	if i.suggested {
+		return "✨ " + i.name
+	}
 	return i.name
 }
 
@@ -373,6 +688,9 @@ func (i categorySelectionItem) Description() string {
 	if i.isNew {
 		return "Create a new category for your repositories"
 	}
+	if i.suggested {
+		return "Suggested from the repository's name, description and topics"
+	}
 	return "Existing category"
 }
 
@@ -406,8 +724,18 @@ func (i repositoryItem) Title() string {
 	if i.repository.Verified {
 		verifiedBadge = " ✅"
 	}
+
+	popularBadge := ""
+	if i.popular {
+		popularBadge = " 🔥 popular"
+	}
+	
+	starredBadge := ""
+	if i.starred {
+		starredBadge = "⭐ "
+	}
 	
-	return i.repository.Name + verifiedBadge
+	return starredBadge + i.repository.Name + verifiedBadge + popularBadge
 }
 
 func (i repositoryItem) Description() string {
@@ -416,12 +744,16 @@ func (i repositoryItem) Description() string {
 
 // NewModel creates a new TUI model
 func NewModel(commandManager *commands.Manager, configManager *config.Manager, userCommandManager *commands.Manager, userConfigManager *config.Manager) (*Model, error) {
+	// Initialize theme manager, which also holds the application settings
+	InitializeThemeManager()
+	appSettings := GetThemeManager().GetAppConfig()
+
 	// Initialize cache manager
-	cacheConfig := cache.DefaultCacheConfig()
+	cacheConfig := appSettings.Cache
 	cacheManager, err := cache.NewManager(cacheConfig)
 	if err != nil {
 		// Log error but don't fail - caching is optional
-		fmt.Printf("Warning: failed to initialize cache manager: %v\n", err)
+		logging.Warnf("failed to initialize cache manager: %v", err)
 		cacheManager = nil
 	}
 	// Initialize text inputs for different contexts
@@ -440,24 +772,31 @@ func NewModel(commandManager *commands.Manager, configManager *config.Manager, u
 	categoryInput.CharLimit = 50
 	categoryInput.Width = 60
 	
+	repoTagsInput := textinput.New()
+	repoTagsInput.Placeholder = "Comma-separated tags (filled from GitHub topics)..."
+	repoTagsInput.CharLimit = 200
+	repoTagsInput.Width = 60
+	
+	categoryDescInput := textinput.New()
+	categoryDescInput.Placeholder = "Describe what belongs in this category (optional)..."
+	categoryDescInput.CharLimit = 200
+	categoryDescInput.Width = 60
+	
 	// Initialize report issue inputs
 	issueTitleInput := textinput.New()
 	issueTitleInput.Placeholder = "Enter issue title..."
 	issueTitleInput.CharLimit = 100
 	issueTitleInput.Width = 60
 	
-	issueBodyInput := textinput.New()
+	issueBodyInput := textarea.New()
 	issueBodyInput.Placeholder = "Describe the issue in detail..."
 	issueBodyInput.CharLimit = 2000
-	issueBodyInput.Width = 60
+	issueBodyInput.ShowLineNumbers = false
+	issueBodyInput.SetWidth(60)
+	issueBodyInput.SetHeight(6)
 
 	// Initialize list with custom delegate to remove default styling
-	delegate := NewCustomDelegate()
-	delegate.SetHeight(3) // Account for card height (title + description + border)
-	delegate.SetSpacing(1) // Add spacing between cards
-	delegate.ShowDescription = true
-	
-	l := list.New([]list.Item{}, delegate, 0, 0)
+	l := list.New([]list.Item{}, newListDelegate(false), 0, 0)
 	l.Title = ""
 	l.SetShowStatusBar(false)
 	l.SetFilteringEnabled(false)
@@ -477,7 +816,7 @@ func NewModel(commandManager *commands.Manager, configManager *config.Manager, u
 	}
 	if err := registryManager.LoadRegistries(); err != nil {
 		// Log error but don't fail - user can still use custom URLs
-		fmt.Printf("Warning: failed to load registries: %v\n", err)
+		logging.Warnf("failed to load registries: %v", err)
 	}
 
 	model := &Model{
@@ -485,6 +824,8 @@ func NewModel(commandManager *commands.Manager, configManager *config.Manager, u
 		textInput:          ti,
 		searchInput:        searchInput,
 		categoryInput:      categoryInput,
+		categoryDescInput:  categoryDescInput,
+		repoTagsInput:      repoTagsInput,
 		issueTitleInput:    issueTitleInput,
 		issueBodyInput:     issueBodyInput,
 		commandManager:     commandManager,
@@ -499,21 +840,38 @@ func NewModel(commandManager *commands.Manager, configManager *config.Manager, u
 		availableCategories: make(map[string]string),
 		customRepoInput:     registry.RepositoryInput{},
 		validationErrors:    make(map[string]string),
+		collapsedGroups:     make(map[string]bool),
+		librarySelected:     make(map[string]bool),
+		sortMode:            parseSortMode(appSettings.Library.Sort),
+		remoteKeys:          DefaultRemoteKeyMap(),
+		spinner:             spinner.New(spinner.WithSpinner(spinner.Dot)),
+		progressBar:         progress.New(progress.WithoutPercentage()),
 		
 		// Settings initialization
 		settingsMode:       SettingsModeMain,
 		selectedThemeIndex: 0,
 		themePreviewing:    false,
+		themeHotReload:     appSettings.Developer.ThemeHotReload,
 	}
-
-	// Initialize theme manager for TUI
-	InitializeThemeManager()
+	if model.themeHotReload {
+		model.themeSignature = theme.UserThemesSignature()
+	}
+	if dir, err := usage.DefaultDir(); err == nil {
+		model.usageScanner = usage.NewScanner(dir)
+	}
+	model.libraryWatcher = newLibraryWatcher(commandManager.GetCommandsDir(), userCommandManager.GetCommandsDir())
 
 	// Load commands
 	if err := model.RefreshCommands(); err != nil {
 		return nil, err
 	}
 	
+	// Load key bindings; integrity problems found below take over the status line
+	model.loadKeyMap()
+	
+	// Check that enabled commands actually have their symlinks
+	model.CheckIntegrity()
+	
 	// Initialize main menu since we start in StateMainMenu
 	model.initMainMenu()
 
@@ -597,6 +955,8 @@ func (m *Model) SwitchLibraryMode() tea.Cmd {
 	} else {
 		m.libraryMode = LibraryModeProject
 	}
+	m.resetLibraryView()
+	m.applyDefaultView()
 	
 	// Refresh commands for the new library
 	return func() tea.Msg {
@@ -604,6 +964,17 @@ func (m *Model) SwitchLibraryMode() tea.Cmd {
 	}
 }
 
+// SetLibraryMode switches to the given library mode and reloads its commands
+func (m *Model) SetLibraryMode(mode LibraryMode) error {
+	if m.libraryMode == mode {
+		return nil
+	}
+	m.libraryMode = mode
+	m.resetLibraryView()
+	m.applyDefaultView()
+	return m.RefreshCommands()
+}
+
 // GetLibraryModeString returns a human-readable string for the current library mode
 func (m *Model) GetLibraryModeString() string {
 	if m.libraryMode == LibraryModeUser {
@@ -615,35 +986,577 @@ func (m *Model) GetLibraryModeString() string {
 // RefreshCommands reloads commands from disk and updates the list
 func (m *Model) RefreshCommands() error {
 	currentManager := m.getCurrentCommandManager()
+	m.librarySignature = currentManager.Signature()
 	cmds, err := currentManager.ScanCommands()
 	if err != nil {
 		return err
 	}
 
+	// Collect distinct models for the model filter
+	seenModels := make(map[string]bool)
+	m.libraryModels = nil
+	for _, cmd := range cmds {
+		if cmd.Model != "" && !seenModels[cmd.Model] {
+			seenModels[cmd.Model] = true
+			m.libraryModels = append(m.libraryModels, cmd.Model)
+		}
+	}
+	sort.Strings(m.libraryModels)
+	m.libraryTags = commands.AllTags(cmds)
+	m.libraryAll = cmds
+
+	// Apply model, status and tag filters
+	if m.modelFilter != "" || m.statusFilter != "" || m.tagFilter != "" {
+		filtered := make([]commands.Command, 0, len(cmds))
+		for _, cmd := range cmds {
+			if m.modelFilter != "" && cmd.Model != m.modelFilter {
+				continue
+			}
+			if m.statusFilter == "enabled" && !cmd.Enabled || m.statusFilter == "disabled" && cmd.Enabled {
+				continue
+			}
+			if m.tagFilter != "" && !slices.Contains(cmd.Tags, m.tagFilter) {
+				continue
+			}
+			filtered = append(filtered, cmd)
+		}
+		cmds = filtered
+	}
+
+	// Apply sort order (commands arrive sorted by name)
+	switch m.sortMode {
+	case SortByStatus:
+		sort.SliceStable(cmds, func(i, j int) bool {
+			return cmds[i].Enabled && !cmds[j].Enabled
+		})
+	case SortByModel:
+		sort.SliceStable(cmds, func(i, j int) bool {
+			if cmds[i].Model == "" || cmds[j].Model == "" {
+				return cmds[j].Model == "" && cmds[i].Model != ""
+			}
+			return cmds[i].Model < cmds[j].Model
+		})
+	case SortByModified:
+		sort.SliceStable(cmds, func(i, j int) bool {
+			return cmds[i].ModTime.After(cmds[j].ModTime)
+		})
+	case SortByLocation:
+		rank := make(map[config.SymlinkLocation]int)
+		for i, location := range m.getCurrentCommandManager().SymlinkLocations() {
+			rank[location] = i
+		}
+		sort.SliceStable(cmds, func(i, j int) bool {
+			return rank[cmds[i].SymlinkLocation] < rank[cmds[j].SymlinkLocation]
+		})
+	}
+	
+	// Pinned commands stay on top whatever the sort order
+	sort.SliceStable(cmds, func(i, j int) bool {
+		return cmds[i].Pinned && !cmds[j].Pinned
+	})
+
 	m.commands = cmds
+	m.setLibraryItems(m.buildLibraryItems())
+	return nil
+}
 
-	// Convert to list items
-	items := make([]list.Item, len(cmds))
-	for i, cmd := range cmds {
-		items[i] = commandItem{command: cmd}
+// setLibraryItems shows items in the list. When the list already holds the same commands
+// and groups in the same order, only the items that changed are replaced, which keeps
+// refreshes after a toggle cheap and preserves the cursor and filter.
+func (m *Model) setLibraryItems(items []list.Item) {
+	current := m.list.Items()
+	if len(current) != len(items) {
+		m.list.SetItems(items)
+		return
+	}
+	for i := range items {
+		if libraryItemKey(current[i]) == "" || libraryItemKey(current[i]) != libraryItemKey(items[i]) {
+			m.list.SetItems(items)
+			return
+		}
 	}
 
-	m.list.SetItems(items)
+	for i := range items {
+		if !reflect.DeepEqual(current[i], items[i]) {
+			m.list.SetItem(i, items[i])
+		}
+	}
+}
+
+// libraryItemKey identifies a library list item, or returns "" for other item types
+func libraryItemKey(item list.Item) string {
+	switch item := item.(type) {
+	case commandItem:
+		return "command:" + item.command.Name
+	case groupHeaderItem:
+		return "group:" + item.key
+	}
+	return ""
+}
+
+// buildLibraryItems converts commands to list items, inserting group headers when grouping is active
+func (m *Model) buildLibraryItems() []list.Item {
+	if m.groupMode == GroupModeNone {
+		items := make([]list.Item, len(m.commands))
+		for i, cmd := range m.commands {
+			items[i] = commandItem{command: cmd, marked: m.librarySelected[cmd.Name], usage: m.usageSummary(cmd)}
+		}
+		return items
+	}
+
+	groups := make(map[string][]commands.Command)
+	var keys []string
+	for _, cmd := range m.commands {
+		for _, key := range m.groupKeys(cmd) {
+			if _, exists := groups[key]; !exists {
+				keys = append(keys, key)
+			}
+			groups[key] = append(groups[key], cmd)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return groupSortKey(keys[i]) < groupSortKey(keys[j])
+	})
+
+	items := make([]list.Item, 0, len(m.commands)+len(keys))
+	for _, key := range keys {
+		collapsed := m.collapsedGroups[key]
+		items = append(items, groupHeaderItem{key: key, count: len(groups[key]), collapsed: collapsed})
+		if collapsed {
+			continue
+		}
+		for _, cmd := range groups[key] {
+			items = append(items, commandItem{command: cmd, marked: m.librarySelected[cmd.Name], usage: m.usageSummary(cmd)})
+		}
+	}
+	return items
+}
+
+// groupKeys returns the sections a command belongs to under the current group mode.
+// A command with several tags appears under each of them.
+func (m *Model) groupKeys(cmd commands.Command) []string {
+	switch m.groupMode {
+	case GroupModeNamespace:
+		dir := filepath.Dir(cmd.RelativePath)
+		if dir == "." {
+			return []string{"(root)"}
+		}
+		return []string{filepath.ToSlash(dir)}
+	case GroupModeTag:
+		if len(cmd.Tags) == 0 {
+			return []string{"(untagged)"}
+		}
+		return cmd.Tags
+	case GroupModeSource:
+		if cmd.Source == "" {
+			return []string{"(local)"}
+		}
+		return []string{cmd.Source}
+	case GroupModeStatus:
+		if cmd.Enabled {
+			return []string{"enabled"}
+		}
+		return []string{"disabled"}
+	}
 	return nil
 }
 
+// groupSortKey orders group headers alphabetically, with "enabled" first and fallback groups last
+func groupSortKey(key string) string {
+	switch {
+	case key == "enabled":
+		return "0"
+	case strings.HasPrefix(key, "("):
+		return "2" + key
+	default:
+		return "1" + key
+	}
+}
+
+// CycleGroupMode advances the library grouping through none, namespace, tag, source and status
+func (m *Model) CycleGroupMode() tea.Cmd {
+	m.groupMode = (m.groupMode + 1) % (GroupModeStatus + 1)
+	m.collapsedGroups = make(map[string]bool)
+	m.activeView = ""
+
+	if m.groupMode == GroupModeNone {
+		m.setStatus("Grouping disabled", StatusInfo)
+	} else {
+		m.setStatus(fmt.Sprintf("Grouping commands by %s", m.groupMode), StatusInfo)
+	}
+
+	m.list.SetItems(m.buildLibraryItems())
+	m.list.Select(0)
+	return nil
+}
+
+// ToggleSelectedGroup collapses or expands the group header under the cursor.
+// Returns false if the selection is not a group header.
+func (m *Model) ToggleSelectedGroup() bool {
+	header, ok := m.list.SelectedItem().(groupHeaderItem)
+	if !ok {
+		return false
+	}
+
+	m.collapsedGroups[header.key] = !header.collapsed
+	m.list.SetItems(m.buildLibraryItems())
+	m.selectGroupHeader(header.key)
+	return true
+}
+
+// ToggleAllGroups collapses every group, or expands them all if any is already collapsed
+func (m *Model) ToggleAllGroups() {
+	if m.groupMode == GroupModeNone || len(m.list.Items()) == 0 {
+		return
+	}
+
+	anyCollapsed := false
+	for _, collapsed := range m.collapsedGroups {
+		if collapsed {
+			anyCollapsed = true
+			break
+		}
+	}
+
+	// Keep the cursor on the group the selection currently belongs to
+	currentKey := ""
+	for _, item := range m.list.Items()[:m.list.Index()+1] {
+		if header, ok := item.(groupHeaderItem); ok {
+			currentKey = header.key
+		}
+	}
+
+	m.collapsedGroups = make(map[string]bool)
+	if !anyCollapsed {
+		for _, item := range m.list.Items() {
+			if header, ok := item.(groupHeaderItem); ok {
+				m.collapsedGroups[header.key] = true
+			}
+		}
+	}
+
+	m.list.SetItems(m.buildLibraryItems())
+	m.selectGroupHeader(currentKey)
+}
+
+// selectGroupHeader moves the cursor to the header with the given key
+func (m *Model) selectGroupHeader(key string) {
+	for i, item := range m.list.Items() {
+		if header, ok := item.(groupHeaderItem); ok && header.key == key {
+			m.list.Select(i)
+			return
+		}
+	}
+}
+
+// CycleModelFilter advances the library model filter through all models found in the library
+func (m *Model) CycleModelFilter() tea.Cmd {
+	if len(m.libraryModels) == 0 {
+		m.modelFilter = ""
+		m.setStatus("No commands in this library specify a model", StatusInfo)
+		return nil
+	}
+
+	next := ""
+	if m.modelFilter == "" {
+		next = m.libraryModels[0]
+	} else {
+		for i, model := range m.libraryModels {
+			if model == m.modelFilter && i+1 < len(m.libraryModels) {
+				next = m.libraryModels[i+1]
+				break
+			}
+		}
+	}
+	m.modelFilter = next
+	m.activeView = ""
+
+	if next == "" {
+		m.setStatus("Showing commands for all models", StatusInfo)
+	} else {
+		m.setStatus(fmt.Sprintf("Showing commands targeting model: %s", next), StatusInfo)
+	}
+
+	return func() tea.Msg {
+		return RefreshMsg{}
+	}
+}
+
+// CycleStatusFilter advances the library status filter through all, enabled and disabled
+func (m *Model) CycleStatusFilter() tea.Cmd {
+	switch m.statusFilter {
+	case "":
+		m.statusFilter = "enabled"
+	case "enabled":
+		m.statusFilter = "disabled"
+	default:
+		m.statusFilter = ""
+	}
+	m.activeView = ""
+
+	if m.statusFilter == "" {
+		m.setStatus("Showing enabled and disabled commands", StatusInfo)
+	} else {
+		m.setStatus(fmt.Sprintf("Showing %s commands only", m.statusFilter), StatusInfo)
+	}
+
+	return func() tea.Msg {
+		return RefreshMsg{}
+	}
+}
+
+// CycleTagFilter advances the library tag filter through all tags found in the library
+func (m *Model) CycleTagFilter() tea.Cmd {
+	if len(m.libraryTags) == 0 {
+		m.tagFilter = ""
+		m.setStatus("No commands in this library are tagged", StatusInfo)
+		return nil
+	}
+
+	next := ""
+	if m.tagFilter == "" {
+		next = m.libraryTags[0]
+	} else {
+		for i, tag := range m.libraryTags {
+			if tag == m.tagFilter && i+1 < len(m.libraryTags) {
+				next = m.libraryTags[i+1]
+				break
+			}
+		}
+	}
+	m.tagFilter = next
+	m.activeView = ""
+
+	if next == "" {
+		m.setStatus("Showing all commands", StatusInfo)
+	} else {
+		m.setStatus(fmt.Sprintf("Showing commands tagged: %s", next), StatusInfo)
+	}
+
+	return func() tea.Msg {
+		return RefreshMsg{}
+	}
+}
+
+// CycleSortMode advances the library sort order through name, status, model, modified
+// and location, and saves it as the default for future sessions
+func (m *Model) CycleSortMode() tea.Cmd {
+	m.sortMode = (m.sortMode + 1) % (SortByLocation + 1)
+	m.activeView = ""
+	m.setStatus(fmt.Sprintf("Sorting commands by %s", m.sortMode), StatusInfo)
+	if tm := GetThemeManager(); tm != nil {
+		if err := tm.SetValue("library.sort", m.sortMode.String()); err != nil {
+			m.setStatus(fmt.Sprintf("Sorting commands by %s (not saved: %v)", m.sortMode, err), StatusWarning)
+		}
+	}
+
+	return func() tea.Msg {
+		return RefreshMsg{}
+	}
+}
+
+// currentView captures the library's filters, sort and grouping as a view
+func (m *Model) currentView(name string) theme.LibraryView {
+	return theme.LibraryView{
+		Name:         name,
+		ModelFilter:  m.modelFilter,
+		StatusFilter: m.statusFilter,
+		TagFilter:    m.tagFilter,
+		Sort:         m.sortMode.String(),
+		Group:        m.groupMode.String(),
+	}
+}
+
+// resetLibraryView clears all filters, sort and grouping
+func (m *Model) resetLibraryView() {
+	m.modelFilter = ""
+	m.statusFilter = ""
+	m.tagFilter = ""
+	m.sortMode = defaultSortMode()
+	m.groupMode = GroupModeNone
+	m.collapsedGroups = make(map[string]bool)
+	m.librarySelected = make(map[string]bool)
+	m.activeView = ""
+}
+
+// ApplyView restores the filters, sort and grouping saved in a view
+func (m *Model) ApplyView(view theme.LibraryView) tea.Cmd {
+	m.modelFilter = view.ModelFilter
+	m.statusFilter = view.StatusFilter
+	m.tagFilter = view.TagFilter
+	m.sortMode = parseSortMode(view.Sort)
+	m.groupMode = parseGroupMode(view.Group)
+	m.collapsedGroups = make(map[string]bool)
+	m.activeView = view.Name
+	m.list.Select(0)
+
+	return func() tea.Msg {
+		return RefreshMsg{}
+	}
+}
+
+// applyDefaultView applies the default saved view for the current library mode, if any.
+// Libraries with filters, sort or grouping already set are left untouched.
+func (m *Model) applyDefaultView() {
+	tm := GetThemeManager()
+	if tm == nil {
+		return
+	}
+	if m.activeView != "" || m.modelFilter != "" || m.statusFilter != "" || m.tagFilter != "" ||
+		m.sortMode != defaultSortMode() || m.groupMode != GroupModeNone {
+		return
+	}
+
+	views := tm.GetViews()
+	name := views.Defaults[m.libraryModeKey()]
+	
+	// Fall back to the view suggested by the project's pinned configuration
+	if name == "" && m.libraryMode == LibraryModeProject && m.projectConfig != nil {
+		name = m.projectConfig.Settings.DefaultView
+	}
+	for _, view := range views.Saved {
+		if view.Name == name {
+			m.ApplyView(view)
+			return
+		}
+	}
+}
+
+// libraryModeKey returns the settings key for the current library mode
+func (m *Model) libraryModeKey() string {
+	return strings.ToLower(m.GetLibraryModeString())
+}
+
+// StartViews opens the saved views quick menu
+func (m *Model) StartViews() {
+	m.state = StateViews
+	m.viewCursor = 0
+}
+
+// getSavedViews returns the saved library views
+func (m *Model) getSavedViews() []theme.LibraryView {
+	tm := GetThemeManager()
+	if tm == nil {
+		return nil
+	}
+	return tm.GetViews().Saved
+}
+
+// ApplySelectedView applies the view under the cursor and returns to the library
+func (m *Model) ApplySelectedView() tea.Cmd {
+	views := m.getSavedViews()
+	if m.viewCursor < 0 || m.viewCursor >= len(views) {
+		return nil
+	}
+
+	m.state = StateLibrary
+	view := views[m.viewCursor]
+	m.setStatus(fmt.Sprintf("Applied view: %s", view.Name), StatusSuccess)
+	return m.ApplyView(view)
+}
+
+// StartSaveView prompts for a name to save the current library view under
+func (m *Model) StartSaveView() {
+	m.state = StateSaveView
+	m.textInput.SetValue(m.activeView)
+	m.textInput.Focus()
+}
+
+// ConfirmSaveView saves the current filters, sort and grouping under the entered name
+func (m *Model) ConfirmSaveView() {
+	name := strings.TrimSpace(m.textInput.Value())
+	if name == "" {
+		m.setStatus("View name cannot be empty", StatusError)
+		return
+	}
+
+	tm := GetThemeManager()
+	if tm == nil {
+		return
+	}
+	if err := tm.SaveView(m.currentView(name)); err != nil {
+		m.setStatus(fmt.Sprintf("Failed to save view: %v", err), StatusError)
+		return
+	}
+
+	m.activeView = name
+	m.state = StateLibrary
+	m.setStatus(fmt.Sprintf("Saved view: %s", name), StatusSuccess)
+}
+
+// DeleteSelectedView removes the view under the cursor
+func (m *Model) DeleteSelectedView() {
+	views := m.getSavedViews()
+	if m.viewCursor < 0 || m.viewCursor >= len(views) {
+		return
+	}
+
+	name := views[m.viewCursor].Name
+	if err := GetThemeManager().DeleteView(name); err != nil {
+		m.setStatus(fmt.Sprintf("Failed to delete view: %v", err), StatusError)
+		return
+	}
+
+	if m.activeView == name {
+		m.activeView = ""
+	}
+	if m.viewCursor > 0 && m.viewCursor >= len(views)-1 {
+		m.viewCursor--
+	}
+	m.setStatus(fmt.Sprintf("Deleted view: %s", name), StatusSuccess)
+}
+
+// ToggleDefaultView makes the view under the cursor the default for the current library mode,
+// or clears the default if it already is
+func (m *Model) ToggleDefaultView() {
+	views := m.getSavedViews()
+	if m.viewCursor < 0 || m.viewCursor >= len(views) {
+		return
+	}
+
+	tm := GetThemeManager()
+	mode := m.libraryModeKey()
+	name := views[m.viewCursor].Name
+	if tm.GetViews().Defaults[mode] == name {
+		name = ""
+	}
+
+	if err := tm.SetDefaultView(mode, name); err != nil {
+		m.setStatus(fmt.Sprintf("Failed to set default view: %v", err), StatusError)
+		return
+	}
+
+	if name == "" {
+		m.setStatus(fmt.Sprintf("Cleared default view for %s library", mode), StatusSuccess)
+	} else {
+		m.setStatus(fmt.Sprintf("Default view for %s library: %s", mode, name), StatusSuccess)
+	}
+}
+
 // GetSelectedCommand returns the currently selected command
 func (m *Model) GetSelectedCommand() *commands.Command {
-	if len(m.commands) == 0 {
+	index := m.selectedCommandIndex()
+	if index < 0 {
 		return nil
 	}
 	
-	index := m.list.Index()
-	if index < 0 || index >= len(m.commands) {
-		return nil
+	return &m.commands[index]
+}
+
+// selectedCommandIndex returns the index in m.commands of the selected list item,
+// or -1 if nothing is selected or the selection is a group header
+func (m *Model) selectedCommandIndex() int {
+	item, ok := m.list.SelectedItem().(commandItem)
+	if !ok {
+		return -1
 	}
 	
-	return &m.commands[index]
+	for i := range m.commands {
+		if m.commands[i].Name == item.command.Name {
+			return i
+		}
+	}
+	return -1
 }
 
 // Note: Session change tracking removed - all changes now save immediately
@@ -667,6 +1580,15 @@ func (m *Model) ToggleSelectedCommand() tea.Cmd {
 		err = currentCommandManager.EnableCommand(*cmd)
 	}
 
+	// Offer the suggested free name instead of failing on a taken one
+	var collision *commands.NameCollision
+	if errors.As(err, &collision) {
+		m.StartRename()
+		m.renameCollision = collision
+		m.textInput.SetValue(collision.Suggested)
+		m.textInput.CursorEnd()
+		return nil
+	}
 	if err != nil {
 		return func() tea.Msg {
 			return ErrorMsg{Error: err}
@@ -683,8 +1605,10 @@ func (m *Model) ToggleSelectedCommand() tea.Cmd {
 	// Set success status message  
 	if wasEnabled {
 		m.setStatus(fmt.Sprintf("Disabled command: %s", cmd.DisplayName), StatusSuccess)
+		m.logAction(actionDisabled, cmd.DisplayName)
 	} else {
 		m.setStatus(fmt.Sprintf("Enabled command: %s", cmd.DisplayName), StatusSuccess)
+		m.logAction(actionEnabled, cmd.DisplayName)
 	}
 	
 	return func() tea.Msg {
@@ -700,8 +1624,9 @@ func (m *Model) StartRename() {
 	}
 
 	m.state = StateRename
-	m.renameIndex = m.list.Index()
+	m.renameIndex = m.selectedCommandIndex()
 	m.renameOriginal = cmd.DisplayName
+	m.renameCollision = nil
 	m.textInput.SetValue(cmd.DisplayName)
 	m.textInput.Focus()
 }
@@ -718,6 +1643,14 @@ func (m *Model) ConfirmRename() tea.Cmd {
 	currentConfigManager := m.getCurrentConfigManager()
 	
 	cmd := &m.commands[m.renameIndex]
+	if m.renameCollision != nil {
+		renamed := *cmd
+		renamed.DisplayName = newName
+		if collision := currentCommandManager.CheckNameCollision(renamed, m.commands); collision != nil {
+			m.validationErrors["name"] = collision.Error()
+			return nil
+		}
+	}
 	err := currentCommandManager.RenameCommand(*cmd, newName)
 	if err != nil {
 		m.state = StateLibrary
@@ -725,6 +1658,19 @@ func (m *Model) ConfirmRename() tea.Cmd {
 			return ErrorMsg{Error: err}
 		}
 	}
+	m.logAction(actionRenamed, m.renameOriginal+" → "+newName)
+	if m.renameCollision != nil {
+		renamed := *cmd
+		renamed.DisplayName = newName
+		if err := currentCommandManager.EnableCommand(renamed); err != nil {
+			m.state = StateLibrary
+			return func() tea.Msg {
+				return ErrorMsg{Error: err}
+			}
+		}
+		m.logAction(actionEnabled, newName)
+		m.setStatus(fmt.Sprintf("Enabled command as %s", newName), StatusSuccess)
+	}
 
 	// Save configuration immediately
 	if err := currentConfigManager.Save(); err != nil {
@@ -741,6 +1687,235 @@ func (m *Model) ConfirmRename() tea.Cmd {
 	}
 }
 
+// EditSelectedCommand suspends the TUI and opens the selected command in $EDITOR,
+// re-scanning the library afterwards so frontmatter changes show up immediately
+func (m *Model) EditSelectedCommand() tea.Cmd {
+	cmd := m.GetSelectedCommand()
+	if cmd == nil {
+		return nil
+	}
+
+	// Keep the original to tell whether the editor was quit without saving
+	original, err := os.ReadFile(cmd.FilePath)
+	if err != nil {
+		m.setStatus(fmt.Sprintf("Failed to read %s: %v", cmd.DisplayName, err), StatusError)
+		return nil
+	}
+	name, path := cmd.DisplayName, cmd.FilePath
+	return tea.ExecProcess(commands.EditorCommand(path), func(err error) tea.Msg {
+		return EditorFinishedMsg{Name: name, Path: path, Original: string(original), Error: err}
+	})
+}
+
+// CheckIntegrity compares enabled commands in both libraries against their symlinks and source files
+func (m *Model) CheckIntegrity() {
+	if report, err := m.commandManager.CheckIntegrity(); err == nil {
+		m.projectIntegrity = report
+	}
+	if report, err := m.userCommandManager.CheckIntegrity(); err == nil {
+		m.userIntegrity = report
+	}
+}
+
+// HasIntegrityIssues returns true if either library has enabled commands out of sync
+func (m *Model) HasIntegrityIssues() bool {
+	return !m.projectIntegrity.IsHealthy() || !m.userIntegrity.IsHealthy()
+}
+
+// FixIntegrity restores missing symlinks and drops stale config entries in both libraries
+func (m *Model) FixIntegrity() tea.Cmd {
+	if !m.HasIntegrityIssues() {
+		return nil
+	}
+
+	repairs := []struct {
+		report        commands.IntegrityReport
+		manager       *commands.Manager
+		configManager *config.Manager
+	}{
+		{m.projectIntegrity, m.commandManager, m.configManager},
+		{m.userIntegrity, m.userCommandManager, m.userConfigManager},
+	}
+
+	total := 0
+	for _, repair := range repairs {
+		if repair.report.IsHealthy() {
+			continue
+		}
+		fixed, err := repair.manager.RepairIntegrity(repair.report)
+		total += fixed
+		if saveErr := repair.configManager.Save(); err == nil {
+			err = saveErr
+		}
+		if err != nil {
+			m.CheckIntegrity()
+			m.setStatus(fmt.Sprintf("Fixed %d issue(s) before failing: %v", total, err), StatusError)
+			return func() tea.Msg {
+				return RefreshMsg{}
+			}
+		}
+	}
+
+	m.CheckIntegrity()
+	m.setStatus(fmt.Sprintf("Fixed %d symlink issue(s)", total), StatusSuccess)
+	return func() tea.Msg {
+		return RefreshMsg{}
+	}
+}
+
+// SetProjectConfig sets the pinned project configuration and checks the library against it
+func (m *Model) SetProjectConfig(cfg *project.Config) {
+	m.projectConfig = cfg
+	m.CheckProjectConfig()
+}
+
+// CheckProjectConfig compares the project library against the pinned project configuration
+func (m *Model) CheckProjectConfig() {
+	m.projectDrift = nil
+	if m.projectConfig == nil {
+		return
+	}
+
+	cmds, err := m.commandManager.ScanCommands()
+	if err != nil {
+		return
+	}
+	m.projectDrift = m.projectConfig.Check(cmds)
+}
+
+// ReconcileAction is the user's choice for a single item on the reconciliation screen
+type ReconcileAction int
+
+const (
+	ReconcileFix      ReconcileAction = iota // Apply the change the project expects
+	ReconcileSkip                            // Leave as is this time
+	ReconcileRemember                        // Leave as is and remember the exception locally
+)
+
+// StartReconcile opens the reconciliation screen with a suggested action for each drift item
+func (m *Model) StartReconcile() {
+	if len(m.projectDrift) == 0 || m.reconciling {
+		return
+	}
+
+	m.reconcileActions = make([]ReconcileAction, len(m.projectDrift))
+	for i, item := range m.projectDrift {
+		// Default to keeping extras and anything that cannot be fixed automatically
+		if item.Kind == project.DriftExtra || item.Kind == project.DriftMissing && item.Repository == "" {
+			m.reconcileActions[i] = ReconcileSkip
+		}
+	}
+	m.reconcileCursor = 0
+	m.reconcileReturn = m.state
+	m.state = StateReconcile
+}
+
+// CycleReconcileAction moves the action for the item under the cursor forward or backward
+func (m *Model) CycleReconcileAction(step int) {
+	if m.reconcileCursor < 0 || m.reconcileCursor >= len(m.reconcileActions) {
+		return
+	}
+	count := int(ReconcileRemember) + 1
+	action := (int(m.reconcileActions[m.reconcileCursor]) + step + count) % count
+	// Exceptions are kept by command name, so a whole repository cannot be remembered
+	if ReconcileAction(action) == ReconcileRemember && m.projectDrift[m.reconcileCursor].Name == "" {
+		m.setStatus("Only single commands can be remembered as exceptions", StatusWarning)
+		action = (action + step + count) % count
+	}
+	m.reconcileActions[m.reconcileCursor] = ReconcileAction(action)
+}
+
+// ApplyReconcile remembers exceptions and applies the chosen fixes
+func (m *Model) ApplyReconcile() tea.Cmd {
+	var toFix project.Drift
+	var remember []string
+	for i, item := range m.projectDrift {
+		switch m.reconcileActions[i] {
+		case ReconcileFix:
+			toFix = append(toFix, item)
+		case ReconcileRemember:
+			remember = append(remember, item.Name)
+		}
+	}
+
+	m.state = m.reconcileReturn
+
+	if len(remember) > 0 {
+		if err := m.projectConfig.AddExceptions(remember...); err != nil {
+			m.setStatus(fmt.Sprintf("Failed to save exceptions: %v", err), StatusError)
+			return nil
+		}
+	}
+
+	if len(toFix) == 0 {
+		m.CheckProjectConfig()
+		m.setStatus(fmt.Sprintf("Remembered %d exception(s) in %s", len(remember), project.LocalFileName), StatusSuccess)
+		return nil
+	}
+
+	m.reconciling = true
+	m.setStatus("Reconciling with "+project.FileName+"...", StatusInfo)
+
+	// Only the imports run in the background; the library configuration is changed in
+	// Update, where the rest of the model reads it
+	commandsDir := m.commandManager.GetCommandsDir()
+	return m.runInBackground("Reconcile with "+project.FileName, func() tea.Msg {
+		return ProjectReconcileMsg{Plan: project.ImportMissing(commandsDir, toFix)}
+	})
+}
+
+// remoteImportTarget returns the library directory remote imports are written to,
+// following the import.default_target setting
+func (m *Model) remoteImportTarget() (string, bool) {
+	if GetThemeManager().GetAppConfig().Import.DefaultTarget == "project" {
+		return m.commandManager.GetCommandsDir(), true
+	}
+	homeDir, _ := os.UserHomeDir()
+	return filepath.Join(homeDir, ".claude", "command_library"), false
+}
+
+// StartDelete opens the delete confirmation dialog for the selected command
+func (m *Model) StartDelete() {
+	cmd := m.GetSelectedCommand()
+	if cmd == nil {
+		return
+	}
+
+	m.state = StateConfirmDelete
+	m.deleteIndex = m.selectedCommandIndex()
+}
+
+// ConfirmDelete deletes the command pending confirmation and saves immediately
+func (m *Model) ConfirmDelete() tea.Cmd {
+	m.state = StateLibrary
+	if m.deleteIndex < 0 || m.deleteIndex >= len(m.commands) {
+		return nil
+	}
+
+	currentCommandManager := m.getCurrentCommandManager()
+	currentConfigManager := m.getCurrentConfigManager()
+	
+	cmd := m.commands[m.deleteIndex]
+	if _, err := currentCommandManager.DeleteCommand(cmd); err != nil {
+		return func() tea.Msg {
+			return ErrorMsg{Error: err}
+		}
+	}
+	m.logAction(actionDeleted, cmd.DisplayName)
+
+	// Save configuration immediately
+	if err := currentConfigManager.Save(); err != nil {
+		return func() tea.Msg {
+			return ErrorMsg{Error: err}
+		}
+	}
+
+	m.setStatus(fmt.Sprintf("Deleted command: %s (moved to trash; undo with 'ccm trash restore %s')", cmd.DisplayName, cmd.Name), StatusSuccess)
+
+	return func() tea.Msg {
+		return RefreshMsg{}
+	}
+}
 
 // ToggleSelectedCommandLocation toggles the symlink location of the selected command and saves immediately
 func (m *Model) ToggleSelectedCommandLocation() tea.Cmd {
@@ -758,6 +1933,7 @@ func (m *Model) ToggleSelectedCommandLocation() tea.Cmd {
 			return ErrorMsg{Error: err}
 		}
 	}
+	m.logAction(actionMoved, cmd.DisplayName)
 
 	// Save configuration immediately
 	if err := currentConfigManager.Save(); err != nil {
@@ -785,20 +1961,51 @@ func (m *Model) Quit() tea.Cmd {
 // StartReportIssue initiates the report issue flow
 func (m *Model) StartReportIssue() {
 	m.state = StateReportIssue
-	m.issueCurrentField = 0 // Start with title field
+	m.issueCurrentField = issueFieldTitle
 	m.issueSubmitting = false
 	m.issueSubmitError = ""
+	m.issueDiagnostics = false
+	m.issueType = remote.IssueTypeNames[0]
 	
 	// Clear and focus title input
 	m.issueTitleInput.SetValue("")
-	m.issueBodyInput.SetValue("")
+	m.issueBodyInput.SetValue(remote.IssueTemplates[m.issueType])
 	m.issueTitleInput.Focus()
 	m.issueBodyInput.Blur()
+	m.resizeIssueBody()
 	
 	// Clear validation errors
 	m.clearValidationErrors()
 }
 
+// Fields of the report issue form, in Tab order
+const (
+	issueFieldType = iota
+	issueFieldTitle
+	issueFieldBody
+	issueFieldDiagnostics
+	issueFieldCount
+)
+
+// cycleIssueType switches to the next or previous issue type, replacing the body with the
+// new type's template unless it was edited
+func (m *Model) cycleIssueType(step int) {
+	count := len(remote.IssueTypeNames)
+	i := (slices.Index(remote.IssueTypeNames, m.issueType) + step + count) % count
+	body := strings.TrimSpace(m.issueBodyInput.Value())
+	if body == "" || body == strings.TrimSpace(remote.IssueTemplates[m.issueType]) {
+		m.issueBodyInput.SetValue(remote.IssueTemplates[remote.IssueTypeNames[i]])
+	}
+	m.issueType = remote.IssueTypeNames[i]
+}
+
+// resizeIssueBody fits the issue description to the terminal, leaving room for the
+// title, character count and footer
+func (m *Model) resizeIssueBody() {
+	m.issueBodyInput.SetWidth(max(min(m.width-12, 100), 20))
+	m.issueBodyInput.SetHeight(max(min(m.height-24, 15), 3))
+}
+
 // StartSettings initiates the settings menu flow
 func (m *Model) StartSettings() {
 	m.state = StateSettings
@@ -816,7 +2023,15 @@ func (m *Model) StartThemeSettings() {
 // Remote import methods
 
 // StartRemoteImport initiates the remote import flow
-func (m *Model) StartRemoteImport() {
+func (m *Model) StartRemoteImport() tea.Cmd {
+	// Explain a missing or logged-out gh before its API errors do
+	if status := remote.CheckGHAuth(); !status.Ready() && !m.ghAnonymous {
+		m.ghAuthStatus = status
+		m.state = StateGitHubAuth
+		m.ghAuthPoll++
+		return watchGHAuth(m.ghAuthPoll)
+	}
+	
 	m.state = StateRemoteBrowse
 	m.browseMode = BrowseModeCategories
 	m.currentCategory = ""
@@ -829,6 +2044,7 @@ func (m *Model) StartRemoteImport() {
 	m.remoteLoading = false
 	m.remoteError = ""
 	m.remoteSelected = make(map[int]bool)
+	m.remoteRenames = make(map[int]string)
 	m.remoteConflicts = nil
 	m.remoteResult = nil
 	m.browseSelected = make(map[int]bool)
@@ -847,6 +2063,7 @@ func (m *Model) StartRemoteImport() {
 		}
 		// If registry still fails to load, the view will show the error screen
 	}
+	return nil
 }
 
 // ProcessRemoteURL validates and processes the entered repository URL
@@ -865,7 +2082,55 @@ func (m *Model) ProcessRemoteURL() tea.Cmd {
 	
 	// Start the enhanced custom repository flow
 	m.startCustomRepoFlow(url)
-	return nil
+	return m.fetchRepoDetails(url)
+}
+
+// fetchRepoDetails loads the repository's GitHub description and topics in the background
+func (m *Model) fetchRepoDetails(url string) tea.Cmd {
+	return func() tea.Msg {
+		repo, err := remote.ParseGitHubURL(url)
+		if err != nil {
+			return nil
+		}
+		details, err := remote.NewGitHubClient().FetchRepositoryDetails(repo)
+		if err != nil {
+			// Suggestions are best effort; fall back to the name and typed description
+			return nil
+		}
+		return RepoDetailsMsg{URL: url, Details: details}
+	}
+}
+
+// handleRepoDetails stores GitHub topics for the custom repository and pre-fills its description
+func (m *Model) handleRepoDetails(msg RepoDetailsMsg) {
+	if msg.Details == nil || msg.URL != m.customRepoInput.URL {
+		return
+	}
+
+	// Topics pre-populate the tags unless the user has already typed some
+	if len(m.customRepoInput.Tags) == 0 && strings.TrimSpace(m.repoTagsInput.Value()) == "" {
+		m.customRepoInput.Tags = msg.Details.Topics
+		m.repoTagsInput.SetValue(strings.Join(msg.Details.Topics, ", "))
+	}
+	if m.state == StateRemoteRepoDetails && strings.TrimSpace(m.textInput.Value()) == "" {
+		m.textInput.SetValue(msg.Details.Description)
+		m.textInput.CursorEnd()
+	}
+}
+
+// parseTags splits a comma-separated tag list, dropping blanks and duplicates
+func parseTags(value string) []string {
+	tags := []string{}
+	seen := make(map[string]bool)
+	for _, tag := range strings.Split(value, ",") {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		tags = append(tags, tag)
+	}
+	return tags
 }
 
 // ToggleRemoteCommand toggles selection of a remote command
@@ -902,9 +2167,9 @@ func (m *Model) SelectAllRemoteCommands(selectAll bool) {
 // GetSelectedRemoteCommands returns the currently selected remote commands
 func (m *Model) GetSelectedRemoteCommands() []remote.RemoteCommand {
 	var selected []remote.RemoteCommand
-	for i, command := range m.remoteCommands {
+	for i := range m.remoteCommands {
 		if m.remoteSelected[i] {
-			cmd := command
+			cmd := m.remoteImportCommand(i)
 			cmd.Selected = true
 			selected = append(selected, cmd)
 		}
@@ -916,11 +2181,15 @@ func (m *Model) GetSelectedRemoteCommands() []remote.RemoteCommand {
 func (m *Model) updateRemoteCommandList() {
 	items := make([]list.Item, len(m.remoteCommands))
 	for i, cmd := range m.remoteCommands {
-		items[i] = remoteCommandItem{
-			command:  cmd,
+		item := remoteCommandItem{
+			command:  m.remoteImportCommand(i),
 			selected: m.remoteSelected[i],
 			index:    i,
 		}
+		if item.command.Name != cmd.Name {
+			item.renamedFrom = cmd.Name
+		}
+		items[i] = item
 	}
 	m.list.SetItems(items)
 }
@@ -932,12 +2201,7 @@ func (m *Model) StartRemoteImportProcess() tea.Cmd {
 		return nil
 	}
 	
-	m.state = StateRemoteImport
-	
-	// Return command to start async import
-	return func() tea.Msg {
-		return RemoteImportMsg{Commands: selectedCommands}
-	}
+	return m.startImport(selectedCommands)
 }
 
 // ReturnToMain returns to the main menu state and refreshes the command list
@@ -970,7 +2234,30 @@ func (m *Model) updateBrowseList() {
 // updateCategoryList populates the list with categories
 func (m *Model) updateCategoryList() {
 	categories := m.registryManager.GetCategories()
-	items := make([]list.Item, 0, len(categories))
+	items := make([]list.Item, 0, len(categories)+1)
+
+	client := remote.NewGitHubClient()
+	if m.cacheManager != nil {
+		client.SetCacheManager(m.cacheManager)
+	}
+
+	// Favorites only appear once something is starred
+	if favorites, ok := m.registryManager.FavoritesCategory(); ok {
+		items = append(items, categoryItem{
+			key:      registry.FavoritesCategoryKey,
+			category: favorites,
+			counts:   m.categoryCounts(client, registry.FavoritesCategoryKey),
+		})
+	}
+
+	// The trending category only appears when the registry has download stats
+	if trending, ok := m.registryManager.TrendingCategory(); ok {
+		items = append(items, categoryItem{
+			key:      registry.TrendingCategoryKey,
+			category: trending,
+			counts:   m.categoryCounts(client, registry.TrendingCategoryKey),
+		})
+	}
 	
 	// Create a sorted list of category keys to ensure consistent ordering
 	sortedKeys := []string{"development", "project_management", "performance", "testing", "security", "general"}
@@ -980,6 +2267,7 @@ func (m *Model) updateCategoryList() {
 			items = append(items, categoryItem{
 				key:      key,
 				category: category,
+				counts:   m.categoryCounts(client, key),
 			})
 		}
 	}
@@ -997,6 +2285,7 @@ func (m *Model) updateCategoryList() {
 			items = append(items, categoryItem{
 				key:      key,
 				category: category,
+				counts:   m.categoryCounts(client, key),
 			})
 		}
 	}
@@ -1004,6 +2293,39 @@ func (m *Model) updateCategoryList() {
 	m.list.SetItems(items)
 }
 
+// categoryCounts summarizes a category as "14 repos, ~120 commands". Command counts come
+// from cached repositories only; when some are not cached the total is extrapolated from
+// the cached ones and marked approximate.
+func (m *Model) categoryCounts(client *remote.GitHubClient, categoryKey string) string {
+	repositories := m.registryManager.GetCategoryRepositories(categoryKey)
+	if len(repositories) == 0 {
+		return ""
+	}
+	counts := fmt.Sprintf("%d repos", len(repositories))
+	if len(repositories) == 1 {
+		counts = "1 repo"
+	}
+
+	cached, commands := 0, 0
+	for _, repository := range repositories {
+		repo, err := remote.ParseGitHubURL(repository.URL)
+		if err != nil {
+			continue
+		}
+		if count, ok := client.CachedCommandCount(repo); ok {
+			cached++
+			commands += count
+		}
+	}
+	switch {
+	case cached == 0:
+		return counts
+	case cached < len(repositories):
+		return fmt.Sprintf("%s, ~%d commands", counts, commands*len(repositories)/cached)
+	}
+	return fmt.Sprintf("%s, %d commands", counts, commands)
+}
+
 // updateRepositoryList populates the list with repositories from current category
 func (m *Model) updateRepositoryList() {
 	var repositories []remote.CuratedRepository
@@ -1020,6 +2342,8 @@ func (m *Model) updateRepositoryList() {
 			repository: repo,
 			selected:   m.browseSelected[i],
 			index:      i,
+			popular:    m.registryManager.IsPopular(repo.URL),
+			starred:    m.registryManager.IsStarred(repo.URL),
 		}
 	}
 	
@@ -1037,6 +2361,8 @@ func (m *Model) updateSearchResults() {
 			repository: repo,
 			selected:   m.browseSelected[i],
 			index:      i,
+			popular:    m.registryManager.IsPopular(repo.URL),
+			starred:    m.registryManager.IsStarred(repo.URL),
 		}
 	}
 	
@@ -1058,6 +2384,33 @@ func (m *Model) enterCategory() {
 	}
 }
 
+// toggleRepositoryStar stars or unstars the focused repository
+func (m *Model) toggleRepositoryStar() {
+	index := m.list.Index()
+	if index < 0 || index >= len(m.filteredRepos) {
+		return
+	}
+	repo := m.filteredRepos[index]
+
+	starred, err := m.registryManager.ToggleStar(repo.URL)
+	if err != nil {
+		m.setStatus(fmt.Sprintf("Failed to save favorites: %v", err), StatusError)
+		return
+	}
+	if starred {
+		m.setStatus("Starred "+repo.Name, StatusSuccess)
+	} else {
+		m.setStatus("Unstarred "+repo.Name, StatusInfo)
+	}
+
+	// Re-sort search results and drop unstarred repositories from Favorites
+	m.updateBrowseList()
+	if index >= len(m.filteredRepos) {
+		index = len(m.filteredRepos) - 1
+	}
+	m.list.Select(max(index, 0))
+}
+
 // toggleRepositorySelection toggles selection of a repository
 func (m *Model) toggleRepositorySelection() {
 	if m.browseMode != BrowseModeRepositories && m.browseMode != BrowseModeSearch {
@@ -1161,6 +2514,19 @@ func (m *Model) startCustomRepoFlow(repoURL string) {
 func (m *Model) setupRepoDetailsInput() {
 	m.textInput.SetValue(m.customRepoInput.Description)
 	m.textInput.Placeholder = "Enter repository description..."
+	m.repoTagsInput.SetValue(strings.Join(m.customRepoInput.Tags, ", "))
+	m.focusRepoDetailsField(0)
+}
+
+// focusRepoDetailsField focuses the description (0) or tags (1) input on the repository details form
+func (m *Model) focusRepoDetailsField(field int) {
+	m.repoDetailsField = field
+	if field == 1 {
+		m.textInput.Blur()
+		m.repoTagsInput.Focus()
+		return
+	}
+	m.repoTagsInput.Blur()
 	m.textInput.Focus()
 }
 
@@ -1174,12 +2540,27 @@ func (m *Model) startCategorySelection() {
 func (m *Model) setupCategorySelection() {
 	items := make([]list.Item, 0, len(m.availableCategories)+1)
 	
-	// Add existing categories
-	for key, name := range m.availableCategories {
+	m.suggestedCategoryKey = m.registryManager.SuggestCategory(
+		m.customRepoInput.Name, m.customRepoInput.Description, m.customRepoInput.Tags)
+	
+	// Add existing categories, sorted by name
+	keys := make([]string, 0, len(m.availableCategories))
+	for key := range m.availableCategories {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return m.availableCategories[keys[i]] < m.availableCategories[keys[j]]
+	})
+	selected := 0
+	for i, key := range keys {
+		if key == m.suggestedCategoryKey {
+			selected = i
+		}
 		items = append(items, categorySelectionItem{
-			key:  key,
-			name: name,
-			isNew: false,
+			key:       key,
+			name:      m.availableCategories[key],
+			isNew:     false,
+			suggested: key == m.suggestedCategoryKey,
 		})
 	}
 	
@@ -1191,7 +2572,7 @@ func (m *Model) setupCategorySelection() {
 	})
 	
 	m.list.SetItems(items)
-	m.list.Select(0)
+	m.list.Select(selected)
 }
 
 // confirmCategorySelection confirms the category selection
@@ -1221,13 +2602,59 @@ func (m *Model) confirmCategorySelection() {
 	}
 }
 
+// categoryIcons is the curated set of icons offered when creating a category
+var categoryIcons = []string{
+	"📦", "🛠️", "🚀", "🧪", "📝", "🔍", "🐛", "⚙️",
+	"🎨", "📊", "🔒", "🌐", "🤖", "📚", "💡", "⚡",
+}
+
 // startNewCategoryCreation starts the new category creation flow
 func (m *Model) startNewCategoryCreation() {
 	m.categoryInput.SetValue("")
+	m.categoryDescInput.SetValue("")
+	m.categoryIconIndex = 0
+	m.categoryField = 0
 	m.categoryInput.Focus()
+	m.categoryDescInput.Blur()
 	// Stay in StateRemoteCategory but change the UI context
 }
 
+// CycleCategoryField moves focus between the name, description and icon fields
+func (m *Model) CycleCategoryField(step int) {
+	m.categoryField = (m.categoryField + step + 3) % 3
+	m.categoryInput.Blur()
+	m.categoryDescInput.Blur()
+	switch m.categoryField {
+	case 0:
+		m.categoryInput.Focus()
+	case 1:
+		m.categoryDescInput.Focus()
+	}
+}
+
+// MoveCategoryIcon changes the selected icon in the icon picker
+func (m *Model) MoveCategoryIcon(step int) {
+	m.categoryIconIndex = (m.categoryIconIndex + step + len(categoryIcons)) % len(categoryIcons)
+}
+
+// newCategoryInput builds the category definition from the new category form
+func (m *Model) newCategoryInput() registry.CategoryInput {
+	name := strings.TrimSpace(m.categoryInput.Value())
+	description := strings.TrimSpace(m.categoryDescInput.Value())
+	if description == "" {
+		description = fmt.Sprintf("Custom category: %s", name)
+	}
+
+	return registry.CategoryInput{
+		// Category key from name (lowercase, replace spaces with underscores)
+		CategoryKey: strings.ToLower(strings.ReplaceAll(name, " ", "_")),
+		IsNew:       true,
+		Name:        name,
+		Description: description,
+		Icon:        categoryIcons[m.categoryIconIndex],
+	}
+}
+
 // finalizeCustomRepository completes the custom repository addition
 func (m *Model) finalizeCustomRepository() {
 	// Update description from text input
@@ -1319,13 +2746,12 @@ func (m *Model) calculateAvailableHeight() int {
 	case StateMainMenu:
 		// Account for ASCII header and card styling overhead
 		headerHeight := 15 // Full ASCII art header
-		if m.width < 80 { // Updated threshold to match view.go
-			headerHeight = 8 // Simple header is smaller
+		if m.compactLayout() || m.plainMode() {
+			headerHeight = 4 // Text title and version
 		}
 		
-		// Each card item takes approximately 2 lines (borders + content)
-		// With 3 menu items, we need about 6 lines for content + some spacing
-		cardOverhead := 8
+		// Spacing around the list and the boxed footer
+		cardOverhead := 3
 		
 		availableHeight := m.height - headerHeight - baseReserved - cardOverhead
 		if availableHeight < 3 {
@@ -1333,14 +2759,27 @@ func (m *Model) calculateAvailableHeight() int {
 		}
 		return availableHeight
 		
-	case StateLibrary, StateRemoteBrowse, StateRemoteSelect:
+	case StateLibrary:
+		// The full footer wraps onto several lines on all but the widest terminals
+		footerLines := strings.Count(wrapHints(m.pagedFooter(m.renderFooter()), m.width-4), "\n")
+		// The stats and filters can wrap the header too
+		headerLines := lipgloss.Height(leftMarginHeaderStyle.Width(m.width-4).Render(m.libraryHeader())) - 1
+		return m.height - 6 - baseReserved - footerLines - headerLines // Header + footer space
+		
+	case StateRemoteBrowse, StateRemoteSelect:
 		return m.height - 6 - baseReserved // Header + footer space
 		
-	case StateRename, StateRemoteURL, StateRemoteRepoDetails, StateRemoteCategory:  
-		return m.height - 10 - baseReserved // More space for input forms
+	case StateThemeSettings:
+		// The current theme is described above the list, and narrow terminals show the
+		// highlighted theme's colors below it rather than beside it
+		reserved := m.height - 8 - baseReserved - 5 - len(theme.UserThemeProblems())
+		if m.width < themeMockMinWidth {
+			reserved -= 2
+		}
+		return reserved
 		
-	case StateHelp:
-		return m.height - 4 - baseReserved // Minimal header for help
+	case StateRename, StateDuplicate, StateEditMeta, StateRemoteURL, StateRemoteRepoDetails, StateRemoteCategory:  
+		return m.height - 10 - baseReserved // More space for input forms
 		
 	default:
 		return m.height - 8 - baseReserved // Default conservative estimate
@@ -1366,37 +2805,27 @@ func (m *Model) validateReportIssueInput() bool {
 	}
 	
 	// Validate body (optional but recommended)
-	body := strings.TrimSpace(m.issueBodyInput.Value())
-	if len(body) > 2000 {
-		m.validationErrors["body"] = "Description too long (max 2000 characters)"
+	if m.issueBodyInput.Length() > m.issueBodyInput.CharLimit {
+		m.validationErrors["body"] = fmt.Sprintf("Description too long (max %d characters)", m.issueBodyInput.CharLimit)
 		isValid = false
 	}
 	
 	return isValid
 }
 
-// SubmitIssue submits the issue to GitHub
+// SubmitIssue submits the previewed issue to GitHub
 func (m *Model) SubmitIssue() tea.Cmd {
-	title := strings.TrimSpace(m.issueTitleInput.Value())
-	body := strings.TrimSpace(m.issueBodyInput.Value())
+	draft := m.issuePreview.draft
+	repoInfo, title, body, label := draft.repo, draft.title, draft.body, draft.label
 	
 	// Set submitting state
 	m.issueSubmitting = true
 	m.issueSubmitError = ""
 	
-	// Return async command to submit issue
-	return func() tea.Msg {
-		// Get repository information
-		repoInfo, err := remote.GetRepositoryInfo()
-		if err != nil {
-			return IssueSubmissionCompleteMsg{
-				Success: false,
-				Error:   fmt.Sprintf("Failed to get repository info: %v", err),
-			}
-		}
-		
+	// Submit the issue in the background
+	return m.runInBackground("Submit issue: "+title, func() tea.Msg {
 		// Create the issue
-		err = remote.CreateGitHubIssue(repoInfo, title, body)
+		issueURL, err := remote.CreateGitHubIssue(repoInfo, title, body, label)
 		if err != nil {
 			return IssueSubmissionCompleteMsg{
 				Success: false,
@@ -1406,9 +2835,9 @@ func (m *Model) SubmitIssue() tea.Cmd {
 		
 		return IssueSubmissionCompleteMsg{
 			Success: true,
-			IssueURL: fmt.Sprintf("https://github.com/%s/%s/issues", repoInfo.Owner, repoInfo.Repo),
+			IssueURL: issueURL,
 		}
-	}
+	})
 }
 
 // validateInput validates input fields and sets validation errors
@@ -1417,7 +2846,7 @@ func (m *Model) validateInput() bool {
 	isValid := true
 	
 	switch m.state {
-	case StateRename:
+	case StateRename, StateDuplicate:
 		newName := strings.TrimSpace(m.textInput.Value())
 		if newName == "" {
 			m.validationErrors["name"] = "Name cannot be empty"
@@ -1473,6 +2902,8 @@ func (m *Model) setStatus(message string, statusType StatusType) {
 	m.statusMessage = message
 	m.statusType = statusType
 	m.showStatus = true
+	m.statusFading = false
+	m.statusSeq++
 }
 
 // clearStatus clears the current status message
@@ -1507,6 +2938,7 @@ func (m *Model) StartPreview() {
 	}
 	
 	// Store the command to preview and the previous state
+	m.markdown = markdownCache{} // Re-render in case the theme changed
 	m.previewCommand = &m.remoteCommands[index]
 	m.previousState = m.state
 	m.state = StateRemotePreview
@@ -1523,10 +2955,50 @@ func (m *Model) ExitPreview() {
 	m.previewCommand = nil
 }
 
+// StartAnalysis enters the code block analysis view for the focused or previewed command
+func (m *Model) StartAnalysis() {
+	var command *remote.RemoteCommand
+	
+	switch m.state {
+	case StateRemoteSelect:
+		index := m.list.Index()
+		if index < 0 || index >= len(m.remoteCommands) {
+			return
+		}
+		command = &m.remoteCommands[index]
+	case StateRemotePreview:
+		command = m.previewCommand
+	default:
+		return
+	}
+	
+	if command == nil {
+		return
+	}
+	
+	analysis := remote.AnalyzeCommand(command.Content)
+	m.analysisCommand = command
+	m.analysisResult = &analysis
+	m.analysisReturn = m.state
+	m.state = StateRemoteAnalysis
+}
+
+// ExitAnalysis returns to the state the analysis was opened from
+func (m *Model) ExitAnalysis() {
+	if m.state != StateRemoteAnalysis {
+		return
+	}
+	
+	m.state = m.analysisReturn
+	m.analysisCommand = nil
+	m.analysisResult = nil
+}
+
 // SetRemoteCommands sets the remote commands for testing purposes
 func (m *Model) SetRemoteCommands(commands []remote.RemoteCommand) {
 	m.remoteCommands = commands
 	m.remoteSelected = make(map[int]bool)
+	m.remoteRenames = make(map[int]string)
 	m.state = StateRemoteSelect
 	m.updateRemoteCommandList()
 }
@@ -1542,9 +3014,21 @@ func (m *Model) initSettingsMenu() {
 			icon:        "🎨",
 			action:      "themes",
 		},
+		menuItem{
+			title:       "Keybindings",
+			description: fmt.Sprintf("Profile: %s • Enter to switch (%s)", keymapProfile(), strings.Join(theme.LibraryKeymaps, ", ")),
+			icon:        "⌨️",
+			action:      "keymap",
+		},
+		menuItem{
+			title:       "Cache",
+			description: "Repository data cache: TTL, size, background refresh",
+			icon:        "🗄️",
+			action:      "cache",
+		},
 		menuItem{
 			title:       "General",
-			description: "General preferences and options",
+			description: "Symlink, import and sort defaults, confirmations, message timeouts",
 			icon:        "⚙️",
 			action:      "general",
 		},
@@ -1562,6 +3046,9 @@ func (m *Model) initSettingsMenu() {
 // initThemePickerMenu initializes the theme picker menu
 func (m *Model) initThemePickerMenu() {
 	themeManager := GetThemeManager()
+
+	// Pick up themes imported with `ccm theme import` while the TUI was running
+	theme.ReloadUserThemes()
 	themes := themeManager.GetAvailableThemes()
 	
 	items := make([]list.Item, len(themes))
@@ -1574,9 +3061,14 @@ func (m *Model) initThemePickerMenu() {
 			m.selectedThemeIndex = i
 		}
 		
+		description := theme.Description
+		if theme.Custom {
+			description = "Custom • " + description
+		}
+		
 		items[i] = menuItem{
 			title:       activeIndicator + theme.Name,
-			description: theme.Description,
+			description: description,
 			icon:        "", // Theme preview will be shown differently
 			action:      theme.ID,
 		}
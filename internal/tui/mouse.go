@@ -0,0 +1,174 @@
+package tui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// listMarker and footerMarker are put in front of the list and the footer as they are
+// rendered, so View can find where they ended up on screen. They are APC strings, which
+// take no space while the view is laid out, and View removes them before drawing.
+const (
+	listMarker   = "\x1b_ccm:list\x1b\\"
+	footerMarker = "\x1b_ccm:footer\x1b\\"
+)
+
+// mouseLayout records where the clickable parts of the last frame were drawn
+type mouseLayout struct {
+	listTop, listLeft int      // Screen position of the list, or -1 if it was not shown
+	footerTop         int      // First footer line, or -1 if there was no footer
+	footer            []string // Footer lines as plain text
+}
+
+// listView renders the shared list with its position marker
+func (m *Model) listView() string {
+	return listMarker + m.list.View()
+}
+
+// trackLayout records where the markers in a rendered screen are and removes them
+func (m *Model) trackLayout(view string) string {
+	m.layout = mouseLayout{listTop: -1, listLeft: -1, footerTop: -1}
+	lines := strings.Split(view, "\n")
+	for i, line := range lines {
+		if at := strings.Index(line, listMarker); at >= 0 {
+			m.layout.listTop, m.layout.listLeft = i, ansi.StringWidth(line[:at])
+			line = strings.Replace(line, listMarker, "", 1)
+		}
+		if at := strings.Index(line, footerMarker); at >= 0 {
+			m.layout.footerTop = i
+			line = strings.Replace(line, footerMarker, "", 1)
+		}
+		lines[i] = line
+	}
+	if m.layout.footerTop >= 0 {
+		for _, line := range lines[m.layout.footerTop:] {
+			m.layout.footer = append(m.layout.footer, ansi.Strip(line))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// handleMouseMsg selects list rows on click, scrolls with the wheel and runs the
+// footer hint that was clicked
+func (m *Model) handleMouseMsg(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
+	if m.palette.open || m.help.open || m.commandLineActive {
+		return m, nil
+	}
+
+	switch {
+	case msg.Button == tea.MouseButtonWheelUp:
+		return m.handleKeyMsg(keyMsgFor("up"))
+	case msg.Button == tea.MouseButtonWheelDown:
+		return m.handleKeyMsg(keyMsgFor("down"))
+	case msg.Button != tea.MouseButtonLeft || msg.Action != tea.MouseActionPress:
+		return m, nil
+	}
+
+	if row := msg.Y - m.layout.footerTop; m.layout.footerTop >= 0 && row >= 0 && row < len(m.layout.footer) {
+		if name := footerHintAt(m.layout.footer[row], msg.X); name != "" {
+			return m.handleKeyMsg(keyMsgFor(name))
+		}
+		return m, nil
+	}
+	if index, ok := m.listItemAt(msg.X, msg.Y); ok {
+		m.list.Select(index)
+	}
+	return m, nil
+}
+
+// listItemAt returns the index of the list item drawn at a screen position
+func (m *Model) listItemAt(x, y int) (int, bool) {
+	if m.layout.listTop < 0 || y < m.layout.listTop || x < m.layout.listLeft {
+		return 0, false
+	}
+
+	// Measure the items as the view drew them, in the narrower column beside the detail panel
+	list := m.list
+	if m.state == StateLibrary && m.splitPaneActive() {
+		listWidth, _ := m.splitPaneWidths()
+		if x >= m.layout.listLeft+listWidth {
+			return 0, false
+		}
+		list.SetWidth(listWidth)
+	}
+
+	items := list.VisibleItems()
+	start, end := list.Paginator.GetSliceBounds(len(items))
+	delegate := newListDelegate(m.compactList)
+	top := m.layout.listTop
+	for i := start; i < end; i++ {
+		var item strings.Builder
+		delegate.Render(&item, list, i, items[i])
+		height := lipgloss.Height(item.String())
+		if y < top+height {
+			return i, true
+		}
+		top += height + delegate.Spacing()
+	}
+	return 0, false
+}
+
+// footerHintAt returns the key of the "key: Label" hint under column x of a footer line,
+// or "" if there is none. Where a hint lists several keys ("p/Esc"), the first is used.
+func footerHintAt(line string, x int) string {
+	column := 0
+	for _, hint := range strings.Split(line, " • ") {
+		width := ansi.StringWidth(hint)
+		if x < column || x >= column+width {
+			column += width + ansi.StringWidth(" • ")
+			continue
+		}
+		if x < column+width-ansi.StringWidth(strings.TrimLeft(hint, " ")) {
+			return "" // In the margin
+		}
+		keys, _, found := strings.Cut(strings.TrimSpace(hint), ": ")
+		if !found || strings.Contains(keys, " ") {
+			return ""
+		}
+		if strings.HasPrefix(keys, "/") {
+			return "/" // The key is the separator itself
+		}
+		first, _, _ := strings.Cut(keys, "/")
+		return keyName(first)
+	}
+	return ""
+}
+
+// keyName turns a key label from the footer back into the name Bubble Tea gives the key
+func keyName(label string) string {
+	switch label {
+	case "Space":
+		return " "
+	case "↑":
+		return "up"
+	case "↓":
+		return "down"
+	case "←":
+		return "left"
+	case "→":
+		return "right"
+	}
+	if len([]rune(label)) > 1 {
+		return strings.ToLower(label) // Enter -> enter, Ctrl+C -> ctrl+c
+	}
+	return label
+}
+
+// keyMsgFor builds the key message Bubble Tea sends when the named key is pressed
+func keyMsgFor(name string) tea.KeyMsg {
+	msg := tea.KeyMsg{Type: tea.KeyRunes}
+	if rest, ok := strings.CutPrefix(name, "alt+"); ok && len(rest) > 0 {
+		msg.Alt, name = true, rest
+	}
+	for t := tea.KeyType(-128); t < 128; t++ {
+		if t != tea.KeyRunes && t.String() == name {
+			msg.Type = t
+			return msg
+		}
+	}
+	msg.Runes = []rune(name)
+	return msg
+}
@@ -0,0 +1,238 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// maxPaletteMatches is how many matching actions the palette lists at once
+const maxPaletteMatches = 10
+
+// paletteEntry is an action offered by the command palette
+type paletteEntry struct {
+	title string
+	hint  string // Key that runs the action directly, if any
+	run   func() tea.Cmd
+}
+
+// commandPalette is the Ctrl+P overlay for finding and running actions by name
+type commandPalette struct {
+	open    bool
+	input   textinput.Model
+	entries []paletteEntry
+	matches []list.Rank // Fuzzy matches of the input against entry titles, best first
+	cursor  int
+}
+
+// paletteAvailable reports whether the palette can open over the current screen
+func (m *Model) paletteAvailable() bool {
+	switch m.state {
+	case StateMainMenu, StateLibrary, StateSettings:
+		return !m.commandLineActive
+	}
+	return false
+}
+
+// paletteEntries lists the actions available in the current state
+func (m *Model) paletteEntries() []paletteEntry {
+	var entries []paletteEntry
+	if m.state == StateLibrary {
+		for _, action := range m.keys.actions() {
+			switch action.name {
+			case "up", "down", "page_up", "page_down", "top", "bottom", "command":
+				continue // Navigation is quicker with the keys themselves
+			}
+			name := action.name
+			entries = append(entries, paletteEntry{
+				title: action.binding.Help().Desc,
+				hint:  action.binding.Help().Key,
+				run:   func() tea.Cmd { return m.runLibraryAction(name) },
+			})
+		}
+	} else {
+		entries = append(entries,
+			paletteEntry{"Open the command library", "", func() tea.Cmd {
+				m.state = StateLibrary
+				m.applyDefaultView()
+				return func() tea.Msg {
+					return RefreshMsg{}
+				}
+			}},
+			paletteEntry{m.keys.Import.Help().Desc, m.keys.Import.Help().Key, m.StartRemoteImport},
+			paletteEntry{m.keys.Fix.Help().Desc, m.keys.Fix.Help().Key, m.FixIntegrity},
+			paletteEntry{m.keys.Reconcile.Help().Desc, m.keys.Reconcile.Help().Key, func() tea.Cmd {
+				m.StartReconcile()
+				return nil
+			}},
+			paletteEntry{"Show the keys of this screen", m.keys.Help.Help().Key, func() tea.Cmd {
+				m.openHelp()
+				return nil
+			}},
+			paletteEntry{"Quit", m.keys.Quit.Help().Key, m.Quit},
+		)
+	}
+
+	if m.state != StateMainMenu {
+		entries = append(entries, paletteEntry{"Go to the main menu", "", func() tea.Cmd {
+			m.state = StateMainMenu
+			m.initMainMenu()
+			return nil
+		}})
+	}
+	return append(entries,
+		paletteEntry{"Open settings", "", func() tea.Cmd {
+			m.StartSettings()
+			return nil
+		}},
+		paletteEntry{"Change theme", "", func() tea.Cmd {
+			m.StartThemeSettings()
+			return nil
+		}},
+		paletteEntry{"Switch key binding profile", "", m.CycleKeymapProfile},
+		paletteEntry{"Report a bug or request a feature", "", func() tea.Cmd {
+			m.StartReportIssue()
+			return nil
+		}},
+	)
+}
+
+// OpenPalette shows the command palette over the current screen
+func (m *Model) OpenPalette() tea.Cmd {
+	input := textinput.New()
+	input.Placeholder = "Type to search actions..."
+	input.CharLimit = 60
+	input.Width = 50
+
+	m.palette = commandPalette{open: true, input: input, entries: m.paletteEntries()}
+	m.filterPalette()
+	return m.palette.input.Focus()
+}
+
+// filterPalette fuzzy-matches the input against the palette entries
+func (m *Model) filterPalette() {
+	palette := &m.palette
+	palette.cursor = 0
+	query := strings.TrimSpace(palette.input.Value())
+	if query == "" {
+		palette.matches = make([]list.Rank, len(palette.entries))
+		for i := range palette.entries {
+			palette.matches[i] = list.Rank{Index: i}
+		}
+		return
+	}
+
+	titles := make([]string, len(palette.entries))
+	for i, entry := range palette.entries {
+		titles[i] = entry.title
+	}
+	palette.matches = list.DefaultFilter(query, titles)
+}
+
+// handlePaletteKeys handles keys while the palette is open
+func (m *Model) handlePaletteKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	palette := &m.palette
+	switch msg.String() {
+	case "ctrl+c":
+		return m, m.Quit()
+	case "esc", "ctrl+p":
+		palette.open = false
+		return m, nil
+	case "up", "ctrl+k":
+		if palette.cursor > 0 {
+			palette.cursor--
+		}
+		return m, nil
+	case "down", "ctrl+j", "ctrl+n":
+		if palette.cursor < min(len(palette.matches), maxPaletteMatches)-1 {
+			palette.cursor++
+		}
+		return m, nil
+	case "enter":
+		palette.open = false
+		if len(palette.matches) == 0 {
+			return m, nil
+		}
+		m.clearStatus()
+		return m, palette.entries[palette.matches[palette.cursor].Index].run()
+	}
+
+	var cmd tea.Cmd
+	palette.input, cmd = palette.input.Update(msg)
+	m.filterPalette()
+	return m, cmd
+}
+
+// paletteView renders the palette box
+func (m *Model) paletteView() string {
+	palette := m.palette
+	width := min(m.width-8, 70)
+
+	var content strings.Builder
+	content.WriteString(palette.input.View())
+	content.WriteString("\n\n")
+	if len(palette.matches) == 0 {
+		content.WriteString(subtleStyle.Render("No matching actions"))
+		content.WriteString("\n")
+	}
+	for i, match := range palette.matches[:min(len(palette.matches), maxPaletteMatches)] {
+		entry := palette.entries[match.Index]
+		title := lipgloss.StyleRunes(entry.title, match.MatchedIndexes, highlightStyle, lipgloss.NewStyle())
+		cursor := "  "
+		if i == palette.cursor {
+			cursor = "▶ "
+		}
+		hint := ""
+		if entry.hint != "" {
+			hint = keyStyle.Render(entry.hint)
+		}
+		inner := width - 2 // Inside the padding
+		line := cursor + ansi.Truncate(title, inner-len(cursor)-1-lipgloss.Width(hint), "…")
+		gap := max(inner-lipgloss.Width(line)-lipgloss.Width(hint), 1)
+		content.WriteString(line + strings.Repeat(" ", gap) + hint + "\n")
+	}
+	if len(palette.matches) > maxPaletteMatches {
+		content.WriteString(subtleStyle.Render("  …and more, keep typing to narrow down"))
+		content.WriteString("\n")
+	}
+	content.WriteString("\n")
+	content.WriteString(subtleStyle.Render("↑/↓: Choose • Enter: Run • Esc: Close"))
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(primaryColor).
+		Padding(0, 1).
+		Width(width).
+		Render(content.String())
+}
+
+// overlay draws box centered over the lines of background
+func overlay(background, box string, width int) string {
+	top := max((strings.Count(background, "\n")+1-lipgloss.Height(box))/2, 0)
+	left := max((width-lipgloss.Width(box))/2, 0)
+	return overlayAt(background, box, top, left)
+}
+
+// overlayAt draws box over background with its top-left corner at the given line and column
+func overlayAt(background, box string, top, left int) string {
+	lines := strings.Split(background, "\n")
+	boxLines := strings.Split(box, "\n")
+
+	for len(lines) < top+len(boxLines) {
+		lines = append(lines, "")
+	}
+	for i, boxLine := range boxLines {
+		line := lines[top+i]
+		prefix := ansi.Truncate(line, left, "")
+		if pad := left - ansi.StringWidth(prefix); pad > 0 {
+			prefix += strings.Repeat(" ", pad)
+		}
+		suffix := ansi.TruncateLeft(line, left+lipgloss.Width(boxLine), "")
+		lines[top+i] = prefix + "\x1b[0m" + boxLine + suffix
+	}
+	return strings.Join(lines, "\n")
+}
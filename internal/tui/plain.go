@@ -0,0 +1,73 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// plainRunes are the ASCII stand-ins for symbols in plain mode. plainText pads each one to
+// the width of the symbol it replaces, so boxes and columns stay aligned; only "..." is wider.
+var plainRunes = map[rune]string{
+	'╭': "+", '╮': "+", '╰': "+", '╯': "+", '┌': "+", '┐': "+", '└': "+", '┘': "+",
+	'├': "+", '┤': "+", '┬': "+", '┴': "+", '┼': "+", '╔': "+", '╗': "+", '╚': "+", '╝': "+",
+	'─': "-", '━': "-", '═': "-", '│': "|", '┃': "|", '║': "|",
+	'█': "#", '▓': "#", '▒': "#", '░': "-",
+	'•': "*", '●': "*", '◆': "*", '★': "*", '⭐': "*",
+	'▶': ">", '▼': "v", '→': ">", '←': "<", '↑': "^", '↓': "v",
+	'—': "-", '…': "...",
+	'✓': "x", '✔': "x", '✗': "X", '❌': "X", '✅': "OK", '⚠': "!", 'ℹ': "i",
+	'👤': "U", '📁': "P", '🔗': "L", '📌': "#", '📋': "", '📄': "",
+}
+
+// plainMode reports whether the TUI should draw with ASCII only, for screen readers
+// and fonts without emoji or box drawing characters
+func (m *Model) plainMode() bool {
+	if m.plain {
+		return true
+	}
+	if tm := GetThemeManager(); tm != nil {
+		return tm.GetAppConfig().Theme.Plain
+	}
+	return false
+}
+
+// SetPlain turns on plain mode regardless of the theme.plain setting (--plain, NO_EMOJI)
+func (m *Model) SetPlain(plain bool) {
+	m.plain = plain
+}
+
+// plainText replaces symbols in a rendered screen with ASCII and blanks out other emoji,
+// keeping the width each one took. Escape sequences are ASCII, so styling passes through untouched.
+func plainText(view string) string {
+	runes := []rune(view)
+	var b strings.Builder
+	b.Grow(len(view))
+	for i, r := range runes {
+		replacement, ok := plainRunes[r]
+		switch {
+		case r == '️', r == '‍': // Emoji presentation selector and joiner
+			continue
+		case i > 0 && runes[i-1] == '‍':
+			continue // Drawn as part of the emoji before the joiner
+		case ok:
+		case r >= 0x2800 && r <= 0x28FF: // Braille, used by spinners
+			replacement = "*"
+		case r >= 0x1F000 && r <= 0x1FAFF, r >= 0x2600 && r <= 0x27BF, r >= 0x2B00 && r <= 0x2BFF:
+			// Decorative emoji and symbols without a stand-in
+		default:
+			b.WriteRune(r)
+			continue
+		}
+
+		width := ansi.StringWidth(string(r))
+		if i+1 < len(runes) && runes[i+1] == '️' {
+			width = 2 // The selector draws a text symbol such as ⚠ as a wide emoji
+		}
+		b.WriteString(replacement)
+		if pad := width - len(replacement); pad > 0 {
+			b.WriteString(strings.Repeat(" ", pad))
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,173 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/shel-corp/Claude-command-manager/internal/commands"
+	"github.com/shel-corp/Claude-command-manager/internal/models"
+)
+
+// libraryPreviewState holds the local command shown in the library preview
+type libraryPreviewState struct {
+	command     commands.Command
+	fields      []commands.FrontmatterField
+	body        string
+	modTime     time.Time // When the file was read, to tell if the detail panel is stale
+	symlinkPath string
+	symlink     string // Where the symlink currently points, or a description of its absence
+	scroll      int    // First body line shown
+	err         error  // Why the detail panel could not read the file
+}
+
+// StartLibraryPreview reads the selected command and shows its frontmatter, symlink and content
+func (m *Model) StartLibraryPreview() {
+	cmd := m.GetSelectedCommand()
+	if cmd == nil {
+		return
+	}
+
+	preview, err := m.loadLibraryPreview(*cmd)
+	if err != nil {
+		m.setStatus(fmt.Sprintf("Failed to read %s: %v", cmd.DisplayName, err), StatusError)
+		return
+	}
+	m.libraryPreview = preview
+	m.markdown = markdownCache{} // Re-render in case the theme changed
+	m.state = StateLibraryPreview
+}
+
+// loadLibraryPreview reads a command file and where its symlink points
+func (m *Model) loadLibraryPreview(cmd commands.Command) (libraryPreviewState, error) {
+	info, err := os.Stat(cmd.FilePath)
+	if err != nil {
+		return libraryPreviewState{}, err
+	}
+	data, err := os.ReadFile(cmd.FilePath)
+	if err != nil {
+		return libraryPreviewState{}, err
+	}
+
+	fields, body := commands.SplitFrontmatter(string(data))
+	symlinkPath := m.getCurrentCommandManager().SymlinkPath(cmd)
+	return libraryPreviewState{
+		command:     cmd,
+		fields:      fields,
+		body:        body,
+		modTime:     info.ModTime(),
+		symlinkPath: symlinkPath,
+		symlink:     describeSymlink(symlinkPath, cmd.FilePath),
+	}, nil
+}
+
+// describeSymlink reports whether the symlink at path exists and points at the command file
+func describeSymlink(path, filePath string) string {
+	target, err := os.Readlink(path)
+	switch {
+	case os.IsNotExist(err):
+		return "not linked"
+	case err != nil:
+		return "not a symlink"
+	case target != filePath:
+		return "points elsewhere: " + target
+	}
+	return "→ " + target
+}
+
+// previewBodyLines returns how many body lines fit below the preview metadata
+func (m *Model) previewBodyLines() int {
+	lines := m.height - 14 - len(m.libraryPreview.fields)
+	if m.showStatus {
+		lines -= 2 // Room for the status message
+	}
+	if lines < 5 {
+		lines = 5
+	}
+	return lines
+}
+
+// handleLibraryPreviewStateKeys handles keys in the local command preview
+func (m *Model) handleLibraryPreviewStateKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	maxScroll := len(m.markdownLines(m.libraryPreview.body)) - m.previewBodyLines()
+	if maxScroll < 0 {
+		maxScroll = 0
+	}
+
+	switch msg.String() {
+	case "esc", "p", "q":
+		m.state = StateLibrary
+	case "ctrl+c":
+		return m, m.Quit()
+	case "up", "k":
+		if m.libraryPreview.scroll > 0 {
+			m.libraryPreview.scroll--
+		}
+	case "down", "j":
+		if m.libraryPreview.scroll < maxScroll {
+			m.libraryPreview.scroll++
+		}
+	case "pgup", "b":
+		m.libraryPreview.scroll = max(m.libraryPreview.scroll-m.previewBodyLines(), 0)
+	case "pgdown", " ", "f":
+		m.libraryPreview.scroll = min(m.libraryPreview.scroll+m.previewBodyLines(), maxScroll)
+	case "e":
+		m.state = StateLibrary
+		return m, m.EditSelectedCommand()
+	case "y", "Y":
+		m.copyCommand(&m.libraryPreview.command, msg.String() == "Y")
+	}
+	return m, nil
+}
+
+// libraryPreviewView renders the local command preview
+func (m *Model) libraryPreviewView() string {
+	preview := m.libraryPreview
+	cmd := preview.command
+	header := fmt.Sprintf("📄 Preview: %s", cmd.DisplayName)
+
+	status := "disabled"
+	if cmd.Enabled {
+		status = fmt.Sprintf("enabled (%s)", cmd.SymlinkLocation)
+	}
+
+	var content strings.Builder
+	content.WriteString(fmt.Sprintf("File: %s\n", subtleStyle.Render(cmd.FilePath)))
+	content.WriteString(fmt.Sprintf("Status: %s\n", highlightStyle.Render(status)))
+	content.WriteString(fmt.Sprintf("Symlink: %s\n", subtleStyle.Render(preview.symlinkPath)))
+	if cmd.Enabled && !strings.HasPrefix(preview.symlink, "→") {
+		content.WriteString(fmt.Sprintf("         %s\n", warningStyle.Render("⚠️  "+preview.symlink)))
+	} else {
+		content.WriteString(fmt.Sprintf("         %s\n", subtleStyle.Render(preview.symlink)))
+	}
+	for _, field := range preview.fields {
+		content.WriteString(fmt.Sprintf("%s: %s\n", field.Key, field.Value))
+	}
+	if warning := models.DeprecationWarning(cmd.Model); warning != "" {
+		content.WriteString(warningStyle.Render("⚠️  " + warning))
+		content.WriteString("\n")
+	}
+	content.WriteString("\n")
+	content.WriteString(strings.Repeat("─", m.previewWidth()))
+	content.WriteString("\n\n")
+
+	// Rendering to a new width can shorten the body below the scroll position
+	lines := m.markdownLines(preview.body)
+	start := min(preview.scroll, len(lines)-1)
+	end := min(start+m.previewBodyLines(), len(lines))
+	for _, line := range lines[start:end] {
+		content.WriteString(line)
+		content.WriteString("\n")
+	}
+	if len(lines) > m.previewBodyLines() {
+		content.WriteString(subtleStyle.Render(fmt.Sprintf("lines %d-%d of %d", start+1, end, len(lines))))
+		content.WriteString("\n")
+	}
+	content.WriteString(m.renderStatusMessage())
+
+	footer := "↑/↓: Scroll • Space/b: Page • e: Edit • y/Y: Copy Content/Path • p/Esc: Back • Ctrl+C: Quit"
+	return centerView(header, content.String(), footer, m.width)
+}
@@ -0,0 +1,217 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/shel-corp/Claude-command-manager/internal/commands"
+)
+
+// maxSearchSnippets caps how many matching lines are shown per command
+const maxSearchSnippets = 3
+
+// searchMatch is a line of a command file that contains the search text
+type searchMatch struct {
+	line int // 1-based
+	text string
+}
+
+// searchResult is a command whose name, description or contents contain the search text
+type searchResult struct {
+	command commands.Command
+	matches []searchMatch // Matching lines of the file; empty if only the name or description matched
+}
+
+// contentSearchState holds the library search across command names, descriptions and contents
+type contentSearchState struct {
+	input    textinput.Model
+	contents map[string][]string // Lines of each command file, by path, read when the search opened
+	results  []searchResult
+	cursor   int
+}
+
+// StartContentSearch opens the full-text search over the commands of the current library
+func (m *Model) StartContentSearch() tea.Cmd {
+	if len(m.commands) == 0 {
+		m.setStatus("No commands to search", StatusWarning)
+		return nil
+	}
+
+	input := textinput.New()
+	input.Placeholder = "Text to find in command names, descriptions and contents"
+	input.CharLimit = 100
+	input.Width = 60
+
+	search := contentSearchState{input: input, contents: make(map[string][]string, len(m.commands))}
+	for _, cmd := range m.commands {
+		data, err := os.ReadFile(cmd.FilePath)
+		if err != nil {
+			continue // Still found by name and description
+		}
+		search.contents[cmd.FilePath] = strings.Split(string(data), "\n")
+	}
+	m.contentSearch = search
+	m.state = StateContentSearch
+	m.clearStatus()
+	return m.contentSearch.input.Focus()
+}
+
+// runContentSearch finds the commands matching the input, in library order. Matching
+// ignores case; the whole input is looked for as one phrase.
+func (m *Model) runContentSearch() {
+	search := &m.contentSearch
+	search.results = nil
+	search.cursor = 0
+	query := strings.ToLower(strings.TrimSpace(search.input.Value()))
+	if query == "" {
+		return
+	}
+
+	for _, cmd := range m.commands {
+		result := searchResult{command: cmd}
+		for i, line := range search.contents[cmd.FilePath] {
+			if strings.Contains(strings.ToLower(line), query) {
+				result.matches = append(result.matches, searchMatch{line: i + 1, text: strings.TrimSpace(line)})
+			}
+		}
+		if len(result.matches) > 0 ||
+			strings.Contains(strings.ToLower(cmd.QualifiedName()), query) ||
+			strings.Contains(strings.ToLower(cmd.Description), query) {
+			search.results = append(search.results, result)
+		}
+	}
+}
+
+// handleContentSearchStateKeys handles keys in the library search
+func (m *Model) handleContentSearchStateKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	search := &m.contentSearch
+	switch msg.String() {
+	case "ctrl+c":
+		return m, m.Quit()
+	case "esc":
+		m.state = StateLibrary
+		return m, nil
+	case "up", "ctrl+k":
+		if search.cursor > 0 {
+			search.cursor--
+		}
+		return m, nil
+	case "down", "ctrl+j":
+		if search.cursor < len(search.results)-1 {
+			search.cursor++
+		}
+		return m, nil
+	case "enter":
+		if len(search.results) > 0 {
+			m.showInLibrary(search.results[search.cursor].command)
+		}
+		return m, nil
+	}
+
+	before := search.input.Value()
+	var cmd tea.Cmd
+	search.input, cmd = search.input.Update(msg)
+	if search.input.Value() != before {
+		m.runContentSearch()
+	}
+	return m, cmd
+}
+
+// showInLibrary goes back to the library with the cursor on a command
+func (m *Model) showInLibrary(cmd commands.Command) {
+	m.state = StateLibrary
+	for i, item := range m.list.Items() {
+		if ci, ok := item.(commandItem); ok && ci.command.Name == cmd.Name {
+			m.list.Select(i)
+			return
+		}
+	}
+	m.setStatus(fmt.Sprintf("%s is hidden by the current filters or a collapsed group", cmd.DisplayName), StatusWarning)
+}
+
+// searchSnippet shortens a matching line to width, keeping the match in view and highlighted
+func searchSnippet(line, query string, width int) string {
+	at := strings.Index(strings.ToLower(line), query)
+	if at < 0 || len(strings.ToLower(line)) != len(line) {
+		// Case folding changed the byte length, so the offset cannot be mapped back
+		return ansi.Truncate(line, width, "…")
+	}
+
+	before, match, after := line[:at], line[at:at+len(query)], line[at+len(query):]
+	if lead := width / 3; ansi.StringWidth(before) > lead {
+		before = "…" + ansi.TruncateLeft(before, ansi.StringWidth(before)-lead+1, "")
+	}
+	return ansi.Truncate(before+highlightStyle.Render(match)+after, width, "…")
+}
+
+// contentSearchView renders the library search
+func (m *Model) contentSearchView() string {
+	search := m.contentSearch
+	header := fmt.Sprintf("🔍 Search %s Library", m.GetLibraryModeString())
+	width := contentWidth(m.width)
+	query := strings.ToLower(strings.TrimSpace(search.input.Value()))
+
+	var content strings.Builder
+	content.WriteString(search.input.View())
+	content.WriteString("\n\n")
+
+	switch {
+	case query == "":
+		content.WriteString(subtleStyle.Render(fmt.Sprintf("Searching %d commands", len(m.commands))))
+		content.WriteString("\n")
+	case len(search.results) == 0:
+		content.WriteString(subtleStyle.Render("No commands contain \"" + search.input.Value() + "\""))
+		content.WriteString("\n")
+	default:
+		total := 0
+		for _, result := range search.results {
+			total += len(result.matches)
+		}
+		content.WriteString(subtleStyle.Render(fmt.Sprintf("%d command(s), %d matching line(s)", len(search.results), total)))
+		content.WriteString("\n\n")
+	}
+
+	// Render each result as a block, then show the blocks around the cursor that fit
+	blocks := make([][]string, len(search.results))
+	for i, result := range search.results {
+		cursor, name := "  ", result.command.QualifiedName()
+		if i == search.cursor {
+			cursor, name = "▶ ", highlightStyle.Render(name)
+		}
+		summary := fmt.Sprintf("%d match(es)", len(result.matches))
+		if len(result.matches) == 0 {
+			summary = "name or description"
+		}
+		block := []string{cursor + name + "  " + subtleStyle.Render(summary)}
+		for _, match := range result.matches[:min(len(result.matches), maxSearchSnippets)] {
+			number := fmt.Sprintf("    %4d: ", match.line)
+			block = append(block, subtleStyle.Render(number)+searchSnippet(match.text, query, width-len(number)))
+		}
+		if extra := len(result.matches) - maxSearchSnippets; extra > 0 {
+			block = append(block, subtleStyle.Render(fmt.Sprintf("          …and %d more", extra)))
+		}
+		blocks[i] = block
+	}
+
+	rows := max(m.height-14, 5)
+	start, used := search.cursor, 0
+	for len(blocks) > 0 && start > 0 && used+len(blocks[start-1]) <= rows-len(blocks[search.cursor]) {
+		start--
+		used += len(blocks[start])
+	}
+	used = 0
+	for i := start; i < len(blocks) && (used == 0 || used+len(blocks[i]) <= rows); i++ {
+		content.WriteString(strings.Join(blocks[i], "\n"))
+		content.WriteString("\n")
+		used += len(blocks[i])
+	}
+	content.WriteString(m.renderStatusMessage())
+
+	footer := "Type to search • ↑/↓: Choose • Enter: Show in Library • Esc: Back"
+	return centerView(header, content.String(), footer, m.width)
+}
@@ -0,0 +1,166 @@
+package tui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/shel-corp/Claude-command-manager/internal/commands"
+)
+
+// ToggleLibraryMark marks or unmarks the command under the cursor for bulk changes
+func (m *Model) ToggleLibraryMark() {
+	cmd := m.GetSelectedCommand()
+	if cmd == nil {
+		return
+	}
+	if m.librarySelected[cmd.Name] {
+		delete(m.librarySelected, cmd.Name)
+	} else {
+		m.librarySelected[cmd.Name] = true
+	}
+	m.setLibraryItems(m.buildLibraryItems())
+}
+
+// MarkAllLibraryCommands marks every command shown in the library, or clears the marks
+func (m *Model) MarkAllLibraryCommands(markAll bool) {
+	m.librarySelected = make(map[string]bool)
+	if markAll {
+		for _, cmd := range m.commands {
+			m.librarySelected[cmd.Name] = true
+		}
+	}
+	m.setLibraryItems(m.buildLibraryItems())
+}
+
+// markedCommands returns the marked commands shown in the library, in library order.
+// Marked commands hidden by a filter are left out.
+func (m *Model) markedCommands() []commands.Command {
+	var marked []commands.Command
+	for _, cmd := range m.commands {
+		if m.librarySelected[cmd.Name] {
+			marked = append(marked, cmd)
+		}
+	}
+	return marked
+}
+
+// ToggleMarkedCommands enables every marked command, or disables them all if they are
+// already enabled, then saves once
+func (m *Model) ToggleMarkedCommands() tea.Cmd {
+	marked := m.markedCommands()
+	enable := false
+	for _, cmd := range marked {
+		if !cmd.Enabled {
+			enable = true
+			break
+		}
+	}
+
+	var changes []commands.BatchChange
+	for _, cmd := range marked {
+		if cmd.Enabled != enable {
+			changes = append(changes, commands.BatchChange{Name: cmd.Name, Enabled: &enable})
+		}
+	}
+
+	verb, action := "Disable", actionDisabled
+	if enable {
+		verb, action = "Enable", actionEnabled
+	}
+	if _, err := m.getCurrentCommandManager().ApplyBatch(changes); err != nil {
+		return m.finishBulkChange(fmt.Sprintf("%s %d command(s)", verb, len(changes)), err)
+	}
+	for _, cmd := range marked {
+		if cmd.Enabled != enable {
+			m.logAction(action, cmd.DisplayName)
+		}
+	}
+	return m.finishBulkChange(fmt.Sprintf("%sd %d command(s)", verb, len(changes)), nil)
+}
+
+// MoveMarkedCommands moves every marked command to the location after the first one's,
+// so repeated presses cycle the whole selection through user, project and targets
+func (m *Model) MoveMarkedCommands() tea.Cmd {
+	marked := m.markedCommands()
+	manager := m.getCurrentCommandManager()
+	locations := manager.SymlinkLocations()
+	target := locations[0]
+	for i, location := range locations {
+		if location == marked[0].SymlinkLocation {
+			target = locations[(i+1)%len(locations)]
+			break
+		}
+	}
+
+	var changes []commands.BatchChange
+	for _, cmd := range marked {
+		if cmd.SymlinkLocation != target {
+			changes = append(changes, commands.BatchChange{Name: cmd.Name, Location: target})
+		}
+	}
+
+	if _, err := manager.ApplyBatch(changes); err != nil {
+		return m.finishBulkChange(fmt.Sprintf("Move %d command(s) to %s", len(changes), target), err)
+	}
+	for _, cmd := range marked {
+		if cmd.SymlinkLocation != target {
+			m.logAction(actionMoved, cmd.DisplayName)
+		}
+	}
+	return m.finishBulkChange(fmt.Sprintf("Moved %d command(s) to %s", len(changes), target), nil)
+}
+
+// TogglePinned pins the marked commands, or the highlighted one, to the top of the list.
+// If they are all pinned already they are unpinned instead.
+func (m *Model) TogglePinned() tea.Cmd {
+	targets := m.markedCommands()
+	if len(targets) == 0 {
+		cmd := m.GetSelectedCommand()
+		if cmd == nil {
+			return nil
+		}
+		targets = []commands.Command{*cmd}
+	}
+
+	pin := false
+	for _, cmd := range targets {
+		if !cmd.Pinned {
+			pin = true
+			break
+		}
+	}
+
+	manager := m.getCurrentCommandManager()
+	for _, cmd := range targets {
+		manager.SetPinned(cmd, pin)
+	}
+
+	verb := "Unpinned"
+	if pin {
+		verb = "Pinned"
+	}
+	if len(targets) == 1 {
+		return m.finishBulkChange(fmt.Sprintf("%s %s", verb, targets[0].DisplayName), nil)
+	}
+	return m.finishBulkChange(fmt.Sprintf("%s %d command(s)", verb, len(targets)), nil)
+}
+
+// finishBulkChange saves the configuration after a bulk change and reports the outcome.
+// A failed change was rolled back as a whole, so there is nothing to save.
+func (m *Model) finishBulkChange(summary string, err error) tea.Cmd {
+	if err != nil {
+		m.setStatus(fmt.Sprintf("%s failed, nothing was changed: %v", summary, err), StatusError)
+		return nil
+	}
+	if saveErr := m.getCurrentConfigManager().Save(); saveErr != nil {
+		return func() tea.Msg {
+			return ErrorMsg{Error: saveErr}
+		}
+	}
+
+	m.setStatus(summary, StatusSuccess)
+	return func() tea.Msg {
+		return RefreshMsg{}
+	}
+}
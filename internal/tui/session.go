@@ -0,0 +1,69 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Session actions, listed in the order the quit summary shows them
+const (
+	actionCreated  = "Created"
+	actionEnabled  = "Enabled"
+	actionDisabled = "Disabled"
+	actionRenamed  = "Renamed"
+	actionEdited   = "Edited"
+	actionMoved    = "Moved"
+	actionTagged   = "Retagged"
+	actionDeleted  = "Deleted"
+	actionImported = "Imported"
+)
+
+var sessionActionOrder = []string{actionCreated, actionEnabled, actionDisabled, actionRenamed, actionEdited, actionMoved, actionTagged, actionDeleted, actionImported}
+
+// sessionEntry is one change made to a library during this session
+type sessionEntry struct {
+	action string
+	name   string // Command display name, or "old → new" for renames
+}
+
+// logAction records a change for the end-of-session summary
+func (m *Model) logAction(action string, names ...string) {
+	for _, name := range names {
+		m.sessionLog = append(m.sessionLog, sessionEntry{action: action, name: name})
+	}
+}
+
+// sessionSummary lists the session's changes grouped by action, or returns "" if nothing changed
+func (m *Model) sessionSummary() string {
+	if len(m.sessionLog) == 0 {
+		return ""
+	}
+
+	names := make(map[string][]string)
+	seen := make(map[sessionEntry]bool)
+	for _, entry := range m.sessionLog {
+		if !seen[entry] {
+			seen[entry] = true
+			names[entry.action] = append(names[entry.action], entry.name)
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString("This session:\n")
+	for _, action := range sessionActionOrder {
+		if len(names[action]) > 0 {
+			b.WriteString(fmt.Sprintf("  %s %d: %s\n", action, len(names[action]), strings.Join(names[action], ", ")))
+		}
+	}
+	return b.String()
+}
+
+// quitView is the last frame left in the terminal after quitting
+func (m *Model) quitView() string {
+	if tm := GetThemeManager(); tm != nil && tm.GetAppConfig().Library.SessionSummary {
+		if summary := m.sessionSummary(); summary != "" {
+			return summary + "Goodbye!\n"
+		}
+	}
+	return "Goodbye!\n"
+}
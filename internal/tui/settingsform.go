@@ -0,0 +1,268 @@
+package tui
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/shel-corp/Claude-command-manager/internal/commands"
+	"github.com/shel-corp/Claude-command-manager/internal/theme"
+)
+
+// settingsField is one setting on a settings form, stored under its `ccm config` key
+type settingsField struct {
+	key     string
+	label   string
+	choices []string // Values cycled with ←/→; empty for on/off and number settings
+}
+
+// settingsForm edits a group of settings in place. Each change is validated and saved
+// through the theme manager as soon as it is made, like `ccm config set`.
+type settingsForm struct {
+	title   string
+	note    string // Shown under the fields
+	fields  []settingsField
+	cursor  int
+	editing bool // Typing a number into input
+	input   textinput.Model
+	err     string
+}
+
+// openSettingsForm shows a settings form in the given state
+func (m *Model) openSettingsForm(state State, form settingsForm) {
+	form.input = textinput.New()
+	form.input.CharLimit = 10
+	form.input.Width = 12
+	m.settingsForm = form
+	m.state = state
+	m.clearStatus()
+}
+
+// StartCacheSettings opens the form for the repository cache settings
+func (m *Model) StartCacheSettings() {
+	m.openSettingsForm(StateCacheSettings, settingsForm{
+		title: "🗄️  Cache Settings",
+		note:  "Saved to " + theme.DefaultConfigPath() + "; applies the next time ccm starts.",
+		fields: []settingsField{
+			{key: "cache.enabled", label: "Enabled"},
+			{key: "cache.ttl_hours", label: "TTL (hours)"},
+			{key: "cache.max_size_mb", label: "Max size (MB)"},
+			{key: "cache.background_refresh", label: "Background refresh"},
+			{key: "cache.concurrent_workers", label: "Workers"},
+		},
+	})
+}
+
+// StartGeneralSettings opens the form for the library, import, color depth, confirmation,
+// status message and developer preferences
+func (m *Model) StartGeneralSettings() {
+	var locations []string
+	for _, location := range m.getCurrentCommandManager().SymlinkLocations() {
+		locations = append(locations, string(location))
+	}
+	m.openSettingsForm(StateGeneralSettings, settingsForm{
+		title: "⚙️  General Settings",
+		note:  "Saved to " + theme.DefaultConfigPath() + ".",
+		fields: []settingsField{
+			{key: "library.default_symlink_location", label: "Symlink new commands to", choices: locations},
+			{key: "import.default_target", label: "Import into", choices: []string{"user", "project"}},
+			{key: "library.sort", label: "Sort library by", choices: theme.LibrarySortOrders},
+			{key: "library.usage_stats", label: "Usage from Claude logs"},
+			{key: "theme.colors", label: "Color depth", choices: theme.ColorDepths},
+			{key: "confirm.delete", label: "Confirm deletes"},
+			{key: "confirm.overwrite", label: "Confirm overwrites"},
+			{key: "status.info_seconds", label: "Info messages (s)"},
+			{key: "status.success_seconds", label: "Success messages (s)"},
+			{key: "status.warning_seconds", label: "Warnings (s)"},
+			{key: "status.error_seconds", label: "Errors (s)"},
+			{key: "developer.theme_hot_reload", label: "Reload themes (restart)"},
+		},
+	})
+}
+
+// settingsValue returns the current value of a setting, or "" if it is unknown
+func settingsValue(key string) string {
+	value, err := GetThemeManager().GetValue(key)
+	if err != nil {
+		return ""
+	}
+	return value
+}
+
+// setSettingsValue validates and saves a setting, reporting a rejected value on the form
+func (m *Model) setSettingsValue(field settingsField, value string) {
+	if err := GetThemeManager().SetValue(field.key, value); err != nil {
+		m.settingsForm.err = err.Error()
+		return
+	}
+	m.settingsForm.err = ""
+	m.applySetting(field.key, value)
+	m.setStatus(fmt.Sprintf("%s set to %s", field.label, displaySettingsValue(value)), StatusSuccess)
+}
+
+// applySetting puts a saved setting into effect for the running session, for the
+// settings that are otherwise only read at startup
+func (m *Model) applySetting(key, value string) {
+	switch key {
+	case "library.default_symlink_location":
+		for _, manager := range []*commands.Manager{m.commandManager, m.userCommandManager} {
+			if location, err := manager.ParseSymlinkLocation(value); err == nil {
+				manager.SetDefaultSymlinkLocation(location)
+			}
+		}
+	case "library.sort":
+		m.sortMode = parseSortMode(value)
+		m.activeView = ""
+	case "theme.colors":
+		theme.ApplyColorDepth(value)
+		RefreshStyles()
+		m.markdown = markdownCache{} // Re-render previews at the new depth
+	}
+}
+
+// displaySettingsValue shows on/off settings as "on" and "off"
+func displaySettingsValue(value string) string {
+	switch value {
+	case "true":
+		return "on"
+	case "false":
+		return "off"
+	}
+	return value
+}
+
+// stepSettingsField changes the focused setting by one step: toggles it, moves to the
+// previous or next choice, or adds delta to a number
+func (m *Model) stepSettingsField(delta int) {
+	field := m.settingsForm.fields[m.settingsForm.cursor]
+	value := settingsValue(field.key)
+
+	switch {
+	case len(field.choices) > 0:
+		i := slices.Index(field.choices, value)
+		i = (i + delta + len(field.choices)) % len(field.choices)
+		m.setSettingsValue(field, field.choices[i])
+	case value == "true" || value == "false":
+		m.setSettingsValue(field, strconv.FormatBool(value != "true"))
+	default:
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return
+		}
+		m.setSettingsValue(field, strconv.Itoa(n+delta))
+	}
+}
+
+// handleSettingsFormKeys handles keys on a settings form
+func (m *Model) handleSettingsFormKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	form := &m.settingsForm
+	if form.editing {
+		switch msg.String() {
+		case "ctrl+c":
+			return m, m.Quit()
+		case "esc":
+			form.editing = false
+			form.input.Blur()
+		case "enter":
+			form.editing = false
+			form.input.Blur()
+			m.setSettingsValue(form.fields[form.cursor], strings.TrimSpace(form.input.Value()))
+		default:
+			var cmd tea.Cmd
+			form.input, cmd = form.input.Update(msg)
+			return m, cmd
+		}
+		return m, nil
+	}
+
+	switch msg.String() {
+	case "ctrl+c", "q":
+		return m, m.Quit()
+	case "esc":
+		m.state = StateSettings
+		m.initSettingsMenu()
+	case "up", "k":
+		if form.cursor > 0 {
+			form.cursor--
+		}
+		form.err = ""
+	case "down", "j":
+		if form.cursor < len(form.fields)-1 {
+			form.cursor++
+		}
+		form.err = ""
+	case "left", "h":
+		m.stepSettingsField(-1)
+	case "right", "l":
+		m.stepSettingsField(1)
+	case " ", "enter":
+		field := form.fields[form.cursor]
+		value := settingsValue(field.key)
+		if _, err := strconv.Atoi(value); err == nil && len(field.choices) == 0 {
+			// Numbers are typed in
+			form.editing = true
+			form.input.SetValue(value)
+			form.input.CursorEnd()
+			return m, form.input.Focus()
+		}
+		m.stepSettingsField(1)
+	}
+	return m, nil
+}
+
+// settingsFormView renders a settings form
+func (m *Model) settingsFormView() string {
+	form := m.settingsForm
+	labelWidth := 0
+	for _, field := range form.fields {
+		labelWidth = max(labelWidth, len(field.label))
+	}
+
+	var content strings.Builder
+	for i, field := range form.fields {
+		value := displaySettingsValue(settingsValue(field.key))
+		if len(field.choices) > 0 {
+			value = "‹ " + value + " ›"
+		}
+
+		cursor, label := "  ", fmt.Sprintf("%-*s", labelWidth, field.label)
+		if i == form.cursor {
+			cursor = "▶ "
+			label = highlightStyle.Render(label)
+		}
+		if i == form.cursor && form.editing {
+			value = form.input.View()
+		} else {
+			value = highlightStyle.Render(value)
+		}
+		content.WriteString(fmt.Sprintf("%s%s  %s\n", cursor, label, value))
+	}
+
+	content.WriteString("\n")
+	for _, configKey := range theme.ConfigKeys() {
+		if configKey.Key == form.fields[form.cursor].key {
+			content.WriteString(subtleStyle.Render(fmt.Sprintf("%s: %s", configKey.Key, configKey.Description)))
+			content.WriteString("\n")
+		}
+	}
+	if form.err != "" {
+		content.WriteString(dangerStyle.Render("⚠️  " + form.err))
+		content.WriteString("\n")
+	}
+	if form.note != "" {
+		content.WriteString("\n")
+		content.WriteString(subtleStyle.Render(form.note))
+		content.WriteString("\n")
+	}
+	content.WriteString(m.renderStatusMessage())
+
+	footer := "↑/↓: Choose • Space/Enter: Toggle or Edit • ←/→: Change • Esc: Back"
+	if form.editing {
+		footer = "Enter: Save • Esc: Cancel"
+	}
+	return centerView(form.title, content.String(), footer, m.width)
+}
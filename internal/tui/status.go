@@ -0,0 +1,67 @@
+package tui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// statusFadeTime is how long a status message is shown dimmed before it clears
+const statusFadeTime = time.Second
+
+// statusExpiredMsg fades, then clears, the status message set with sequence number seq
+type statusExpiredMsg struct {
+	seq  int
+	fade bool // Dim the message now and clear it after statusFadeTime
+}
+
+// statusDuration returns how long messages of a type stay on screen, or 0 to keep them
+func statusDuration(statusType StatusType) time.Duration {
+	settings := GetThemeManager().GetAppConfig().Status
+	seconds := settings.InfoSeconds
+	switch statusType {
+	case StatusSuccess:
+		seconds = settings.SuccessSeconds
+	case StatusWarning:
+		seconds = settings.WarningSeconds
+	case StatusError:
+		seconds = settings.ErrorSeconds
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// expireStatus starts the timer for a status message set since the last one was started
+func (m *Model) expireStatus() tea.Cmd {
+	if !m.showStatus || m.statusTimerSeq == m.statusSeq || GetThemeManager() == nil {
+		return nil
+	}
+	m.statusTimerSeq = m.statusSeq
+
+	duration := statusDuration(m.statusType)
+	if duration <= 0 {
+		return nil
+	}
+	seq := m.statusSeq
+	fade := duration > statusFadeTime
+	if fade {
+		duration -= statusFadeTime
+	}
+	return tea.Tick(duration, func(time.Time) tea.Msg {
+		return statusExpiredMsg{seq: seq, fade: fade}
+	})
+}
+
+// handleStatusExpired dims or clears the status message unless it has been replaced
+func (m *Model) handleStatusExpired(msg statusExpiredMsg) (tea.Model, tea.Cmd) {
+	if msg.seq != m.statusSeq || !m.showStatus {
+		return m, nil
+	}
+	if msg.fade {
+		m.statusFading = true
+		return m, tea.Tick(statusFadeTime, func(time.Time) tea.Msg {
+			return statusExpiredMsg{seq: msg.seq}
+		})
+	}
+	m.clearStatus()
+	return m, nil
+}
@@ -1,10 +1,8 @@
 package tui
 
 import (
-	"os"
-	"path/filepath"
-
 	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
 	"github.com/shel-corp/Claude-command-manager/internal/theme"
 )
 
@@ -17,11 +15,7 @@ func InitializeThemeManager() {
 		return // Already initialized
 	}
 	
-	// Get config path for theme settings
-	homeDir, _ := os.UserHomeDir()
-	themeConfigPath := filepath.Join(homeDir, ".config", "claude_command_manager", "config.json")
-	
-	themeManager = theme.NewManager(themeConfigPath)
+	themeManager = theme.NewManager(theme.DefaultConfigPath())
 	
 	// Load theme settings
 	if err := themeManager.Load(); err != nil {
@@ -95,6 +89,58 @@ func getBorderColor() lipgloss.AdaptiveColor {
 	return themeManager.GetStyles().BorderCol
 }
 
+func getSurfaceColor() lipgloss.AdaptiveColor {
+	if themeManager == nil {
+		return lipgloss.AdaptiveColor{Light: "#F1F5F9", Dark: "#0F172A"} // Default panel background
+	}
+	return themeManager.GetStyles().SurfaceCol
+}
+
+func getSelectionColor() lipgloss.AdaptiveColor {
+	if themeManager == nil {
+		return lipgloss.AdaptiveColor{Light: "#E0F2FE", Dark: "#1E293B"} // Default selected card
+	}
+	return themeManager.GetStyles().SelectionCol
+}
+
+func getSelectionTextColor() lipgloss.AdaptiveColor {
+	if themeManager == nil {
+		return lipgloss.AdaptiveColor{Light: "#334155", Dark: "#CBD5E1"} // Default selected card text
+	}
+	return themeManager.GetStyles().SelectionTextCol
+}
+
+func getSubtextColor() lipgloss.AdaptiveColor {
+	if themeManager == nil {
+		return lipgloss.AdaptiveColor{Light: "#6B7280", Dark: "#9CA3AF"} // Default secondary text
+	}
+	return themeManager.GetStyles().SubtextCol
+}
+
+func getBorderVariantColor() lipgloss.AdaptiveColor {
+	if themeManager == nil {
+		return lipgloss.AdaptiveColor{Light: "#D1D5DB", Dark: "#374151"} // Default subtle border
+	}
+	return themeManager.GetStyles().BorderVariantCol
+}
+
+// getBoldText reports whether the theme draws body and secondary text in bold
+func getBoldText() bool {
+	if themeManager == nil {
+		return false
+	}
+	return themeManager.GetStyles().BoldText
+}
+
+// getSelectedBorder returns the border of the selected list card: doubled when color is
+// off (NO_COLOR or theme.colors none), as the highlight colors no longer set it apart
+func getSelectedBorder() lipgloss.Border {
+	if lipgloss.ColorProfile() == termenv.Ascii {
+		return lipgloss.DoubleBorder()
+	}
+	return lipgloss.RoundedBorder()
+}
+
 // Dynamic style getters that update when theme changes
 
 // Color accessors (backward compatibility) - now adaptive
@@ -105,6 +151,13 @@ var warningColor = getWarningColor()
 var mutedColor = getMutedColor()
 var backgroundColor = getBackgroundColor()
 var textColor = getTextColor()
+var surfaceColor = getSurfaceColor()
+var selectionColor = getSelectionColor()
+var selectionTextColor = getSelectionTextColor()
+var subtextColor = getSubtextColor()
+var borderVariantColor = getBorderVariantColor()
+var boldText = getBoldText()
+var selectedBorder = getSelectedBorder()
 
 // Dynamic style functions that get fresh styles from theme manager with fallbacks
 func getBaseStyle() lipgloss.Style {
@@ -223,6 +276,13 @@ func RefreshStyles() {
 	mutedColor = getMutedColor()
 	backgroundColor = getBackgroundColor()
 	textColor = getTextColor()
+	surfaceColor = getSurfaceColor()
+	selectionColor = getSelectionColor()
+	selectionTextColor = getSelectionTextColor()
+	subtextColor = getSubtextColor()
+	borderVariantColor = getBorderVariantColor()
+	boldText = getBoldText()
+	selectedBorder = getSelectedBorder()
 
 	// Update style variables
 	baseStyle = getBaseStyle()
@@ -254,6 +314,7 @@ func RefreshStyles() {
 
 	leftMarginFooterStyle = lipgloss.NewStyle().
 		Foreground(mutedColor).
+		Bold(boldText).
 		Italic(true).
 		Align(lipgloss.Left).
 		Padding(1, 0, 0, 0).
@@ -283,9 +344,10 @@ func leftMarginView(header, content, footer string, width int) string {
 		return "    " + header + "\n\n    " + content + "\n    " + footer
 	}
 	
-	styledHeader := leftMarginHeaderStyle.Width(width).Render(header)
-	styledFooter := leftMarginFooterStyle.Width(width).Render(footer)
-	styledContent := leftMarginContainerStyle.Width(width).Render(content)
+	// The margin is outside the width, so leave room for it to keep lines on screen
+	styledHeader := leftMarginHeaderStyle.Width(width - 4).Render(header)
+	styledFooter := leftMarginFooterStyle.Width(width - 4).Render(footerMarker + wrapHints(footer, width-4))
+	styledContent := leftMarginContainerStyle.Width(width - 4).Render(content)
 	
 	return styledHeader + "\n\n" + styledContent + "\n" + styledFooter
 }
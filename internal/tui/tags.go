@@ -0,0 +1,284 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/shel-corp/Claude-command-manager/internal/commands"
+)
+
+// maxTagSuggestions caps how many matching tags are listed under the input
+const maxTagSuggestions = 8
+
+// tagEditorState holds the bulk tag editor: commands are chosen first, then the changes
+type tagEditorState struct {
+	selected map[string]bool // Command names the edit applies to
+	cursor   int
+	editing  bool            // Editing the tag changes rather than choosing commands
+	input    textinput.Model // Changes such as "+review -draft"
+	known    []string        // Every tag in the library, for completion
+}
+
+// StartTagEditor opens the bulk tag editor over the commands shown in the library,
+// with the command under the cursor preselected
+func (m *Model) StartTagEditor() {
+	if len(m.commands) == 0 {
+		m.setStatus("No commands to tag", StatusWarning)
+		return
+	}
+
+	input := textinput.New()
+	input.Placeholder = "+tag to add, -tag to remove (Tab completes)"
+	input.CharLimit = 200
+	input.Width = 60
+
+	m.tagEditor = tagEditorState{selected: make(map[string]bool), input: input}
+	if cmds, err := m.getCurrentCommandManager().ScanCommands(); err == nil {
+		m.tagEditor.known = commands.AllTags(cmds)
+	}
+	if index := m.selectedCommandIndex(); index >= 0 {
+		m.tagEditor.cursor = index
+		m.tagEditor.selected[m.commands[index].Name] = true
+	}
+	m.state = StateTagEditor
+}
+
+// tagEditorCommands returns the commands chosen for the edit, in library order
+func (m *Model) tagEditorCommands() []commands.Command {
+	var cmds []commands.Command
+	for _, cmd := range m.commands {
+		if m.tagEditor.selected[cmd.Name] {
+			cmds = append(cmds, cmd)
+		}
+	}
+	return cmds
+}
+
+// planTagEdit parses the input and returns the resulting changes to the chosen commands
+func (m *Model) planTagEdit() ([]commands.TagChange, error) {
+	add, remove, err := commands.ParseTagEdit(m.tagEditor.input.Value())
+	if err != nil {
+		return nil, err
+	}
+	return commands.PlanTagEdit(m.tagEditorCommands(), add, remove), nil
+}
+
+// ApplyTagEdit records the planned tag changes and returns to the library
+func (m *Model) ApplyTagEdit() tea.Cmd {
+	changes, err := m.planTagEdit()
+	if err != nil {
+		m.setStatus(err.Error(), StatusError)
+		return nil
+	}
+	if len(changes) == 0 {
+		m.setStatus("No tag changes to apply", StatusWarning)
+		return nil
+	}
+
+	applied, err := m.getCurrentCommandManager().ApplyTagChanges(changes)
+	for _, change := range changes[:applied] {
+		m.logAction(actionTagged, change.Command.DisplayName)
+	}
+	if saveErr := m.getCurrentConfigManager().Save(); saveErr != nil && err == nil {
+		err = saveErr
+	}
+	m.state = StateLibrary
+	if err != nil {
+		m.setStatus(fmt.Sprintf("Updated tags on %d command(s), then failed: %v", applied, err), StatusError)
+	} else {
+		m.setStatus(fmt.Sprintf("Updated tags on %d command(s)", applied), StatusSuccess)
+	}
+	return func() tea.Msg {
+		return RefreshMsg{}
+	}
+}
+
+// tagSuggestions returns the known tags matching the word being typed
+func (m *Model) tagSuggestions() (prefix string, matches []string) {
+	value := m.tagEditor.input.Value()
+	if value == "" || strings.HasSuffix(value, " ") || strings.HasSuffix(value, ",") {
+		return "", nil
+	}
+	fields := strings.FieldsFunc(value, func(r rune) bool { return r == ' ' || r == ',' })
+	prefix = strings.TrimLeft(fields[len(fields)-1], "+-")
+	for _, tag := range m.tagEditor.known {
+		if strings.HasPrefix(tag, prefix) && tag != prefix {
+			matches = append(matches, tag)
+		}
+	}
+	return prefix, matches
+}
+
+// completeTag extends the word being typed to the longest prefix shared by the matching tags
+func (m *Model) completeTag() {
+	prefix, matches := m.tagSuggestions()
+	if len(matches) == 0 {
+		return
+	}
+
+	common := matches[0]
+	for _, tag := range matches[1:] {
+		for !strings.HasPrefix(tag, common) {
+			common = common[:len(common)-1]
+		}
+	}
+	if len(matches) == 1 {
+		common += " "
+	}
+	m.tagEditor.input.SetValue(m.tagEditor.input.Value() + strings.TrimPrefix(common, prefix))
+	m.tagEditor.input.CursorEnd()
+}
+
+// handleTagEditorStateKeys handles keys in the bulk tag editor
+func (m *Model) handleTagEditorStateKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if msg.String() == "ctrl+c" {
+		return m, m.Quit()
+	}
+	if m.tagEditor.editing {
+		return m.handleTagEditorInputKeys(msg)
+	}
+
+	switch msg.String() {
+	case "esc", "q":
+		m.state = StateLibrary
+	case "up", "k":
+		if m.tagEditor.cursor > 0 {
+			m.tagEditor.cursor--
+		}
+	case "down", "j":
+		if m.tagEditor.cursor < len(m.commands)-1 {
+			m.tagEditor.cursor++
+		}
+	case " ", "x":
+		name := m.commands[m.tagEditor.cursor].Name
+		m.tagEditor.selected[name] = !m.tagEditor.selected[name]
+	case "a":
+		all := len(m.tagEditorCommands()) == len(m.commands)
+		for _, cmd := range m.commands {
+			m.tagEditor.selected[cmd.Name] = !all
+		}
+	case "enter", "tab":
+		if len(m.tagEditorCommands()) == 0 {
+			m.setStatus("Select at least one command", StatusWarning)
+			return m, nil
+		}
+		m.clearStatus()
+		m.tagEditor.editing = true
+		return m, m.tagEditor.input.Focus()
+	}
+	return m, nil
+}
+
+// handleTagEditorInputKeys handles keys while typing the tag changes
+func (m *Model) handleTagEditorInputKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.tagEditor.editing = false
+		m.tagEditor.input.Blur()
+		return m, nil
+	case "tab":
+		m.completeTag()
+		return m, nil
+	case "enter":
+		return m, m.ApplyTagEdit()
+	}
+
+	var cmd tea.Cmd
+	m.tagEditor.input, cmd = m.tagEditor.input.Update(msg)
+	return m, cmd
+}
+
+// tagEditorView renders the command picker, or the tag input with a preview of the result
+func (m *Model) tagEditorView() string {
+	header := "Edit Tags"
+	if m.tagEditor.editing {
+		return centerView(header, m.renderTagEditorInput(), "Tab: Complete • Enter: Apply • Esc: Back to selection", m.width)
+	}
+
+	var content strings.Builder
+	content.WriteString(subtleStyle.Render(fmt.Sprintf("Choose the commands to edit (%d selected)", len(m.tagEditorCommands()))))
+	content.WriteString("\n\n")
+
+	// Keep the cursor in view on long libraries
+	rows := m.height - 12
+	if rows < 5 {
+		rows = 5
+	}
+	start := 0
+	if m.tagEditor.cursor >= rows {
+		start = m.tagEditor.cursor - rows + 1
+	}
+	end := start + rows
+	if end > len(m.commands) {
+		end = len(m.commands)
+	}
+
+	for i := start; i < end; i++ {
+		cmd := m.commands[i]
+		cursor := "  "
+		name := cmd.DisplayName
+		if i == m.tagEditor.cursor {
+			cursor = "▶ "
+			name = highlightStyle.Render(name)
+		}
+		checkbox := "[ ]"
+		if m.tagEditor.selected[cmd.Name] {
+			checkbox = successStyle.Render("[✓]")
+		}
+		content.WriteString(fmt.Sprintf("%s%s %s  %s\n", cursor, checkbox, name, subtleStyle.Render(formatTagSet(cmd.Tags))))
+	}
+	content.WriteString(m.renderStatusMessage())
+
+	footer := "↑/↓: Move • Space: Select • a: All/None • Enter: Edit Tags • Esc: Cancel"
+	return centerView(header, content.String(), footer, m.width)
+}
+
+// renderTagEditorInput renders the tag input, matching tags and the resulting tag sets
+func (m *Model) renderTagEditorInput() string {
+	var content strings.Builder
+	selected := m.tagEditorCommands()
+	content.WriteString(subtleStyle.Render(fmt.Sprintf("Changes for %d command(s):", len(selected))))
+	content.WriteString("\n")
+	content.WriteString(m.tagEditor.input.View())
+	content.WriteString("\n")
+
+	if _, matches := m.tagSuggestions(); len(matches) > 0 {
+		if len(matches) > maxTagSuggestions {
+			matches = append(matches[:maxTagSuggestions:maxTagSuggestions], "…")
+		}
+		content.WriteString(subtleStyle.Render("  " + strings.Join(matches, "  ")))
+		content.WriteString("\n")
+	}
+	content.WriteString("\n")
+
+	changes, err := m.planTagEdit()
+	switch {
+	case err != nil:
+		content.WriteString(dangerStyle.Render(err.Error()))
+	case len(changes) == 0:
+		content.WriteString(subtleStyle.Render("No changes yet"))
+	default:
+		content.WriteString(highlightStyle.Render("Preview"))
+		content.WriteString("\n")
+		for _, change := range changes {
+			content.WriteString(fmt.Sprintf("  %s: %s → %s\n", change.Command.DisplayName,
+				subtleStyle.Render(formatTagSet(change.Before)), successStyle.Render(formatTagSet(change.After))))
+		}
+		if unchanged := len(selected) - len(changes); unchanged > 0 {
+			content.WriteString(subtleStyle.Render(fmt.Sprintf("  %d command(s) unchanged", unchanged)))
+		}
+	}
+	content.WriteString(m.renderStatusMessage())
+	return content.String()
+}
+
+// formatTagSet renders tags as a YAML flow list
+func formatTagSet(tags []string) string {
+	if len(tags) == 0 {
+		return "(no tags)"
+	}
+	return "[" + strings.Join(tags, ", ") + "]"
+}
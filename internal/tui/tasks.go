@@ -0,0 +1,372 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// taskState tracks a background task through the queue
+type taskState int
+
+const (
+	taskQueued taskState = iota
+	taskRunning
+	taskDone
+	taskFailed
+)
+
+// maxFinishedTasks is how many completed tasks the progress panel keeps
+const maxFinishedTasks = 5
+
+// backgroundTask is a long-running operation run by the session's task scheduler
+type backgroundTask struct {
+	id        int
+	title     string
+	state     taskState
+	err       string
+	started   time.Time
+	finished  time.Time
+	run       func() tea.Msg
+	progress  taskProgress       // Latest report from a task started with runWithProgress
+	updates   chan taskProgress  // Progress reports, closed when the task finishes
+	cancel    context.CancelFunc // Stops a task started with runWithProgress
+	cancelled bool
+}
+
+// taskProgress is what a running task last reported doing
+type taskProgress struct {
+	step  string
+	done  int // Items finished in this step, out of total; total 0 means the step is not counted
+	total int
+}
+
+// String formats the progress as "Fetching command content 3/12"
+func (p taskProgress) String() string {
+	if p.total == 0 {
+		return p.step
+	}
+	return fmt.Sprintf("%s %d/%d", p.step, p.done, p.total)
+}
+
+// taskQueue runs background tasks one at a time, in the order they were queued
+type taskQueue struct {
+	tasks  []*backgroundTask
+	nextID int
+}
+
+// taskDoneMsg carries a finished task's result back to the update loop
+type taskDoneMsg struct {
+	id     int
+	result tea.Msg
+}
+
+// taskTickMsg redraws running task timers while the progress panel is open
+type taskTickMsg struct{}
+
+// taskProgressMsg carries a running task's progress report to the update loop
+type taskProgressMsg struct {
+	id       int
+	progress taskProgress
+	updates  chan taskProgress
+}
+
+// runInBackground queues an operation; its result message is delivered through Update
+// once it finishes, so screens keep responding while it runs
+func (m *Model) runInBackground(title string, run func() tea.Msg) tea.Cmd {
+	m.taskQueue.nextID++
+	m.taskQueue.tasks = append(m.taskQueue.tasks, &backgroundTask{
+		id:    m.taskQueue.nextID,
+		title: title,
+		state: taskQueued,
+		run:   run,
+	})
+	if m.runningTask() != nil {
+		return nil
+	}
+	return m.startNextTask()
+}
+
+// runWithProgress queues an operation like runInBackground, passing it a context that
+// cancelTask cancels and a report function whose updates are shown while it runs.
+// It returns the task's ID for looking up its progress.
+func (m *Model) runWithProgress(title string, run func(ctx context.Context, report func(step string, done, total int)) tea.Msg) (int, tea.Cmd) {
+	ctx, cancel := context.WithCancel(context.Background())
+	updates := make(chan taskProgress, 1)
+	report := func(step string, done, total int) {
+		// Keep only the latest report so a slow redraw never blocks the task
+		select {
+		case <-updates:
+		default:
+		}
+		updates <- taskProgress{step: step, done: done, total: total}
+	}
+	cmd := m.runInBackground(title, func() tea.Msg {
+		defer close(updates)
+		return run(ctx, report)
+	})
+	task := m.taskQueue.tasks[len(m.taskQueue.tasks)-1]
+	task.updates = updates
+	task.cancel = cancel
+	if task.state == taskRunning {
+		cmd = tea.Batch(cmd, waitForTaskProgress(task.id, updates))
+	}
+	return task.id, cmd
+}
+
+// waitForTaskProgress delivers a task's next progress report
+func waitForTaskProgress(id int, updates chan taskProgress) tea.Cmd {
+	return func() tea.Msg {
+		progress, ok := <-updates
+		if !ok {
+			return nil
+		}
+		return taskProgressMsg{id: id, progress: progress, updates: updates}
+	}
+}
+
+// handleTaskProgress records a progress report and waits for the next one
+func (m *Model) handleTaskProgress(msg taskProgressMsg) (tea.Model, tea.Cmd) {
+	if task := m.findTask(msg.id); task != nil {
+		task.progress = msg.progress
+	}
+	return m, waitForTaskProgress(msg.id, msg.updates)
+}
+
+// findTask returns the task with the given ID, if it is still listed
+func (m *Model) findTask(id int) *backgroundTask {
+	for _, task := range m.taskQueue.tasks {
+		if task.id == id {
+			return task
+		}
+	}
+	return nil
+}
+
+// cancelTask stops a queued or running task. A running task still delivers its result,
+// which handlers can check for context.Canceled; a queued one is dropped without running.
+func (m *Model) cancelTask(id int) {
+	task := m.findTask(id)
+	if task == nil || task.cancel == nil || (task.state != taskQueued && task.state != taskRunning) {
+		return
+	}
+	task.cancelled = true
+	task.cancel()
+	if task.state == taskQueued {
+		task.state = taskFailed
+		task.err = "cancelled"
+		task.run = nil
+		task.started = time.Now()
+		task.finished = task.started
+		m.pruneFinishedTasks()
+	}
+}
+
+// startNextTask starts the oldest queued task, if any
+func (m *Model) startNextTask() tea.Cmd {
+	for _, task := range m.taskQueue.tasks {
+		if task.state != taskQueued {
+			continue
+		}
+		task.state = taskRunning
+		task.started = time.Now()
+		id, run := task.id, task.run
+		var progress tea.Cmd
+		if task.updates != nil {
+			progress = waitForTaskProgress(id, task.updates)
+		}
+		return tea.Batch(func() tea.Msg {
+			return taskDoneMsg{id: id, result: run()}
+		}, progress, m.tickTasks())
+	}
+	return nil
+}
+
+// tickTasks keeps the panel's timers moving while it is open and a task runs
+func (m *Model) tickTasks() tea.Cmd {
+	if m.taskTicking || !m.showTaskPanel || m.runningTask() == nil {
+		return nil
+	}
+	m.taskTicking = true
+	return tea.Tick(time.Second, func(time.Time) tea.Msg {
+		return taskTickMsg{}
+	})
+}
+
+// handleTaskTick schedules the next redraw of the progress panel
+func (m *Model) handleTaskTick() (tea.Model, tea.Cmd) {
+	m.taskTicking = false
+	return m, m.tickTasks()
+}
+
+// handleTaskDone records a finished task, delivers its result and starts the next one
+func (m *Model) handleTaskDone(msg taskDoneMsg) (tea.Model, tea.Cmd) {
+	for _, task := range m.taskQueue.tasks {
+		if task.id != msg.id {
+			continue
+		}
+		task.finished = time.Now()
+		task.run = nil
+		task.state = taskDone
+		if task.cancelled {
+			task.state = taskFailed
+			task.err = "cancelled"
+		} else if reason := taskFailure(msg.result); reason != "" {
+			task.state = taskFailed
+			task.err = reason
+		}
+		if task.cancel != nil {
+			task.cancel()
+		}
+	}
+	m.pruneFinishedTasks()
+
+	next := m.startNextTask()
+	if msg.result == nil {
+		return m, next
+	}
+	_, cmd := m.Update(msg.result)
+	return m, tea.Batch(cmd, next)
+}
+
+// taskFailure extracts the error from a task result message, if it reports one
+func taskFailure(msg tea.Msg) string {
+	switch msg := msg.(type) {
+	case ErrorMsg:
+		return msg.Error.Error()
+	case RemoteLoadedMsg:
+		return msg.Error
+	case RemoteImportCompleteMsg:
+		if msg.Error != "" {
+			return msg.Error
+		}
+		if msg.Result != nil && len(msg.Result.Failed) > 0 {
+			return fmt.Sprintf("%d command(s) failed", len(msg.Result.Failed))
+		}
+	case IssueSubmissionCompleteMsg:
+		if !msg.Success {
+			return msg.Error
+		}
+	}
+	return ""
+}
+
+// runningTask returns the task currently running, if any
+func (m *Model) runningTask() *backgroundTask {
+	for _, task := range m.taskQueue.tasks {
+		if task.state == taskRunning {
+			return task
+		}
+	}
+	return nil
+}
+
+// activeTaskCount returns how many tasks are running or waiting to run
+func (m *Model) activeTaskCount() int {
+	count := 0
+	for _, task := range m.taskQueue.tasks {
+		if task.state == taskQueued || task.state == taskRunning {
+			count++
+		}
+	}
+	return count
+}
+
+// pruneFinishedTasks drops the oldest completed tasks beyond maxFinishedTasks
+func (m *Model) pruneFinishedTasks() {
+	finished := 0
+	for _, task := range m.taskQueue.tasks {
+		if task.state == taskDone || task.state == taskFailed {
+			finished++
+		}
+	}
+
+	kept := m.taskQueue.tasks[:0]
+	for _, task := range m.taskQueue.tasks {
+		if finished > maxFinishedTasks && (task.state == taskDone || task.state == taskFailed) {
+			finished--
+			continue
+		}
+		kept = append(kept, task)
+	}
+	m.taskQueue.tasks = kept
+}
+
+// toggleTaskPanel shows or hides the background task panel
+func (m *Model) toggleTaskPanel() tea.Cmd {
+	m.showTaskPanel = !m.showTaskPanel
+	return m.tickTasks()
+}
+
+// acceptsTextInput reports whether key presses in the current state go to a text field
+func (m *Model) acceptsTextInput() bool {
+	switch m.state {
+	case StateRename, StateDuplicate, StateEditMeta, StateSaveView, StateRemoteURL, StateRemoteRepoDetails, StateRemoteCategory, StateReportIssue, StateInlineEdit, StateContentSearch, StateRemoteRename:
+		return true
+	case StateNewCommand:
+		return m.newCommand.step < wizardStepTemplate
+	case StateRemoteBrowse:
+		return m.browseMode == BrowseModeSearch
+	case StateTagEditor:
+		return m.tagEditor.editing
+	case StateGeneralSettings, StateCacheSettings:
+		return m.settingsForm.editing
+	case StateLibrary:
+		return m.commandLineActive
+	}
+	return false
+}
+
+// renderTaskPanel renders the background task panel, or a one-line summary while it is collapsed
+func (m *Model) renderTaskPanel() string {
+	if !m.showTaskPanel {
+		active := m.activeTaskCount()
+		if active == 0 {
+			return ""
+		}
+		return subtleStyle.Render(fmt.Sprintf("⏳ %d background task(s) • %s: show progress", active, m.keys.Tasks.Help().Key))
+	}
+
+	var b strings.Builder
+	b.WriteString(highlightStyle.Render("Background tasks"))
+	b.WriteString("\n")
+	if len(m.taskQueue.tasks) == 0 {
+		b.WriteString(subtleStyle.Render("No tasks this session"))
+	}
+	for i, task := range m.taskQueue.tasks {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString(renderTaskLine(task))
+	}
+	b.WriteString("\n")
+	b.WriteString(subtleStyle.Render(m.keys.Tasks.Help().Key + ": hide"))
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(borderVariantColor).
+		Padding(0, 1).
+		Render(b.String())
+}
+
+func renderTaskLine(task *backgroundTask) string {
+	switch task.state {
+	case taskQueued:
+		return subtleStyle.Render("• " + task.title + " (queued)")
+	case taskRunning:
+		elapsed := time.Since(task.started).Round(time.Second)
+		line := warningStyle.Render("⏳ "+task.title) + subtleStyle.Render(fmt.Sprintf(" (%s)", elapsed))
+		if task.progress.step != "" {
+			line += subtleStyle.Render(" • " + task.progress.String())
+		}
+		return line
+	case taskFailed:
+		return dangerStyle.Render("✗ "+task.title) + subtleStyle.Render(": "+task.err)
+	}
+	elapsed := task.finished.Sub(task.started).Round(100 * time.Millisecond)
+	return successStyle.Render("✓ "+task.title) + subtleStyle.Render(fmt.Sprintf(" (%s)", elapsed))
+}
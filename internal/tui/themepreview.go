@@ -0,0 +1,84 @@
+package tui
+
+import (
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/shel-corp/Claude-command-manager/internal/theme"
+)
+
+// themeMockMinWidth is the narrowest terminal that shows the theme mock beside the theme
+// list; narrower ones show a bar of the theme's colors under it
+const themeMockMinWidth = 100
+
+// themePickerListView renders the theme list, beside a mock of the library drawn in the
+// highlighted theme when there is room for both
+func (m *Model) themePickerListView() string {
+	themes := GetThemeManager().GetAvailableThemes()
+	index := m.list.Index()
+	if index < 0 || index >= len(themes) {
+		return m.listView()
+	}
+	highlighted := themes[index]
+
+	if m.width < themeMockMinWidth {
+		return m.listView() + "\n" + "Preview: " + highlighted.GeneratePreview().ColorBar
+	}
+
+	available := m.width - 12 // Container margin and padding
+	listWidth := max(available*2/5, 36)
+	list := m.list
+	list.SetWidth(listWidth)
+	listView := lipgloss.NewStyle().Width(listWidth).Render(listMarker + list.View())
+	return lipgloss.JoinHorizontal(lipgloss.Top, listView, "  ", themeMock(highlighted, available-listWidth-2))
+}
+
+// themeMock renders a miniature library screen in t's colors: the header, a card, the
+// selected card, status messages and the footer, so contrast can be judged before applying it
+func themeMock(t theme.Theme, width int) string {
+	t = t.WithDefaults()
+	inner := width - 4 // Border and padding
+	fit := func(style lipgloss.Style, text string, width int) string {
+		return style.Render(ansi.Truncate(text, width, "…"))
+	}
+	line := func(style lipgloss.Style, text string) string { return fit(style, text, inner) }
+	cardLine := func(style lipgloss.Style, text string) string { return fit(style, text, inner-4) }
+
+	card := lipgloss.NewStyle().
+		Width(inner-2).
+		Align(lipgloss.Center).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(t.BorderVariant).
+		Padding(0, 1)
+	selected := card.
+		BorderForeground(t.Primary).
+		Background(t.Selection)
+
+	plainCard := card.Render(
+		cardLine(lipgloss.NewStyle().Foreground(t.Text).Bold(t.Bold), "[✓] 📁 commit") + "\n" +
+			cardLine(lipgloss.NewStyle().Foreground(t.Subtext).Bold(t.Bold), "Create a conventional commit"))
+	selectedCard := selected.Render(
+		cardLine(lipgloss.NewStyle().Foreground(t.Primary).Bold(true), "[ ] 👤 review") + "\n" +
+			cardLine(lipgloss.NewStyle().Foreground(t.SelectionText).Bold(t.Bold).Italic(true), "Review the current diff"))
+
+	content := lipgloss.JoinVertical(lipgloss.Left,
+		line(lipgloss.NewStyle().Foreground(t.Primary).Bold(true), "📁 Command Library — 2 commands — 1 enabled"),
+		"",
+		plainCard,
+		selectedCard,
+		"",
+		line(lipgloss.NewStyle().Foreground(t.Success).Bold(true), "● Enabled command: commit"),
+		line(lipgloss.NewStyle().Foreground(t.Warning).Bold(true), "⚠️  1 command out of sync"),
+		line(lipgloss.NewStyle().Foreground(t.Danger).Bold(true), "✗ Failed to import: review"),
+		"",
+		line(lipgloss.NewStyle().Foreground(t.Muted).Bold(t.Bold).Italic(true), "↑/↓: Navigate • Space: Toggle • /: Search"),
+	)
+
+	title := lipgloss.NewStyle().Foreground(t.Muted).Render("Preview: " + t.Name)
+	box := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(t.Border).
+		Padding(0, 1).
+		Width(width - 2)
+	return title + "\n" + box.Render(content)
+}
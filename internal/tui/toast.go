@@ -0,0 +1,116 @@
+package tui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// maxToasts is how many notifications are stacked on screen at once
+const maxToasts = 3
+
+// toast is a notification about something that finished in the background. Unlike the
+// status line, toasts stack, so rapid events do not overwrite each other.
+type toast struct {
+	id         int
+	message    string
+	statusType StatusType
+	scheduled  bool // Its expiry timer has been started
+	fading     bool
+}
+
+// toastExpiredMsg fades, then removes, the toast with the given ID
+type toastExpiredMsg struct {
+	id   int
+	fade bool // Dim the toast now and remove it after statusFadeTime
+}
+
+// notify shows a toast, pushing out the oldest one if the stack is full. Toasts expire
+// after the same per-type durations as status messages.
+func (m *Model) notify(message string, statusType StatusType) {
+	m.nextToastID++
+	m.toasts = append(m.toasts, toast{id: m.nextToastID, message: message, statusType: statusType})
+	if len(m.toasts) > maxToasts {
+		m.toasts = m.toasts[len(m.toasts)-maxToasts:]
+	}
+}
+
+// expireToasts starts the timers for toasts added since the last call
+func (m *Model) expireToasts() tea.Cmd {
+	if GetThemeManager() == nil {
+		return nil
+	}
+
+	var cmds []tea.Cmd
+	for i := range m.toasts {
+		t := &m.toasts[i]
+		if t.scheduled {
+			continue
+		}
+		t.scheduled = true
+
+		duration := statusDuration(t.statusType)
+		if duration <= 0 {
+			continue // Stays until newer toasts push it out
+		}
+		id := t.id
+		fade := duration > statusFadeTime
+		if fade {
+			duration -= statusFadeTime
+		}
+		cmds = append(cmds, tea.Tick(duration, func(time.Time) tea.Msg {
+			return toastExpiredMsg{id: id, fade: fade}
+		}))
+	}
+	return tea.Batch(cmds...)
+}
+
+// handleToastExpired dims or removes a toast, if it is still shown
+func (m *Model) handleToastExpired(msg toastExpiredMsg) (tea.Model, tea.Cmd) {
+	for i := range m.toasts {
+		if m.toasts[i].id != msg.id {
+			continue
+		}
+		if msg.fade {
+			m.toasts[i].fading = true
+			return m, tea.Tick(statusFadeTime, func(time.Time) tea.Msg {
+				return toastExpiredMsg{id: msg.id}
+			})
+		}
+		m.toasts = append(m.toasts[:i], m.toasts[i+1:]...)
+		break
+	}
+	return m, nil
+}
+
+// renderToasts stacks the current toasts, newest at the bottom, or returns "" if there are none
+func (m *Model) renderToasts() string {
+	if len(m.toasts) == 0 {
+		return ""
+	}
+
+	width := min(m.width/3, 50)
+	boxes := make([]string, len(m.toasts))
+	for i, t := range m.toasts {
+		color, style := primaryColor, highlightStyle
+		switch t.statusType {
+		case StatusSuccess:
+			color, style = successColor, successStyle
+		case StatusWarning:
+			color, style = warningColor, warningStyle
+		case StatusError:
+			color, style = dangerColor, dangerStyle
+		}
+		if t.fading {
+			color, style = borderVariantColor, subtleStyle
+		}
+		boxes[i] = lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(color).
+			Padding(0, 1).
+			Width(width).
+			Render(style.Render(t.message))
+	}
+	return lipgloss.JoinVertical(lipgloss.Right, boxes...)
+}
@@ -1,15 +1,22 @@
 package tui
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
-	"path/filepath"
 	"strings"
+	"time"
 	
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 	
-	"github.com/shel-corp/Claude-command-manager/internal/registry"
+	"github.com/shel-corp/Claude-command-manager/internal/project"
 	"github.com/shel-corp/Claude-command-manager/internal/remote"
+	"github.com/shel-corp/Claude-command-manager/internal/theme"
+	"github.com/shel-corp/Claude-command-manager/internal/version"
 )
 
 // Message types for Bubble Tea
@@ -22,28 +29,58 @@ type (
 		Error error
 	}
 	
+	// EditorFinishedMsg signals that the external editor has exited
+	EditorFinishedMsg struct {
+		Name     string
+		Path     string
+		Original string // File content before editing
+		Error    error
+	}
+	
+	// ProjectReconcileMsg carries a reconciliation with the pinned project configuration
+	// whose missing commands were imported; the rest is applied in the update loop
+	ProjectReconcileMsg struct {
+		Plan project.ReconcilePlan
+	}
+	
 	// Remote import message types
 	
 	// RemoteLoadingMsg signals to start loading remote repository data
 	RemoteLoadingMsg struct{}
 	
+	// RepoDetailsMsg carries GitHub metadata for a custom repository being added
+	RepoDetailsMsg struct {
+		URL     string
+		Details *remote.RepositoryDetails
+	}
+	
 	// RemoteLoadedMsg contains loaded remote repository data
 	RemoteLoadedMsg struct {
-		Commands []remote.RemoteCommand
-		Error    string
+		Repo      *remote.RemoteRepository // The repository the load was started for
+		Commands  []remote.RemoteCommand
+		Error     string
+		Cancelled bool // Stopped with Esc; the user already moved on
 	}
 	
 	// RemoteImportMsg signals to start importing selected commands
 	RemoteImportMsg struct {
-		Commands []remote.RemoteCommand
+		Commands    []remote.RemoteCommand
+		Resolutions map[string]remote.ConflictResolution // Chosen on the conflict screen, by command name
 	}
 	
 	// RemoteImportCompleteMsg contains import results
 	RemoteImportCompleteMsg struct {
-		Result *remote.ImportResult
-		Error  string
+		Repo       *remote.RemoteRepository
+		Result     *remote.ImportResult
+		Project    bool // Imported into the project library rather than the user library
+		Cancelled  bool // Stopped early; Result lists what was imported before that
+		Error      string
+		QueueError string // Why the failures could not be queued for `ccm imports retry`
 	}
 	
+	// themeWatchMsg is sent periodically while theme hot-reload is enabled
+	themeWatchMsg struct{}
+
 	// IssueSubmissionCompleteMsg contains issue submission results
 	IssueSubmissionCompleteMsg struct {
 		Success  bool
@@ -54,11 +91,63 @@ type (
 
 // Init initializes the application
 func (m Model) Init() tea.Cmd {
-	return nil
+	// Usage stats are read right away, then on a timer
+	checkUsage := func() tea.Msg { return usageTickMsg{} }
+	if m.themeHotReload {
+		return tea.Batch(watchThemes(), waitForLibraryChange(m.libraryWatcher), checkUsage)
+	}
+	return tea.Batch(waitForLibraryChange(m.libraryWatcher), checkUsage)
+}
+
+// themeWatchInterval is how often custom theme files are checked for changes
+const themeWatchInterval = time.Second
+
+// watchThemes schedules the next check of the custom theme files
+func watchThemes() tea.Cmd {
+	return tea.Tick(themeWatchInterval, func(time.Time) tea.Msg {
+		return themeWatchMsg{}
+	})
+}
+
+// handleThemeWatch re-applies the active theme when a custom theme file changed on disk
+func (m *Model) handleThemeWatch() (tea.Model, tea.Cmd) {
+	signature := theme.UserThemesSignature()
+	if signature == m.themeSignature {
+		return m, watchThemes()
+	}
+	m.themeSignature = signature
+
+	theme.ReloadUserThemes()
+	for _, problem := range theme.UserThemeProblems() {
+		m.notify(fmt.Sprintf("Skipped theme %s: %v", problem.File, problem.Err), StatusError)
+	}
+	if err := themeManager.ReapplyTheme(); err != nil {
+		m.notify(fmt.Sprintf("Theme reload failed: %v", err), StatusError)
+		return m, watchThemes()
+	}
+	RefreshStyles()
+	if m.state == StateThemeSettings {
+		m.initThemePickerMenu()
+	}
+	m.notify("Reloaded theme: "+themeManager.GetCurrentTheme().Name, StatusInfo)
+	return m, watchThemes()
 }
 
-// Update handles messages and updates the model
+// Update handles messages and updates the model, then starts the timers that clear
+// any status message or toasts the message set
 func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	state := m.state
+	model, cmd := m.update(msg)
+	if m.state != state {
+		// Screens reserve different room around the list, so its pages change with them
+		m.applyLayout()
+	}
+	m.syncDetail(false)
+	m.cacheMarkdown()
+	return model, tea.Batch(cmd, m.checkLibrary(), m.expireStatus(), m.expireToasts())
+}
+
+func (m *Model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 	var cmds []tea.Cmd
 
@@ -66,15 +155,21 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
-		// Calculate available space dynamically based on current state
-		availableHeight := m.calculateAvailableHeight()
-		if availableHeight < 3 {
-			availableHeight = 3 // Minimum height for list
+		m.applyLayout()
+		switch m.state {
+		case StateInlineEdit:
+			m.resizeInlineEditor()
+		case StateReportIssue:
+			m.resizeIssueBody()
 		}
-		m.list.SetWidth(msg.Width)
-		m.list.SetHeight(availableHeight)
 		return m, nil
 
+	case statusExpiredMsg:
+		return m.handleStatusExpired(msg)
+
+	case toastExpiredMsg:
+		return m.handleToastExpired(msg)
+
 	case RefreshMsg:
 		if err := m.RefreshCommands(); err != nil {
 			return m, func() tea.Msg {
@@ -92,6 +187,27 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 
+	case EditorFinishedMsg:
+		if msg.Error != nil {
+			m.setStatus(fmt.Sprintf("Editor failed: %v", msg.Error), StatusError)
+			return m, nil
+		}
+		if content, err := os.ReadFile(msg.Path); err == nil && string(content) == msg.Original {
+			m.setStatus(fmt.Sprintf("No changes to %s", msg.Name), StatusInfo)
+			return m, nil
+		}
+		if err := m.RefreshCommands(); err != nil {
+			return m, func() tea.Msg {
+				return ErrorMsg{Error: err}
+			}
+		}
+		m.logAction(actionEdited, msg.Name)
+		m.setStatus(fmt.Sprintf("Edited command: %s", msg.Name), StatusSuccess)
+		return m, nil
+
+	case ProjectReconcileMsg:
+		return m.handleProjectReconcile(msg)
+
 	case RemoteLoadingMsg:
 		return m.handleRemoteLoading()
 
@@ -104,11 +220,60 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case RemoteImportCompleteMsg:
 		return m.handleRemoteImportComplete(msg)
 
+	case RepoDetailsMsg:
+		m.handleRepoDetails(msg)
+		return m, nil
+
 	case IssueSubmissionCompleteMsg:
 		return m.handleIssueSubmissionComplete(msg)
 
+	case similarIssuesMsg:
+		m.handleSimilarIssues(msg)
+		return m, nil
+
+	case themeWatchMsg:
+		return m.handleThemeWatch()
+
+	case libraryChangedMsg:
+		return m.handleLibraryChanged()
+
+	case libraryScannedMsg:
+		return m.handleLibraryScanned(msg)
+
+	case usageTickMsg:
+		return m.handleUsageTick()
+
+	case usageLoadedMsg:
+		return m.handleUsageLoaded(msg)
+
+	case taskDoneMsg:
+		return m.handleTaskDone(msg)
+
+	case taskTickMsg:
+		return m.handleTaskTick()
+
+	case taskProgressMsg:
+		return m.handleTaskProgress(msg)
+
+	case spinner.TickMsg:
+		// The spinner stops once nothing is loading; the next load starts it again
+		if m.state != StateRemoteLoading {
+			return m, nil
+		}
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd
+
+	case ghAuthTickMsg:
+		return m.handleGHAuthTick(msg)
+
+	case ghAuthStatusMsg:
+		return m.handleGHAuthStatus(msg)
+
 	case tea.KeyMsg:
 		return m.handleKeyMsg(msg)
+
+	case tea.MouseMsg:
+		return m.handleMouseMsg(msg)
 	}
 
 	// Handle updates based on current state
@@ -121,10 +286,26 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.list, cmd = m.list.Update(msg)
 		cmds = append(cmds, cmd)
 		
-	case StateRename:
+	case StateRename, StateDuplicate:
 		m.textInput, cmd = m.textInput.Update(msg)
 		cmds = append(cmds, cmd)
 		
+	case StateTagEditor:
+		if m.tagEditor.editing {
+			m.tagEditor.input, cmd = m.tagEditor.input.Update(msg)
+			cmds = append(cmds, cmd)
+		}
+		
+	case StateInlineEdit:
+		m.inlineEditor.input, cmd = m.inlineEditor.input.Update(msg)
+		cmds = append(cmds, cmd)
+		
+	case StateNewCommand:
+		if step := m.newCommand.step; step < wizardStepTemplate {
+			m.newCommand.inputs[step], cmd = m.newCommand.inputs[step].Update(msg)
+			cmds = append(cmds, cmd)
+		}
+		
 	case StateRemoteBrowse:
 		// Handle both list and search input based on browse mode
 		if m.browseMode == BrowseModeSearch {
@@ -144,12 +325,16 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case StateRemoteRepoDetails:
 		m.textInput, cmd = m.textInput.Update(msg)
 		cmds = append(cmds, cmd)
+		m.repoTagsInput, cmd = m.repoTagsInput.Update(msg)
+		cmds = append(cmds, cmd)
 		
 	case StateRemoteCategory:
 		if m.isNewCategory && m.selectedCategoryKey == "new" {
-			// Handle category input for new category creation
+			// Handle category inputs for new category creation
 			m.categoryInput, cmd = m.categoryInput.Update(msg)
 			cmds = append(cmds, cmd)
+			m.categoryDescInput, cmd = m.categoryDescInput.Update(msg)
+			cmds = append(cmds, cmd)
 		} else {
 			// Handle list navigation for category selection
 			m.list, cmd = m.list.Update(msg)
@@ -160,8 +345,8 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.list, cmd = m.list.Update(msg)
 		cmds = append(cmds, cmd)
 		
-	case StateRemotePreview:
-		// No input handling in preview mode (handled by key handlers)
+	case StateRemotePreview, StateRemoteAnalysis, StateImportConflicts:
+		// No input handling in preview modes (handled by key handlers)
 		
 	case StateRemoteLoading, StateRemoteImport:
 		// No input handling during loading/import states
@@ -171,10 +356,10 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		
 	case StateReportIssue:
 		// Handle input updates for issue form fields
-		if m.issueCurrentField == 0 {
+		if m.issueCurrentField == issueFieldTitle {
 			m.issueTitleInput, cmd = m.issueTitleInput.Update(msg)
 			cmds = append(cmds, cmd)
-		} else {
+		} else if m.issueCurrentField == issueFieldBody {
 			m.issueBodyInput, cmd = m.issueBodyInput.Update(msg)
 			cmds = append(cmds, cmd)
 		}
@@ -193,6 +378,20 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 // handleKeyMsg handles keyboard input based on current state
 func (m *Model) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.palette.open {
+		return m.handlePaletteKeys(msg)
+	}
+	if m.help.open {
+		return m.handleHelpKeys(msg)
+	}
+	if msg.String() == "ctrl+p" && m.paletteAvailable() {
+		return m, m.OpenPalette()
+	}
+	
+	if key.Matches(msg, m.keys.Tasks) && !m.acceptsTextInput() && m.pendingKey == "" {
+		return m, m.toggleTaskPanel()
+	}
+	
 	switch m.state {
 	case StateMainMenu:
 		return m.handleMainMenuStateKeys(msg)
@@ -200,8 +399,30 @@ func (m *Model) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m.handleLibraryStateKeys(msg)
 	case StateRename:
 		return m.handleRenameStateKeys(msg)
-	case StateHelp:
-		return m.handleHelpStateKeys(msg)
+	case StateDuplicate:
+		return m.handleDuplicateStateKeys(msg)
+	case StateEditMeta:
+		return m.handleEditMetaStateKeys(msg)
+	case StateConfirmDelete:
+		return m.handleConfirmDeleteStateKeys(msg)
+	case StateViews:
+		return m.handleViewsStateKeys(msg)
+	case StateReconcile:
+		return m.handleReconcileStateKeys(msg)
+	case StateTagEditor:
+		return m.handleTagEditorStateKeys(msg)
+	case StateLibraryPreview:
+		return m.handleLibraryPreviewStateKeys(msg)
+	case StateCommandInfo:
+		return m.handleCommandInfoStateKeys(msg)
+	case StateContentSearch:
+		return m.handleContentSearchStateKeys(msg)
+	case StateInlineEdit:
+		return m.handleInlineEditStateKeys(msg)
+	case StateNewCommand:
+		return m.handleNewCommandStateKeys(msg)
+	case StateSaveView:
+		return m.handleSaveViewStateKeys(msg)
 	case StateRemoteBrowse:
 		return m.handleRemoteBrowseStateKeys(msg)
 	case StateRemoteURL:
@@ -214,38 +435,88 @@ func (m *Model) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m.handleRemoteSelectStateKeys(msg)
 	case StateRemotePreview:
 		return m.handleRemotePreviewStateKeys(msg)
+	case StateRemoteAnalysis:
+		return m.handleRemoteAnalysisStateKeys(msg)
+	case StateImportConflicts:
+		return m.handleImportConflictStateKeys(msg)
+	case StateRemoteRename:
+		return m.handleRemoteRenameStateKeys(msg)
 	case StateRemoteResults:
 		return m.handleRemoteResultsStateKeys(msg)
 	case StateReportIssue:
 		return m.handleReportIssueStateKeys(msg)
+	case StateReportIssuePreview:
+		return m.handleIssuePreviewStateKeys(msg)
 	case StateSettings:
 		return m.handleSettingsStateKeys(msg)
 	case StateThemeSettings:
 		return m.handleThemeSettingsStateKeys(msg)
+	case StateCacheSettings, StateGeneralSettings:
+		return m.handleSettingsFormKeys(msg)
+	case StateRemoteLoading, StateRemoteImport:
+		return m.handleRemoteWaitingStateKeys(msg)
+	case StateGitHubAuth:
+		return m.handleGitHubAuthStateKeys(msg)
 	}
 	
 	return m, nil
 }
 
+// handleRemoteWaitingStateKeys lets the user leave a loading or import screen;
+// the operation keeps running as a background task
+func (m *Model) handleRemoteWaitingStateKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m, m.Quit()
+	case "esc":
+		// Stop the operation and go back to where it was started from
+		if m.state == StateRemoteImport {
+			m.cancelTask(m.remoteImportTask)
+			m.state = StateRemoteSelect
+			m.setStatus("Import cancelled", StatusWarning)
+			return m, nil
+		}
+		m.cancelTask(m.remoteLoadTask)
+		name := m.remoteRepo.FullName()
+		cmd := m.StartRemoteImport()
+		m.setStatus("Cancelled loading "+name, StatusWarning)
+		return m, cmd
+	case "enter":
+		m.setStatus("Still running in the background • "+m.keys.Tasks.Help().Key+": show progress", StatusInfo)
+		if m.state == StateRemoteImport {
+			m.state = StateRemoteSelect
+			return m, nil
+		}
+		return m, m.StartRemoteImport()
+	}
+	return m, nil
+}
+
 // handleMainMenuStateKeys handles keys in the main menu state
 func (m *Model) handleMainMenuStateKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "ctrl+c", "q":
+	switch {
+	case msg.String() == "ctrl+c", key.Matches(msg, m.keys.Quit):
 		return m, m.Quit()
 		
-	case "enter":
+	case msg.String() == "enter":
 		return m.executeSelectedMenuItem()
 		
-	case "1":
+	case msg.String() == "1":
 		m.state = StateLibrary
 		return m, nil
 		
-	case "2", "i":
-		m.StartRemoteImport()
+	case msg.String() == "2", key.Matches(msg, m.keys.Import):
+		return m, m.StartRemoteImport()
+		
+	case key.Matches(msg, m.keys.Fix):
+		return m, m.FixIntegrity()
+		
+	case key.Matches(msg, m.keys.Reconcile):
+		m.StartReconcile()
 		return m, nil
 		
-	case "h", "?":
-		m.state = StateHelp
+	case key.Matches(msg, m.keys.Help):
+		m.openHelp()
 		return m, nil
 	}
 	
@@ -266,12 +537,12 @@ func (m *Model) executeSelectedMenuItem() (tea.Model, tea.Cmd) {
 	case "library":
 		// Switch to library view and refresh command list
 		m.state = StateLibrary
+		m.applyDefaultView()
 		return m, func() tea.Msg {
 			return RefreshMsg{}
 		}
 	case "import":
-		m.StartRemoteImport()
-		return m, nil
+		return m, m.StartRemoteImport()
 	case "settings":
 		m.StartSettings()
 		return m, nil
@@ -285,42 +556,180 @@ func (m *Model) executeSelectedMenuItem() (tea.Model, tea.Cmd) {
 
 // handleLibraryStateKeys handles keys in the library state
 func (m *Model) handleLibraryStateKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.commandLineActive {
+		return m.handleCommandLineKeys(msg)
+	}
+	
 	switch msg.String() {
-	case "ctrl+c", "q":
+	case "ctrl+c":
 		return m, m.Quit()
 		
 	case "esc":
+		m.pendingKey = ""
 		m.clearStatus()
+		if len(m.librarySelected) > 0 {
+			m.MarkAllLibraryCommands(false)
+			return m, nil
+		}
 		m.state = StateMainMenu
 		m.initMainMenu()
 		return m, nil
+	}
+	
+	// Hold the first key of a sequence such as "g g" until the next one arrives
+	pressed := msg.String()
+	if m.pendingKey != "" {
+		pressed, m.pendingKey = m.pendingKey+" "+pressed, ""
+	} else if m.keys.action(pressed) == "" && m.keys.startsSequence(pressed) {
+		m.pendingKey = pressed
+		return m, nil
+	}
+	if action := m.keys.action(pressed); action != "" {
+		return m, m.runLibraryAction(action)
+	}
+	if pressed != msg.String() {
+		return m, nil // Unbound sequence
+	}
+	
+	// Let the list handle other keys (navigation)
+	var cmd tea.Cmd
+	m.list, cmd = m.list.Update(msg)
+	return m, cmd
+}
+
+// runLibraryAction performs a library action by its keybindings name
+func (m *Model) runLibraryAction(action string) tea.Cmd {
+	switch action {
+	case "quit":
+		return m.Quit()
+		
+	case "up":
+		m.list.CursorUp()
+		
+	case "down":
+		m.list.CursorDown()
+		
+	case "page_up":
+		m.list.PrevPage()
 		
-	case "enter", "t":
-		return m, m.ToggleSelectedCommand()
+	case "page_down":
+		m.list.NextPage()
 		
-	case "r":
+	case "top":
+		m.list.Select(0)
+		
+	case "bottom":
+		m.list.Select(len(m.list.Items()) - 1)
+		
+	case "toggle":
+		if m.ToggleSelectedGroup() {
+			return nil
+		}
+		if len(m.markedCommands()) > 0 {
+			return m.ToggleMarkedCommands()
+		}
+		return m.ToggleSelectedCommand()
+		
+	case "select":
+		m.ToggleLibraryMark()
+		
+	case "select_all":
+		m.MarkAllLibraryCommands(true)
+		
+	case "select_none":
+		m.MarkAllLibraryCommands(false)
+		
+	case "group":
+		return m.CycleGroupMode()
+		
+	case "collapse_all":
+		m.ToggleAllGroups()
+		
+	case "rename":
 		m.StartRename()
-		return m, nil
 		
-	case "l":
-		return m, m.ToggleSelectedCommandLocation()
+	case "duplicate":
+		m.StartDuplicate()
 		
-	case "s":
-		return m, m.SwitchLibraryMode()
+	case "meta":
+		return m.StartMetaEditor()
 		
-	case "i":
-		m.StartRemoteImport()
-		return m, nil
+	case "delete":
+		m.StartDelete()
+		if m.state == StateConfirmDelete && !GetThemeManager().GetAppConfig().Confirm.Delete {
+			return m.ConfirmDelete()
+		}
 		
-	case "h", "?":
-		m.state = StateHelp
-		return m, nil
+	case "edit":
+		return m.EditSelectedCommand()
+		
+	case "location":
+		if len(m.markedCommands()) > 0 {
+			return m.MoveMarkedCommands()
+		}
+		return m.ToggleSelectedCommandLocation()
+		
+	case "pin":
+		return m.TogglePinned()
+		
+	case "switch_library":
+		return m.SwitchLibraryMode()
+		
+	case "model_filter":
+		return m.CycleModelFilter()
+		
+	case "status_filter":
+		return m.CycleStatusFilter()
+		
+	case "tag_filter":
+		return m.CycleTagFilter()
+		
+	case "sort":
+		return m.CycleSortMode()
+		
+	case "views":
+		m.StartViews()
+		
+	case "tags":
+		m.StartTagEditor()
+		
+	case "search":
+		return m.StartContentSearch()
+		
+	case "preview":
+		m.StartLibraryPreview()
+		
+	case "info":
+		m.StartCommandInfo()
+		
+	case "copy", "copy_path":
+		m.copyCommand(m.GetSelectedCommand(), action == "copy_path")
+		
+	case "edit_inline":
+		return m.StartInlineEdit()
+		
+	case "new":
+		return m.StartNewCommand()
+		
+	case "fix":
+		return m.FixIntegrity()
+		
+	case "reconcile":
+		m.StartReconcile()
+		
+	case "import":
+		return m.StartRemoteImport()
+		
+	case "tasks":
+		return m.toggleTaskPanel()
+		
+	case "command":
+		return m.StartCommandLine()
+		
+	case "help":
+		m.openHelp()
 	}
-	
-	// Let the list handle other keys (navigation)
-	var cmd tea.Cmd
-	m.list, cmd = m.list.Update(msg)
-	return m, cmd
+	return nil
 }
 
 // handleRenameStateKeys handles keys in the rename state
@@ -350,29 +759,152 @@ func (m *Model) handleRenameStateKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
-// handleHelpStateKeys handles keys in the help state
-func (m *Model) handleHelpStateKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+// handleConfirmDeleteStateKeys handles keys in the delete confirmation dialog
+func (m *Model) handleConfirmDeleteStateKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
-	case "esc", "h", "?", "q", "enter":
-		m.state = StateMainMenu
-		m.initMainMenu()
+	case "y", "Y":
+		return m, m.ConfirmDelete()
+		
+	case "n", "N", "esc", "q":
+		m.state = StateLibrary
+		return m, nil
+		
+	case "ctrl+c":
+		return m, m.Quit()
+	}
+	
+	return m, nil
+}
+
+// handleViewsStateKeys handles keys in the saved views quick menu
+func (m *Model) handleViewsStateKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	views := m.getSavedViews()
+	
+	switch msg.String() {
+	case "ctrl+c":
+		return m, m.Quit()
+		
+	case "esc", "q", "v":
+		m.state = StateLibrary
+		return m, nil
+		
+	case "up", "k":
+		if m.viewCursor > 0 {
+			m.viewCursor--
+		}
+		return m, nil
+		
+	case "down", "j":
+		if m.viewCursor < len(views)-1 {
+			m.viewCursor++
+		}
+		return m, nil
+		
+	case "enter":
+		return m, m.ApplySelectedView()
+		
+	case "n", "s":
+		m.StartSaveView()
 		return m, nil
 		
+	case "*":
+		m.ToggleDefaultView()
+		return m, nil
+		
+	case "x", "delete":
+		m.DeleteSelectedView()
+		return m, nil
+	}
+	
+	return m, nil
+}
+
+// handleReconcileStateKeys handles keys on the project reconciliation screen
+func (m *Model) handleReconcileStateKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
 	case "ctrl+c":
 		return m, m.Quit()
+		
+	case "esc", "q":
+		m.state = m.reconcileReturn
+		return m, nil
+		
+	case "up", "k":
+		if m.reconcileCursor > 0 {
+			m.reconcileCursor--
+		}
+		return m, nil
+		
+	case "down", "j":
+		if m.reconcileCursor < len(m.projectDrift)-1 {
+			m.reconcileCursor++
+		}
+		return m, nil
+		
+	case " ", "right", "l", "tab":
+		m.CycleReconcileAction(1)
+		return m, nil
+		
+	case "left", "h", "shift+tab":
+		m.CycleReconcileAction(-1)
+		return m, nil
+		
+	case "enter":
+		return m, m.ApplyReconcile()
 	}
 	
 	return m, nil
 }
 
+// handleSaveViewStateKeys handles keys while naming a view to save
+func (m *Model) handleSaveViewStateKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		m.ConfirmSaveView()
+		return m, nil
+		
+	case "esc":
+		m.state = StateViews
+		return m, nil
+		
+	case "ctrl+c":
+		return m, m.Quit()
+	}
+	
+	var cmd tea.Cmd
+	m.textInput, cmd = m.textInput.Update(msg)
+	return m, cmd
+}
+
 // Note: Confirm quit state removed since changes are saved immediately
 
 // Remote import message handlers
 
+// Steps of loading a remote repository, in order, as shown on the loading screen
+const (
+	loadStepValidate  = "Validating repository"
+	loadStepList      = "Listing commands"
+	loadStepContent   = "Fetching command content"
+	loadStepConflicts = "Checking for conflicts"
+)
+
+var remoteLoadSteps = []string{loadStepValidate, loadStepList, loadStepContent, loadStepConflicts}
+
 func (m *Model) handleRemoteLoading() (tea.Model, tea.Cmd) {
-	// Start async loading of remote repository data with caching
-	return m, func() tea.Msg {
+	// Load remote repository data with caching in the background; the task keeps its
+	// own reference since the user may start browsing another repository meanwhile
+	repo := m.remoteRepo
+	id, cmd := m.runWithProgress("Load "+repo.FullName(), func(ctx context.Context, report func(step string, done, total int)) tea.Msg {
+		// A cancelled load fails with whatever step it was in; report it as cancelled
+		failed := func(err error) tea.Msg {
+			if ctx.Err() != nil {
+				return RemoteLoadedMsg{Repo: repo, Cancelled: true}
+			}
+			return RemoteLoadedMsg{Repo: repo, Error: err.Error()}
+		}
+		
 		client := remote.NewGitHubClient()
+		client.SetContext(ctx)
 		
 		// Set cache manager if available
 		if m.cacheManager != nil {
@@ -380,39 +912,58 @@ func (m *Model) handleRemoteLoading() (tea.Model, tea.Cmd) {
 		}
 		
 		// Validate repository
-		if err := client.ValidateRepository(m.remoteRepo); err != nil {
-			return RemoteLoadedMsg{Error: err.Error()}
+		report(loadStepValidate, 0, 0)
+		if err := client.ValidateRepository(repo); err != nil {
+			return failed(err)
 		}
 		
 		// Fetch commands with caching enabled
-		if err := client.FetchCommandsWithCache(m.remoteRepo, true); err != nil {
-			return RemoteLoadedMsg{Error: err.Error()}
+		report(loadStepList, 0, 0)
+		if err := client.FetchCommandsWithCache(repo, true); err != nil {
+			return failed(err)
 		}
 		
 		// Load command details for commands that don't have content yet
-		for i := range m.remoteRepo.Commands {
-			if m.remoteRepo.Commands[i].Content == "" {
-				if err := client.FetchCommandContent(m.remoteRepo, &m.remoteRepo.Commands[i]); err != nil {
-					m.remoteRepo.Commands[i].Description = "Failed to load description"
+		for i := range repo.Commands {
+			report(loadStepContent, i, len(repo.Commands))
+			if repo.Commands[i].Content == "" {
+				if err := client.FetchCommandContent(repo, &repo.Commands[i]); err != nil {
+					if ctx.Err() != nil {
+						return RemoteLoadedMsg{Repo: repo, Cancelled: true}
+					}
+					repo.Commands[i].Description = "Failed to load description"
 				}
 			}
 		}
 		
 		// Check for local conflicts
+		report(loadStepConflicts, 0, 0)
 		importer := remote.NewImporter("")
-		homeDir, _ := os.UserHomeDir()
-		targetDir := filepath.Join(homeDir, ".claude", "command_library")
-		if err := importer.CheckLocalExists(m.remoteRepo.Commands, targetDir); err != nil {
-			return RemoteLoadedMsg{Error: err.Error()}
+		targetDir, _ := m.remoteImportTarget()
+		if err := importer.CheckLocalExists(repo.Commands, targetDir); err != nil {
+			return failed(err)
 		}
 		
-		return RemoteLoadedMsg{Commands: m.remoteRepo.Commands}
-	}
+		return RemoteLoadedMsg{Repo: repo, Commands: repo.Commands}
+	})
+	m.remoteLoadTask = id
+	m.spinner.Style = lipgloss.NewStyle().Foreground(primaryColor) // Follow theme changes
+	return m, tea.Batch(cmd, m.spinner.Tick)
 }
 
 func (m *Model) handleRemoteLoaded(msg RemoteLoadedMsg) (tea.Model, tea.Cmd) {
+	// Esc already left the loading screen, and may have started loading another repository;
+	// a load that finishes late may also belong to a repository the user has since left
+	if msg.Cancelled || msg.Repo != m.remoteRepo {
+		return m, nil
+	}
 	m.remoteLoading = false
 	
+	// The user moved on while the repository loaded; the task panel shows the outcome
+	if m.state != StateRemoteLoading {
+		return m, nil
+	}
+	
 	if msg.Error != "" {
 		m.remoteError = msg.Error
 		m.state = StateRemoteURL
@@ -422,6 +973,7 @@ func (m *Model) handleRemoteLoaded(msg RemoteLoadedMsg) (tea.Model, tea.Cmd) {
 	// Store commands and initialize selection state
 	m.remoteCommands = msg.Commands
 	m.remoteSelected = make(map[int]bool)
+	m.remoteRenames = make(map[int]string)
 	
 	// Transition to selection state
 	m.state = StateRemoteSelect
@@ -431,38 +983,127 @@ func (m *Model) handleRemoteLoaded(msg RemoteLoadedMsg) (tea.Model, tea.Cmd) {
 }
 
 func (m *Model) handleRemoteImport(msg RemoteImportMsg) (tea.Model, tea.Cmd) {
-	// Start async import process
-	return m, func() tea.Msg {
-		homeDir, err := os.UserHomeDir()
-		if err != nil {
-			return RemoteImportCompleteMsg{Error: err.Error()}
+	// Import in the background
+	selected := 0
+	for _, command := range msg.Commands {
+		if command.Selected {
+			selected++
 		}
-		
-		targetDir := filepath.Join(homeDir, ".claude", "command_library")
+	}
+	repo := m.remoteRepo
+	title := fmt.Sprintf("Import %d command(s) from %s", selected, repo.FullName())
+	id, cmd := m.runWithProgress(title, func(ctx context.Context, report func(step string, done, total int)) tea.Msg {
+		targetDir, project := m.remoteImportTarget()
 		options := remote.GetDefaultImportOptions(targetDir)
+		options.CreateBackups = GetThemeManager().GetAppConfig().Import.CreateBackups
+		options.Context = ctx
+		options.Progress = func(done, total int, name string) {
+			report(name, done, total)
+		}
 		
-		// Set overwrite based on conflicts - for now, default to overwrite
+		// Conflicts without a choice (confirm.overwrite is off) are overwritten
 		options.OverwriteExisting = true
+		options.Resolutions = msg.Resolutions
 		
 		importer := remote.NewImporter(targetDir)
-		result, err := importer.ImportCommands(m.remoteRepo, msg.Commands, options)
+		result, err := importer.ImportCommands(repo, msg.Commands, options)
+		if errors.Is(err, context.Canceled) {
+			// Keep what was imported before the cancel; the rest was never attempted
+			return RemoteImportCompleteMsg{Repo: repo, Result: result, Project: project, Cancelled: true}
+		}
+		complete := RemoteImportCompleteMsg{Repo: repo, Result: result, Project: project}
+		if err != nil {
+			// Nothing was imported; queue the whole selection so it can be retried
+			complete = RemoteImportCompleteMsg{Repo: repo, Error: err.Error()}
+			result = remote.BatchFailure(msg.Commands, err)
+		}
+		
+		// Queue failures so they can be retried later with `ccm imports retry`
+		queue, err := remote.LoadFailureQueue()
+		if err == nil {
+			queue.RecordResult(repo, msg.Commands, options, result)
+			err = queue.Save()
+		}
 		if err != nil {
-			return RemoteImportCompleteMsg{Error: err.Error()}
+			complete.QueueError = err.Error()
 		}
 		
-		return RemoteImportCompleteMsg{Result: result}
+		return complete
+	})
+	m.remoteImportTask = id
+	return m, cmd
+}
+
+func (m *Model) handleProjectReconcile(msg ProjectReconcileMsg) (tea.Model, tea.Cmd) {
+	m.reconciling = false
+	result := msg.Plan.ApplyTo(m.commandManager)
+	
+	if err := m.configManager.Save(); err != nil {
+		m.notify(fmt.Sprintf("Failed to save configuration: %v", err), StatusError)
+		return m, nil
+	}
+	
+	m.CheckProjectConfig()
+	m.CheckIntegrity()
+	
+	if len(result.Unresolved) > 0 {
+		m.notify(fmt.Sprintf("Reconciled %d item(s); %d could not be fixed: %s",
+			len(result.Fixed), len(result.Unresolved), result.Unresolved[0]), StatusWarning)
+	} else {
+		m.notify(fmt.Sprintf("Reconciled %d item(s) with %s", len(result.Fixed), project.FileName), StatusSuccess)
+	}
+	
+	return m, func() tea.Msg {
+		return RefreshMsg{}
 	}
 }
 
 func (m *Model) handleRemoteImportComplete(msg RemoteImportCompleteMsg) (tea.Model, tea.Cmd) {
+	// Only take over the screen if the user is still waiting on the import
+	waiting := m.state == StateRemoteImport
+	
 	if msg.Error != "" {
-		m.remoteError = msg.Error
-		m.state = StateRemoteSelect
+		if waiting {
+			m.remoteError = msg.Error
+			m.state = StateRemoteSelect
+		} else {
+			m.notify("Import failed: "+msg.Error, StatusError)
+		}
+		if msg.QueueError != "" {
+			m.notify("Failed to update failed-imports queue: "+msg.QueueError, StatusWarning)
+		}
 		return m, nil
 	}
 	
 	m.remoteResult = msg.Result
-	m.state = StateRemoteResults
+	if msg.Result != nil {
+		m.logAction(actionImported, msg.Result.Imported...)
+	}
+	if msg.QueueError != "" {
+		m.notify("Failed to update failed-imports queue: "+msg.QueueError, StatusWarning)
+	}
+	if msg.Cancelled {
+		m.notify(fmt.Sprintf("Import cancelled after %d command(s) were imported", len(msg.Result.Imported)), StatusWarning)
+	} else if waiting {
+		m.state = StateRemoteResults
+	} else if msg.Result != nil {
+		m.notify(fmt.Sprintf("Background import finished: %d imported, %d skipped, %d failed",
+			len(msg.Result.Imported), len(msg.Result.Skipped), len(msg.Result.Failed)), StatusInfo)
+	}
+	
+	// Remember where imported commands came from for grouping by source
+	if msg.Result != nil && msg.Repo != nil && len(msg.Result.Files) > 0 {
+		commandManager, configManager := m.userCommandManager, m.userConfigManager
+		if msg.Project {
+			commandManager, configManager = m.commandManager, m.configManager
+		}
+		for _, file := range msg.Result.Files {
+			commandManager.RecordSource(file, msg.Repo.FullName())
+		}
+		if err := configManager.Save(); err != nil {
+			m.notify(fmt.Sprintf("Failed to record command sources: %v", err), StatusWarning)
+		}
+	}
 	
 	return m, nil
 }
@@ -522,25 +1163,30 @@ func (m *Model) handleRegistryErrorKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 }
 
 func (m *Model) handleCategoryBrowseKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "enter":
+	k := m.remoteKeys
+	switch {
+	case key.Matches(msg, k.Open):
 		m.enterCategory()
 		return m, nil
 		
-	case "/", "s":
+	case key.Matches(msg, k.Search):
 		m.startSearch()
 		return m, nil
 		
-	case "c":
+	case key.Matches(msg, k.CustomURL):
 		m.goToCustomURL()
 		return m, nil
 		
-	case "esc":
+	case key.Matches(msg, k.Help):
+		m.openHelp()
+		return m, nil
+		
+	case msg.String() == "esc":
 		m.state = StateMainMenu
 		m.initMainMenu()
 		return m, nil
 		
-	case "ctrl+c":
+	case msg.String() == "ctrl+c":
 		return m, m.Quit()
 	}
 	
@@ -551,8 +1197,9 @@ func (m *Model) handleCategoryBrowseKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 }
 
 func (m *Model) handleRepositoryBrowseKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "enter":
+	k := m.remoteKeys
+	switch {
+	case key.Matches(msg, k.Open):
 		// Load commands from the focused repository
 		index := m.list.Index()
 		if index < 0 || index >= len(m.filteredRepos) {
@@ -564,34 +1211,30 @@ func (m *Model) handleRepositoryBrowseKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd)
 		focusedRepo := m.filteredRepos[index]
 		return m, m.importSingleRepository(focusedRepo)
 		
-	case " ":
-		// Space also loads repository commands (alternative to Enter)
-		index := m.list.Index()
-		if index < 0 || index >= len(m.filteredRepos) {
-			// Show bounds error to user
-			m.remoteError = "No repository selected. Please select a repository from the list."
-			m.state = StateRemoteURL
-			return m, nil
-		}
-		focusedRepo := m.filteredRepos[index]
-		return m, m.importSingleRepository(focusedRepo)
+	case key.Matches(msg, k.Star):
+		m.toggleRepositoryStar()
+		return m, nil
 		
-	case "/", "s":
+	case key.Matches(msg, k.Search):
 		m.startSearch()
 		return m, nil
 		
-	case "c":
+	case key.Matches(msg, k.CustomURL):
 		m.goToCustomURL()
 		return m, nil
 		
-	case "esc":
+	case key.Matches(msg, k.Help):
+		m.openHelp()
+		return m, nil
+		
+	case msg.String() == "esc":
 		// Go back to categories
 		m.browseMode = BrowseModeCategories
 		m.currentCategory = ""
 		m.updateBrowseList()
 		return m, nil
 		
-	case "ctrl+c":
+	case msg.String() == "ctrl+c":
 		return m, m.Quit()
 	}
 	
@@ -602,8 +1245,9 @@ func (m *Model) handleRepositoryBrowseKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd)
 }
 
 func (m *Model) handleSearchKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "enter":
+	k := m.remoteKeys
+	switch {
+	case msg.String() == "enter":
 		// If search results are showing and text input is not focused, load repository commands
 		if len(m.filteredRepos) > 0 && !m.textInput.Focused() {
 			index := m.list.Index()
@@ -618,7 +1262,7 @@ func (m *Model) handleSearchKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 		
-	case "tab":
+	case key.Matches(msg, k.SwitchFocus):
 		// Switch focus between search input and results
 		if m.searchInput.Focused() {
 			m.searchInput.Blur()
@@ -627,7 +1271,7 @@ func (m *Model) handleSearchKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 		
-	case "esc":
+	case msg.String() == "esc":
 		if m.searchInput.Value() != "" {
 			// Clear search first
 			m.searchInput.SetValue("")
@@ -640,11 +1284,19 @@ func (m *Model) handleSearchKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		
 	// Removed multi-select functionality - repositories are now single-select
 		
-	case "c":
+	case key.Matches(msg, k.Star) && !m.searchInput.Focused():
+		m.toggleRepositoryStar()
+		return m, nil
+		
+	case key.Matches(msg, k.Help) && !m.searchInput.Focused():
+		m.openHelp()
+		return m, nil
+		
+	case key.Matches(msg, k.CustomURL):
 		m.goToCustomURL()
 		return m, nil
 		
-	case "ctrl+c":
+	case msg.String() == "ctrl+c":
 		return m, m.Quit()
 	}
 	
@@ -689,32 +1341,47 @@ func (m *Model) handleRemoteURLStateKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 }
 
 func (m *Model) handleRemoteSelectStateKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "enter":
+	k := m.remoteKeys
+	switch {
+	case key.Matches(msg, k.Toggle):
 		m.ToggleRemoteCommand()
 		return m, nil
 		
-	case "p":
+	case key.Matches(msg, k.Preview):
 		m.StartPreview()
 		return m, nil
 		
-	case "a":
+	case key.Matches(msg, k.Analyze):
+		m.StartAnalysis()
+		return m, nil
+		
+	case key.Matches(msg, k.SelectAll):
 		m.SelectAllRemoteCommands(true)
 		return m, nil
 		
-	case "n":
+	case key.Matches(msg, k.SelectNone):
 		m.SelectAllRemoteCommands(false)
 		return m, nil
 		
-	case "i":
+	case key.Matches(msg, k.Rename):
+		return m, m.StartRemoteRename(false)
+		
+	case key.Matches(msg, k.Prefix):
+		return m, m.StartRemoteRename(true)
+		
+	case key.Matches(msg, k.Import):
 		return m, m.StartRemoteImportProcess()
 		
-	case "esc":
+	case key.Matches(msg, k.Help):
+		m.openHelp()
+		return m, nil
+		
+	case msg.String() == "esc":
 		m.state = StateMainMenu
 		m.initMainMenu()
 		return m, nil
 		
-	case "ctrl+c":
+	case msg.String() == "ctrl+c":
 		return m, m.Quit()
 	}
 	
@@ -730,6 +1397,23 @@ func (m *Model) handleRemotePreviewStateKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd
 		m.ExitPreview()
 		return m, nil
 		
+	case "x":
+		m.StartAnalysis()
+		return m, nil
+		
+	case "ctrl+c":
+		return m, m.Quit()
+	}
+	
+	return m, nil
+}
+
+func (m *Model) handleRemoteAnalysisStateKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "x", "q":
+		m.ExitAnalysis()
+		return m, nil
+		
 	case "ctrl+c":
 		return m, m.Quit()
 	}
@@ -757,8 +1441,9 @@ func (m *Model) handleRemoteRepoDetailsStateKeys(msg tea.KeyMsg) (tea.Model, tea
 			return m, nil // Show validation errors
 		}
 		
-		// Update description from input
+		// Update description and tags from input
 		m.customRepoInput.Description = strings.TrimSpace(m.textInput.Value())
+		m.customRepoInput.Tags = parseTags(m.repoTagsInput.Value())
 		
 		// Check if category is already selected
 		if m.customRepoInput.Category.CategoryKey != "" {
@@ -779,12 +1464,22 @@ func (m *Model) handleRemoteRepoDetailsStateKeys(msg tea.KeyMsg) (tea.Model, tea
 		if !m.validateInput() {
 			return m, nil // Stay on current field if invalid
 		}
-		// Update description and move to category selection
 		m.customRepoInput.Description = strings.TrimSpace(m.textInput.Value())
 		m.clearValidationErrors()
+		if m.repoDetailsField == 0 {
+			// Move from description to tags
+			m.focusRepoDetailsField(1)
+			return m, nil
+		}
+		// Update tags and move to category selection
+		m.customRepoInput.Tags = parseTags(m.repoTagsInput.Value())
 		m.startCategorySelection()
 		return m, nil
 		
+	case "shift+tab":
+		m.focusRepoDetailsField(0)
+		return m, nil
+		
 	case "esc":
 		m.clearValidationErrors()
 		m.state = StateRemoteURL
@@ -798,9 +1493,13 @@ func (m *Model) handleRemoteRepoDetailsStateKeys(msg tea.KeyMsg) (tea.Model, tea
 	// Clear validation errors on input change
 	m.clearValidationErrors()
 	
-	// Let text input handle other keys
+	// Let the focused input handle other keys
 	var cmd tea.Cmd
-	m.textInput, cmd = m.textInput.Update(msg)
+	if m.repoDetailsField == 1 {
+		m.repoTagsInput, cmd = m.repoTagsInput.Update(msg)
+	} else {
+		m.textInput, cmd = m.textInput.Update(msg)
+	}
 	return m, cmd
 }
 
@@ -814,20 +1513,8 @@ func (m *Model) handleRemoteCategoryStateKeys(msg tea.KeyMsg) (tea.Model, tea.Cm
 				return m, nil // Show validation errors
 			}
 			
-			// Get the name from category input
-			newCategoryName := strings.TrimSpace(m.categoryInput.Value())
-			
-			// Create category key from name (lowercase, replace spaces with underscores)
-			categoryKey := strings.ToLower(strings.ReplaceAll(newCategoryName, " ", "_"))
-			
-			// Set up the category input
-			m.customRepoInput.Category = registry.CategoryInput{
-				CategoryKey: categoryKey,
-				IsNew:       true,
-				Name:        newCategoryName,
-				Description: fmt.Sprintf("Custom category: %s", newCategoryName),
-				Icon:        "📦", // Default icon
-			}
+			// Set up the category input from the form
+			m.customRepoInput.Category = m.newCategoryInput()
 			
 			// Finalize the repository
 			m.clearValidationErrors()
@@ -869,9 +1556,30 @@ func (m *Model) handleRemoteCategoryStateKeys(msg tea.KeyMsg) (tea.Model, tea.Cm
 	
 	// Handle different input contexts
 	if m.isNewCategory && m.selectedCategoryKey == "new" {
-		// Handle category input for new category creation
+		switch msg.String() {
+		case "tab", "down":
+			m.CycleCategoryField(1)
+			return m, nil
+		case "shift+tab", "up":
+			m.CycleCategoryField(-1)
+			return m, nil
+		}
+		
 		var cmd tea.Cmd
-		m.categoryInput, cmd = m.categoryInput.Update(msg)
+		switch m.categoryField {
+		case 0:
+			m.categoryInput, cmd = m.categoryInput.Update(msg)
+		case 1:
+			m.categoryDescInput, cmd = m.categoryDescInput.Update(msg)
+		case 2:
+			// Icon picker
+			switch msg.String() {
+			case "left", "h":
+				m.MoveCategoryIcon(-1)
+			case "right", "l", " ":
+				m.MoveCategoryIcon(1)
+			}
+		}
 		return m, cmd
 	} else {
 		// Handle list navigation for category selection
@@ -884,45 +1592,43 @@ func (m *Model) handleRemoteCategoryStateKeys(msg tea.KeyMsg) (tea.Model, tea.Cm
 // handleReportIssueStateKeys handles keys in the report issue state
 func (m *Model) handleReportIssueStateKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
-	case "enter":
-		// Submit the issue
+	case "enter", "ctrl+s":
+		// Enter adds a line in the description, so only submits from the title
+		if msg.String() == "enter" && m.issueCurrentField == issueFieldBody {
+			break
+		}
+		// Show the issue as it will be filed before submitting it
 		if m.validateReportIssueInput() && !m.issueSubmitting {
-			return m, m.SubmitIssue()
+			return m, m.StartIssuePreview()
 		}
 		return m, nil // Show validation errors
 		
 	case "tab":
-		// Switch between fields
+		// Switch between fields: type, title, body, diagnostics
 		m.clearValidationErrors()
-		if m.issueCurrentField == 0 {
-			// Switch from title to body
-			m.issueCurrentField = 1
-			m.issueTitleInput.Blur()
-			m.issueBodyInput.Focus()
-		} else {
-			// Switch from body to title
-			m.issueCurrentField = 0
-			m.issueBodyInput.Blur()
-			m.issueTitleInput.Focus()
-		}
+		m.focusIssueField((m.issueCurrentField + 1) % issueFieldCount)
 		return m, nil
 		
 	case "shift+tab":
 		// Switch between fields (reverse direction)
 		m.clearValidationErrors()
-		if m.issueCurrentField == 0 {
-			// Switch from title to body
-			m.issueCurrentField = 1
-			m.issueTitleInput.Blur()
-			m.issueBodyInput.Focus()
-		} else {
-			// Switch from body to title
-			m.issueCurrentField = 0
-			m.issueBodyInput.Blur()
-			m.issueTitleInput.Focus()
-		}
+		m.focusIssueField((m.issueCurrentField + issueFieldCount - 1) % issueFieldCount)
 		return m, nil
 		
+	case " ", "left", "right":
+		switch m.issueCurrentField {
+		case issueFieldType:
+			step := 1
+			if msg.String() == "left" {
+				step = -1
+			}
+			m.cycleIssueType(step)
+			return m, nil
+		case issueFieldDiagnostics:
+			m.issueDiagnostics = !m.issueDiagnostics
+			return m, nil
+		}
+		
 	case "esc":
 		// Cancel and return to main menu
 		m.clearValidationErrors()
@@ -938,14 +1644,26 @@ func (m *Model) handleReportIssueStateKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd)
 	m.clearValidationErrors()
 	
 	// Let the appropriate text input handle other keys
-	if m.issueCurrentField == 0 {
-		var cmd tea.Cmd
+	var cmd tea.Cmd
+	switch m.issueCurrentField {
+	case issueFieldTitle:
 		m.issueTitleInput, cmd = m.issueTitleInput.Update(msg)
-		return m, cmd
-	} else {
-		var cmd tea.Cmd
+	case issueFieldBody:
 		m.issueBodyInput, cmd = m.issueBodyInput.Update(msg)
-		return m, cmd
+	}
+	return m, cmd
+}
+
+// focusIssueField moves the focus on the report issue form to field
+func (m *Model) focusIssueField(field int) {
+	m.issueCurrentField = field
+	m.issueTitleInput.Blur()
+	m.issueBodyInput.Blur()
+	switch field {
+	case issueFieldTitle:
+		m.issueTitleInput.Focus()
+	case issueFieldBody:
+		m.issueBodyInput.Focus()
 	}
 }
 
@@ -964,7 +1682,7 @@ func (m *Model) handleSettingsStateKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m.executeSelectedSettingsMenuItem()
 		
 	case "h", "?":
-		m.state = StateHelp
+		m.openHelp()
 		return m, nil
 	}
 	
@@ -1015,13 +1733,17 @@ func (m *Model) executeSelectedSettingsMenuItem() (tea.Model, tea.Cmd) {
 	case "themes":
 		m.StartThemeSettings()
 		return m, nil
+	case "keymap":
+		return m, m.CycleKeymapProfile()
+	case "cache":
+		m.StartCacheSettings()
+		return m, nil
 	case "general":
-		// TODO: Implement general settings
-		m.setStatus("General settings not yet implemented", StatusWarning)
+		m.StartGeneralSettings()
 		return m, nil
 	case "about":
-		// TODO: Implement about dialog
-		m.setStatus("About dialog not yet implemented", StatusWarning)
+		info := version.Get()
+		m.setStatus(fmt.Sprintf("ccm %s • %s", info.Short(), info.Platform), StatusInfo)
 		return m, nil
 	}
 	
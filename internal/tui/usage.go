@@ -0,0 +1,102 @@
+package tui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/shel-corp/Claude-command-manager/internal/commands"
+	"github.com/shel-corp/Claude-command-manager/internal/logging"
+	"github.com/shel-corp/Claude-command-manager/internal/usage"
+)
+
+// usageRefreshInterval is how often Claude's session transcripts are checked for new
+// invocations; unchanged transcripts are not read again
+const usageRefreshInterval = 30 * time.Second
+
+// usageTickMsg asks for the next read of the session transcripts
+type usageTickMsg struct{}
+
+// usageLoadedMsg carries the usage stats read in the background
+type usageLoadedMsg struct {
+	stats map[string]usage.Stats
+	err   error
+}
+
+// usageEnabled reports whether the user opted in to reading Claude's session transcripts
+func usageEnabled() bool {
+	tm := GetThemeManager()
+	return tm != nil && tm.GetAppConfig().Library.UsageStats
+}
+
+// watchUsage schedules the next read of the session transcripts
+func watchUsage() tea.Cmd {
+	return tea.Tick(usageRefreshInterval, func(time.Time) tea.Msg {
+		return usageTickMsg{}
+	})
+}
+
+// handleUsageTick reads the session transcripts in the background when usage stats are
+// on, and forgets the stats when they were turned off
+func (m *Model) handleUsageTick() (tea.Model, tea.Cmd) {
+	if !usageEnabled() || m.usageScanner == nil {
+		if m.usage != nil {
+			m.usage = nil
+			m.refreshUsageItems()
+		}
+		return m, watchUsage()
+	}
+
+	scanner := m.usageScanner
+	return m, func() tea.Msg {
+		stats, err := scanner.Scan(time.Now())
+		return usageLoadedMsg{stats: stats, err: err}
+	}
+}
+
+// handleUsageLoaded shows freshly read usage stats in the library
+func (m *Model) handleUsageLoaded(msg usageLoadedMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		logging.Warnf("failed to read Claude session transcripts: %v", msg.err)
+		return m, watchUsage()
+	}
+	if usageEnabled() {
+		m.usage = msg.stats
+		m.refreshUsageItems()
+	}
+	return m, watchUsage()
+}
+
+// refreshUsageItems redraws the library list with the current usage stats. Other screens
+// share the list, so they pick the stats up when the library is next opened.
+func (m *Model) refreshUsageItems() {
+	if m.state == StateLibrary {
+		m.setLibraryItems(m.buildLibraryItems())
+	}
+}
+
+// commandUsage returns how often cmd was invoked under the name Claude knows it by, and
+// whether usage stats are available at all
+func (m *Model) commandUsage(cmd commands.Command) (usage.Stats, bool) {
+	if m.usage == nil {
+		return usage.Stats{}, false
+	}
+	return usage.Lookup(m.usage, cmd.QualifiedName()), true
+}
+
+// usageSummary describes a command's usage for the library list: "3 this month, used
+// 2 days ago", or "unused" for an enabled command never invoked. It is "" when usage
+// stats are off.
+func (m *Model) usageSummary(cmd commands.Command) string {
+	stats, ok := m.commandUsage(cmd)
+	switch {
+	case !ok:
+		return ""
+	case stats.Total == 0 && cmd.Enabled:
+		return "unused"
+	case stats.Total == 0:
+		return ""
+	}
+	return fmt.Sprintf("%d this month, used %s", stats.ThisMonth, formatAge(stats.LastUsed))
+}
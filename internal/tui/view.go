@@ -2,9 +2,20 @@ package tui
 
 import (
 	"fmt"
+	"path/filepath"
 	"strings"
 	
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/shel-corp/Claude-command-manager/internal/commands"
+	"github.com/shel-corp/Claude-command-manager/internal/config"
+	"github.com/shel-corp/Claude-command-manager/internal/models"
+	"github.com/shel-corp/Claude-command-manager/internal/project"
+	"github.com/shel-corp/Claude-command-manager/internal/registry"
+	"github.com/shel-corp/Claude-command-manager/internal/remote"
+	"github.com/shel-corp/Claude-command-manager/internal/theme"
+	"github.com/shel-corp/Claude-command-manager/internal/version"
 )
 
 // min returns the smaller of two integers
@@ -18,9 +29,31 @@ func min(a, b int) int {
 // View renders the application UI
 func (m *Model) View() string {
 	if m.quitting {
-		return "Goodbye!\n"
+		return m.quitView()
 	}
 
+	m.syncListRows() // Plain mode can be switched on in the settings
+	view := m.trackLayout(m.stateView())
+	if toasts := m.renderToasts(); toasts != "" {
+		view = overlayAt(view, toasts, 1, max(m.width-lipgloss.Width(toasts)-1, 0))
+	}
+	if m.palette.open {
+		view = overlay(view, m.paletteView(), m.width)
+	}
+	if m.help.open {
+		view = overlay(view, m.helpView(), m.width)
+	}
+	if panel := m.renderTaskPanel(); panel != "" {
+		view = lipgloss.JoinVertical(lipgloss.Left, view, lipgloss.NewStyle().MarginLeft(2).Render(panel))
+	}
+	if m.plainMode() {
+		view = plainText(view)
+	}
+	return view
+}
+
+// stateView renders the screen for the current state
+func (m *Model) stateView() string {
 	// Debug: Log current state and dimensions
 	stateStr := "Unknown"
 	switch m.state {
@@ -33,9 +66,42 @@ func (m *Model) View() string {
 	case StateRename:
 		stateStr = "Rename"
 		return m.renameView()
-	case StateHelp:
-		stateStr = "Help"
-		return m.helpView()
+	case StateDuplicate:
+		stateStr = "Duplicate"
+		return m.duplicateView()
+	case StateEditMeta:
+		stateStr = "EditMeta"
+		return m.editMetaView()
+	case StateConfirmDelete:
+		stateStr = "ConfirmDelete"
+		return m.confirmDeleteView()
+	case StateViews:
+		stateStr = "Views"
+		return m.viewsView()
+	case StateReconcile:
+		stateStr = "Reconcile"
+		return m.reconcileView()
+	case StateTagEditor:
+		stateStr = "TagEditor"
+		return m.tagEditorView()
+	case StateLibraryPreview:
+		stateStr = "LibraryPreview"
+		return m.libraryPreviewView()
+	case StateContentSearch:
+		stateStr = "ContentSearch"
+		return m.contentSearchView()
+	case StateCommandInfo:
+		stateStr = "CommandInfo"
+		return m.commandInfoView()
+	case StateInlineEdit:
+		stateStr = "InlineEdit"
+		return m.inlineEditView()
+	case StateNewCommand:
+		stateStr = "NewCommand"
+		return m.newCommandView()
+	case StateSaveView:
+		stateStr = "SaveView"
+		return m.saveViewView()
 	case StateRemoteBrowse:
 		stateStr = "RemoteBrowse"
 		return m.remoteBrowseView()
@@ -57,6 +123,15 @@ func (m *Model) View() string {
 	case StateRemotePreview:
 		stateStr = "RemotePreview"
 		return m.remotePreviewView()
+	case StateRemoteAnalysis:
+		stateStr = "RemoteAnalysis"
+		return m.remoteAnalysisView()
+	case StateImportConflicts:
+		stateStr = "ImportConflicts"
+		return m.importConflictsView()
+	case StateRemoteRename:
+		stateStr = "RemoteRename"
+		return m.remoteRenameView()
 	case StateRemoteImport:
 		stateStr = "RemoteImport"
 		return m.remoteImportView()
@@ -66,12 +141,24 @@ func (m *Model) View() string {
 	case StateReportIssue:
 		stateStr = "ReportIssue"
 		return m.reportIssueView()
+	case StateReportIssuePreview:
+		stateStr = "ReportIssuePreview"
+		return m.issuePreviewView()
 	case StateSettings:
 		stateStr = "Settings"
 		return m.settingsView()
 	case StateThemeSettings:
 		stateStr = "ThemeSettings"
 		return m.themeSettingsView()
+	case StateCacheSettings:
+		stateStr = "CacheSettings"
+		return m.settingsFormView()
+	case StateGeneralSettings:
+		stateStr = "GeneralSettings"
+		return m.settingsFormView()
+	case StateGitHubAuth:
+		stateStr = "GitHubAuth"
+		return m.gitHubAuthView()
 	}
 
 	// Fallback with debug info
@@ -103,13 +190,14 @@ func (m *Model) mainMenuView() string {
 Command Manager`
 
 	var headerContent string
-	if m.width < 80 { // Increased threshold since ASCII art is wide
+	if m.compactLayout() || m.plainMode() { // The ASCII art is too wide, or unreadable to screen readers
 		// Compact header for narrow terminals
 		headerContent = "CLAUDE COMMANDS\nCommand Manager"
 	} else {
 		// Full ASCII art header for wider terminals  
 		headerContent = asciiHeader
 	}
+	headerContent += "\n" + lipgloss.NewStyle().Foreground(subtextColor).Bold(boldText).Render(version.Get().Short())
 	
 	// Style the header with clean, borderless design
 	headerStyle := lipgloss.NewStyle().
@@ -118,6 +206,9 @@ Command Manager`
 		Margin(1, 0).
 		Align(lipgloss.Center).
 		Width(m.width - 10)
+	if m.compactLayout() {
+		headerStyle = headerStyle.Padding(0, 1).Margin(0)
+	}
 	
 	// Apply styling and center the header
 	finalHeader := lipgloss.NewStyle().
@@ -125,21 +216,35 @@ Command Manager`
 		Align(lipgloss.Center).
 		Render(headerStyle.Render(headerContent))
 	
-	// Get the menu content
-	content := m.list.View()
+	// Get the menu content, with any startup warnings centered above it
+	content := lipgloss.NewStyle().
+		Width(m.width).
+		Align(lipgloss.Center).
+		Render(m.listView())
+	if notices := m.renderStartupNotices() + m.renderStatusMessage(); notices != "" {
+		content = lipgloss.NewStyle().
+			Width(m.width).
+			Align(lipgloss.Center).
+			Render(notices) + "\n" + content
+	}
 	
 	// Create an elegant footer with better styling
 	footerStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#64748B")).
-		Background(lipgloss.Color("#0F172A")).
+		Foreground(mutedColor).
+		Bold(boldText).
+		Background(surfaceColor).
 		Padding(1, 2).
 		Margin(1, 0).
 		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("#334155")).
+		BorderForeground(borderVariantColor).
 		Align(lipgloss.Center).
 		Width(m.width - 10)
 	
-	footerText := "↑/↓ Navigate  •  Enter Select  •  q Quit  •  h Help"
+	footerText := "↑/↓ Navigate  •  Enter Select  •  Ctrl+P Actions  •  " + m.keys.Quit.Help().Key + " Quit  •  " + m.keys.Help.Help().Key + " Help"
+	if m.compactLayout() {
+		footerStyle = footerStyle.Padding(0, 1).Margin(0)
+		footerText = "Enter Select • " + m.keys.Quit.Help().Key + " Quit • " + m.keys.Help.Help().Key + " Help"
+	}
 	footer := lipgloss.NewStyle().
 		Width(m.width).
 		Align(lipgloss.Center).
@@ -156,7 +261,41 @@ Command Manager`
 
 // libraryView renders the main application view
 func (m *Model) libraryView() string {
-	// Header with library type
+	// Include status message and main content
+	content := m.renderStartupNotices() + m.renderStatusMessage() + m.libraryListView()
+	footer := m.pagedFooter(m.renderFooter())
+	
+	return centerView(m.libraryHeader(), content, footer, m.width)
+}
+
+// libraryStats summarizes the whole library, ignoring filters, as
+// "42 commands — 17 enabled (12 user / 5 project)"
+func (m *Model) libraryStats() string {
+	enabled := 0
+	byLocation := make(map[config.SymlinkLocation]int)
+	for _, cmd := range m.libraryAll {
+		if cmd.Enabled {
+			enabled++
+			byLocation[cmd.SymlinkLocation]++
+		}
+	}
+	
+	stats := fmt.Sprintf("%d commands — %d enabled", len(m.libraryAll), enabled)
+	if enabled == 0 {
+		return stats
+	}
+	var locations []string
+	for _, location := range m.getCurrentCommandManager().SymlinkLocations() {
+		if count := byLocation[location]; count > 0 || location == config.SymlinkLocationUser || location == config.SymlinkLocationProject {
+			locations = append(locations, fmt.Sprintf("%d %s", count, location))
+		}
+	}
+	return stats + " (" + strings.Join(locations, " / ") + ")"
+}
+
+// libraryHeader is the library title with the library stats and the active filters, sort,
+// grouping, view and selection
+func (m *Model) libraryHeader() string {
 	libraryType := m.GetLibraryModeString()
 	var icon string
 	if m.libraryMode == LibraryModeUser {
@@ -164,13 +303,29 @@ func (m *Model) libraryView() string {
 	} else {
 		icon = "📁"
 	}
-	header := fmt.Sprintf("%s Command Library (%s)", icon, libraryType)
-	
-	// Include status message and main content
-	content := m.renderStatusMessage() + m.list.View()
-	footer := m.renderFooter()
-	
-	return centerView(header, content, footer, m.width)
+	header := fmt.Sprintf("%s Command Library (%s) — %s", icon, libraryType, m.libraryStats())
+	if m.modelFilter != "" {
+		header += fmt.Sprintf(" • model: %s", m.modelFilter)
+	}
+	if m.statusFilter != "" {
+		header += fmt.Sprintf(" • %s only", m.statusFilter)
+	}
+	if m.tagFilter != "" {
+		header += fmt.Sprintf(" • tag: %s", m.tagFilter)
+	}
+	if m.sortMode != SortByName {
+		header += fmt.Sprintf(" • sorted by: %s", m.sortMode)
+	}
+	if m.groupMode != GroupModeNone {
+		header += fmt.Sprintf(" • grouped by: %s", m.groupMode)
+	}
+	if m.activeView != "" {
+		header += fmt.Sprintf(" • view: %s", m.activeView)
+	}
+	if marked := len(m.markedCommands()); marked > 0 {
+		header += fmt.Sprintf(" • %d selected", marked)
+	}
+	return header
 }
 
 // renameView renders the rename input view
@@ -186,6 +341,13 @@ func (m *Model) renameView() string {
 			subtleStyle.Render(cmd.Description)))
 	}
 
+	if collision := m.renameCollision; collision != nil {
+		content.WriteString(warningStyle.Render(fmt.Sprintf("/%s is taken by %s.", collision.Command.QualifiedName(), collisionOwner(collision))))
+		content.WriteString("\n")
+		content.WriteString(subtleStyle.Render("Choose another name; the command is enabled under it."))
+		content.WriteString("\n\n")
+	}
+
 	content.WriteString("New name:\n")
 	content.WriteString(m.textInput.View())
 	
@@ -200,62 +362,153 @@ func (m *Model) renameView() string {
 	return centerView(header, content.String(), footer, m.width)
 }
 
-// helpView renders the help screen
-func (m *Model) helpView() string {
-	header := "Help"
+// collisionOwner describes what holds a taken name
+func collisionOwner(collision *commands.NameCollision) string {
+	if collision.Other != nil {
+		return collision.Other.RelativePath
+	}
+	return filepath.Base(collision.Path) + ", a file ccm does not manage"
+}
+
+// confirmDeleteView renders the delete confirmation dialog
+func (m *Model) confirmDeleteView() string {
+	header := "Delete Command"
 	
 	var content strings.Builder
-	helpItems := []struct {
-		key  string
-		desc string
-	}{
-		{"↑/↓, j/k", "Navigate up/down"},
-		{"Enter, t", "Toggle command enabled/disabled"},
-		{"r", "Rename selected command"},
-		{"l", "Toggle symlink location (👤 user / 📁 project)"},
-		{"s", "Switch library (👤 user / 📁 project)"},
-		{"i", "Browse and import repository commands"},
-		{"q", "Quit"},
-		{"h, ?", "Show this help screen"},
-		{"Ctrl+C", "Force quit"},
-		{"", ""},
-		{"Repository Browser:", ""},
-		{"i", "Import focused repository (or selected repositories)"},
-		{"Enter", "Select category or toggle repository selection"},
-		{"/", "Search repositories"},
-		{"c", "Enter custom GitHub URL"},
-		{"a", "Select all repositories"},
-		{"n", "Select none"},
-		{"p", "Preview selected command"},
-		{"Space", "Toggle repository selection"},
-		{"Tab", "Switch between search and results"},
-		{"Esc", "Go back or cancel"},
-	}
-
-	for _, item := range helpItems {
-		content.WriteString(fmt.Sprintf("  %s  %s\n", 
-			keyStyle.Render(item.key),
-			item.desc))
+	if m.deleteIndex >= 0 && m.deleteIndex < len(m.commands) {
+		cmd := m.commands[m.deleteIndex]
+		content.WriteString(fmt.Sprintf("Command: %s\n", 
+			highlightStyle.Render(cmd.DisplayName)))
+		content.WriteString(fmt.Sprintf("File: %s\n\n", 
+			subtleStyle.Render(cmd.RelativePath)))
+		if cmd.Enabled {
+			content.WriteString(warningStyle.Render("⚠️  This command is enabled and its symlink will be removed"))
+			content.WriteString("\n\n")
+		}
 	}
-
-	content.WriteString("\n")
-	content.WriteString(subtleStyle.Render("Commands are stored as .md files in the commands/ directory."))
+	
+	content.WriteString(dangerStyle.Render("Delete this command?"))
 	content.WriteString("\n")
-	content.WriteString(subtleStyle.Render("Enabled commands are symlinked to ~/.claude/commands/"))
+	content.WriteString(subtleStyle.Render("The file will be moved to the library's .trash directory."))
+
+	footer := "y: Delete • n/Esc: Cancel • Ctrl+C: Quit"
+	
+	return centerView(header, content.String(), footer, m.width)
+}
+
+// viewsView renders the saved library views quick menu
+func (m *Model) viewsView() string {
+	header := fmt.Sprintf("Saved Views (%s library)", m.GetLibraryModeString())
+	
+	var content strings.Builder
+	content.WriteString(m.renderStatusMessage())
+	
+	views := m.getSavedViews()
+	if len(views) == 0 {
+		content.WriteString(subtleStyle.Render("No saved views yet."))
+		content.WriteString("\n\n")
+		content.WriteString("Set up filters, sort and grouping in the library, then press n here to save them.\n")
+	}
+	
+	defaultView := ""
+	if tm := GetThemeManager(); tm != nil {
+		defaultView = tm.GetViews().Defaults[m.libraryModeKey()]
+	}
+	
+	for i, view := range views {
+		cursor := "  "
+		name := view.Name
+		if i == m.viewCursor {
+			cursor = "▶ "
+			name = highlightStyle.Render(name)
+		}
+		if view.Name == defaultView {
+			name += " ★"
+		}
+		content.WriteString(cursor + name + "\n")
+		content.WriteString("    " + subtleStyle.Render(describeView(view)) + "\n")
+	}
+	
 	content.WriteString("\n")
-	content.WriteString(subtleStyle.Render("All changes are saved immediately."))
+	content.WriteString(subtleStyle.Render("Current: " + describeView(m.currentView(""))))
+
+	footer := "Enter: Apply • n: Save Current • *: Toggle Default • x: Delete • Esc: Back"
+	
+	return centerView(header, content.String(), footer, m.width)
+}
+
+// saveViewView renders the name input for saving the current library view
+func (m *Model) saveViewView() string {
+	header := "Save View"
+	
+	var content strings.Builder
+	content.WriteString(m.renderStatusMessage())
+	content.WriteString(subtleStyle.Render(describeView(m.currentView(""))))
+	content.WriteString("\n\nView name:\n")
+	content.WriteString(m.textInput.View())
 
-	footer := "Press any key to return"
+	footer := "Enter: Save • Esc: Back"
 	
 	return centerView(header, content.String(), footer, m.width)
 }
 
+// describeView summarizes a view's filters, sort and grouping on one line
+func describeView(view theme.LibraryView) string {
+	parts := []string{}
+	if view.ModelFilter != "" {
+		parts = append(parts, "model: "+view.ModelFilter)
+	}
+	if view.StatusFilter != "" {
+		parts = append(parts, view.StatusFilter+" only")
+	}
+	if view.TagFilter != "" {
+		parts = append(parts, "tag: "+view.TagFilter)
+	}
+	if view.Sort != "" && view.Sort != SortByName.String() {
+		parts = append(parts, "sorted by "+view.Sort)
+	}
+	if view.Group != "" && view.Group != GroupModeNone.String() {
+		parts = append(parts, "grouped by "+view.Group)
+	}
+	if len(parts) == 0 {
+		return "all commands"
+	}
+	return strings.Join(parts, " • ")
+}
+
 // Note: Confirm quit view removed since changes are saved immediately
 
 // renderFooter renders the footer with key bindings
 func (m *Model) renderFooter() string {
+	k := m.keys
+	if m.state == StateLibrary && m.commandLineActive {
+		return m.commandLine.View() + "  " + subtleStyle.Render("Tab: Complete • Enter: Run • Esc: Cancel")
+	}
+	if m.state == StateLibrary && m.compactLayout() {
+		// Only the essentials fit; the help screen lists the rest
+		if len(m.markedCommands()) > 0 {
+			return joinHints(keyHint(k.Toggle, "Enable/Disable"), keyHint(k.Location, "Move"), "Esc: Clear", keyHint(k.Help, "Help"))
+		}
+		return joinHints(keyHint(k.Toggle, "Toggle"), keyHint(k.Preview, "Preview"), keyHint(k.Info, "Info"),
+			"Esc: Menu", keyHint(k.Quit, "Quit"), keyHint(k.Help, "Help"))
+	}
+	if m.state == StateLibrary && len(m.markedCommands()) > 0 {
+		return joinHints(
+			keyHint(k.Select, "Select"), keyHint(k.SelectAll, "All"), keyHint(k.SelectNone, "None"),
+			keyHint(k.Toggle, "Enable/Disable Selected"), keyHint(k.Location, "Move Selected"), keyHint(k.Pin, "Pin Selected"),
+			"Esc: Clear Selection", keyHint(k.Quit, "Quit"), keyHint(k.Help, "Help"),
+		)
+	}
 	if m.state == StateLibrary {
-		return "Enter/t: Toggle • r: Rename • l: Location • s: Switch Library • i: Import • Esc: Main Menu • q: Quit • h: Help"
+		edit := keyLabel(append(append([]string{}, k.Edit.Keys()...), k.EditInline.Keys()...)) + ": Edit"
+		return joinHints(
+			keyHint(k.Select, "Select"), keyHint(k.Toggle, "Toggle"), keyHint(k.Rename, "Rename"), edit,
+			keyHint(k.NewCommand, "New"), keyHint(k.Preview, "Preview"), keyHint(k.Info, "Info"), keyHint(k.Copy, "Copy"), keyHint(k.Duplicate, "Duplicate"), keyHint(k.Meta, "Frontmatter"), keyHint(k.Delete, "Delete"),
+			keyHint(k.Group, "Group"), keyHint(k.Location, "Location"), keyHint(k.Pin, "Pin"), keyHint(k.SwitchLibrary, "Switch Library"),
+			keyHint(k.ModelFilter, "Model Filter"), keyHint(k.StatusFilter, "Status"), keyHint(k.TagFilter, "Tag Filter"), keyHint(k.Sort, "Sort"),
+			keyHint(k.Views, "Views"), keyHint(k.Tags, "Tags"), keyHint(k.Search, "Search"), keyHint(k.Import, "Import"), keyHint(k.CommandLine, "Command"),
+			"Esc: Main Menu", keyHint(k.Quit, "Quit"), keyHint(k.Help, "Help"),
+		)
 	}
 	return "Enter/t: Toggle • r: Rename • l: Location • i: Browse/Import • q: Quit • h: Help"
 }
@@ -304,9 +557,10 @@ func (m *Model) categoryBrowseView() string {
 	var content strings.Builder
 	content.WriteString(subtleStyle.Render("Select a category to explore available repositories:"))
 	content.WriteString("\n\n")
-	content.WriteString(m.list.View())
+	content.WriteString(m.listView())
 	
-	footer := "Enter: Browse Category • /: Search • c: Custom URL • Esc: Cancel"
+	k := m.remoteKeys
+	footer := m.pagedFooter(joinHints("Enter: Browse Category", keyHint(k.Search, "Search"), keyHint(k.CustomURL, "Custom URL"), "Esc: Cancel", keyHint(k.Help, "Help")))
 	
 	return centerView(header, content.String(), footer, m.width)
 }
@@ -316,7 +570,14 @@ func (m *Model) repositoryBrowseView() string {
 	// Header with category info
 	categoryName := "All Repositories"
 	categoryIcon := "📦"
-	if m.currentCategory != "" {
+	if m.currentCategory == registry.TrendingCategoryKey {
+		if trending, ok := m.registryManager.TrendingCategory(); ok {
+			categoryName = trending.Name
+			categoryIcon = trending.Icon
+		}
+	} else if m.currentCategory == registry.FavoritesCategoryKey {
+		categoryName, categoryIcon = "Favorites", "⭐"
+	} else if m.currentCategory != "" {
 		if categories := m.registryManager.GetCategories(); categories != nil {
 			if cat, exists := categories[m.currentCategory]; exists {
 				categoryName = cat.Name
@@ -328,10 +589,12 @@ func (m *Model) repositoryBrowseView() string {
 	
 	var content strings.Builder
 	content.WriteString(subtleStyle.Render("Select a repository to browse its available commands:"))
+	content.WriteString(m.renderStatusMessage())
 	content.WriteString("\n\n")
-	content.WriteString(m.list.View())
+	content.WriteString(m.listView())
 
-	footer := "Enter: Browse Commands • /: Search • c: Custom URL • Esc: Back"
+	k := m.remoteKeys
+	footer := m.pagedFooter(joinHints(keyHint(k.Open, "Browse Commands"), keyHint(k.Star, "Star"), keyHint(k.Search, "Search"), keyHint(k.CustomURL, "Custom URL"), "Esc: Back", keyHint(k.Help, "Help")))
 	
 	return centerView(header, content.String(), footer, m.width)
 }
@@ -352,11 +615,12 @@ func (m *Model) searchBrowseView() string {
 		content.WriteString(fmt.Sprintf("Found %d repositories matching \"%s\"", 
 			len(m.filteredRepos), m.searchQuery))
 	}
+	content.WriteString(m.renderStatusMessage())
 	content.WriteString("\n\n")
 
 	// Results list (if any)
 	if len(m.filteredRepos) > 0 {
-		content.WriteString(m.list.View())
+		content.WriteString(m.listView())
 	}
 
 	// Instructions
@@ -364,7 +628,8 @@ func (m *Model) searchBrowseView() string {
 	if m.searchInput.Focused() {
 		footer = "Tab: Switch to Results • Esc: Clear/Exit • Enter: Search"
 	} else {
-		footer = "Tab: Search Input • Enter: Browse Commands • c: Custom URL • Esc: Exit"
+		k := m.remoteKeys
+		footer = m.pagedFooter(joinHints(keyHint(k.SwitchFocus, "Search Input"), "Enter: Browse Commands", keyHint(k.Star, "Star"), keyHint(k.CustomURL, "Custom URL"), "Esc: Exit", keyHint(k.Help, "Help")))
 	}
 	
 	return centerView(header, content.String(), footer, m.width)
@@ -419,19 +684,32 @@ func (m *Model) remoteLoadingView() string {
 			subtleStyle.Render(m.remoteRepo.Path)))
 	}
 
-	// Simple loading spinner
-	spinner := []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
-	// Use a simple time-based animation (this is a simplified approach)
-	spinnerChar := spinner[0] // In a real implementation, this would cycle
-	
-	content.WriteString(fmt.Sprintf("%s Connecting to repository...\n", spinnerChar))
-	content.WriteString("📦 Scanning for commands...\n")
-	content.WriteString("🔄 Loading command details...\n")
-	content.WriteString("⚠️  Checking for conflicts...\n\n")
-
-	content.WriteString(subtleStyle.Render("Please wait..."))
+	// Steps before the one the task last reported are done
+	var progress taskProgress
+	if task := m.findTask(m.remoteLoadTask); task != nil {
+		progress = task.progress
+	}
+	current := 0
+	for i, step := range remoteLoadSteps {
+		if step == progress.step {
+			current = i
+		}
+	}
+	for i, step := range remoteLoadSteps {
+		switch {
+		case i < current:
+			content.WriteString(successStyle.Render("✓ "+step) + "\n")
+		case i == current:
+			content.WriteString(m.spinner.View() + " " + highlightStyle.Render(step) + "\n")
+			if progress.step == step && progress.total > 0 {
+				content.WriteString("  " + m.renderProgressBar(progress.done, progress.total) + "\n")
+			}
+		default:
+			content.WriteString(subtleStyle.Render("· "+step) + "\n")
+		}
+	}
 
-	footer := "Loading... Please wait"
+	footer := "Esc: Cancel • Enter: Continue in Background • " + keyHint(m.keys.Tasks, "Tasks")
 	
 	return centerView(header, content.String(), footer, m.width)
 }
@@ -449,11 +727,11 @@ func (m *Model) remoteSelectView() string {
 	// Show selection summary
 	selectedCount := 0
 	conflictCount := 0
-	for i, cmd := range m.remoteCommands {
+	for i := range m.remoteCommands {
 		if m.remoteSelected[i] {
 			selectedCount++
 		}
-		if cmd.LocalExists {
+		if m.remoteImportCommand(i).LocalExists {
 			conflictCount++
 		}
 	}
@@ -466,9 +744,12 @@ func (m *Model) remoteSelectView() string {
 	content.WriteString("\n\n")
 
 	// Command list
-	content.WriteString(m.list.View())
+	content.WriteString(m.listView())
 
-	footer := "Enter: Toggle • p: Preview • a: Select All • n: Select None • i: Import • Esc: Cancel"
+	k := m.remoteKeys
+	footer := m.pagedFooter(joinHints(keyHint(k.Toggle, "Toggle"), keyHint(k.Preview, "Preview"), keyHint(k.Analyze, "Analyze"),
+		keyHint(k.SelectAll, "Select All"), keyHint(k.SelectNone, "Select None"), keyHint(k.Rename, "Rename"), keyHint(k.Prefix, "Prefix"),
+		keyHint(k.Import, "Import"), "Esc: Cancel", keyHint(k.Help, "Help")))
 	
 	return centerView(header, content.String(), footer, m.width)
 }
@@ -484,21 +765,46 @@ func (m *Model) remoteImportView() string {
 		}
 	}
 
-	var content strings.Builder
-	content.WriteString(fmt.Sprintf("Importing %d commands...\n\n", selectedCount))
-
-	// Import animation
-	content.WriteString("📥 Processing selected commands...\n")
-	content.WriteString("💾 Writing files to disk...\n")
-	content.WriteString("🔄 Updating configuration...\n\n")
+	var progress taskProgress
+	if task := m.findTask(m.remoteImportTask); task != nil {
+		progress = task.progress
+	}
+	if progress.total == 0 {
+		progress.total = selectedCount // Nothing reported yet
+	}
 
-	content.WriteString(subtleStyle.Render("Please wait..."))
+	var content strings.Builder
+	if m.remoteRepo != nil {
+		content.WriteString(fmt.Sprintf("From: %s\n\n", highlightStyle.Render(m.remoteRepo.FullName())))
+	}
+	content.WriteString(m.renderProgressBar(progress.done, progress.total))
+	content.WriteString("\n\n")
+	if progress.step != "" {
+		content.WriteString(fmt.Sprintf("Importing %s\n", highlightStyle.Render(progress.step)))
+	} else {
+		content.WriteString(subtleStyle.Render("Starting import...") + "\n")
+	}
 
-	footer := ""
+	footer := "Esc: Cancel • Enter: Continue in Background • " + keyHint(m.keys.Tasks, "Tasks")
 	
 	return centerView(header, content.String(), footer, m.width)
 }
 
+// renderProgressBar renders a bar with a "17/42" count after it
+func (m *Model) renderProgressBar(done, total int) string {
+	percent := 0.0
+	if total > 0 {
+		percent = float64(done) / float64(total)
+	}
+	bar := m.progressBar
+	bar.Width = min(m.width-20, 60)
+	bar.FullColor = string(primaryColor.Dark)
+	if !lipgloss.HasDarkBackground() {
+		bar.FullColor = string(primaryColor.Light)
+	}
+	return bar.ViewAs(percent) + subtleStyle.Render(fmt.Sprintf(" %d/%d", done, total))
+}
+
 // remotePreviewView renders the command preview view
 func (m *Model) remotePreviewView() string {
 	if m.previewCommand == nil {
@@ -513,6 +819,13 @@ func (m *Model) remotePreviewView() string {
 	content.WriteString(fmt.Sprintf("Name: %s\n", highlightStyle.Render(m.previewCommand.Name)))
 	content.WriteString(fmt.Sprintf("Path: %s\n", subtleStyle.Render(m.previewCommand.Path)))
 	content.WriteString(fmt.Sprintf("Description: %s\n", m.previewCommand.Description))
+	if m.previewCommand.Model != "" {
+		content.WriteString(fmt.Sprintf("Model: %s\n", highlightStyle.Render(models.Badge(m.previewCommand.Model))))
+		if warning := models.DeprecationWarning(m.previewCommand.Model); warning != "" {
+			content.WriteString(warningStyle.Render("⚠️  " + warning))
+			content.WriteString("\n")
+		}
+	}
 	if m.previewCommand.LocalExists {
 		content.WriteString(warningStyle.Render("⚠️  A local command with this name already exists"))
 		content.WriteString("\n")
@@ -520,13 +833,14 @@ func (m *Model) remotePreviewView() string {
 	content.WriteString("\n")
 	
 	// Content divider
-	content.WriteString(strings.Repeat("─", min(m.width-4, 80)))
+	content.WriteString(strings.Repeat("─", m.previewWidth()))
 	content.WriteString("\n\n")
 	
 	// Command content
 	if m.previewCommand.Content != "" {
-		// Split content into lines and limit display height
-		lines := strings.Split(m.previewCommand.Content, "\n")
+		// Render the body (metadata is shown above) and limit display height
+		_, body := commands.SplitFrontmatter(m.previewCommand.Content)
+		lines := m.markdownLines(body)
 		maxLines := m.height - 12 // Reserve space for header, metadata, and footer
 		if maxLines < 5 {
 			maxLines = 5
@@ -548,7 +862,82 @@ func (m *Model) remotePreviewView() string {
 		content.WriteString("\n")
 	}
 	
-	footer := "p/Esc: Back • Ctrl+C: Quit"
+	footer := "x: Analyze • p/Esc: Back • Ctrl+C: Quit"
+	
+	return centerView(header, content.String(), footer, m.width)
+}
+
+// remoteAnalysisView renders the code block risk analysis for a command
+func (m *Model) remoteAnalysisView() string {
+	if m.analysisCommand == nil || m.analysisResult == nil {
+		return "No command to analyze"
+	}
+	
+	header := fmt.Sprintf("🛡️  Analysis: %s", m.analysisCommand.Name)
+	analysis := m.analysisResult
+	
+	var content strings.Builder
+	
+	// Summary
+	content.WriteString(fmt.Sprintf("Code blocks: %d  •  Shell: %d  •  Executes: %d  •  Warnings: %d\n",
+		len(analysis.Blocks), analysis.ShellCount, analysis.ExecuteCount, analysis.WarningCount))
+	if len(analysis.AllowedTools) > 0 {
+		content.WriteString(fmt.Sprintf("Allowed tools: %s\n", subtleStyle.Render(strings.Join(analysis.AllowedTools, ", "))))
+	}
+	if analysis.BashAllowed {
+		content.WriteString(warningStyle.Render("⚠️  This command grants Bash tool permissions"))
+		content.WriteString("\n")
+	}
+	content.WriteString("\n")
+	
+	if len(analysis.Blocks) == 0 {
+		content.WriteString(successStyle.Render("✅ No code blocks or shell snippets found"))
+		content.WriteString("\n")
+	}
+	
+	// Block list
+	for _, block := range analysis.Blocks {
+		language := block.Language
+		if language == "" {
+			language = "text"
+		}
+		kind := "block"
+		if block.Inline {
+			kind = "inline"
+		}
+		
+		label := fmt.Sprintf("Line %d • %s %s", block.Line, language, kind)
+		switch {
+		case len(block.Warnings) > 0:
+			content.WriteString(dangerStyle.Render("✗ " + label))
+		case block.Executes:
+			content.WriteString(warningStyle.Render("▶ " + label + " (runs with Bash permissions)"))
+		case block.IsShell:
+			content.WriteString(highlightStyle.Render("$ " + label))
+		default:
+			content.WriteString(subtleStyle.Render("· " + label))
+		}
+		content.WriteString("\n")
+		
+		for _, warning := range block.Warnings {
+			content.WriteString(dangerStyle.Render("    ⚠️ " + warning))
+			content.WriteString("\n")
+		}
+		
+		// Show the first few lines of shell snippets so reviewers can assess them
+		if block.IsShell {
+			lines := strings.Split(block.Content, "\n")
+			if len(lines) > 5 {
+				lines = append(lines[:5], "...")
+			}
+			for _, line := range lines {
+				content.WriteString(subtleStyle.Render("    " + line))
+				content.WriteString("\n")
+			}
+		}
+	}
+	
+	footer := "x/Esc: Back • Ctrl+C: Quit"
 	
 	return centerView(header, content.String(), footer, m.width)
 }
@@ -564,7 +953,14 @@ func (m *Model) remoteResultsView() string {
 			content.WriteString("🎉 " + successStyle.Render(fmt.Sprintf("Successfully imported %d commands:", len(m.remoteResult.Imported))))
 			content.WriteString("\n")
 			for _, name := range m.remoteResult.Imported {
-				content.WriteString(fmt.Sprintf("  ✅ %s\n", name))
+				switch {
+				case m.remoteResult.Renamed[name] != "":
+					content.WriteString(fmt.Sprintf("  ✅ %s (kept both, imported as %s)\n", name, m.remoteResult.Renamed[name]))
+				case m.remoteResult.Conflicts[name] == remote.ConflictOverwrite:
+					content.WriteString(fmt.Sprintf("  ✅ %s (overwrote the local command)\n", name))
+				default:
+					content.WriteString(fmt.Sprintf("  ✅ %s\n", name))
+				}
 			}
 			content.WriteString("\n")
 		}
@@ -585,7 +981,8 @@ func (m *Model) remoteResultsView() string {
 			for i, name := range m.remoteResult.Failed {
 				content.WriteString(fmt.Sprintf("  ❌ %s: %s\n", name, m.remoteResult.Errors[i]))
 			}
-			content.WriteString("\n")
+			content.WriteString(subtleStyle.Render("🔁 Failures were queued; run 'ccm imports retry' once the problem is fixed."))
+			content.WriteString("\n\n")
 		}
 
 		if len(m.remoteResult.Imported) > 0 {
@@ -615,7 +1012,12 @@ func (m *Model) remoteRepoDetailsView() string {
 		subtleStyle.Render(m.customRepoInput.Author)))
 	
 	// Description input
-	content.WriteString("Description:\n")
+	descStyle := subtleStyle
+	if m.repoDetailsField == 0 {
+		descStyle = highlightStyle
+	}
+	content.WriteString(descStyle.Render("Description:"))
+	content.WriteString("\n")
 	content.WriteString(m.textInput.View())
 	
 	// Show validation errors
@@ -625,6 +1027,16 @@ func (m *Model) remoteRepoDetailsView() string {
 	}
 	content.WriteString("\n\n")
 	
+	// Tags input, pre-populated from the repository's GitHub topics
+	tagsStyle := subtleStyle
+	if m.repoDetailsField == 1 {
+		tagsStyle = highlightStyle
+	}
+	content.WriteString(tagsStyle.Render("Tags:"))
+	content.WriteString("\n")
+	content.WriteString(m.repoTagsInput.View())
+	content.WriteString("\n\n")
+	
 	// Show current category selection status
 	if m.customRepoInput.Category.CategoryKey != "" {
 		if m.customRepoInput.Category.IsNew {
@@ -636,7 +1048,7 @@ func (m *Model) remoteRepoDetailsView() string {
 				highlightStyle.Render(categoryName)))
 		}
 	} else {
-		content.WriteString(subtleStyle.Render("Press Tab to select category"))
+		content.WriteString(subtleStyle.Render("Press Tab on Tags to select category"))
 		content.WriteString("\n")
 	}
 
@@ -646,7 +1058,7 @@ func (m *Model) remoteRepoDetailsView() string {
 		content.WriteString(dangerStyle.Render("Error: " + m.remoteError))
 	}
 
-	footer := "Tab: Select Category • Enter: Continue • Esc: Back to URL"
+	footer := "Tab: Next Field • Shift+Tab: Previous • Enter: Continue • Esc: Back to URL"
 	
 	return centerView(header, content.String(), footer, m.width)
 }
@@ -664,9 +1076,9 @@ func (m *Model) remoteCategoryView() string {
 	var content strings.Builder
 	content.WriteString(subtleStyle.Render("Choose a category for your repository:"))
 	content.WriteString("\n\n")
-	content.WriteString(m.list.View())
+	content.WriteString(m.listView())
 
-	footer := "Enter: Select • Esc: Back"
+	footer := m.pagedFooter("Enter: Select • Esc: Back")
 	
 	return centerView(header, content.String(), footer, m.width)
 }
@@ -679,7 +1091,13 @@ func (m *Model) newCategoryCreationView() string {
 	content.WriteString(subtleStyle.Render("Create a new category for your repositories:"))
 	content.WriteString("\n\n")
 	
-	content.WriteString("Category Name:\n")
+	// Field 1: Category name
+	nameStyle := subtleStyle
+	if m.categoryField == 0 {
+		nameStyle = highlightStyle
+	}
+	content.WriteString(nameStyle.Render("Category Name:"))
+	content.WriteString("\n")
 	content.WriteString(m.categoryInput.View())
 	
 	// Show validation errors
@@ -689,11 +1107,48 @@ func (m *Model) newCategoryCreationView() string {
 	}
 	content.WriteString("\n\n")
 	
-	content.WriteString(subtleStyle.Render("The category will be created with a default icon and description."))
+	// Field 2: Description
+	descStyle := subtleStyle
+	if m.categoryField == 1 {
+		descStyle = highlightStyle
+	}
+	content.WriteString(descStyle.Render("Description:"))
+	content.WriteString("\n")
+	content.WriteString(m.categoryDescInput.View())
+	content.WriteString("\n\n")
+	
+	// Field 3: Icon picker
+	iconStyle := subtleStyle
+	if m.categoryField == 2 {
+		iconStyle = highlightStyle
+	}
+	content.WriteString(iconStyle.Render("Icon:"))
+	content.WriteString("\n")
+	for i, icon := range categoryIcons {
+		if i == m.categoryIconIndex {
+			content.WriteString(highlightStyle.Render("[" + icon + "]"))
+		} else {
+			content.WriteString(" " + icon + " ")
+		}
+	}
+	content.WriteString("\n\n")
+	
+	// Preview as the category will appear in the repository browser
+	category := m.newCategoryInput()
+	name := category.Name
+	if name == "" {
+		name = "New Category"
+		if strings.TrimSpace(m.categoryDescInput.Value()) == "" {
+			category.Description = "Custom category: " + name
+		}
+	}
+	content.WriteString(subtleStyle.Render("Preview:"))
 	content.WriteString("\n")
-	content.WriteString(subtleStyle.Render("You can customize these later."))
+	content.WriteString("  " + highlightStyle.Render(category.Icon+" "+name))
+	content.WriteString("\n")
+	content.WriteString("  " + subtleStyle.Render(category.Description))
 
-	footer := "Enter: Create Category • Esc: Back to Category List"
+	footer := "Tab: Next Field • ←/→: Choose Icon • Enter: Create Category • Esc: Back to Category List"
 	
 	return centerView(header, content.String(), footer, m.width)
 }
@@ -703,12 +1158,35 @@ func (m *Model) reportIssueView() string {
 	header := "Request Feature or Report Issue"
 	
 	var content strings.Builder
-	content.WriteString(subtleStyle.Render("Help us improve ccm by reporting bugs or requesting features:"))
+	if repo := GetThemeManager().GetAppConfig().Report.Repository; repo != "" {
+		content.WriteString(subtleStyle.Render("Issues are filed against " + repo + " (report.repository):"))
+	} else {
+		content.WriteString(subtleStyle.Render("Help us improve ccm by reporting bugs or requesting features:"))
+	}
 	content.WriteString("\n\n")
 	
-	// Field 1: Issue Title
+	// Field 1: Issue Type
+	typeStyle := subtleStyle
+	if m.issueCurrentField == issueFieldType {
+		typeStyle = highlightStyle
+	}
+	content.WriteString(typeStyle.Render("Issue Type:"))
+	content.WriteString("\n")
+	var types []string
+	for _, name := range remote.IssueTypeNames {
+		if name == m.issueType {
+			types = append(types, highlightStyle.Render("(•) "+name))
+		} else {
+			types = append(types, subtleStyle.Render("( ) "+name))
+		}
+	}
+	content.WriteString(strings.Join(types, "   "))
+	content.WriteString(subtleStyle.Render("   → label: " + remote.IssueTypes[m.issueType]))
+	content.WriteString("\n\n")
+	
+	// Field 2: Issue Title
 	titleStyle := subtleStyle
-	if m.issueCurrentField == 0 {
+	if m.issueCurrentField == issueFieldTitle {
 		titleStyle = highlightStyle
 	}
 	content.WriteString(titleStyle.Render("Issue Title:"))
@@ -723,9 +1201,9 @@ func (m *Model) reportIssueView() string {
 	}
 	content.WriteString("\n")
 	
-	// Field 2: Issue Body
+	// Field 3: Issue Body
 	bodyStyle := subtleStyle
-	if m.issueCurrentField == 1 {
+	if m.issueCurrentField == issueFieldBody {
 		bodyStyle = highlightStyle
 	}
 	content.WriteString(bodyStyle.Render("Issue Description:"))
@@ -733,6 +1211,34 @@ func (m *Model) reportIssueView() string {
 	content.WriteString(m.issueBodyInput.View())
 	content.WriteString("\n")
 	
+	// Live count against the limit, turning to a warning as it gets close
+	count := fmt.Sprintf("%d/%d characters", m.issueBodyInput.Length(), m.issueBodyInput.CharLimit)
+	switch limit := m.issueBodyInput.CharLimit; {
+	case m.issueBodyInput.Length() >= limit:
+		count = dangerStyle.Render(count + " (limit reached)")
+	case m.issueBodyInput.Length() >= limit*9/10:
+		count = warningStyle.Render(count)
+	default:
+		count = subtleStyle.Render(count)
+	}
+	content.WriteString(count)
+	content.WriteString("\n")
+	
+	// Field 4: Opt-in diagnostics
+	checkbox := "[ ]"
+	if m.issueDiagnostics {
+		checkbox = "[✓]"
+	}
+	diagnosticsStyle := subtleStyle
+	if m.issueCurrentField == issueFieldDiagnostics {
+		diagnosticsStyle = highlightStyle
+	}
+	content.WriteString("\n")
+	content.WriteString(diagnosticsStyle.Render(checkbox + " Attach diagnostics"))
+	content.WriteString("\n")
+	content.WriteString(subtleStyle.Render(fmt.Sprintf("    ccm version, OS/arch, terminal size, gh status and the last %d log lines", diagnosticLogLines)))
+	content.WriteString("\n")
+	
 	// Show validation errors for body
 	if errorMsg, hasError := m.validationErrors["body"]; hasError {
 		content.WriteString(dangerStyle.Render("⚠️ " + errorMsg))
@@ -752,7 +1258,7 @@ func (m *Model) reportIssueView() string {
 		content.WriteString("\n")
 	}
 	
-	footer := "Tab: Switch Field • Enter: Submit • Esc: Cancel • Ctrl+C: Quit"
+	footer := "Tab: Switch Field • ←/→: Change Type • Space: Toggle Diagnostics • Ctrl+S: Preview (or Enter outside the description) • Esc: Cancel • Ctrl+C: Quit"
 	
 	return centerView(header, content.String(), footer, m.width)
 }
@@ -764,9 +1270,10 @@ func (m *Model) settingsView() string {
 	var content strings.Builder
 	content.WriteString(subtleStyle.Render("Configure themes and preferences:"))
 	content.WriteString("\n\n")
-	content.WriteString(m.list.View())
+	content.WriteString(m.renderStatusMessage())
+	content.WriteString(m.listView())
 	
-	footer := "Enter: Select • Esc: Back to Main Menu • q: Quit • h: Help"
+	footer := m.pagedFooter("Enter: Select • Esc: Back to Main Menu • q: Quit • h: Help")
 	
 	return centerView(header, content.String(), footer, m.width)
 }
@@ -783,28 +1290,114 @@ func (m *Model) themeSettingsView() string {
 	themeManager := GetThemeManager()
 	currentTheme := themeManager.GetCurrentTheme()
 	content.WriteString(fmt.Sprintf("Current: %s\n", highlightStyle.Render(currentTheme.Name)))
-	content.WriteString(fmt.Sprintf("%s\n\n", subtleStyle.Render(currentTheme.Description)))
-	
-	// Theme list
-	content.WriteString(m.list.View())
-	
-	// Show theme preview if available
-	if len(themeManager.GetAvailableThemes()) > 0 {
-		themes := themeManager.GetAvailableThemes()
-		selectedIndex := m.list.Index()
-		if selectedIndex >= 0 && selectedIndex < len(themes) {
-			selectedTheme := themes[selectedIndex]
-			preview := selectedTheme.GeneratePreview()
-			content.WriteString("\n")
-			content.WriteString("Preview: " + preview.ColorBar)
+	content.WriteString(fmt.Sprintf("%s\n", subtleStyle.Render(currentTheme.Description)))
+	if key, _, ok := themeManager.ActiveProfileBinding(); ok {
+		content.WriteString(subtleStyle.Render(fmt.Sprintf("Bound to terminal profile %q; applying a theme updates this binding", key)) + "\n")
+	}
+	for _, problem := range theme.UserThemeProblems() {
+		content.WriteString(warningStyle.Render(ansi.Truncate(fmt.Sprintf("⚠️  Skipped %s: %v", problem.File, problem.Err), contentWidth(m.width), "…")) + "\n")
+	}
+	content.WriteString("\n")
+	
+	// Theme list, with a preview of the highlighted theme
+	content.WriteString(m.themePickerListView())
+	
+	footer := m.pagedFooter("Enter: Apply Theme • p: Preview • Esc: Back to Settings • q: Quit")
+	
+	return centerView(header, content.String(), footer, m.width)
+}
+
+// renderStartupNotices renders warnings about state that diverged while ccm was not running
+func (m *Model) renderStartupNotices() string {
+	return m.renderIntegrityBanner() + m.renderProjectBanner()
+}
+
+// renderProjectBanner renders a warning when the project library differs from .claude/ccm.yaml
+func (m *Model) renderProjectBanner() string {
+	if len(m.projectDrift) == 0 {
+		return ""
+	}
+	
+	return "\n" + warningStyle.Render("📌 "+project.FileName+": "+m.projectDrift.Summary()) + "\n" +
+		subtleStyle.Render("   Press "+m.keys.Reconcile.Help().Key+" to reconcile") + "\n"
+}
+
+// reconcileView renders the per-item reconciliation screen for the pinned project configuration
+func (m *Model) reconcileView() string {
+	header := "Reconcile with " + project.FileName
+	
+	var content strings.Builder
+	content.WriteString(subtleStyle.Render("The project library differs from " + m.projectConfig.Path()))
+	content.WriteString("\n\n")
+	
+	for i, item := range m.projectDrift {
+		cursor := "  "
+		description := item.Describe()
+		if i == m.reconcileCursor {
+			cursor = "▶ "
+			description = highlightStyle.Render(description)
+		}
+		
+		var action string
+		switch m.reconcileActions[i] {
+		case ReconcileFix:
+			action = successStyle.Render("[" + reconcileFixLabel(item) + "]")
+		case ReconcileSkip:
+			action = subtleStyle.Render("[ignore once]")
+		case ReconcileRemember:
+			action = warningStyle.Render("[ignore always]")
 		}
+		
+		content.WriteString(fmt.Sprintf("%s%s  %s\n", cursor, action, description))
 	}
 	
-	footer := "Enter: Apply Theme • p: Preview • Esc: Back to Settings • q: Quit"
+	if exceptions := m.projectConfig.Exceptions(); len(exceptions) > 0 {
+		content.WriteString("\n")
+		content.WriteString(subtleStyle.Render(fmt.Sprintf("Already ignored on this machine (%s): %s",
+			project.LocalFileName, strings.Join(exceptions, ", "))))
+	}
+
+	footer := "↑/↓: Select • Space/←/→: Change Action • Enter: Apply • Esc: Cancel"
 	
 	return centerView(header, content.String(), footer, m.width)
 }
 
+// reconcileFixLabel describes what fixing a drift item will do
+func reconcileFixLabel(item project.DriftItem) string {
+	switch item.Kind {
+	case project.DriftMissing:
+		if item.Repository == "" {
+			return "cannot fix"
+		}
+		return "import & enable"
+	case project.DriftDisabled:
+		return "enable"
+	case project.DriftMisplaced:
+		return "move to " + string(item.Location)
+	case project.DriftExtra:
+		return "disable"
+	}
+	return "fix"
+}
+
+// renderIntegrityBanner renders a warning when enabled commands are out of sync with their symlinks
+func (m *Model) renderIntegrityBanner() string {
+	if !m.HasIntegrityIssues() {
+		return ""
+	}
+	
+	var lines []string
+	if !m.projectIntegrity.IsHealthy() {
+		lines = append(lines, "📁 Project library: "+m.projectIntegrity.Summary())
+	}
+	if !m.userIntegrity.IsHealthy() {
+		lines = append(lines, "👤 User library: "+m.userIntegrity.Summary())
+	}
+	
+	return "\n" + warningStyle.Render("⚠️  "+strings.Join(lines, "\n   ")) + "\n" +
+		subtleStyle.Render("   Press "+m.keys.Fix.Help().Key+" to fix") + "\n"
+}
+
 // renderStatusMessage renders a status message if one is set
 func (m *Model) renderStatusMessage() string {
 	if !m.showStatus || m.statusMessage == "" {
@@ -812,5 +1405,8 @@ func (m *Model) renderStatusMessage() string {
 	}
 	
 	style := m.getStatusStyle()
+	if m.statusFading {
+		style = subtleStyle
+	}
 	return "\n" + style.Render("● " + m.statusMessage) + "\n"
 }
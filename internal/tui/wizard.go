@@ -0,0 +1,270 @@
+package tui
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/shel-corp/Claude-command-manager/internal/templates"
+)
+
+// Steps of the new-command wizard
+const (
+	wizardStepName = iota
+	wizardStepDescription
+	wizardStepArgumentHint
+	wizardStepTemplate
+	wizardStepReview
+)
+
+// newCommandWizard holds the answers collected by the new-command wizard
+type newCommandWizard struct {
+	step      int
+	inputs    [wizardStepTemplate]textinput.Model // Name, description and argument hint
+	templates []templates.Template
+	template  int  // Selected entry in templates
+	enable    bool // Enable the command once it is created
+	err       string
+}
+
+// StartNewCommand opens the wizard for creating a command in the active library
+func (m *Model) StartNewCommand() tea.Cmd {
+	tmpls, err := templates.List()
+	if err != nil {
+		m.setStatus(fmt.Sprintf("Failed to load templates: %v", err), StatusError)
+		return nil
+	}
+
+	wizard := newCommandWizard{templates: tmpls, enable: true}
+	placeholders := [wizardStepTemplate]string{
+		"command name, or dir/name for a namespace",
+		"what the command does",
+		"e.g. [file] [focus] (optional)",
+	}
+	for i := range wizard.inputs {
+		input := textinput.New()
+		input.Placeholder = placeholders[i]
+		input.CharLimit = 200
+		input.Width = 60
+		wizard.inputs[i] = input
+	}
+	for i, tmpl := range tmpls {
+		if tmpl.Name == templates.DefaultTemplate {
+			wizard.template = i
+		}
+	}
+
+	m.newCommand = wizard
+	m.state = StateNewCommand
+	return m.newCommand.inputs[wizardStepName].Focus()
+}
+
+// setWizardStep moves the wizard to step, focusing its input if it has one
+func (m *Model) setWizardStep(step int) tea.Cmd {
+	wizard := &m.newCommand
+	wizard.err = ""
+	for i := range wizard.inputs {
+		wizard.inputs[i].Blur()
+	}
+	wizard.step = step
+	if step < wizardStepTemplate {
+		return wizard.inputs[step].Focus()
+	}
+	return nil
+}
+
+// wizardPath returns the library-relative path the command will be created at
+func (m *Model) wizardPath() string {
+	return strings.TrimSuffix(strings.TrimSpace(m.newCommand.inputs[wizardStepName].Value()), ".md") + ".md"
+}
+
+// validateWizardStep checks the answer for the current step before moving on
+func (m *Model) validateWizardStep() bool {
+	wizard := &m.newCommand
+	switch wizard.step {
+	case wizardStepName:
+		name := strings.TrimSpace(wizard.inputs[wizardStepName].Value())
+		if name == "" {
+			wizard.err = "A name is required"
+			return false
+		}
+		if strings.ContainsAny(name, " \t") {
+			wizard.err = "Names cannot contain spaces"
+			return false
+		}
+	case wizardStepDescription:
+		if strings.TrimSpace(wizard.inputs[wizardStepDescription].Value()) == "" {
+			wizard.err = "A description is required"
+			return false
+		}
+	}
+	return true
+}
+
+// CreateWizardCommand renders the chosen template and creates the command
+func (m *Model) CreateWizardCommand() tea.Cmd {
+	wizard := &m.newCommand
+	tmpl := wizard.templates[wizard.template]
+	path := m.wizardPath()
+
+	content, err := tmpl.Render(templates.Values{
+		Name:         strings.TrimSuffix(filepath.Base(path), ".md"),
+		Description:  strings.TrimSpace(wizard.inputs[wizardStepDescription].Value()),
+		ArgumentHint: strings.TrimSpace(wizard.inputs[wizardStepArgumentHint].Value()),
+	})
+	if err != nil {
+		wizard.err = err.Error()
+		return nil
+	}
+
+	manager := m.getCurrentCommandManager()
+	cmd, err := manager.CreateCommand(path, content)
+	if err != nil {
+		wizard.err = err.Error()
+		return nil
+	}
+	m.logAction(actionCreated, cmd.DisplayName)
+	status, statusType := fmt.Sprintf("Created command: %s", cmd.DisplayName), StatusSuccess
+	if wizard.enable {
+		if err := manager.EnableCommand(cmd); err != nil {
+			status, statusType = fmt.Sprintf("%s, but enabling it failed: %v", status, err), StatusWarning
+		} else {
+			m.logAction(actionEnabled, cmd.DisplayName)
+			status = fmt.Sprintf("Created and enabled command: %s", cmd.DisplayName)
+		}
+	}
+
+	m.state = StateLibrary
+	if err := m.getCurrentConfigManager().Save(); err != nil {
+		return func() tea.Msg {
+			return ErrorMsg{Error: err}
+		}
+	}
+	m.setStatus(status, statusType)
+	return func() tea.Msg {
+		return RefreshMsg{}
+	}
+}
+
+// handleNewCommandStateKeys handles keys in the new-command wizard
+func (m *Model) handleNewCommandStateKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	wizard := &m.newCommand
+	switch msg.String() {
+	case "ctrl+c":
+		return m, m.Quit()
+	case "esc":
+		m.state = StateLibrary
+		return m, nil
+	case "shift+tab":
+		if wizard.step > wizardStepName {
+			return m, m.setWizardStep(wizard.step - 1)
+		}
+		return m, nil
+	case "enter", "tab":
+		if !m.validateWizardStep() {
+			return m, nil
+		}
+		if wizard.step == wizardStepReview {
+			if msg.String() == "tab" {
+				return m, nil
+			}
+			return m, m.CreateWizardCommand()
+		}
+		return m, m.setWizardStep(wizard.step + 1)
+	}
+
+	switch wizard.step {
+	case wizardStepTemplate:
+		switch msg.String() {
+		case "up", "k":
+			if wizard.template > 0 {
+				wizard.template--
+			}
+		case "down", "j":
+			if wizard.template < len(wizard.templates)-1 {
+				wizard.template++
+			}
+		}
+	case wizardStepReview:
+		switch msg.String() {
+		case " ", "y", "n":
+			wizard.enable = msg.String() == "y" || (msg.String() == " " && !wizard.enable)
+		}
+	default:
+		wizard.err = ""
+		var cmd tea.Cmd
+		wizard.inputs[wizard.step], cmd = wizard.inputs[wizard.step].Update(msg)
+		return m, cmd
+	}
+	return m, nil
+}
+
+// newCommandView renders the current step of the wizard with the answers so far
+func (m *Model) newCommandView() string {
+	wizard := m.newCommand
+	header := fmt.Sprintf("New Command (%s library) • step %d of %d", m.GetLibraryModeString(), wizard.step+1, wizardStepReview+1)
+	labels := [wizardStepTemplate]string{"Name", "Description", "Argument hint"}
+
+	var content strings.Builder
+	for i, label := range labels {
+		switch {
+		case i == wizard.step:
+			content.WriteString(highlightStyle.Render(label + ":"))
+			content.WriteString("\n")
+			content.WriteString(wizard.inputs[i].View())
+			content.WriteString("\n\n")
+		case i < wizard.step:
+			value := strings.TrimSpace(wizard.inputs[i].Value())
+			if value == "" {
+				value = subtleStyle.Render("(none)")
+			}
+			content.WriteString(fmt.Sprintf("%s %s\n", subtleStyle.Render(label+":"), value))
+		}
+	}
+
+	switch wizard.step {
+	case wizardStepTemplate:
+		content.WriteString("\n")
+		content.WriteString(highlightStyle.Render("Template:"))
+		content.WriteString("\n")
+		nameWidth := 0
+		for _, tmpl := range wizard.templates {
+			nameWidth = max(nameWidth, len(tmpl.Name))
+		}
+		for i, tmpl := range wizard.templates {
+			cursor := "  "
+			name := fmt.Sprintf("%-*s", nameWidth, tmpl.Name)
+			if i == wizard.template {
+				cursor = "▶ "
+				name = highlightStyle.Render(name)
+			}
+			content.WriteString(fmt.Sprintf("%s%s  %s\n", cursor, name, subtleStyle.Render(tmpl.Description)))
+		}
+	case wizardStepReview:
+		enable := "no"
+		if wizard.enable {
+			enable = "yes"
+		}
+		content.WriteString(fmt.Sprintf("%s %s\n", subtleStyle.Render("Template:"), wizard.templates[wizard.template].Name))
+		content.WriteString(fmt.Sprintf("%s %s\n\n", subtleStyle.Render("File:"), filepath.Join(m.getCurrentCommandManager().GetCommandsDir(), m.wizardPath())))
+		content.WriteString(fmt.Sprintf("Enable after creating: %s\n", highlightStyle.Render(enable)))
+	}
+
+	if wizard.err != "" {
+		content.WriteString("\n")
+		content.WriteString(dangerStyle.Render("⚠️ " + wizard.err))
+		content.WriteString("\n")
+	}
+
+	footer := "Enter/Tab: Next • Shift+Tab: Back • Esc: Cancel"
+	switch wizard.step {
+	case wizardStepTemplate:
+		footer = "↑/↓: Choose Template • " + footer
+	case wizardStepReview:
+		footer = "Space/y/n: Enable After Creating • Enter: Create • Shift+Tab: Back • Esc: Cancel"
+	}
+	return centerView(header, content.String(), footer, m.width)
+}
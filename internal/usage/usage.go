@@ -0,0 +1,210 @@
+// Package usage counts slash command invocations in Claude session transcripts, the
+// JSONL files Claude keeps under ~/.claude/projects/<project>/<session>.jsonl
+package usage
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Stats is how often a slash command was invoked across all sessions
+type Stats struct {
+	Total     int
+	ThisMonth int       // Invocations since the start of the current calendar month
+	LastUsed  time.Time // Zero if never invoked
+}
+
+// invocation is one slash command typed in a session
+type invocation struct {
+	name string // Normalized command name, see normalizeName
+	at   time.Time
+}
+
+// fileEntry is the invocations found in a transcript as of its last modification
+type fileEntry struct {
+	modTime     time.Time
+	size        int64
+	invocations []invocation
+}
+
+// Scanner reads session transcripts, re-reading a file only when it changed since the
+// previous scan. It is safe for concurrent use.
+type Scanner struct {
+	dir string
+
+	mu    sync.Mutex
+	files map[string]fileEntry // By transcript path
+}
+
+// commandNamePattern finds the command Claude records for a slash command invocation
+var commandNamePattern = regexp.MustCompile(`<command-name>\s*(/?[^<\s]+)\s*</command-name>`)
+
+// NewScanner creates a scanner for the transcripts under dir, usually ~/.claude/projects
+func NewScanner(dir string) *Scanner {
+	return &Scanner{dir: dir, files: make(map[string]fileEntry)}
+}
+
+// DefaultDir returns ~/.claude/projects, where Claude keeps its session transcripts
+func DefaultDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".claude", "projects"), nil
+}
+
+// Scan returns the stats of every command invoked in the transcripts, by normalized
+// name; now decides which invocations count for the current month. A missing
+// transcripts directory is not an error and yields no stats.
+func (s *Scanner) Scan(now time.Time) (map[string]Stats, error) {
+	paths, err := filepath.Glob(filepath.Join(s.dir, "*", "*.jsonl"))
+	if err != nil {
+		return nil, err
+	}
+
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	stats := make(map[string]Stats)
+	seen := make(map[string]bool, len(paths))
+	for _, path := range paths {
+		seen[path] = true
+		invocations, err := s.fileInvocations(path)
+		if err != nil {
+			continue // Unreadable or removed while scanning; the next scan retries it
+		}
+		for _, inv := range invocations {
+			st := stats[inv.name]
+			st.Total++
+			if !inv.at.Before(monthStart) {
+				st.ThisMonth++
+			}
+			if inv.at.After(st.LastUsed) {
+				st.LastUsed = inv.at
+			}
+			stats[inv.name] = st
+		}
+	}
+
+	s.mu.Lock()
+	for path := range s.files {
+		if !seen[path] {
+			delete(s.files, path)
+		}
+	}
+	s.mu.Unlock()
+	return stats, nil
+}
+
+// Lookup returns the stats of the command Claude knows as name, such as "git:commit" for a
+// namespaced command or "commit" for a top-level one
+func Lookup(stats map[string]Stats, name string) Stats {
+	return stats[normalizeName(name)]
+}
+
+// fileInvocations returns the invocations in a transcript, from the cache when the file
+// is unchanged
+func (s *Scanner) fileInvocations(path string) ([]invocation, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	entry, ok := s.files[path]
+	s.mu.Unlock()
+	if ok && entry.modTime.Equal(info.ModTime()) && entry.size == info.Size() {
+		return entry.invocations, nil
+	}
+
+	invocations, err := readTranscript(path)
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	s.files[path] = fileEntry{modTime: info.ModTime(), size: info.Size(), invocations: invocations}
+	s.mu.Unlock()
+	return invocations, nil
+}
+
+// transcriptLine is the part of a transcript entry that records a user message
+type transcriptLine struct {
+	Type      string    `json:"type"`
+	UUID      string    `json:"uuid"`
+	Timestamp time.Time `json:"timestamp"`
+	Message   struct {
+		Content json.RawMessage `json:"content"`
+	} `json:"message"`
+}
+
+// readTranscript finds the slash commands typed in a session. Only user messages count,
+// and an entry repeated under the same uuid (as when a session is resumed) counts once.
+func readTranscript(path string) ([]invocation, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var invocations []invocation
+	seen := make(map[string]bool)
+	reader := bufio.NewReader(file)
+	for {
+		line, err := reader.ReadBytes('\n')
+		// Most lines are tool output and replies; only decode the ones that can match
+		if bytes.Contains(line, []byte("command-name")) {
+			var entry transcriptLine
+			if json.Unmarshal(line, &entry) == nil && entry.Type == "user" && !seen[entry.UUID] {
+				if entry.UUID != "" {
+					seen[entry.UUID] = true
+				}
+				for _, match := range commandNamePattern.FindAllStringSubmatch(messageText(entry.Message.Content), -1) {
+					invocations = append(invocations, invocation{name: normalizeName(match[1]), at: entry.Timestamp})
+				}
+			}
+		}
+		if err != nil {
+			break // io.EOF, or a read error on a file being written; keep what was read
+		}
+	}
+	return invocations, nil
+}
+
+// messageText returns a message's content as text: either a plain string or the text
+// blocks of a content list
+func messageText(content json.RawMessage) string {
+	var text string
+	if json.Unmarshal(content, &text) == nil {
+		return text
+	}
+	var blocks []struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	}
+	if json.Unmarshal(content, &blocks) != nil {
+		return ""
+	}
+	var b strings.Builder
+	for _, block := range blocks {
+		if block.Type == "text" {
+			b.WriteString(block.Text)
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
+
+// normalizeName strips the slash and the scope prefixes older Claude versions recorded,
+// so "/user:cl:git:commit" and "git:commit" are the same command. ccm links commands
+// into a cl/ directory, which Claude treats as a namespace.
+func normalizeName(name string) string {
+	name = strings.TrimPrefix(strings.TrimSpace(name), "/")
+	for _, prefix := range []string{"user:", "project:"} {
+		name = strings.TrimPrefix(name, prefix)
+	}
+	return strings.TrimPrefix(name, "cl:")
+}
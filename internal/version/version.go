@@ -0,0 +1,89 @@
+// Package version holds build metadata for ccm. Release builds set the variables with
+// -ldflags "-X github.com/shel-corp/Claude-command-manager/internal/version.Version=v1.2.3 ...";
+// other builds fall back to the VCS information the Go toolchain embeds.
+package version
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/debug"
+)
+
+// Set at build time via -ldflags -X
+var (
+	Version = "dev"
+	Commit  = ""
+	Date    = ""
+)
+
+// Info describes the running build
+type Info struct {
+	Version   string
+	Commit    string
+	Date      string
+	GoVersion string
+	Platform  string
+	Modified  bool // Built from a working tree with uncommitted changes
+}
+
+// Get returns the build metadata, filling gaps from the embedded build info
+func Get() Info {
+	info := Info{
+		Version:   Version,
+		Commit:    Commit,
+		Date:      Date,
+		GoVersion: runtime.Version(),
+		Platform:  runtime.GOOS + "/" + runtime.GOARCH,
+	}
+
+	if buildInfo, ok := debug.ReadBuildInfo(); ok {
+		if info.Version == "dev" && buildInfo.Main.Version != "" && buildInfo.Main.Version != "(devel)" {
+			info.Version = buildInfo.Main.Version
+		}
+		for _, setting := range buildInfo.Settings {
+			switch setting.Key {
+			case "vcs.revision":
+				if info.Commit == "" {
+					info.Commit = setting.Value
+				}
+			case "vcs.time":
+				if info.Date == "" {
+					info.Date = setting.Value
+				}
+			case "vcs.modified":
+				info.Modified = setting.Value == "true"
+			}
+		}
+	}
+
+	if len(info.Commit) > 12 {
+		info.Commit = info.Commit[:12]
+	}
+	return info
+}
+
+// Short returns a one-line description such as "v1.2.3 (abc123def456)"
+func (i Info) Short() string {
+	if i.Commit == "" {
+		return i.Version
+	}
+	commit := i.Commit
+	if i.Modified {
+		commit += "-dirty"
+	}
+	return fmt.Sprintf("%s (%s)", i.Version, commit)
+}
+
+// String returns the full multi-line description printed by `ccm version`
+func (i Info) String() string {
+	commit, date := i.Commit, i.Date
+	if commit == "" {
+		commit = "unknown"
+	} else if i.Modified {
+		commit += "-dirty"
+	}
+	if date == "" {
+		date = "unknown"
+	}
+	return fmt.Sprintf("ccm %s\ncommit:   %s\nbuilt:    %s\ngo:       %s\nplatform: %s", i.Version, commit, date, i.GoVersion, i.Platform)
+}